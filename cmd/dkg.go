@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/urfave/cli"
+)
+
+// DKGCommand contains the definition of the dkg command-line subcommand and
+// its own subcommands.
+var DKGCommand cli.Command
+
+const (
+	seedFlag   = "seed"
+	memberFlag = "member"
+)
+
+const dkgDescription = `The dkg command allows inspecting distributed key
+	generations executed by operators of this client. The "evidence list"
+	subcommand lists evidence logs of distributed key generations stored in
+	the data directory. The "evidence export" subcommand decodes evidence logs
+	of the distributed key generation with the given seed, including all
+	accusations made by group members, and prints them as JSON.`
+
+func init() {
+	DKGCommand = cli.Command{
+		Name:        "dkg",
+		Usage:       `Provides access to distributed key generations data.`,
+		Description: dkgDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:  "evidence",
+				Usage: "Provides access to stored DKG evidence logs.",
+				Subcommands: []cli.Command{
+					{
+						Name:   "list",
+						Usage:  "Lists stored DKG evidence logs.",
+						Action: listDKGEvidence,
+					},
+					{
+						Name:   "export",
+						Usage:  "Exports DKG evidence logs as JSON.",
+						Action: exportDKGEvidence,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  seedFlag,
+								Usage: "hex-encoded seed of the DKG",
+							},
+							&cli.IntFlag{
+								Name:  memberFlag,
+								Usage: "index of the member; all members if not set",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// operatorDKGEvidence is a DKG evidence log stored by one of the operators.
+type operatorDKGEvidence struct {
+	operator string
+	evidence *registry.DKGEvidence
+}
+
+// exportedDKGEvidence is a decoded DKG evidence log in the form in which it
+// is exported.
+type exportedDKGEvidence struct {
+	Operator string         `json:"operator"`
+	Seed     string         `json:"seed"`
+	StoredAt time.Time      `json:"storedAt"`
+	Evidence *gjkr.Evidence `json:"evidence"`
+}
+
+// listDKGEvidence prints evidence logs stored by all operators configured
+// in the client, along with the number of accusations in each of them.
+func listDKGEvidence(c *cli.Context) error {
+	evidences, err := loadDKGEvidence(c)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(
+		writer,
+		"OPERATOR\tSEED\tMEMBER\tSTORED AT\tSHARES ACCUSATIONS\tPOINTS ACCUSATIONS",
+	)
+
+	for _, operatorEvidence := range evidences {
+		evidence := operatorEvidence.evidence
+
+		decoded, err := gjkr.DecodeEvidence(evidence.Evidence)
+		if err != nil {
+			return fmt.Errorf(
+				"could not decode evidence for seed [0x%x]: [%v]",
+				evidence.Seed,
+				err,
+			)
+		}
+
+		fmt.Fprintf(
+			writer,
+			"%v\t0x%x\t%v\t%v\t%v\t%v\n",
+			operatorEvidence.operator,
+			evidence.Seed,
+			decoded.MemberIndex,
+			evidence.StoredAt.Format(time.RFC3339),
+			len(decoded.SecretSharesAccusations),
+			len(decoded.PointsAccusations),
+		)
+	}
+
+	return writer.Flush()
+}
+
+// exportDKGEvidence decodes evidence logs of the distributed key generation
+// with the given seed and prints them as JSON.
+func exportDKGEvidence(c *cli.Context) error {
+	seedString := strings.TrimPrefix(c.String(seedFlag), "0x")
+	seed, ok := new(big.Int).SetString(seedString, 16)
+	if !ok {
+		return fmt.Errorf("invalid seed [%v]", c.String(seedFlag))
+	}
+
+	memberIndex := group.MemberIndex(c.Int(memberFlag))
+
+	evidences, err := loadDKGEvidence(c)
+	if err != nil {
+		return err
+	}
+
+	exported := make([]*exportedDKGEvidence, 0)
+	for _, operatorEvidence := range evidences {
+		evidence := operatorEvidence.evidence
+		if evidence.Seed.Cmp(seed) != 0 {
+			continue
+		}
+
+		decoded, err := gjkr.DecodeEvidence(evidence.Evidence)
+		if err != nil {
+			return fmt.Errorf(
+				"could not decode evidence for seed [0x%x]: [%v]",
+				evidence.Seed,
+				err,
+			)
+		}
+
+		if memberIndex != 0 && decoded.MemberIndex != memberIndex {
+			continue
+		}
+
+		exported = append(exported, &exportedDKGEvidence{
+			Operator: operatorEvidence.operator,
+			Seed:     fmt.Sprintf("0x%x", evidence.Seed),
+			StoredAt: evidence.StoredAt,
+			Evidence: decoded,
+		})
+	}
+
+	if len(exported) == 0 {
+		return fmt.Errorf("no evidence found for seed [0x%x]", seed)
+	}
+
+	exportedJSON, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal evidence: [%v]", err)
+	}
+
+	fmt.Println(string(exportedJSON))
+
+	return nil
+}
+
+// loadDKGEvidence reads evidence logs stored in data directories of all
+// operators configured in the client.
+func loadDKGEvidence(c *cli.Context) ([]*operatorDKGEvidence, error) {
+	config, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	evidences := make([]*operatorDKGEvidence, 0)
+	for _, operatorConfig := range readOperatorConfigs(config) {
+		operatorKey, err := ethutil.DecryptKeyFile(
+			operatorConfig.account.KeyFile,
+			operatorConfig.account.KeyFilePassword,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to read key file [%s]: [%v]",
+				operatorConfig.account.KeyFile,
+				err,
+			)
+		}
+		operator := operatorKey.Address.Hex()
+
+		dataDir := operatorConfig.dataDir
+		if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+			continue
+		}
+
+		handle, err := persistence.NewDiskHandle(dataDir)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed while creating a storage disk handler: [%v]",
+				err,
+			)
+		}
+
+		evidenceLog := registry.NewDKGEvidenceLog(
+			persistence.NewEncryptedPersistence(
+				handle,
+				operatorConfig.account.KeyFilePassword,
+			),
+			dkgEvidenceRetention(config),
+		)
+
+		for _, evidence := range evidenceLog.LoadExisting() {
+			evidences = append(evidences, &operatorDKGEvidence{
+				operator: operator,
+				evidence: evidence,
+			})
+		}
+	}
+
+	return evidences, nil
+}
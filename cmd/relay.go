@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"math/big"
 	"os"
+	"strings"
+	"text/tabwriter"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/urfave/cli"
 )
@@ -20,7 +27,29 @@ const relayDescription = `The relay command allows interacting with Keep's
 	from the relay, which is equivalent to asking for a new random number. This
 	subcommand waits for the entry to appear on-chain and then reports the value.
 	The "genesis" subcommand triggers the first group selection. This action 
-    can be done only once when there are no groups on the chain.`
+    can be done only once when there are no groups on the chain.
+	The "selection-estimate" subcommand estimates, fully offline, how many
+	tickets the operator submits in each group selection round, how many
+	seats in the group it wins and how much gas it spends on ticket
+	submissions. Group selections are executed with the client's ticket
+	generation and submission rules for consecutive seeds starting from the
+	given or a random one. Stakes of all stakers are read from the --snapshot
+	JSON file, a list of {"address": "0x...", "stake": "..."} objects, where
+	the operator is identified by the --operator address. Without a snapshot,
+	the rest of the network stake is treated as a single staker, which does
+	not change the operator's expected outcome.`
+
+const (
+	stakeFlag             = "stake"
+	minimumStakeFlag      = "minimum-stake"
+	networkStakeFlag      = "network-stake"
+	snapshotFlag          = "snapshot"
+	operatorFlag          = "operator"
+	selectionsFlag        = "selections"
+	submissionTimeoutFlag = "submission-timeout"
+	ticketGasFlag         = "ticket-gas"
+	gasPriceFlag          = "gas-price"
+)
 
 func init() {
 	RelayCommand = cli.Command{
@@ -38,6 +67,62 @@ func init() {
 				Usage:  "Performs genesis. Can be executed only one time.",
 				Action: genesis,
 			},
+			{
+				Name:   "selection-estimate",
+				Usage:  "Estimates group selection outcome and cost offline.",
+				Action: selectionEstimate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  seedFlag,
+						Usage: "hex-encoded seed of the first group selection; random if not set",
+					},
+					&cli.StringFlag{
+						Name:  stakeFlag,
+						Usage: "stake of the operator; ignored with --snapshot",
+					},
+					&cli.StringFlag{
+						Name:  minimumStakeFlag,
+						Usage: "minimum stake of a virtual staker",
+					},
+					&cli.StringFlag{
+						Name:  networkStakeFlag,
+						Usage: "total stake of all stakers, including the operator; ignored with --snapshot",
+					},
+					&cli.StringFlag{
+						Name:  snapshotFlag,
+						Usage: "path to the JSON file with stakes of all stakers",
+					},
+					&cli.StringFlag{
+						Name:  operatorFlag,
+						Usage: "address of the operator; required with --snapshot",
+					},
+					&cli.IntFlag{
+						Name:  groupSizeFlag,
+						Usage: "number of members in the group",
+						Value: 64,
+					},
+					&cli.IntFlag{
+						Name:  selectionsFlag,
+						Usage: "number of group selections to average over",
+						Value: 100,
+					},
+					&cli.Uint64Flag{
+						Name:  submissionTimeoutFlag,
+						Usage: "ticket submission timeout in blocks",
+						Value: 6*11 + 12,
+					},
+					&cli.Uint64Flag{
+						Name:  ticketGasFlag,
+						Usage: "gas spent on a single ticket submission",
+						Value: 250000,
+					},
+					&cli.Float64Flag{
+						Name:  gasPriceFlag,
+						Usage: "gas price in gwei",
+						Value: 20,
+					},
+				},
+			},
 		},
 	}
 }
@@ -51,7 +136,7 @@ func relayRequest(c *cli.Context) error {
 		return fmt.Errorf("error reading config file: [%v]", err)
 	}
 
-	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	utility, err := ethereum.ConnectUtility(cfg.Ethereum.Config)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
@@ -91,7 +176,7 @@ func genesis(c *cli.Context) error {
 		return fmt.Errorf("error reading config file: [%v]", err)
 	}
 
-	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	utility, err := ethereum.ConnectUtility(cfg.Ethereum.Config)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
@@ -102,3 +187,210 @@ func genesis(c *cli.Context) error {
 	}
 	return nil
 }
+
+// stakeSnapshotEntry is the stake of a single staker as stored in the stakes
+// snapshot file.
+type stakeSnapshotEntry struct {
+	Address string `json:"address"`
+	Stake   string `json:"stake"`
+}
+
+// selectionEstimate estimates group selections offline and prints the
+// expected outcome and cost for the operator.
+func selectionEstimate(c *cli.Context) error {
+	seed, err := simulationSeed(c.String(seedFlag))
+	if err != nil {
+		return err
+	}
+
+	minimumStake, err := parseStake(c, minimumStakeFlag)
+	if err != nil {
+		return err
+	}
+
+	var stakers []*groupselection.Staker
+	var operator int
+	if path := c.String(snapshotFlag); path != "" {
+		stakers, operator, err = readStakeSnapshot(path, c.String(operatorFlag))
+	} else {
+		stakers, err = networkStakers(c)
+	}
+	if err != nil {
+		return err
+	}
+
+	networkStake := big.NewInt(0)
+	for _, staker := range stakers {
+		networkStake.Add(networkStake, staker.Stake)
+	}
+
+	estimate, err := groupselection.EstimateSelection(
+		seed,
+		c.Int(selectionsFlag),
+		operator,
+		stakers,
+		minimumStake,
+		c.Int(groupSizeFlag),
+		c.Uint64(submissionTimeoutFlag),
+	)
+	if err != nil {
+		return fmt.Errorf("estimation failed: [%v]", err)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(writer, "Seed:\t0x%x\n", seed)
+	fmt.Fprintf(writer, "Group selections:\t%v\n", estimate.Selections)
+	fmt.Fprintf(writer, "Group size:\t%v\n", c.Int(groupSizeFlag))
+	fmt.Fprintf(writer, "Minimum stake:\t%v\n", minimumStake)
+	fmt.Fprintf(writer, "Operator stake:\t%v\n", stakers[operator].Stake)
+	fmt.Fprintf(writer, "Network stake:\t%v\n", networkStake)
+	fmt.Fprintln(writer)
+
+	rounds := len(estimate.TicketsPerRound)
+	fmt.Fprintln(writer, "ROUND\tLEADING ZEROS\tEXPECTED TICKETS")
+	for round, tickets := range estimate.TicketsPerRound {
+		leadingZeros := fmt.Sprint(rounds - 1 - round)
+		if round == 0 {
+			leadingZeros = leadingZeros + "+"
+		}
+		fmt.Fprintf(writer, "%v\t%v\t%.2f\n", round, leadingZeros, tickets)
+	}
+	fmt.Fprintln(writer)
+
+	gas := estimate.Tickets() * float64(c.Uint64(ticketGasFlag))
+	cost := gas * c.Float64(gasPriceFlag) / 1e9
+
+	fmt.Fprintf(writer, "Expected tickets:\t%.2f\n", estimate.Tickets())
+	fmt.Fprintf(writer, "Expected seats:\t%.2f\n", estimate.Seats)
+	fmt.Fprintf(writer, "Selection probability:\t%.4f\n", estimate.SelectionProbability)
+	fmt.Fprintf(writer, "Expected gas:\t%.0f\n", gas)
+	fmt.Fprintf(writer, "Expected cost (ETH):\t%.6f\n", cost)
+
+	return writer.Flush()
+}
+
+// networkStakers returns the operator, as the first staker, and the rest of
+// the network stake as a single staker.
+func networkStakers(c *cli.Context) ([]*groupselection.Staker, error) {
+	stake, err := parseStake(c, stakeFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	networkStake, err := parseStake(c, networkStakeFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	if networkStake.Cmp(stake) < 0 {
+		return nil, fmt.Errorf("network stake is lower than operator stake")
+	}
+
+	operatorAddress := common.Address{}
+	if address := c.String(operatorFlag); address != "" {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid operator address [%v]", address)
+		}
+		operatorAddress = common.HexToAddress(address)
+	}
+
+	// any address different from the operator's one
+	networkAddress := common.BytesToAddress(
+		new(big.Int).Add(
+			new(big.Int).SetBytes(operatorAddress.Bytes()),
+			big.NewInt(1),
+		).Bytes(),
+	)
+
+	return []*groupselection.Staker{
+		{
+			Address: operatorAddress.Bytes(),
+			Stake:   stake,
+		},
+		{
+			Address: networkAddress.Bytes(),
+			Stake:   new(big.Int).Sub(networkStake, stake),
+		},
+	}, nil
+}
+
+// readStakeSnapshot reads stakers from the snapshot file and returns them
+// along with the index of the operator with the given address.
+func readStakeSnapshot(
+	path string,
+	operatorAddress string,
+) ([]*groupselection.Staker, int, error) {
+	if !common.IsHexAddress(operatorAddress) {
+		return nil, 0, fmt.Errorf(
+			"invalid operator address [%v]",
+			operatorAddress,
+		)
+	}
+	operator := common.HexToAddress(operatorAddress)
+
+	snapshotFile, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"could not read stakes snapshot [%v]: [%v]",
+			path,
+			err,
+		)
+	}
+
+	var entries []*stakeSnapshotEntry
+	if err := json.Unmarshal(snapshotFile, &entries); err != nil {
+		return nil, 0, fmt.Errorf(
+			"could not parse stakes snapshot [%v]: [%v]",
+			path,
+			err,
+		)
+	}
+
+	operatorIndex := -1
+	stakers := make([]*groupselection.Staker, 0, len(entries))
+	for _, entry := range entries {
+		if !common.IsHexAddress(entry.Address) {
+			return nil, 0, fmt.Errorf(
+				"invalid staker address [%v] in stakes snapshot",
+				entry.Address,
+			)
+		}
+		address := common.HexToAddress(entry.Address)
+
+		stake, ok := new(big.Int).SetString(entry.Stake, 10)
+		if !ok || stake.Sign() < 0 {
+			return nil, 0, fmt.Errorf(
+				"invalid stake [%v] of staker [%v] in stakes snapshot",
+				entry.Stake,
+				entry.Address,
+			)
+		}
+
+		if address == operator {
+			operatorIndex = len(stakers)
+		}
+
+		stakers = append(stakers, &groupselection.Staker{
+			Address: address.Bytes(),
+			Stake:   stake,
+		})
+	}
+
+	if operatorIndex < 0 {
+		return nil, 0, fmt.Errorf(
+			"operator [%v] not found in stakes snapshot",
+			operatorAddress,
+		)
+	}
+
+	return stakers, operatorIndex, nil
+}
+
+func parseStake(c *cli.Context, flag string) (*big.Int, error) {
+	stake, ok := new(big.Int).SetString(strings.TrimSpace(c.String(flag)), 10)
+	if !ok || stake.Sign() < 0 {
+		return nil, fmt.Errorf("invalid --%v [%v]", flag, c.String(flag))
+	}
+
+	return stake, nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg/simulator"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/urfave/cli"
+)
+
+// SimulateCommand contains the definition of the simulate command-line
+// subcommand and its own subcommands.
+var SimulateCommand cli.Command
+
+const (
+	groupSizeFlag   = "group-size"
+	thresholdFlag   = "threshold"
+	faultFlag       = "fault"
+	faultScriptFlag = "fault-script"
+)
+
+const simulateDescription = `The simulate command executes protocols of the
+	client locally, without connecting to the chain and network. The "dkg"
+	subcommand runs the full distributed key generation, including the result
+	publication, on the local chain with the given group size and honest
+	threshold. Faulty members misbehave according to faults passed with
+	--fault flags or read from the --fault-script file, one fault per line:
+
+	  member <N> goes silent after phase <P>
+	  member <N> goes silent in phase <P>
+	  member <N> sends invalid shares [to member <M>] in phase 3
+	  member <N> sends invalid commitments in phase 3
+	  member <N> sends invalid public key share points in phase 7
+
+	Once the protocol completes, the published result, inactive and
+	disqualified members are printed.`
+
+func init() {
+	SimulateCommand = cli.Command{
+		Name:        "simulate",
+		Usage:       `Simulates protocols executed by the client.`,
+		Description: simulateDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "dkg",
+				Usage:  "Simulates the distributed key generation.",
+				Action: simulateDKG,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  groupSizeFlag,
+						Usage: "number of members in the group",
+						Value: 5,
+					},
+					&cli.IntFlag{
+						Name:  thresholdFlag,
+						Usage: "honest threshold; majority of the group if not set",
+					},
+					&cli.StringFlag{
+						Name:  seedFlag,
+						Usage: "hex-encoded seed of the DKG; random if not set",
+					},
+					&cli.StringSliceFlag{
+						Name:  faultFlag,
+						Usage: "fault of a group member; can be repeated",
+					},
+					&cli.StringFlag{
+						Name:  faultScriptFlag,
+						Usage: "path to the file with faults of group members",
+					},
+				},
+			},
+		},
+	}
+}
+
+// simulateDKG executes the distributed key generation on the local chain with
+// the requested faults and prints its outcome.
+func simulateDKG(c *cli.Context) error {
+	groupSize := c.Int(groupSizeFlag)
+
+	honestThreshold := c.Int(thresholdFlag)
+	if honestThreshold == 0 {
+		honestThreshold = groupSize/2 + 1
+	}
+
+	seed, err := simulationSeed(c.String(seedFlag))
+	if err != nil {
+		return err
+	}
+
+	script := strings.Join(c.StringSlice(faultFlag), "\n")
+	if path := c.String(faultScriptFlag); path != "" {
+		scriptFile, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("could not read fault script [%v]: [%v]", path, err)
+		}
+		script = script + "\n" + string(scriptFile)
+	}
+
+	faults, err := simulator.ParseFaultScript(script)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(writer, "Seed:\t0x%x\n", seed)
+	fmt.Fprintf(writer, "Group size:\t%v\n", groupSize)
+	fmt.Fprintf(writer, "Honest threshold:\t%v\n", honestThreshold)
+	for _, fault := range faults {
+		fmt.Fprintf(writer, "Fault:\t%v\n", fault)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	result, err := simulator.Simulate(groupSize, honestThreshold, seed, faults)
+	if err != nil {
+		return fmt.Errorf("simulation failed: [%v]", err)
+	}
+
+	signers := make([]group.MemberIndex, 0)
+	for _, signer := range result.GetSigners() {
+		signers = append(signers, signer.MemberID())
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintf(writer, "Inactive members:\t%v\n", result.GetInactiveMembers())
+	fmt.Fprintf(writer, "Disqualified members:\t%v\n", result.GetDisqualifiedMembers())
+	fmt.Fprintf(writer, "Successful signers:\t%v\n", sortedMembers(signers))
+	for _, failure := range result.GetMemberFailures() {
+		fmt.Fprintf(writer, "Member failure:\t%v\n", failure)
+	}
+
+	dkgResult := result.GetDKGResult()
+	if dkgResult == nil {
+		fmt.Fprintf(writer, "Result published:\tfalse\n")
+		return writer.Flush()
+	}
+
+	misbehaved := make([]group.MemberIndex, 0)
+	for _, memberIndex := range dkgResult.Misbehaved {
+		misbehaved = append(misbehaved, group.MemberIndex(memberIndex))
+	}
+
+	supporting := make([]group.MemberIndex, 0)
+	for memberIndex := range result.GetDKGResultSignatures() {
+		supporting = append(supporting, memberIndex)
+	}
+
+	fmt.Fprintf(writer, "Result published:\ttrue\n")
+	fmt.Fprintf(writer, "Group public key:\t0x%x\n", dkgResult.GroupPublicKey)
+	fmt.Fprintf(writer, "Misbehaved members:\t%v\n", misbehaved)
+	fmt.Fprintf(writer, "Supporting members:\t%v\n", sortedMembers(supporting))
+
+	return writer.Flush()
+}
+
+// simulationSeed parses the hex-encoded seed or generates a random one if
+// the seed is not set.
+func simulationSeed(seedString string) (*big.Int, error) {
+	if seedString == "" {
+		seed, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+		if err != nil {
+			return nil, fmt.Errorf("could not generate seed: [%v]", err)
+		}
+		return seed, nil
+	}
+
+	seed, ok := new(big.Int).SetString(strings.TrimPrefix(seedString, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid seed [%v]", seedString)
+	}
+
+	return seed, nil
+}
+
+func sortedMembers(members []group.MemberIndex) []group.MemberIndex {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i] < members[j]
+	})
+	return members
+}
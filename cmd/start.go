@@ -4,17 +4,25 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/keep-network/keep-core/pkg/diagnostics"
 	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ipfs/go-log"
+	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/beacon/observer"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/firewall"
@@ -37,10 +45,16 @@ const (
 	portShort         = "p"
 	waitForStakeFlag  = "wait-for-stake"
 	waitForStakeShort = "w"
+	observerFlag      = "observer"
 )
 
 const startDescription = `Starts the Keep client in the foreground. Currently this only consists of the
-   threshold relay client for the Keep random beacon.`
+   threshold relay client for the Keep random beacon.
+
+   When started with the --observer flag, the client requires no stake. It does
+   not join the network and never submits anything to the chain; it only
+   follows and verifies the random beacon activity on-chain and exposes it
+   through metrics.`
 
 // Values related with balance monitoring.
 // defaultBalanceAlertThreshold determines the alert threshold below which
@@ -50,6 +64,14 @@ var defaultBalanceAlertThreshold = big.NewInt(500000000000000000) // 0.5 ether
 // check should be triggered.
 const defaultBalanceMonitoringTick = 10 * time.Minute
 
+// defaultDKGEvidenceRetention determines how long evidence logs of distributed
+// key generations are kept if the retention period is not configured.
+const defaultDKGEvidenceRetention = 30 * 24 * time.Hour
+
+// shutdownTimeout determines how long the client waits for protocols in
+// progress to complete after receiving a termination signal.
+const shutdownTimeout = 5 * time.Minute
+
 func init() {
 	StartCommand =
 		cli.Command{
@@ -64,6 +86,10 @@ func init() {
 				&cli.IntFlag{
 					Name: waitForStakeFlag + "," + waitForStakeShort,
 				},
+				&cli.BoolFlag{
+					Name:  observerFlag,
+					Usage: "Follows the random beacon without staking",
+				},
 			},
 		}
 }
@@ -80,23 +106,67 @@ func Start(c *cli.Context) error {
 		config.LibP2P.Port = c.Int(portFlag)
 	}
 
-	ethereumKey, err := ethutil.DecryptKeyFile(
-		config.Ethereum.Account.KeyFile,
-		config.Ethereum.Account.KeyFilePassword,
-	)
-	if err != nil {
-		return fmt.Errorf(
-			"failed to read key file [%s]: [%v]",
-			config.Ethereum.Account.KeyFile,
-			err,
+	// The observer holds no operator account, so it does not read any key
+	// file nor open the storage of any operator.
+	if c.Bool(observerFlag) {
+		return startObserver(config)
+	}
+
+	operatorConfigs := readOperatorConfigs(config)
+
+	accounts := make([]commonEthereum.Account, len(operatorConfigs))
+	ethereumKeys := make([]*keystore.Key, len(operatorConfigs))
+	persistenceHandles := make([]persistence.Handle, len(operatorConfigs))
+	transactionJournals := make([]*ethereum.TransactionJournal, len(operatorConfigs))
+	for i, operatorConfig := range operatorConfigs {
+		ethereumKey, err := ethutil.DecryptKeyFile(
+			operatorConfig.account.KeyFile,
+			operatorConfig.account.KeyFilePassword,
 		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to read key file [%s]: [%v]",
+				operatorConfig.account.KeyFile,
+				err,
+			)
+		}
+
+		persistenceHandle, err := operatorPersistence(operatorConfig)
+		if err != nil {
+			return err
+		}
+
+		transactionJournal, err := ethereum.OpenTransactionJournal(
+			persistenceHandle,
+		)
+		if err != nil {
+			return err
+		}
+
+		accounts[i] = operatorConfig.account
+		ethereumKeys[i] = ethereumKey
+		persistenceHandles[i] = persistenceHandle
+		transactionJournals[i] = transactionJournal
 	}
 
-	chainProvider, err := ethereum.Connect(config.Ethereum)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	// All operators share a single connection to the Ethereum node.
+	chainProviders, err := ethereum.ConnectOperators(
+		ctx,
+		config.Ethereum.Config,
+		config.Ethereum.EventConfirmations,
+		accounts,
+		failoverConfig(config),
+		transactionJournals,
+	)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
 
+	chainProvider := chainProviders[0]
+
 	blockCounter, err := chainProvider.BlockCounter()
 	if err != nil {
 		return err
@@ -106,78 +176,318 @@ func Start(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error obtaining stake monitor handle [%v]", err)
 	}
-	if c.Int(waitForStakeFlag) != 0 {
-		err = waitForStake(stakeMonitor, ethereumKey.Address.Hex(), c.Int(waitForStakeFlag))
+
+	for _, operatorKey := range ethereumKeys {
+		err := ensureMinimumStake(
+			stakeMonitor,
+			operatorKey.Address.Hex(),
+			c.Int(waitForStakeFlag),
+		)
 		if err != nil {
 			return err
 		}
 	}
-	hasMinimumStake, err := stakeMonitor.HasMinimumStake(
-		ethereumKey.Address.Hex(),
-	)
+
+	operators := make([]*beacon.Operator, len(operatorConfigs))
+	netProviders := make([]net.Provider, len(operatorConfigs))
+	for i, operatorConfig := range operatorConfigs {
+		operatorKey := ethereumKeys[i]
+
+		networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
+			operator.EthereumKeyToOperatorKey(operatorKey),
+		)
+		netProvider, err := libp2p.Connect(
+			ctx,
+			operatorConfig.libp2p,
+			networkPrivateKey,
+			libp2p.ProtocolBeacon,
+			firewall.MinimumStakePolicy(stakeMonitor),
+			retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+		)
+		if err != nil {
+			return err
+		}
+
+		nodeHeader(
+			netProvider.ConnectionManager().AddrStrings(),
+			operatorConfig.libp2p.Port,
+		)
+
+		netProviders[i] = netProvider
+		operators[i] = &beacon.Operator{
+			StakingID:                       operatorKey.Address.Hex(),
+			ChainHandle:                     chainProviders[i],
+			NetProvider:                     netProvider,
+			Persistence:                     persistenceHandles[i],
+			DKGEvidenceRetention:            dkgEvidenceRetention(config),
+			TicketSubmissionPolicy:          ticketSubmissionPolicy(config),
+			OperatorContractDeploymentBlock: config.Ethereum.OperatorContractDeploymentBlock,
+		}
+	}
+
+	beaconHandles, err := beacon.InitializeOperators(ctx, operators)
 	if err != nil {
-		return fmt.Errorf("could not check the stake [%v]", err)
+		return fmt.Errorf("error initializing beacon: [%v]", err)
 	}
-	if !hasMinimumStake {
-		return fmt.Errorf(
-			"no minimum KEEP stake or operator is not authorized to use it; " +
-				"please make sure the operator address in the configuration " +
-				"is correct and it has KEEP tokens delegated and the operator " +
-				"contract has been authorized to operate on the stake",
-		)
+
+	monitoredOperators := make([]*monitoredOperator, len(operators))
+	for i, operatorKey := range ethereumKeys {
+		monitoredOperators[i] = &monitoredOperator{
+			address:       operatorKey.Address.Hex(),
+			netProvider:   netProviders[i],
+			chainProvider: chainProviders[i],
+			beaconHandle:  beaconHandles[i],
+		}
 	}
 
-	ctx := context.Background()
+	initializeMetrics(ctx, config, stakeMonitor, monitoredOperators)
+	initializeDiagnostics(ctx, config, monitoredOperators)
 
-	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
-		operator.EthereumKeyToOperatorKey(ethereumKey),
-	)
-	netProvider, err := libp2p.Connect(
-		ctx,
-		config.LibP2P,
-		networkPrivateKey,
-		libp2p.ProtocolBeacon,
-		firewall.MinimumStakePolicy(stakeMonitor),
-		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+	for i, operatorKey := range ethereumKeys {
+		initializeBalanceMonitoring(ctx, chainProviders[i], config, operatorKey.Address.Hex())
+	}
+
+	signals := waitForTerminationSignal()
+
+	shutdownCtx, cancelShutdownCtx := context.WithTimeout(
+		context.Background(),
+		shutdownTimeout,
 	)
-	if err != nil {
-		return err
+	defer cancelShutdownCtx()
+
+	// Second signal received while shutting down forces the client to stop
+	// without waiting for protocols in progress to complete.
+	go func() {
+		<-signals
+		logger.Warningf("received another signal; forcing shutdown")
+		cancelShutdownCtx()
+	}()
+
+	if err := beacon.StopAll(shutdownCtx, beaconHandles); err != nil {
+		return fmt.Errorf("could not gracefully stop beacon: [%v]", err)
+	}
+
+	return nil
+}
+
+// operatorConfig holds configuration of a single operator run by the client.
+type operatorConfig struct {
+	account commonEthereum.Account
+	libp2p  libp2p.Config
+	dataDir string
+}
+
+// readOperatorConfigs returns configurations of all operators run by the
+// client. The operator configured in the Ethereum section always comes first.
+// Additional operators use the same network configuration apart from the port
+// and announced addresses.
+func readOperatorConfigs(config *config.Config) []*operatorConfig {
+	operatorConfigs := []*operatorConfig{
+		{
+			account: config.Ethereum.Account,
+			libp2p:  config.LibP2P,
+			dataDir: config.Storage.DataDir,
+		},
 	}
 
-	nodeHeader(netProvider.ConnectionManager().AddrStrings(), config.LibP2P.Port)
+	for _, additionalOperator := range config.Operators {
+		libp2pConfig := config.LibP2P
+		libp2pConfig.Port = additionalOperator.Port
+		libp2pConfig.AnnouncedAddresses = additionalOperator.AnnouncedAddresses
+
+		operatorConfigs = append(operatorConfigs, &operatorConfig{
+			account: additionalOperator.Account,
+			libp2p:  libp2pConfig,
+			dataDir: additionalOperator.DataDir,
+		})
+	}
+
+	return operatorConfigs
+}
 
-	handle, err := persistence.NewDiskHandle(config.Storage.DataDir)
+// operatorPersistence returns the encrypted persistence handle of the data
+// directory of the operator.
+func operatorPersistence(operatorConfig *operatorConfig) (persistence.Handle, error) {
+	handle, err := persistence.NewDiskHandle(operatorConfig.dataDir)
 	if err != nil {
-		return fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
+		return nil, fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
 	}
-	persistence := persistence.NewEncryptedPersistence(
+
+	return persistence.NewEncryptedPersistence(
 		handle,
-		config.Ethereum.Account.KeyFilePassword,
-	)
+		operatorConfig.account.KeyFilePassword,
+	), nil
+}
 
-	err = beacon.Initialize(
-		ctx,
-		ethereumKey.Address.Hex(),
-		chainProvider,
-		netProvider,
-		persistence,
+// dkgEvidenceRetention returns the configured retention period of DKG
+// evidence logs or the default one if it is not configured.
+func dkgEvidenceRetention(config *config.Config) time.Duration {
+	if config.Storage.DKGEvidenceRetentionDays <= 0 {
+		return defaultDKGEvidenceRetention
+	}
+
+	return time.Duration(config.Storage.DKGEvidenceRetentionDays) * 24 * time.Hour
+}
+
+// failoverConfig returns the configuration of additional Ethereum endpoints
+// and their health checks.
+func failoverConfig(config *config.Config) ethereum.FailoverConfig {
+	endpoints := make([]ethereum.Endpoint, len(config.Ethereum.Endpoints))
+	for i, endpoint := range config.Ethereum.Endpoints {
+		endpoints[i] = ethereum.Endpoint{
+			URL:      endpoint.URL,
+			Priority: endpoint.Priority,
+		}
+	}
+
+	healthCheck := config.Ethereum.HealthCheck
+
+	return ethereum.FailoverConfig{
+		Endpoints:           endpoints,
+		HealthCheckInterval: time.Duration(healthCheck.Interval) * time.Second,
+		MaxHeadLag:          healthCheck.MaxHeadLag,
+		MaxErrorRate:        healthCheck.MaxErrorRate,
+		MaxLatency:          time.Duration(healthCheck.MaxLatency) * time.Millisecond,
+	}
+}
+
+// ticketSubmissionPolicy returns the factory of group selection ticket
+// submission policies limiting the spend on ticket submissions to the
+// configured limit. Each submission is charged at its estimated gas use and
+// gas price. If the cost of a submission could not be estimated, it is
+// charged at the submission gas limit and the maximum gas price.
+func ticketSubmissionPolicy(
+	config *config.Config,
+) groupselection.SubmissionPolicyFactory {
+	maxGasPrice := ethereum.DefaultMaxGasPrice
+	if config.Ethereum.MaxGasPrice != nil {
+		maxGasPrice = config.Ethereum.MaxGasPrice.Int
+	}
+
+	maxSubmissionCost := new(big.Int).Mul(
+		big.NewInt(ethereum.TicketSubmissionGasLimit),
+		maxGasPrice,
 	)
-	if err != nil {
-		return fmt.Errorf("error initializing beacon: [%v]", err)
+
+	var spendLimit *big.Int
+	if config.Ethereum.TicketSubmissionSpendLimit != nil {
+		spendLimit = config.Ethereum.TicketSubmissionSpendLimit.Int
 	}
 
-	initializeMetrics(ctx, config, netProvider, stakeMonitor, ethereumKey.Address.Hex())
-	initializeDiagnostics(ctx, config, netProvider)
-	initializeBalanceMonitoring(ctx, chainProvider, config, ethereumKey.Address.Hex())
+	return groupselection.NewGasAwarePolicy(maxSubmissionCost, spendLimit)
+}
 
-	select {
-	case <-ctx.Done():
+// ensureMinimumStake returns an error if the operator has no minimum stake.
+// If waitMins is not zero, it first waits up to the given number of minutes
+// for the stake to become available.
+func ensureMinimumStake(
+	stakeMonitor chain.StakeMonitor,
+	address string,
+	waitMins int,
+) error {
+	if waitMins != 0 {
+		err := waitForStake(stakeMonitor, address, waitMins)
 		if err != nil {
 			return err
 		}
+	}
+
+	hasMinimumStake, err := stakeMonitor.HasMinimumStake(address)
+	if err != nil {
+		return fmt.Errorf("could not check the stake [%v]", err)
+	}
+	if !hasMinimumStake {
+		return fmt.Errorf(
+			"no minimum KEEP stake for operator [%v] or operator is not "+
+				"authorized to use it; please make sure the operator address "+
+				"in the configuration is correct and it has KEEP tokens "+
+				"delegated and the operator contract has been authorized to "+
+				"operate on the stake",
+			address,
+		)
+	}
+
+	return nil
+}
+
+// startObserver runs the client in the observer mode. The observer follows
+// the random beacon activity on-chain and exposes it through metrics. It
+// requires no stake, does not join the network, and never submits tickets or
+// any other transaction; its chain handle is read-only.
+func startObserver(config *config.Config) error {
+	logger.Infof("starting the client in the observer mode")
+
+	chainProvider, err := ethereum.ConnectObserver(
+		config.Ethereum.Config,
+		config.Ethereum.EventConfirmations,
+		failoverConfig(config),
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	stakeMonitor, err := chainProvider.StakeMonitor()
+	if err != nil {
+		return fmt.Errorf("error obtaining stake monitor handle [%v]", err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
 
-		return fmt.Errorf("uh-oh, we went boom boom for no reason")
+	relayObserver := observer.Start(chainProvider.ThresholdRelay())
+
+	registry, isConfigured := metrics.Initialize(config.Metrics.Port)
+	if isConfigured {
+		logger.Infof(
+			"enabled metrics on port [%v]",
+			config.Metrics.Port,
+		)
+
+		// The observer holds no account, so the connectivity is checked
+		// by looking up the stake of the zero address.
+		metrics.ObserveEthConnectivity(
+			ctx,
+			registry,
+			stakeMonitor,
+			common.Address{}.Hex(),
+			time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
+		)
+
+		metrics.ObserveRelayActivity(
+			ctx,
+			registry,
+			relayObserver,
+			time.Duration(config.Metrics.RelayMetricsTick)*time.Second,
+		)
+
+		observeEthSubscriptions(ctx, config, registry, chainProvider)
+	} else {
+		logger.Warningf(
+			"metrics are not configured; " +
+				"observed activity will only be logged",
+		)
 	}
+
+	waitForTerminationSignal()
+
+	relayObserver.Stop()
+
+	return nil
+}
+
+// waitForTerminationSignal blocks until the client receives a termination
+// signal. It returns the channel on which further signals are delivered.
+func waitForTerminationSignal() <-chan os.Signal {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	receivedSignal := <-signals
+	logger.Infof(
+		"received [%v] signal; shutting down the client gracefully",
+		receivedSignal,
+	)
+
+	return signals
 }
 
 func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int) error {
@@ -197,12 +507,24 @@ func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int)
 	return fmt.Errorf("timed out waiting for %s to have required minimum stake", address)
 }
 
+// monitoredOperator holds handles of a single operator run by the client
+// which are observed by metrics and diagnostics.
+type monitoredOperator struct {
+	address       string
+	netProvider   net.Provider
+	chainProvider chain.Handle
+	beaconHandle  *beacon.Beacon
+}
+
+// initializeMetrics exposes metrics of all the given operators. Metrics of
+// each operator are labelled with the operator address. Ethereum event
+// subscriptions are shared by all operators, so their metrics are exposed
+// once, without the operator label.
 func initializeMetrics(
 	ctx context.Context,
 	config *config.Config,
-	netProvider net.Provider,
 	stakeMonitor chain.StakeMonitor,
-	ethereumAddress string,
+	operators []*monitoredOperator,
 ) {
 	registry, isConfigured := metrics.Initialize(
 		config.Metrics.Port,
@@ -217,34 +539,79 @@ func initializeMetrics(
 		config.Metrics.Port,
 	)
 
-	metrics.ObserveConnectedPeersCount(
-		ctx,
-		registry,
-		netProvider,
-		time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
-	)
+	for _, operator := range operators {
+		operatorRegistry := metrics.NewOperatorRegistry(
+			registry,
+			operator.address,
+		)
 
-	metrics.ObserveConnectedBootstrapCount(
-		ctx,
-		registry,
-		netProvider,
-		config.LibP2P.Peers,
-		time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
-	)
+		metrics.ObserveConnectedPeersCount(
+			ctx,
+			operatorRegistry,
+			operator.netProvider,
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		)
+
+		metrics.ObserveConnectedBootstrapCount(
+			ctx,
+			operatorRegistry,
+			operator.netProvider,
+			config.LibP2P.Peers,
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		)
 
-	metrics.ObserveEthConnectivity(
+		metrics.ObserveEthConnectivity(
+			ctx,
+			operatorRegistry,
+			stakeMonitor,
+			operator.address,
+			time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
+		)
+
+		metrics.ObserveDKGActivity(
+			ctx,
+			operatorRegistry,
+			operator.beaconHandle,
+			time.Duration(config.Metrics.RelayMetricsTick)*time.Second,
+		)
+	}
+
+	if len(operators) > 0 {
+		observeEthSubscriptions(
+			ctx,
+			config,
+			registry,
+			operators[0].chainProvider,
+		)
+	}
+}
+
+// observeEthSubscriptions exposes metrics of Ethereum event subscriptions if
+// the chain handle provides them.
+func observeEthSubscriptions(
+	ctx context.Context,
+	config *config.Config,
+	registry metrics.Registry,
+	chainProvider chain.Handle,
+) {
+	source, ok := chainProvider.(metrics.EthSubscriptionSource)
+	if !ok {
+		return
+	}
+
+	metrics.ObserveEthSubscriptions(
 		ctx,
 		registry,
-		stakeMonitor,
-		ethereumAddress,
+		source,
 		time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
 	)
 }
 
+// initializeDiagnostics exposes diagnostics of all the given operators.
 func initializeDiagnostics(
 	ctx context.Context,
 	config *config.Config,
-	netProvider net.Provider,
+	operators []*monitoredOperator,
 ) {
 	registry, isConfigured := diagnostics.Initialize(
 		config.Diagnostics.Port,
@@ -259,8 +626,21 @@ func initializeDiagnostics(
 		config.Diagnostics.Port,
 	)
 
-	diagnostics.RegisterConnectedPeersSource(registry, netProvider)
-	diagnostics.RegisterClientInfoSource(registry, netProvider)
+	for i, operator := range operators {
+		operatorRegistry := diagnostics.NewOperatorRegistry(
+			registry,
+			i,
+			operator.address,
+		)
+
+		diagnostics.RegisterConnectedPeersSource(operatorRegistry, operator.netProvider)
+		diagnostics.RegisterClientInfoSource(operatorRegistry, operator.netProvider)
+		diagnostics.RegisterGroupsSource(operatorRegistry, operator.beaconHandle)
+		diagnostics.RegisterQuarantinedMembershipsSource(operatorRegistry, operator.beaconHandle)
+		diagnostics.RegisterPendingOperationsSource(operatorRegistry, operator.beaconHandle)
+		diagnostics.RegisterRunningProtocolsSource(operatorRegistry, operator.beaconHandle)
+		diagnostics.RegisterChainConfigSource(operatorRegistry, operator.beaconHandle)
+	}
 }
 
 func initializeBalanceMonitoring(
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+// TransactionsCommand contains the definition of the transactions
+// command-line subcommand and its own subcommands.
+var TransactionsCommand cli.Command
+
+const outputFlag = "output"
+
+const transactionsDescription = `The transactions command allows inspecting
+	the journal of transactions submitted by operators of this client. The
+	journal of each operator is kept encrypted in the data directory of the
+	operator and records the nonce, hash, purpose, gas parameters and status
+	of each transaction. The "list" subcommand lists all journaled
+	transactions. The "export" subcommand exports them as CSV, to the
+	standard output or to the --output file.`
+
+func init() {
+	TransactionsCommand = cli.Command{
+		Name:        "transactions",
+		Usage:       `Provides access to the journal of submitted transactions.`,
+		Description: transactionsDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "Lists journaled transactions.",
+				Action: listTransactions,
+			},
+			{
+				Name:   "export",
+				Usage:  "Exports journaled transactions as CSV.",
+				Action: exportTransactions,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  outputFlag,
+						Usage: "path of the CSV file; standard output if not set",
+					},
+				},
+			},
+		},
+	}
+}
+
+// listTransactions prints all transactions recorded in the journal.
+func listTransactions(c *cli.Context) error {
+	entries, err := loadTransactionJournal(c)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(
+		writer,
+		"SUBMITTED AT\tFROM\tNONCE\tPURPOSE\tSTATUS\tGAS LIMIT\tGAS PRICE\tHASH",
+	)
+
+	for _, entry := range entries {
+		fmt.Fprintf(
+			writer,
+			"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			entry.SubmittedAt.Format(time.RFC3339),
+			entry.From.Hex(),
+			entry.Nonce,
+			entry.Purpose,
+			entry.Status,
+			entry.GasLimit,
+			entry.GasPrice,
+			entry.Hash.Hex(),
+		)
+	}
+
+	return writer.Flush()
+}
+
+// exportTransactions writes all transactions recorded in the journal as CSV.
+func exportTransactions(c *cli.Context) error {
+	entries, err := loadTransactionJournal(c)
+	if err != nil {
+		return err
+	}
+
+	output := io.Writer(os.Stdout)
+	if path := c.String(outputFlag); path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("could not create output file: [%v]", err)
+		}
+		defer file.Close()
+
+		output = file
+	}
+
+	return writeTransactionsCSV(output, entries)
+}
+
+func writeTransactionsCSV(
+	output io.Writer,
+	entries []*ethereum.JournalEntry,
+) error {
+	writer := csv.NewWriter(output)
+
+	records := [][]string{{
+		"submitted_at",
+		"updated_at",
+		"from",
+		"to",
+		"nonce",
+		"hash",
+		"purpose",
+		"status",
+		"gas_limit",
+		"gas_price",
+	}}
+
+	for _, entry := range entries {
+		to := ""
+		if entry.To != nil {
+			to = entry.To.Hex()
+		}
+
+		gasPrice := ""
+		if entry.GasPrice != nil {
+			gasPrice = entry.GasPrice.String()
+		}
+
+		records = append(records, []string{
+			entry.SubmittedAt.UTC().Format(time.RFC3339),
+			entry.UpdatedAt.UTC().Format(time.RFC3339),
+			entry.From.Hex(),
+			to,
+			strconv.FormatUint(entry.Nonce, 10),
+			entry.Hash.Hex(),
+			string(entry.Purpose),
+			string(entry.Status),
+			strconv.FormatUint(entry.GasLimit, 10),
+			gasPrice,
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("could not write CSV: [%v]", err)
+	}
+
+	return nil
+}
+
+// loadTransactionJournal reads transaction journals of all operators from
+// their data directories.
+func loadTransactionJournal(c *cli.Context) ([]*ethereum.JournalEntry, error) {
+	config, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	entries := make([]*ethereum.JournalEntry, 0)
+	for _, operatorConfig := range readOperatorConfigs(config) {
+		if _, err := os.Stat(operatorConfig.dataDir); os.IsNotExist(err) {
+			continue
+		}
+
+		handle, err := operatorPersistence(operatorConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		operatorEntries, err := ethereum.ReadTransactionJournal(handle)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, operatorEntries...)
+	}
+
+	return entries, nil
+}
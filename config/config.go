@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 
@@ -19,16 +20,99 @@ const passwordEnvVariable = "KEEP_ETHEREUM_PASSWORD"
 
 // Config is the top level config structure.
 type Config struct {
-	Ethereum    ethereum.Config
+	Ethereum    Ethereum
+	Operators   []Operator
 	LibP2P      libp2p.Config
 	Storage     Storage
 	Metrics     Metrics
 	Diagnostics Diagnostics
 }
 
+// Ethereum stores Ethereum-related configuration. It extends the common
+// Ethereum configuration with settings specific to this client.
+type Ethereum struct {
+	ethereum.Config
+
+	// EventConfirmations is the number of blocks which need to be mined on
+	// top of the block in which a relay chain event was emitted before the
+	// client acts on the event.
+	EventConfirmations uint64
+
+	// OperatorContractDeploymentBlock is the number of block at which the
+	// KeepRandomBeaconOperator contract has been deployed. Past events of
+	// the contract are searched starting from this block. If not set, they
+	// are searched from the genesis block.
+	OperatorContractDeploymentBlock uint64
+
+	// TicketSubmissionSpendLimit is the maximum amount spent on ticket
+	// submissions in a single group selection. Each submission is charged at
+	// its estimated gas use and gas price, or at its gas limit and the maximum
+	// gas price if the cost could not be estimated. If not set or zero, the
+	// spend is not limited.
+	TicketSubmissionSpendLimit *ethereum.Wei
+
+	// Endpoints are additional Ethereum endpoints the client fails over to
+	// when the endpoint set in URL becomes unhealthy.
+	Endpoints []EthereumEndpoint
+
+	// HealthCheck configures health checks of all Ethereum endpoints.
+	HealthCheck EthereumHealthCheck
+}
+
+// EthereumEndpoint stores configuration of an additional Ethereum endpoint.
+type EthereumEndpoint struct {
+	// URL is the WebSocket or IPC URL of the Ethereum node.
+	URL string
+	// Priority of the endpoint. Healthy endpoints with lower priority values
+	// are preferred. The endpoint set in Ethereum.URL has priority 0.
+	Priority int
+}
+
+// EthereumHealthCheck stores configuration of Ethereum endpoints health
+// checks. Unset values are replaced with defaults.
+type EthereumHealthCheck struct {
+	// Interval is the interval in seconds in which health of all endpoints
+	// is checked.
+	Interval int
+	// MaxHeadLag is the maximum number of blocks the head of a healthy
+	// endpoint can be behind the highest head seen among all endpoints.
+	MaxHeadLag uint64
+	// MaxErrorRate is the maximum rate of failed requests to a healthy
+	// endpoint, between 0 and 1.
+	MaxErrorRate float64
+	// MaxLatency is the maximum latency in milliseconds of a health check
+	// request to a healthy endpoint.
+	MaxLatency int
+}
+
+// Operator stores configuration of an additional operator run by the client
+// alongside the operator configured in Ethereum.Account. All operators share
+// the Ethereum connection, but each of them has its own network identity,
+// listening on its own port, and its own data directory.
+type Operator struct {
+	// Account is the operator account. If the key file password is not set,
+	// the password of Ethereum.Account is used.
+	ethereum.Account
+
+	// Port is the port on which the operator network identity listens.
+	Port int
+	// AnnouncedAddresses are multiaddresses announced to peers by the operator
+	// network identity. If empty, the operator announces its local addresses.
+	AnnouncedAddresses []string
+	// DataDir is the directory in which the operator keeps its data. It must
+	// not be the same as, contain or be contained in data directories of
+	// other operators, including Storage.DataDir.
+	DataDir string
+}
+
 // Storage stores meta-info about keeping data on disk
 type Storage struct {
 	DataDir string
+
+	// DKGEvidenceRetentionDays is the number of days evidence logs of
+	// distributed key generations are kept in the data directory before they
+	// are archived. If not set, evidence logs are kept for 30 days.
+	DKGEvidenceRetentionDays int
 }
 
 // Metrics stores meta-info about metrics.
@@ -36,6 +120,7 @@ type Metrics struct {
 	Port                int
 	NetworkMetricsTick  int
 	EthereumMetricsTick int
+	RelayMetricsTick    int
 }
 
 // Diagnostics stores diagnostics-related configuration.
@@ -84,13 +169,83 @@ func ReadConfig(filePath string) (*Config, error) {
 		return nil, fmt.Errorf("missing value for port; see node section in config file or use --port flag")
 	}
 
+	usedPorts := map[int]bool{config.LibP2P.Port: true}
+	for i := range config.Operators {
+		operator := &config.Operators[i]
+
+		if operator.KeyFile == "" {
+			return nil, fmt.Errorf("missing value for key file of operator [%v]", i)
+		}
+
+		if operator.KeyFilePassword == "" {
+			operator.KeyFilePassword = config.Ethereum.Account.KeyFilePassword
+		}
+
+		if operator.Port == 0 || usedPorts[operator.Port] {
+			return nil, fmt.Errorf(
+				"operator [%v] must listen on a port different from ports "+
+					"of other operators",
+				operator.KeyFile,
+			)
+		}
+		usedPorts[operator.Port] = true
+	}
+
 	if config.Storage.DataDir == "" {
 		return nil, fmt.Errorf("missing value for storage directory data")
 	}
 
+	dataDirs := []string{config.Storage.DataDir}
+	for i, operator := range config.Operators {
+		if operator.DataDir == "" {
+			return nil, fmt.Errorf(
+				"missing value for data directory of operator [%v]",
+				i,
+			)
+		}
+
+		for _, dataDir := range dataDirs {
+			if isSameOrNestedDir(operator.DataDir, dataDir) {
+				return nil, fmt.Errorf(
+					"data directory [%v] of operator [%v] must be separate "+
+						"from data directories of other operators",
+					operator.DataDir,
+					operator.KeyFile,
+				)
+			}
+		}
+		dataDirs = append(dataDirs, operator.DataDir)
+	}
+
 	return config, nil
 }
 
+// isSameOrNestedDir checks whether one of the given directories is the same
+// as or contains the other one.
+func isSameOrNestedDir(dir1, dir2 string) bool {
+	isNested := func(parent, child string) bool {
+		relative, err := filepath.Rel(parent, child)
+		if err != nil {
+			return false
+		}
+
+		return relative != ".." &&
+			!strings.HasPrefix(relative, ".."+string(filepath.Separator))
+	}
+
+	absolute := func(dir string) string {
+		absoluteDir, err := filepath.Abs(dir)
+		if err != nil {
+			return filepath.Clean(dir)
+		}
+
+		return absoluteDir
+	}
+
+	return isNested(absolute(dir1), absolute(dir2)) ||
+		isNested(absolute(dir2), absolute(dir1))
+}
+
 // ReadEthereumConfig reads in the configuration file at `filePath` and returns
 // its contained Ethereum config, or an error if something fails while reading
 // the file.
@@ -105,7 +260,7 @@ func ReadEthereumConfig(filePath string) (ethereum.Config, error) {
 		return ethereum.Config{}, err
 	}
 
-	return config.Ethereum, nil
+	return config.Ethereum.Config, nil
 }
 
 // ReadPassword prompts a user to enter a password.   The read password uses
@@ -5,6 +5,8 @@ import (
 	"os"
 	"reflect"
 	"testing"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 )
 
 func TestReadConfig(t *testing.T) {
@@ -41,6 +43,51 @@ func TestReadConfig(t *testing.T) {
 				"KeepRandomBeaconOperator": "0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb",
 			},
 		},
+		"Ethereum.EventConfirmations": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.EventConfirmations },
+			expectedValue: uint64(12),
+		},
+		"Ethereum.OperatorContractDeploymentBlock": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.OperatorContractDeploymentBlock },
+			expectedValue: uint64(10000000),
+		},
+		"Ethereum.Endpoints": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Endpoints },
+			expectedValue: []EthereumEndpoint{
+				{URL: "ws://192.168.0.159:8546", Priority: 1},
+			},
+		},
+		"Ethereum.HealthCheck": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.HealthCheck },
+			expectedValue: EthereumHealthCheck{
+				Interval:     15,
+				MaxHeadLag:   5,
+				MaxErrorRate: 0.25,
+				MaxLatency:   2000,
+			},
+		},
+		"Operators": {
+			readValueFunc: func(c *Config) interface{} { return c.Operators },
+			expectedValue: []Operator{
+				{
+					Account: ethereum.Account{
+						KeyFile:         "/tmp/UTC--2020-01-01T00-00-00.000000000Z--b5d2c4e3e46a5e0d7d8b8d1f06c4a1e9f9f2b9a1",
+						KeyFilePassword: "not-my-password",
+					},
+					Port:    27002,
+					DataDir: "/my/secure/location-operator-2",
+				},
+				{
+					Account: ethereum.Account{
+						KeyFile:         "/tmp/UTC--2020-01-01T00-00-00.000000000Z--7d2d1a6e4e9f0c3b1a8f5e6d4c3b2a1908f7e6d5",
+						KeyFilePassword: "operator-password",
+					},
+					Port:               27003,
+					AnnouncedAddresses: []string{"/dns4/example.com/tcp/27003"},
+					DataDir:            "/my/secure/location-operator-3",
+				},
+			},
+		},
 		"Storage.DataDir": {
 			readValueFunc: func(c *Config) interface{} { return c.Storage.DataDir },
 			expectedValue: "/my/secure/location",
@@ -49,6 +96,10 @@ func TestReadConfig(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Ethereum.MaxGasPrice.Int },
 			expectedValue: big.NewInt(140000000000),
 		},
+		"Ethereum.TicketSubmissionSpendLimit": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.TicketSubmissionSpendLimit.Int },
+			expectedValue: big.NewInt(500000000000000000),
+		},
 		"Ethereum.BalanceAlertThreshold": {
 			readValueFunc: func(c *Config) interface{} { return c.Ethereum.BalanceAlertThreshold.Int },
 			expectedValue: big.NewInt(2500000000000000000),
@@ -66,3 +117,45 @@ func TestReadConfig(t *testing.T) {
 	}
 
 }
+
+func TestIsSameOrNestedDir(t *testing.T) {
+	var tests = map[string]struct {
+		dir1     string
+		dir2     string
+		expected bool
+	}{
+		"the same directory": {
+			dir1:     "/data/keep",
+			dir2:     "/data/keep/",
+			expected: true,
+		},
+		"nested directory": {
+			dir1:     "/data/keep/0xabc",
+			dir2:     "/data/keep",
+			expected: true,
+		},
+		"containing directory": {
+			dir1:     "/data",
+			dir2:     "/data/keep",
+			expected: true,
+		},
+		"sibling directory": {
+			dir1:     "/data/keep-operator-2",
+			dir2:     "/data/keep",
+			expected: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actual := isSameOrNestedDir(test.dir1, test.dir2)
+			if test.expected != actual {
+				t.Errorf(
+					"unexpected result\nexpected: [%v]\nactual:   [%v]",
+					test.expected,
+					actual,
+				)
+			}
+		})
+	}
+}
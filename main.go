@@ -62,6 +62,9 @@ func main() {
 		cmd.RelayCommand,
 		cmd.PingCommand,
 		cmd.EthereumCommand,
+		cmd.DKGCommand,
+		cmd.SimulateCommand,
+		cmd.TransactionsCommand,
 	}
 
 	cli.AppHelpTemplate = fmt.Sprintf(`%s
@@ -0,0 +1,400 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// processedBlockTracker tracks blocks of chain events which are still being
+// processed by the client. It is used to determine the last block for which
+// all chain events have been fully processed. Events received from the chain
+// but still awaiting confirmations are not processed yet either; their
+// earliest block is obtained from unconfirmedEventBlock.
+type processedBlockTracker struct {
+	mutex      sync.Mutex
+	inProgress map[uint64]int
+
+	unconfirmedEventBlock func() (uint64, bool)
+}
+
+func newProcessedBlockTracker(
+	unconfirmedEventBlock func() (uint64, bool),
+) *processedBlockTracker {
+	return &processedBlockTracker{
+		inProgress:            make(map[uint64]int),
+		unconfirmedEventBlock: unconfirmedEventBlock,
+	}
+}
+
+// start registers processing of an event emitted at the given block. For
+// every call to start, the caller is responsible for calling done once the
+// event is processed.
+func (pbt *processedBlockTracker) start(blockNumber uint64) {
+	pbt.mutex.Lock()
+	defer pbt.mutex.Unlock()
+
+	pbt.inProgress[blockNumber]++
+}
+
+// done marks processing of an event emitted at the given block as completed.
+func (pbt *processedBlockTracker) done(blockNumber uint64) {
+	pbt.mutex.Lock()
+	defer pbt.mutex.Unlock()
+
+	pbt.inProgress[blockNumber]--
+	if pbt.inProgress[blockNumber] <= 0 {
+		delete(pbt.inProgress, blockNumber)
+	}
+}
+
+// lastProcessedBlock returns the last block for which all events have been
+// fully processed given the current block. Events from the current block may
+// not have been delivered yet so the current block is never considered as
+// processed.
+func (pbt *processedBlockTracker) lastProcessedBlock(currentBlock uint64) uint64 {
+	pbt.mutex.Lock()
+	defer pbt.mutex.Unlock()
+
+	lastProcessedBlock := currentBlock
+	for blockNumber := range pbt.inProgress {
+		if blockNumber < lastProcessedBlock {
+			lastProcessedBlock = blockNumber
+		}
+	}
+
+	if pbt.unconfirmedEventBlock != nil {
+		blockNumber, ok := pbt.unconfirmedEventBlock()
+		if ok && blockNumber < lastProcessedBlock {
+			lastProcessedBlock = blockNumber
+		}
+	}
+
+	if lastProcessedBlock == 0 {
+		return 0
+	}
+
+	return lastProcessedBlock - 1
+}
+
+// monitorProcessedBlock persists the last fully processed block every time
+// a new block is mined, until the provided context is done.
+func monitorProcessedBlock(
+	ctx context.Context,
+	blockCounter chain.BlockCounter,
+	tracker *processedBlockTracker,
+	processedBlock *registry.ProcessedBlock,
+) {
+	for currentBlock := range blockCounter.WatchBlocks(ctx) {
+		lastProcessedBlock := tracker.lastProcessedBlock(currentBlock)
+
+		if err := processedBlock.Save(lastProcessedBlock); err != nil {
+			logger.Warningf(
+				"could not persist last processed block [%v]: [%v]",
+				lastProcessedBlock,
+				err,
+			)
+		}
+	}
+}
+
+// backfillEvents fetches relay entry requests, group selection starts and
+// DKG result submissions emitted on-chain since the given block, inclusive,
+// and passes them to the provided handlers. Groups registered by submitted
+// DKG results are always passed as group registrations, while relay entry
+// requests and group selection starts are passed only if they are still
+// actionable. This way, the client does not miss work announced by events
+// emitted while it was offline.
+//
+// Just like events delivered by subscriptions, backfilled events are passed
+// to the handlers only once they have the required number of confirmations.
+// Events from blocks which are already confirmed are passed right away.
+// Events from the remaining blocks up to the current one are fetched again
+// once those blocks get confirmed, so that events removed by a chain
+// reorganization in the meantime are not passed. Events emitted after the
+// current block are delivered by subscriptions.
+func backfillEvents(
+	relayChain relaychain.Interface,
+	blockCounter chain.BlockCounter,
+	chainConfig *relaychain.Config,
+	fromBlock uint64,
+	onRelayEntryRequested func(request *event.Request),
+	onGroupSelectionStarted func(groupSelectionStart *event.GroupSelectionStart),
+	onGroupRegistered func(registration *event.GroupRegistration),
+) error {
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("could not read current block: [%v]", err)
+	}
+
+	confirmations := relayChain.EventConfirmations()
+
+	if currentBlock >= fromBlock+confirmations {
+		confirmedBlock := currentBlock - confirmations
+
+		err := backfillConfirmedEvents(
+			relayChain,
+			blockCounter,
+			chainConfig,
+			fromBlock,
+			confirmedBlock,
+			onRelayEntryRequested,
+			onGroupSelectionStarted,
+			onGroupRegistered,
+		)
+		if err != nil {
+			return err
+		}
+
+		fromBlock = confirmedBlock + 1
+	}
+
+	if fromBlock > currentBlock {
+		return nil
+	}
+
+	logger.Infof(
+		"waiting for block [%v] to backfill events emitted since block [%v]",
+		currentBlock+confirmations,
+		fromBlock,
+	)
+
+	err = blockCounter.WaitForBlockHeight(currentBlock + confirmations)
+	if err != nil {
+		return fmt.Errorf(
+			"could not wait for block [%v]: [%v]",
+			currentBlock+confirmations,
+			err,
+		)
+	}
+
+	return backfillConfirmedEvents(
+		relayChain,
+		blockCounter,
+		chainConfig,
+		fromBlock,
+		currentBlock,
+		onRelayEntryRequested,
+		onGroupSelectionStarted,
+		onGroupRegistered,
+	)
+}
+
+// backfillConfirmedEvents fetches events emitted on-chain in the given range
+// of blocks, both inclusive, and passes them to the provided handlers. All
+// blocks in the range are expected to have the required number of
+// confirmations.
+func backfillConfirmedEvents(
+	relayChain relaychain.Interface,
+	blockCounter chain.BlockCounter,
+	chainConfig *relaychain.Config,
+	fromBlock uint64,
+	toBlock uint64,
+	onRelayEntryRequested func(request *event.Request),
+	onGroupSelectionStarted func(groupSelectionStart *event.GroupSelectionStart),
+	onGroupRegistered func(registration *event.GroupRegistration),
+) error {
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("could not read current block: [%v]", err)
+	}
+
+	pastGroupSelectionStarts, err := relayChain.PastGroupSelectionStartedEvents(
+		fromBlock,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not fetch past group selection started events: [%v]",
+			err,
+		)
+	}
+
+	pastDKGResultSubmissions, err := relayChain.PastDKGResultSubmittedEvents(
+		fromBlock,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not fetch past DKG result submitted events: [%v]",
+			err,
+		)
+	}
+
+	pastRequests, err := relayChain.PastRelayEntryRequestedEvents(fromBlock)
+	if err != nil {
+		return fmt.Errorf(
+			"could not fetch past relay entry requested events: [%v]",
+			err,
+		)
+	}
+
+	currentRequestStartBlock, err := relayChain.CurrentRequestStartBlock()
+	if err != nil {
+		return fmt.Errorf(
+			"could not read current request start block: [%v]",
+			err,
+		)
+	}
+
+	// Events emitted after the last block of the range are not confirmed
+	// yet and are left to subscriptions.
+	groupSelectionStarts := make([]*event.GroupSelectionStart, 0)
+	for _, groupSelectionStart := range pastGroupSelectionStarts {
+		if groupSelectionStart.BlockNumber <= toBlock {
+			groupSelectionStarts = append(
+				groupSelectionStarts,
+				groupSelectionStart,
+			)
+		}
+	}
+
+	dkgResultSubmissions := make([]*event.DKGResultSubmission, 0)
+	for _, submission := range pastDKGResultSubmissions {
+		if submission.BlockNumber <= toBlock {
+			dkgResultSubmissions = append(dkgResultSubmissions, submission)
+		}
+	}
+
+	requests := make([]*event.Request, 0)
+	for _, request := range pastRequests {
+		if request.BlockNumber <= toBlock {
+			requests = append(requests, request)
+		}
+	}
+
+	logger.Infof(
+		"backfilling [%v] group selection started, [%v] DKG result "+
+			"submitted and [%v] relay entry requested events emitted "+
+			"from block [%v] to block [%v]",
+		len(groupSelectionStarts),
+		len(dkgResultSubmissions),
+		len(requests),
+		fromBlock,
+		toBlock,
+	)
+
+	// A group is registered on-chain along with the submission of its DKG
+	// result.
+	for _, submission := range dkgResultSubmissions {
+		onGroupRegistered(&event.GroupRegistration{
+			GroupPublicKey: submission.GroupPublicKey,
+			BlockNumber:    submission.BlockNumber,
+		})
+	}
+
+	for _, groupSelectionStart := range pendingGroupSelectionStarts(
+		groupSelectionStarts,
+		dkgResultSubmissions,
+		currentBlock,
+		chainConfig.TicketSubmissionTimeout,
+	) {
+		onGroupSelectionStarted(groupSelectionStart)
+	}
+
+	for _, request := range pendingRelayEntryRequests(
+		requests,
+		currentBlock,
+		currentRequestStartBlock.Uint64(),
+		chainConfig.RelayEntryTimeout,
+	) {
+		onRelayEntryRequested(request)
+	}
+
+	return nil
+}
+
+// pendingGroupSelectionStarts filters out group selections which are already
+// over. Group selection is over when its ticket submission has ended or when
+// a DKG result has been submitted after it started. The latter can happen
+// because only one group selection and its key generation can be in progress
+// on-chain at a time.
+func pendingGroupSelectionStarts(
+	groupSelectionStarts []*event.GroupSelectionStart,
+	dkgResultSubmissions []*event.DKGResultSubmission,
+	currentBlock uint64,
+	ticketSubmissionTimeout uint64,
+) []*event.GroupSelectionStart {
+	pending := make([]*event.GroupSelectionStart, 0)
+
+	for _, groupSelectionStart := range groupSelectionStarts {
+		ticketSubmissionEnd := groupSelectionStart.BlockNumber +
+			ticketSubmissionTimeout
+
+		if currentBlock >= ticketSubmissionEnd {
+			logger.Infof(
+				"skipping group selection with seed [0x%x] started at "+
+					"block [%v]; ticket submission ended at block [%v]",
+				groupSelectionStart.NewEntry,
+				groupSelectionStart.BlockNumber,
+				ticketSubmissionEnd,
+			)
+			continue
+		}
+
+		completed := false
+		for _, submission := range dkgResultSubmissions {
+			if submission.BlockNumber > groupSelectionStart.BlockNumber {
+				completed = true
+				break
+			}
+		}
+
+		if completed {
+			logger.Infof(
+				"skipping group selection with seed [0x%x] started at "+
+					"block [%v]; DKG result has been already submitted",
+				groupSelectionStart.NewEntry,
+				groupSelectionStart.BlockNumber,
+			)
+			continue
+		}
+
+		pending = append(pending, groupSelectionStart)
+	}
+
+	return pending
+}
+
+// pendingRelayEntryRequests filters out relay entry requests which have
+// already timed out or have been already served. Only one relay request can
+// be in progress on-chain at a time, so a request is still pending only if
+// it started at the current request start block read from the chain.
+func pendingRelayEntryRequests(
+	requests []*event.Request,
+	currentBlock uint64,
+	currentRequestStartBlock uint64,
+	relayEntryTimeout uint64,
+) []*event.Request {
+	pending := make([]*event.Request, 0)
+
+	for _, request := range requests {
+		timeoutBlock := request.BlockNumber + relayEntryTimeout
+
+		if currentBlock >= timeoutBlock {
+			logger.Infof(
+				"skipping relay entry request from block [%v]; "+
+					"it timed out at block [%v]",
+				request.BlockNumber,
+				timeoutBlock,
+			)
+			continue
+		}
+
+		if request.BlockNumber != currentRequestStartBlock {
+			logger.Infof(
+				"skipping relay entry request from block [%v]; "+
+					"it is not in progress anymore",
+				request.BlockNumber,
+			)
+			continue
+		}
+
+		pending = append(pending, request)
+	}
+
+	return pending
+}
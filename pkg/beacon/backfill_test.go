@@ -0,0 +1,343 @@
+package beacon
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestLastProcessedBlock(t *testing.T) {
+	var tests = map[string]struct {
+		inProgress []uint64
+		completed  []uint64
+		// unconfirmed is the earliest block of events awaiting
+		// confirmations; zero if there are none.
+		unconfirmed       uint64
+		currentBlock      uint64
+		expectedLastBlock uint64
+	}{
+		"nothing in progress": {
+			currentBlock:      100,
+			expectedLastBlock: 99,
+		},
+		"one event in progress": {
+			inProgress:        []uint64{90},
+			currentBlock:      100,
+			expectedLastBlock: 89,
+		},
+		"multiple events in progress": {
+			inProgress:        []uint64{95, 90, 97},
+			currentBlock:      100,
+			expectedLastBlock: 89,
+		},
+		"earliest event completed": {
+			inProgress:        []uint64{95, 90, 97},
+			completed:         []uint64{90},
+			currentBlock:      100,
+			expectedLastBlock: 94,
+		},
+		"one of two events from the same block completed": {
+			inProgress:        []uint64{90, 90, 97},
+			completed:         []uint64{90},
+			currentBlock:      100,
+			expectedLastBlock: 89,
+		},
+		"all events completed": {
+			inProgress:        []uint64{90, 97},
+			completed:         []uint64{97, 90},
+			currentBlock:      100,
+			expectedLastBlock: 99,
+		},
+		"event awaiting confirmations": {
+			unconfirmed:       92,
+			currentBlock:      100,
+			expectedLastBlock: 91,
+		},
+		"event awaiting confirmations before events in progress": {
+			inProgress:        []uint64{95, 97},
+			unconfirmed:       92,
+			currentBlock:      100,
+			expectedLastBlock: 91,
+		},
+		"event awaiting confirmations after events in progress": {
+			inProgress:        []uint64{90, 97},
+			unconfirmed:       92,
+			currentBlock:      100,
+			expectedLastBlock: 89,
+		},
+		"genesis block": {
+			currentBlock:      0,
+			expectedLastBlock: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tracker := newProcessedBlockTracker(func() (uint64, bool) {
+				return test.unconfirmed, test.unconfirmed != 0
+			})
+
+			for _, blockNumber := range test.inProgress {
+				tracker.start(blockNumber)
+			}
+			for _, blockNumber := range test.completed {
+				tracker.done(blockNumber)
+			}
+
+			lastBlock := tracker.lastProcessedBlock(test.currentBlock)
+			if lastBlock != test.expectedLastBlock {
+				t.Errorf(
+					"unexpected last processed block\nexpected: [%v]\nactual:   [%v]",
+					test.expectedLastBlock,
+					lastBlock,
+				)
+			}
+		})
+	}
+}
+
+func TestPendingGroupSelectionStarts(t *testing.T) {
+	ticketSubmissionTimeout := uint64(10)
+
+	groupSelectionStarts := []*event.GroupSelectionStart{
+		{NewEntry: big.NewInt(1), BlockNumber: 100},
+		{NewEntry: big.NewInt(2), BlockNumber: 200},
+	}
+
+	var tests = map[string]struct {
+		dkgResultSubmissions []*event.DKGResultSubmission
+		currentBlock         uint64
+		expectedPending      []*event.GroupSelectionStart
+	}{
+		"ticket submission in progress": {
+			currentBlock:    205,
+			expectedPending: groupSelectionStarts[1:],
+		},
+		"ticket submission ended": {
+			currentBlock:    210,
+			expectedPending: []*event.GroupSelectionStart{},
+		},
+		"DKG result submitted after the group selection": {
+			dkgResultSubmissions: []*event.DKGResultSubmission{
+				{BlockNumber: 150},
+				{BlockNumber: 204},
+			},
+			currentBlock:    205,
+			expectedPending: []*event.GroupSelectionStart{},
+		},
+		"DKG result submitted before the group selection": {
+			dkgResultSubmissions: []*event.DKGResultSubmission{
+				{BlockNumber: 150},
+			},
+			currentBlock:    205,
+			expectedPending: groupSelectionStarts[1:],
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			pending := pendingGroupSelectionStarts(
+				groupSelectionStarts,
+				test.dkgResultSubmissions,
+				test.currentBlock,
+				ticketSubmissionTimeout,
+			)
+
+			if !reflect.DeepEqual(test.expectedPending, pending) {
+				t.Errorf(
+					"unexpected pending group selections\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPending,
+					pending,
+				)
+			}
+		})
+	}
+}
+
+func TestPendingRelayEntryRequests(t *testing.T) {
+	relayEntryTimeout := uint64(20)
+
+	requests := []*event.Request{
+		{PreviousEntry: []byte{1}, BlockNumber: 100},
+		{PreviousEntry: []byte{2}, BlockNumber: 110},
+		{PreviousEntry: []byte{3}, BlockNumber: 130},
+	}
+
+	var tests = map[string]struct {
+		currentRequestStartBlock uint64
+		expectedPending          []*event.Request
+	}{
+		"request in progress": {
+			currentRequestStartBlock: 130,
+			expectedPending:          requests[2:],
+		},
+		"request in progress timed out": {
+			currentRequestStartBlock: 100,
+			expectedPending:          []*event.Request{},
+		},
+		"all requests served": {
+			currentRequestStartBlock: 0,
+			expectedPending:          []*event.Request{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			pending := pendingRelayEntryRequests(
+				requests,
+				135,
+				test.currentRequestStartBlock,
+				relayEntryTimeout,
+			)
+
+			if !reflect.DeepEqual(test.expectedPending, pending) {
+				t.Errorf(
+					"unexpected pending relay requests\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPending,
+					pending,
+				)
+			}
+		})
+	}
+}
+
+func TestBackfillEventsRegistersGroupsOfSubmittedResults(t *testing.T) {
+	chainHandle := chainLocal.Connect(5, 3, big.NewInt(200))
+	relayChain := chainHandle.ThresholdRelay()
+
+	blockCounter, err := chainHandle.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupPublicKey := []byte{1, 2, 3}
+
+	relayChain.SubmitDKGResult(
+		1,
+		&relaychain.DKGResult{GroupPublicKey: groupPublicKey},
+		map[relaychain.GroupMemberIndex][]byte{1: {1}, 2: {2}, 3: {3}},
+	).OnFailure(func(err error) {
+		t.Fatal(err)
+	})
+
+	registrations := make([]*event.GroupRegistration, 0)
+
+	err = backfillEvents(
+		relayChain,
+		blockCounter,
+		relayChain.GetConfig(),
+		0,
+		func(request *event.Request) {},
+		func(groupSelectionStart *event.GroupSelectionStart) {},
+		func(registration *event.GroupRegistration) {
+			registrations = append(registrations, registration)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRegistrations := []*event.GroupRegistration{
+		{GroupPublicKey: groupPublicKey, BlockNumber: 0},
+	}
+	if !reflect.DeepEqual(expectedRegistrations, registrations) {
+		t.Errorf(
+			"unexpected group registrations\nexpected: [%v]\nactual:   [%v]",
+			expectedRegistrations,
+			registrations,
+		)
+	}
+}
+
+func TestBackfillEventsWaitsForConfirmations(t *testing.T) {
+	var tests = map[string]struct {
+		removedByReorg        bool
+		expectedRegistrations int
+	}{
+		"event confirmed": {
+			removedByReorg:        false,
+			expectedRegistrations: 1,
+		},
+		"event removed by chain reorganization": {
+			removedByReorg:        true,
+			expectedRegistrations: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chainHandle := chainLocal.Connect(5, 3, big.NewInt(200))
+			chainHandle.SetEventConfirmations(2)
+			relayChain := chainHandle.ThresholdRelay()
+
+			blockCounter, err := chainHandle.BlockCounter()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			submissionBlock, err := blockCounter.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			relayChain.SubmitDKGResult(
+				1,
+				&relaychain.DKGResult{GroupPublicKey: []byte{1, 2, 3}},
+				map[relaychain.GroupMemberIndex][]byte{1: {1}, 2: {2}, 3: {3}},
+			).OnFailure(func(err error) {
+				t.Fatal(err)
+			})
+
+			registrations := make(chan *event.GroupRegistration, 1)
+			backfillErr := make(chan error, 1)
+			go func() {
+				backfillErr <- backfillEvents(
+					relayChain,
+					blockCounter,
+					relayChain.GetConfig(),
+					submissionBlock,
+					func(request *event.Request) {},
+					func(groupSelectionStart *event.GroupSelectionStart) {},
+					func(registration *event.GroupRegistration) {
+						registrations <- registration
+					},
+				)
+			}()
+
+			if test.removedByReorg {
+				chainHandle.SimulateReorg(submissionBlock, 0)
+			}
+
+			if err := <-backfillErr; err != nil {
+				t.Fatal(err)
+			}
+
+			currentBlock, err := blockCounter.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if currentBlock < submissionBlock+2 {
+				t.Errorf(
+					"events backfilled before getting confirmations\n"+
+						"expected block: [>= %v]\nactual block:   [%v]",
+					submissionBlock+2,
+					currentBlock,
+				)
+			}
+
+			if len(registrations) != test.expectedRegistrations {
+				t.Errorf(
+					"unexpected number of group registrations\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedRegistrations,
+					len(registrations),
+				)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package beacon
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -16,43 +18,163 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 var logger = log.Logger("keep-beacon")
 
+// Beacon is a handle to the running random beacon. It allows to gracefully
+// stop the beacon, waiting for all the protocols currently executed by this
+// client to complete.
+type Beacon struct {
+	mutex sync.Mutex
+
+	node *relay.Node
+
+	relayChain             relaychain.Interface
+	chainConfig            *relaychain.Config
+	groupRegistry          *registry.Groups
+	pendingGroupSelections *event.GroupSelectionTrack
+	pendingRelayRequests   *event.RelayRequestTrack
+
+	subscriptions []subscription.EventSubscription
+	cancelCtx     context.CancelFunc
+
+	// stopping is set when the beacon is requested to stop. Once set, no new
+	// group selection is started.
+	stopping bool
+	// groupSelections tracks ticket submissions currently running in
+	// the background.
+	groupSelections *sync.WaitGroup
+}
+
+// startGroupSelection registers a new group selection with the beacon. It
+// returns false if the beacon is stopping and the group selection must not be
+// started. For every call returning true, the caller is responsible for calling
+// groupSelections.Done once the group selection completes.
+func (b *Beacon) startGroupSelection() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.stopping {
+		return false
+	}
+
+	b.groupSelections.Add(1)
+	return true
+}
+
+func (b *Beacon) isStopping() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.stopping
+}
+
+// Stop gracefully stops the random beacon. It unsubscribes from all chain
+// events, cancels ticket submissions in progress, refuses to start any new DKG
+// or signing work, and waits for all DKG and signing executions currently
+// in progress to complete. If the provided context is done before that
+// happens, Stop returns an error.
+func (b *Beacon) Stop(ctx context.Context) error {
+	logger.Infof("stopping random beacon")
+
+	b.mutex.Lock()
+	b.stopping = true
+	b.mutex.Unlock()
+
+	for _, subscription := range b.subscriptions {
+		subscription.Unsubscribe()
+	}
+
+	b.cancelCtx()
+
+	groupSelectionsCompleted := make(chan struct{})
+	go func() {
+		b.groupSelections.Wait()
+		close(groupSelectionsCompleted)
+	}()
+
+	select {
+	case <-groupSelectionsCompleted:
+	case <-ctx.Done():
+		return fmt.Errorf(
+			"random beacon stopped before ticket submissions completed: [%v]",
+			ctx.Err(),
+		)
+	}
+
+	if err := b.node.Stop(ctx); err != nil {
+		return err
+	}
+
+	logger.Infof("random beacon stopped")
+
+	return nil
+}
+
 // Initialize kicks off the random beacon by initializing internal state,
 // ensuring preconditions like staking are met, and then kicking off the
-// internal random beacon implementation. Returns an error if this failed,
-// otherwise enters a blocked loop.
+// internal random beacon implementation. Evidence logs of distributed key
+// generations are kept in the persistence for the given retention period.
+// Group selection tickets are submitted according to policies created by the
+// given factory, or all tickets which can make it to the group are submitted
+// if it is nil. Past events needed to migrate groups persisted in the legacy
+// format are searched starting from the operator contract deployment block.
+// Returns an error if this failed, otherwise returns a handle to the running
+// beacon which should be used to stop it.
 func Initialize(
 	ctx context.Context,
 	stakingID string,
 	chainHandle chain.Handle,
 	netProvider net.Provider,
 	persistence persistence.Handle,
-) error {
+	dkgEvidenceRetention time.Duration,
+	ticketSubmissionPolicy groupselection.SubmissionPolicyFactory,
+	operatorContractDeploymentBlock uint64,
+) (*Beacon, error) {
+	if ticketSubmissionPolicy == nil {
+		// Zero submission cost and no spend limit: all tickets which can
+		// make it to the group are submitted.
+		ticketSubmissionPolicy = groupselection.NewGasAwarePolicy(
+			big.NewInt(0),
+			nil,
+		)
+	}
+
 	relayChain := chainHandle.ThresholdRelay()
 	chainConfig := relayChain.GetConfig()
 
 	stakeMonitor, err := chainHandle.StakeMonitor()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	staker, err := stakeMonitor.StakerFor(stakingID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	blockCounter, err := chainHandle.BlockCounter()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signing := chainHandle.Signing()
 
 	groupRegistry := registry.NewGroupRegistry(relayChain, persistence)
 	groupRegistry.LoadExistingGroups()
+	groupRegistry.MigrateMemberships(
+		relayChain,
+		operatorContractDeploymentBlock,
+	)
+	groupRegistry.VerifyMemberships(relayChain, staker.Address())
+
+	dkgEvidenceLog := registry.NewDKGEvidenceLog(
+		persistence,
+		dkgEvidenceRetention,
+	)
+	dkgEvidenceLog.ArchiveExpired(time.Now())
 
 	node := relay.NewNode(
 		staker,
@@ -60,6 +182,8 @@ func Initialize(
 		blockCounter,
 		chainConfig,
 		groupRegistry,
+		registry.NewDKGCheckpoints(persistence),
+		dkgEvidenceLog,
 	)
 
 	pendingGroupSelections := &event.GroupSelectionTrack{
@@ -72,65 +196,94 @@ func Initialize(
 		Mutex: &sync.Mutex{},
 	}
 
-	node.ResumeSigningIfEligible(relayChain, signing)
+	beaconCtx, cancelBeaconCtx := context.WithCancel(ctx)
+
+	beacon := &Beacon{
+		node:                   &node,
+		relayChain:             relayChain,
+		chainConfig:            chainConfig,
+		groupRegistry:          groupRegistry,
+		pendingGroupSelections: pendingGroupSelections,
+		pendingRelayRequests:   pendingRelayRequests,
+		cancelCtx:              cancelBeaconCtx,
+		groupSelections:        &sync.WaitGroup{},
+	}
 
-	_ = relayChain.OnRelayEntryRequested(func(request *event.Request) {
-		onConfirmed := func() {
-			if node.IsInGroup(request.GroupPublicKey) {
-				go func() {
-					previousEntry := hex.EncodeToString(request.PreviousEntry[:])
-
-					if ok := pendingRelayRequests.Add(previousEntry); !ok {
-						logger.Warningf(
-							"relay entry requested event with previous entry "+
-								"[0x%x] has been registered already",
-							request.PreviousEntry,
-						)
-						return
-					}
-
-					defer pendingRelayRequests.Remove(previousEntry)
-
-					logger.Infof(
-						"new relay entry requested at block [%v] from group "+
-							"[0x%x] using previous entry [0x%x]",
-						request.BlockNumber,
-						request.GroupPublicKey,
-						request.PreviousEntry,
-					)
+	node.ResumeSigningIfEligible(relayChain, signing)
+	node.ResumeDKGIfEligible(relayChain, signing)
 
-					node.GenerateRelayEntry(
-						request.PreviousEntry,
-						relayChain,
-						signing,
-						request.GroupPublicKey,
-						request.BlockNumber,
-					)
-				}()
-			} else {
-				go node.ForwardSignatureShares(request.GroupPublicKey)
-			}
+	processedBlock := registry.NewProcessedBlock(persistence)
+	processedBlockTracker := newProcessedBlockTracker(
+		relayChain.EarliestUnconfirmedEventBlock,
+	)
 
-			go node.MonitorRelayEntry(
-				relayChain,
+	onRelayEntryRequested := func(request *event.Request) {
+		if beacon.isStopping() {
+			logger.Warningf(
+				"beacon is stopping; ignoring relay entry request "+
+					"from block [%v]",
 				request.BlockNumber,
-				chainConfig,
 			)
+			return
 		}
 
-		currentRelayRequestConfirmationRetries := 30
-		currentRelayRequestConfirmationDelay := time.Second
+		processedBlockTracker.start(request.BlockNumber)
+		defer processedBlockTracker.done(request.BlockNumber)
 
-		confirmCurrentRelayRequest(
-			request.BlockNumber,
+		if node.IsInGroup(request.GroupPublicKey) {
+			go func() {
+				previousEntry := hex.EncodeToString(request.PreviousEntry[:])
+
+				if ok := pendingRelayRequests.Add(previousEntry); !ok {
+					logger.Warningf(
+						"relay entry requested event with previous entry "+
+							"[0x%x] has been registered already",
+						request.PreviousEntry,
+					)
+					return
+				}
+
+				defer pendingRelayRequests.Remove(previousEntry)
+
+				logger.Infof(
+					"new relay entry requested at block [%v] from group "+
+						"[0x%x] using previous entry [0x%x]",
+					request.BlockNumber,
+					request.GroupPublicKey,
+					request.PreviousEntry,
+				)
+
+				node.GenerateRelayEntry(
+					request.PreviousEntry,
+					relayChain,
+					signing,
+					request.GroupPublicKey,
+					request.BlockNumber,
+				)
+			}()
+		} else {
+			go node.ForwardSignatureShares(request.GroupPublicKey)
+		}
+
+		go node.MonitorRelayEntry(
 			relayChain,
-			onConfirmed,
-			currentRelayRequestConfirmationRetries,
-			currentRelayRequestConfirmationDelay,
+			request.PreviousEntry,
+			request.GroupPublicKey,
+			request.BlockNumber,
+			chainConfig,
 		)
-	})
+	}
+
+	onGroupSelectionStarted := func(event *event.GroupSelectionStart) {
+		if beacon.isStopping() {
+			logger.Warningf(
+				"beacon is stopping; ignoring group selection "+
+					"started at block [%v]",
+				event.BlockNumber,
+			)
+			return
+		}
 
-	_ = relayChain.OnGroupSelectionStarted(func(event *event.GroupSelectionStart) {
 		onGroupSelected := func(group *groupselection.Result) {
 			for index, staker := range group.SelectedStakers {
 				logger.Infof(
@@ -148,7 +301,16 @@ func Initialize(
 		}
 
 		newEntry := event.NewEntry.Text(16)
+		if !beacon.startGroupSelection() {
+			return
+		}
+
+		processedBlockTracker.start(event.BlockNumber)
+
 		go func() {
+			defer beacon.groupSelections.Done()
+			defer processedBlockTracker.done(event.BlockNumber)
+
 			if ok := pendingGroupSelections.Add(newEntry); !ok {
 				logger.Errorf(
 					"group selection event with seed [0x%x] has been registered already",
@@ -166,122 +328,88 @@ func Initialize(
 			)
 
 			err := groupselection.CandidateToNewGroup(
+				beaconCtx,
 				relayChain,
 				blockCounter,
 				chainConfig,
 				staker,
 				event.NewEntry,
 				event.BlockNumber,
+				ticketSubmissionPolicy(chainConfig.GroupSize),
 				onGroupSelected,
 			)
 			if err != nil {
 				logger.Errorf("Tickets submission failed: [%v]", err)
 			}
 		}()
-	})
+	}
 
-	_ = relayChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
+	onGroupRegistered := func(registration *event.GroupRegistration) {
 		logger.Infof(
 			"new group with public key [0x%x] registered on-chain at block [%v]",
 			registration.GroupPublicKey,
 			registration.BlockNumber,
 		)
 		go groupRegistry.UnregisterStaleGroups(registration.GroupPublicKey)
-	})
+	}
 
-	return nil
-}
+	relayEntryRequestedSubscription := relayChain.OnRelayEntryRequested(
+		onRelayEntryRequested,
+	)
 
-// Before we start relay entry signing process we need to confirm the current
-// relay request start block on the chain. This is to avoid having the client
-// participating in an old relay request signing that has already completed
-// with the rest of the signing group member clients and the result has been
-// already published to the chain.
-//
-// Such situation may happen when the current client received multiple blocks
-// at once after a longer delay and in those blocks to relay request events
-// to the same signing group were emitted.
-//
-// The confirmation mechanism has built-in retries. We can retry in case of an
-// error but also when the expected request start block does not match the one
-// currently registered on the chain. Such situation may happen for Infura-like
-// setup when two or more chain clients are behind a load balancer and they do
-// not have their state in sync yet.
-func confirmCurrentRelayRequest(
-	expectedRequestStartBlock uint64,
-	chain relaychain.RelayEntryInterface,
-	onConfirmed func(),
-	maxRetries int,
-	delay time.Duration,
-) {
-	for i := 1; ; i++ {
-		currentRequestStartBlockBigInt, err := chain.CurrentRequestStartBlock()
-		if err != nil {
-			if i == maxRetries {
-				logger.Errorf(
-					"could not check current request start block: [%v]; "+
-						"giving up after [%v] retries",
-					err,
-					maxRetries,
-				)
-				return
-			}
+	groupSelectionStartedSubscription := relayChain.OnGroupSelectionStarted(
+		onGroupSelectionStarted,
+	)
 
-			logger.Warningf(
-				"could not check current request start block: [%v]; "+
-					"will retry after [%v]",
-				err,
-				delay,
-			)
-			time.Sleep(delay)
-			continue
-		}
+	groupRegisteredSubscription := relayChain.OnGroupRegistered(
+		onGroupRegistered,
+	)
 
-		currentRequestStartBlock := currentRequestStartBlockBigInt.Uint64()
+	go node.MonitorShareRefreshes(beaconCtx, relayChain, signing)
 
-		if currentRequestStartBlock == expectedRequestStartBlock {
-			onConfirmed()
-			return
-		} else if currentRequestStartBlock > expectedRequestStartBlock {
-			logger.Infof(
-				"the currently pending relay request started at block [%v]; "+
-					"skipping the execution of the old relay request from block [%v]",
-				currentRequestStartBlock,
-				expectedRequestStartBlock,
+	beacon.subscriptions = []subscription.EventSubscription{
+		relayEntryRequestedSubscription,
+		groupSelectionStartedSubscription,
+		groupRegisteredSubscription,
+	}
+
+	// Backfill events emitted while the client was offline only after
+	// subscribing so that no event is missed in between. Events delivered
+	// both ways are deduplicated by pending group selections and relay
+	// requests tracks or skipped as no longer actionable. Unregistering
+	// stale groups again for a registration delivered both ways has no
+	// effect. The last processed block is monitored only after the backfill
+	// so that it does not advance past backfilled events before they are
+	// handled.
+	lastProcessedBlock, hasProcessedBlock := processedBlock.Load()
+	go func() {
+		if hasProcessedBlock {
+			err := backfillEvents(
+				relayChain,
+				blockCounter,
+				chainConfig,
+				lastProcessedBlock+1,
+				onRelayEntryRequested,
+				onGroupSelectionStarted,
+				onGroupRegistered,
 			)
-			return
-		} else if i == maxRetries {
-			// This scenario usually happens when an entry was submitted very
-			// fast before this node receives an event and is able to confirm a
-			// request ID.
-			if currentRequestStartBlock == 0 {
-				logger.Warningf(
-					"there is no entry in progress; "+
-						"current request start block is 0 "+
-						"giving up after [%v] retries",
-					currentRequestStartBlock,
-					maxRetries,
-				)
-			} else {
+			if err != nil {
 				logger.Errorf(
-					"could not confirm the expected relay request starting block; "+
-						"the most recent one obtained from chain is [%v] and the "+
-						"expected one is [%v]; giving up after [%v] retries",
-					currentRequestStartBlock,
-					expectedRequestStartBlock,
-					maxRetries,
+					"could not backfill missed chain events: [%v]",
+					err,
 				)
 			}
-			return
 		} else {
-			logger.Infof(
-				"received unexpected pending relay request start block [%v] "+
-					"while the expected was [%v]; will retry after [%v]",
-				currentRequestStartBlock,
-				expectedRequestStartBlock,
-				delay,
-			)
-			time.Sleep(delay)
+			logger.Infof("no processed block found; skipping events backfill")
 		}
-	}
+
+		monitorProcessedBlock(
+			beaconCtx,
+			blockCounter,
+			processedBlockTracker,
+			processedBlock,
+		)
+	}()
+
+	return beacon, nil
 }
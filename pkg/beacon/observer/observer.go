@@ -0,0 +1,266 @@
+// Package observer implements a non-staking observer of the random beacon.
+// The observer follows relay events emitted on-chain, verifies submitted relay
+// entries against the public key of the group that produced them and keeps
+// statistics about the beacon activity. It never takes part in any protocol
+// and never submits anything to the chain.
+package observer
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+var logger = log.Logger("keep-observer")
+
+// Chain is the subset of the relay chain interface used by the observer.
+// It consists of event subscriptions and read-only calls only so that the
+// observer is not able to submit any transaction.
+type Chain interface {
+	OnRelayEntryRequested(
+		func(request *event.Request),
+	) subscription.EventSubscription
+	OnRelayEntrySubmitted(
+		func(entry *event.EntrySubmitted),
+	) subscription.EventSubscription
+	OnGroupSelectionStarted(
+		func(groupSelectionStart *event.GroupSelectionStart),
+	) subscription.EventSubscription
+	OnGroupRegistered(
+		func(groupRegistration *event.GroupRegistration),
+	) subscription.EventSubscription
+	OnDKGResultSubmitted(
+		func(dkgResultSubmission *event.DKGResultSubmission),
+	) subscription.EventSubscription
+	IsGroupRegistered(groupPublicKey []byte) (bool, error)
+}
+
+// Stats holds counters of the random beacon activity seen by the observer.
+type Stats struct {
+	RelayEntriesRequested  uint64
+	RelayEntriesSubmitted  uint64
+	RelayEntriesVerified   uint64
+	RelayEntriesInvalid    uint64
+	RelayEntriesTimedOut   uint64
+	GroupSelectionsStarted uint64
+	DKGResultsSubmitted    uint64
+	GroupsRegistered       uint64
+	// LastEventBlock is the block of the most recent event seen.
+	LastEventBlock uint64
+}
+
+// Observer follows the random beacon activity on-chain.
+type Observer struct {
+	mutex sync.Mutex
+
+	chain         Chain
+	subscriptions []subscription.EventSubscription
+
+	// lastRequest is the most recent relay request. Relay entry submitted
+	// event carries no data, so the entry produced for the last request is
+	// verified once it is revealed as the previous entry of the next request.
+	lastRequest *event.Request
+
+	stats Stats
+}
+
+// Start creates an observer and subscribes it to relay events emitted by the
+// given chain.
+func Start(chain Chain) *Observer {
+	observer := &Observer{chain: chain}
+
+	observer.subscriptions = []subscription.EventSubscription{
+		chain.OnRelayEntryRequested(observer.onRelayEntryRequested),
+		chain.OnRelayEntrySubmitted(observer.onRelayEntrySubmitted),
+		chain.OnGroupSelectionStarted(observer.onGroupSelectionStarted),
+		chain.OnGroupRegistered(observer.onGroupRegistered),
+		chain.OnDKGResultSubmitted(observer.onDKGResultSubmitted),
+	}
+
+	logger.Infof("observing random beacon events")
+
+	return observer
+}
+
+// Stop unsubscribes the observer from all relay events.
+func (o *Observer) Stop() {
+	for _, subscription := range o.subscriptions {
+		subscription.Unsubscribe()
+	}
+
+	logger.Infof("stopped observing random beacon events")
+}
+
+// Stats returns a snapshot of the random beacon activity seen so far.
+func (o *Observer) Stats() Stats {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.stats
+}
+
+func (o *Observer) onRelayEntryRequested(request *event.Request) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.stats.RelayEntriesRequested++
+	o.seenBlock(request.BlockNumber)
+
+	logger.Infof(
+		"relay entry requested at block [%v] from group [0x%x] "+
+			"using previous entry [0x%x]",
+		request.BlockNumber,
+		request.GroupPublicKey,
+		request.PreviousEntry,
+	)
+
+	previousRequest := o.lastRequest
+	o.lastRequest = request
+
+	if previousRequest == nil {
+		return
+	}
+
+	// The same previous entry is requested again when the group selected
+	// for the previous request did not deliver the entry on time.
+	if bytes.Equal(previousRequest.PreviousEntry, request.PreviousEntry) {
+		o.stats.RelayEntriesTimedOut++
+		logger.Warningf(
+			"group [0x%x] did not deliver relay entry requested at block [%v]",
+			previousRequest.GroupPublicKey,
+			previousRequest.BlockNumber,
+		)
+		return
+	}
+
+	err := o.verifyEntry(previousRequest, request.PreviousEntry)
+	if err != nil {
+		o.stats.RelayEntriesInvalid++
+		logger.Errorf(
+			"invalid relay entry [0x%x] produced by group [0x%x] "+
+				"for request from block [%v]: [%v]",
+			request.PreviousEntry,
+			previousRequest.GroupPublicKey,
+			previousRequest.BlockNumber,
+			err,
+		)
+		return
+	}
+
+	o.stats.RelayEntriesVerified++
+	logger.Infof(
+		"verified relay entry [0x%x] produced by group [0x%x] "+
+			"for request from block [%v]",
+		request.PreviousEntry,
+		previousRequest.GroupPublicKey,
+		previousRequest.BlockNumber,
+	)
+}
+
+// verifyEntry checks if the entry is a valid signature of the previous entry
+// of the given request, produced by the registered group selected for the
+// request.
+func (o *Observer) verifyEntry(request *event.Request, entry []byte) error {
+	isGroupRegistered, err := o.chain.IsGroupRegistered(request.GroupPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not check group registration: [%v]", err)
+	}
+	if !isGroupRegistered {
+		return fmt.Errorf("group is not registered")
+	}
+
+	groupPublicKey := new(bn256.G2)
+	if _, err := groupPublicKey.Unmarshal(request.GroupPublicKey); err != nil {
+		return fmt.Errorf("could not unmarshal group public key: [%v]", err)
+	}
+
+	previousEntry := new(bn256.G1)
+	if _, err := previousEntry.Unmarshal(request.PreviousEntry); err != nil {
+		return fmt.Errorf("could not unmarshal previous entry: [%v]", err)
+	}
+
+	signature := new(bn256.G1)
+	if _, err := signature.Unmarshal(entry); err != nil {
+		return fmt.Errorf("could not unmarshal entry: [%v]", err)
+	}
+
+	if !bls.VerifyG1(groupPublicKey, previousEntry, signature) {
+		return fmt.Errorf("entry is not a valid group signature")
+	}
+
+	return nil
+}
+
+func (o *Observer) onRelayEntrySubmitted(entry *event.EntrySubmitted) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.stats.RelayEntriesSubmitted++
+	o.seenBlock(entry.BlockNumber)
+
+	logger.Infof("relay entry submitted at block [%v]", entry.BlockNumber)
+}
+
+func (o *Observer) onGroupSelectionStarted(
+	groupSelectionStart *event.GroupSelectionStart,
+) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.stats.GroupSelectionsStarted++
+	o.seenBlock(groupSelectionStart.BlockNumber)
+
+	logger.Infof(
+		"group selection started with seed [0x%x] at block [%v]",
+		groupSelectionStart.NewEntry,
+		groupSelectionStart.BlockNumber,
+	)
+}
+
+func (o *Observer) onGroupRegistered(
+	groupRegistration *event.GroupRegistration,
+) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.stats.GroupsRegistered++
+	o.seenBlock(groupRegistration.BlockNumber)
+
+	logger.Infof(
+		"group with public key [0x%x] registered at block [%v]",
+		groupRegistration.GroupPublicKey,
+		groupRegistration.BlockNumber,
+	)
+}
+
+func (o *Observer) onDKGResultSubmitted(
+	dkgResultSubmission *event.DKGResultSubmission,
+) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.stats.DKGResultsSubmitted++
+	o.seenBlock(dkgResultSubmission.BlockNumber)
+
+	logger.Infof(
+		"DKG result with group public key [0x%x] submitted by member [%v] "+
+			"at block [%v]; misbehaved members: [0x%x]",
+		dkgResultSubmission.GroupPublicKey,
+		dkgResultSubmission.MemberIndex,
+		dkgResultSubmission.BlockNumber,
+		dkgResultSubmission.Misbehaved,
+	)
+}
+
+func (o *Observer) seenBlock(blockNumber uint64) {
+	if blockNumber > o.stats.LastEventBlock {
+		o.stats.LastEventBlock = blockNumber
+	}
+}
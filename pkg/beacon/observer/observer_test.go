@@ -0,0 +1,218 @@
+package observer
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+func TestRelayEntryVerification(t *testing.T) {
+	groupSecretKey := big.NewInt(123)
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupSecretKey).Marshal()
+
+	unregisteredSecretKey := big.NewInt(456)
+	unregisteredPublicKey := new(bn256.G2).ScalarBaseMult(
+		unregisteredSecretKey,
+	).Marshal()
+
+	entry0 := new(bn256.G1).ScalarBaseMult(big.NewInt(10))
+	entry1 := bls.SignG1(groupSecretKey, entry0)
+	invalidEntry2 := new(bn256.G1).ScalarBaseMult(big.NewInt(20))
+	entry3 := bls.SignG1(unregisteredSecretKey, invalidEntry2)
+
+	var tests = map[string]struct {
+		requests      []*event.Request
+		expectedStats Stats
+	}{
+		"first request": {
+			requests: []*event.Request{
+				newRequest(entry0, groupPublicKey, 100),
+			},
+			expectedStats: Stats{
+				RelayEntriesRequested: 1,
+				LastEventBlock:        100,
+			},
+		},
+		"valid entry": {
+			requests: []*event.Request{
+				newRequest(entry0, groupPublicKey, 100),
+				newRequest(entry1, groupPublicKey, 110),
+			},
+			expectedStats: Stats{
+				RelayEntriesRequested: 2,
+				RelayEntriesVerified:  1,
+				LastEventBlock:        110,
+			},
+		},
+		"invalid entry": {
+			requests: []*event.Request{
+				newRequest(entry0, groupPublicKey, 100),
+				newRequest(invalidEntry2, groupPublicKey, 110),
+			},
+			expectedStats: Stats{
+				RelayEntriesRequested: 2,
+				RelayEntriesInvalid:   1,
+				LastEventBlock:        110,
+			},
+		},
+		"entry of unregistered group": {
+			requests: []*event.Request{
+				newRequest(invalidEntry2, unregisteredPublicKey, 100),
+				newRequest(entry3, groupPublicKey, 110),
+			},
+			expectedStats: Stats{
+				RelayEntriesRequested: 2,
+				RelayEntriesInvalid:   1,
+				LastEventBlock:        110,
+			},
+		},
+		"entry timed out": {
+			requests: []*event.Request{
+				newRequest(entry0, unregisteredPublicKey, 100),
+				newRequest(entry0, groupPublicKey, 120),
+				newRequest(entry1, groupPublicKey, 130),
+			},
+			expectedStats: Stats{
+				RelayEntriesRequested: 3,
+				RelayEntriesTimedOut:  1,
+				RelayEntriesVerified:  1,
+				LastEventBlock:        130,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain := &stubChain{registeredGroups: [][]byte{groupPublicKey}}
+			observer := Start(chain)
+			defer observer.Stop()
+
+			for _, request := range test.requests {
+				chain.relayEntryRequestedHandler(request)
+			}
+
+			stats := observer.Stats()
+			if !reflect.DeepEqual(test.expectedStats, stats) {
+				t.Errorf(
+					"unexpected stats\nexpected: %+v\nactual:   %+v",
+					test.expectedStats,
+					stats,
+				)
+			}
+		})
+	}
+}
+
+func TestGroupEvents(t *testing.T) {
+	chain := &stubChain{}
+	observer := Start(chain)
+	defer observer.Stop()
+
+	chain.groupSelectionStartedHandler(&event.GroupSelectionStart{
+		NewEntry:    big.NewInt(1),
+		BlockNumber: 200,
+	})
+	chain.dkgResultSubmittedHandler(&event.DKGResultSubmission{
+		MemberIndex:    1,
+		GroupPublicKey: []byte{0x01},
+		BlockNumber:    250,
+	})
+	chain.groupRegisteredHandler(&event.GroupRegistration{
+		GroupPublicKey: []byte{0x01},
+		BlockNumber:    250,
+	})
+	chain.relayEntrySubmittedHandler(&event.EntrySubmitted{
+		BlockNumber: 240,
+	})
+
+	expectedStats := Stats{
+		RelayEntriesSubmitted:  1,
+		GroupSelectionsStarted: 1,
+		DKGResultsSubmitted:    1,
+		GroupsRegistered:       1,
+		LastEventBlock:         250,
+	}
+
+	stats := observer.Stats()
+	if !reflect.DeepEqual(expectedStats, stats) {
+		t.Errorf(
+			"unexpected stats\nexpected: %+v\nactual:   %+v",
+			expectedStats,
+			stats,
+		)
+	}
+}
+
+func newRequest(
+	previousEntry *bn256.G1,
+	groupPublicKey []byte,
+	blockNumber uint64,
+) *event.Request {
+	return &event.Request{
+		PreviousEntry:  previousEntry.Marshal(),
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    blockNumber,
+	}
+}
+
+type stubChain struct {
+	registeredGroups [][]byte
+
+	relayEntryRequestedHandler   func(request *event.Request)
+	relayEntrySubmittedHandler   func(entry *event.EntrySubmitted)
+	groupSelectionStartedHandler func(start *event.GroupSelectionStart)
+	groupRegisteredHandler       func(registration *event.GroupRegistration)
+	dkgResultSubmittedHandler    func(submission *event.DKGResultSubmission)
+}
+
+func (sc *stubChain) OnRelayEntryRequested(
+	handler func(request *event.Request),
+) subscription.EventSubscription {
+	sc.relayEntryRequestedHandler = handler
+	return subscription.NewEventSubscription(func() {})
+}
+
+func (sc *stubChain) OnRelayEntrySubmitted(
+	handler func(entry *event.EntrySubmitted),
+) subscription.EventSubscription {
+	sc.relayEntrySubmittedHandler = handler
+	return subscription.NewEventSubscription(func() {})
+}
+
+func (sc *stubChain) OnGroupSelectionStarted(
+	handler func(start *event.GroupSelectionStart),
+) subscription.EventSubscription {
+	sc.groupSelectionStartedHandler = handler
+	return subscription.NewEventSubscription(func() {})
+}
+
+func (sc *stubChain) OnGroupRegistered(
+	handler func(registration *event.GroupRegistration),
+) subscription.EventSubscription {
+	sc.groupRegisteredHandler = handler
+	return subscription.NewEventSubscription(func() {})
+}
+
+func (sc *stubChain) OnDKGResultSubmitted(
+	handler func(submission *event.DKGResultSubmission),
+) subscription.EventSubscription {
+	sc.dkgResultSubmittedHandler = handler
+	return subscription.NewEventSubscription(func() {})
+}
+
+func (sc *stubChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
+	for _, registeredGroup := range sc.registeredGroups {
+		if bytes.Equal(registeredGroup, groupPublicKey) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,116 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Operator holds everything the random beacon needs to run on behalf of one
+// staking operator. When multiple operators are run in a single client
+// process, each of them must have its own chain handle, so that transactions
+// are signed with the operator key, its own network provider, so that
+// messages are signed with the operator network key, and its own persistence
+// handle, so that groups of different operators are not mixed up.
+type Operator struct {
+	StakingID   string
+	ChainHandle chain.Handle
+	NetProvider net.Provider
+	Persistence persistence.Handle
+
+	// DKGEvidenceRetention is how long evidence logs of distributed key
+	// generations are kept in the persistence before they are archived.
+	DKGEvidenceRetention time.Duration
+
+	// TicketSubmissionPolicy creates policies deciding which group selection
+	// tickets are submitted on-chain. If nil, all tickets which can make it
+	// to the group are submitted.
+	TicketSubmissionPolicy groupselection.SubmissionPolicyFactory
+
+	// OperatorContractDeploymentBlock is the block from which past events of
+	// the operator contract are searched when migrating groups persisted in
+	// the legacy format.
+	OperatorContractDeploymentBlock uint64
+}
+
+// InitializeOperators kicks off the random beacon for each of the given
+// operators. Returns handles to the running beacons in the same order as
+// operators. If the random beacon could not be initialized for any of the
+// operators, the ones already running are stopped and an error is returned.
+func InitializeOperators(
+	ctx context.Context,
+	operators []*Operator,
+) ([]*Beacon, error) {
+	beacons := make([]*Beacon, 0, len(operators))
+
+	for _, operator := range operators {
+		beacon, err := Initialize(
+			ctx,
+			operator.StakingID,
+			operator.ChainHandle,
+			operator.NetProvider,
+			operator.Persistence,
+			operator.DKGEvidenceRetention,
+			operator.TicketSubmissionPolicy,
+			operator.OperatorContractDeploymentBlock,
+		)
+		if err != nil {
+			if stopErr := StopAll(ctx, beacons); stopErr != nil {
+				logger.Errorf(
+					"could not stop already initialized beacons: [%v]",
+					stopErr,
+				)
+			}
+
+			return nil, fmt.Errorf(
+				"could not initialize beacon for operator [%v]: [%v]",
+				operator.StakingID,
+				err,
+			)
+		}
+
+		logger.Infof(
+			"initialized random beacon for operator [%v]",
+			operator.StakingID,
+		)
+
+		beacons = append(beacons, beacon)
+	}
+
+	return beacons, nil
+}
+
+// StopAll gracefully stops all the given beacons at the same time. It returns
+// an error if any of the beacons could not be stopped before the provided
+// context is done.
+func StopAll(ctx context.Context, beacons []*Beacon) error {
+	errors := make(chan error, len(beacons))
+
+	var wg sync.WaitGroup
+	wg.Add(len(beacons))
+
+	for _, beacon := range beacons {
+		go func(beacon *Beacon) {
+			defer wg.Done()
+
+			if err := beacon.Stop(ctx); err != nil {
+				errors <- err
+			}
+		}(beacon)
+	}
+
+	wg.Wait()
+	close(errors)
+
+	if err, ok := <-errors; ok {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package beacon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestInitializeAndStopOperators(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	stakingIDs := []string{
+		"0x65ea55c1f10491038425725dc00dffeab2a1e28a",
+		"0x524f2e0176350d950fa630d9a5a59a0a190daf48",
+	}
+
+	operators := make([]*Operator, len(stakingIDs))
+	for i, stakingID := range stakingIDs {
+		operators[i] = &Operator{
+			StakingID:   stakingID,
+			ChainHandle: chainLocal.Connect(5, 3, big.NewInt(200)),
+			NetProvider: netLocal.Connect(),
+			Persistence: &emptyPersistence{},
+		}
+	}
+
+	beacons, err := InitializeOperators(ctx, operators)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(beacons) != len(operators) {
+		t.Fatalf(
+			"unexpected number of beacons\nexpected: [%v]\nactual:   [%v]",
+			len(operators),
+			len(beacons),
+		)
+	}
+
+	if beacons[0].node.Staker == beacons[1].node.Staker {
+		t.Errorf("operators should have separate stakers")
+	}
+
+	if beacons[0].groupRegistry == beacons[1].groupRegistry {
+		t.Errorf("operators should have separate group registries")
+	}
+
+	stopCtx, cancelStopCtx := context.WithTimeout(ctx, time.Second)
+	defer cancelStopCtx()
+
+	if err := StopAll(stopCtx, beacons); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, beacon := range beacons {
+		if !beacon.isStopping() {
+			t.Errorf("beacon [%v] should be stopped", i)
+		}
+	}
+}
+
+type emptyPersistence struct{}
+
+func (ep *emptyPersistence) Save(data []byte, directory string, name string) error {
+	return nil
+}
+
+func (ep *emptyPersistence) Snapshot(data []byte, directory string, name string) error {
+	return nil
+}
+
+func (ep *emptyPersistence) ReadAll() (<-chan persistence.DataDescriptor, <-chan error) {
+	descriptors := make(chan persistence.DataDescriptor)
+	errors := make(chan error)
+
+	close(descriptors)
+	close(errors)
+
+	return descriptors, errors
+}
+
+func (ep *emptyPersistence) Archive(directory string) error {
+	return nil
+}
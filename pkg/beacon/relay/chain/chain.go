@@ -35,10 +35,20 @@ type RelayEntryInterface interface {
 	OnRelayEntryRequested(
 		func(request *event.Request),
 	) subscription.EventSubscription
+	// PastRelayEntryRequestedEvents returns all relay request events emitted
+	// on-chain starting from the given block, inclusive.
+	PastRelayEntryRequestedEvents(fromBlock uint64) ([]*event.Request, error)
 	// ReportRelayEntryTimeout notifies the chain when a selected group which was
 	// supposed to submit a relay entry, did not deliver it within a specified
-	// time frame (relayEntryTimeout) counted in blocks.
-	ReportRelayEntryTimeout() error
+	// time frame (relayEntryTimeout) counted in blocks. The report concerns
+	// the relay request started at the given block; if that request is no
+	// longer in progress, the report is skipped.
+	ReportRelayEntryTimeout(relayRequestBlockNumber uint64) error
+	// OnRelayEntryTimeoutReported is a callback that is invoked when an
+	// on-chain notification of a relay entry timeout report is seen.
+	OnRelayEntryTimeoutReported(
+		func(report *event.RelayEntryTimeoutReport),
+	) subscription.EventSubscription
 	// IsEntryInProgress checks if a new relay entry is currently in progress.
 	IsEntryInProgress() (bool, error)
 	// CurrentRequestStartBlock returns a start block of a current entry.
@@ -47,6 +57,10 @@ type RelayEntryInterface interface {
 	CurrentRequestPreviousEntry() ([]byte, error)
 	// CurrentRequestGroupPublicKey returns group public key for the current request.
 	CurrentRequestGroupPublicKey() ([]byte, error)
+	// GetActiveGroupMembers returns addresses of stakers being members of at
+	// least one active group, without duplicates. They are the stakers
+	// reporting relay entry timeouts.
+	GetActiveGroupMembers() ([]StakerAddress, error)
 }
 
 // GroupSelectionInterface defines the subset of the relay chain interface that
@@ -57,11 +71,20 @@ type GroupSelectionInterface interface {
 	OnGroupSelectionStarted(
 		func(groupSelectionStarted *event.GroupSelectionStart),
 	) subscription.EventSubscription
+	// PastGroupSelectionStartedEvents returns all group selection started
+	// events emitted on-chain starting from the given block, inclusive.
+	PastGroupSelectionStartedEvents(
+		fromBlock uint64,
+	) ([]*event.GroupSelectionStart, error)
 	// SubmitTicket submits a ticket corresponding to the virtual staker to
 	// the chain, and returns a promise to track the submission. The promise
 	// is fulfilled with the entry as seen on-chain, or failed if there is an
 	// error submitting the entry.
 	SubmitTicket(ticket *Ticket) *async.EventGroupTicketSubmissionPromise
+	// EstimateTicketSubmissionCost estimates the amount paid for submitting
+	// the given ticket, that is the gas the submission is expected to use
+	// times the gas price it would be submitted with.
+	EstimateTicketSubmissionCost(ticket *Ticket) (*big.Int, error)
 	// GetSubmittedTickets gets the submitted group candidate tickets so far.
 	GetSubmittedTickets() ([]uint64, error)
 	// GetSelectedParticipants returns `GroupSize` slice of addresses of
@@ -113,6 +136,20 @@ type DistributedKeyGenerationInterface interface {
 	OnDKGResultSubmitted(
 		func(event *event.DKGResultSubmission),
 	) subscription.EventSubscription
+	// OnUnconfirmedDKGResultSubmitted registers a callback that is invoked
+	// as soon as an on-chain notification of a new submitted result is seen,
+	// before the notification gets the number of confirmations required by
+	// OnDKGResultSubmitted. It should be used only to stop submitting the
+	// result by other members; the group is registered only once the
+	// submission is confirmed.
+	OnUnconfirmedDKGResultSubmitted(
+		func(event *event.DKGResultSubmission),
+	) subscription.EventSubscription
+	// PastDKGResultSubmittedEvents returns all DKG result submission events
+	// emitted on-chain starting from the given block, inclusive.
+	PastDKGResultSubmittedEvents(
+		fromBlock uint64,
+	) ([]*event.DKGResultSubmission, error)
 	// IsGroupRegistered checks if group with the given public key is registered
 	// on-chain.
 	IsGroupRegistered(groupPublicKey []byte) (bool, error)
@@ -134,6 +171,15 @@ type Interface interface {
 	// threshold relay. This value can change over time according to the minimum
 	// stake schedule.
 	MinimumStake() (*big.Int, error)
+	// EarliestUnconfirmedEventBlock returns the earliest block in which
+	// an event has been received but not yet delivered to the handlers
+	// because it is still awaiting the required number of confirmations.
+	// The second returned value is false if there are no such events.
+	EarliestUnconfirmedEventBlock() (uint64, bool)
+	// EventConfirmations returns the number of blocks which need to be mined
+	// on top of the block in which an event was emitted before the event is
+	// delivered to the handlers.
+	EventConfirmations() uint64
 
 	GroupInterface
 	RelayEntryInterface
@@ -0,0 +1,41 @@
+package chain
+
+import "fmt"
+
+// TransactionSkippedError is returned by transaction submission functions
+// when the transaction has not been submitted because its simulation against
+// the pending chain state showed it would be rejected.
+type TransactionSkippedError struct {
+	// Method is the name of the contract method the transaction calls.
+	Method string
+	// Reason is the rejection reason reported by the chain.
+	Reason string
+	// AlreadyDone is true if the transaction would be rejected because the
+	// work it was supposed to do has already been done by someone else,
+	// e.g. another group member submitted the relay entry first.
+	AlreadyDone bool
+}
+
+func (tse *TransactionSkippedError) Error() string {
+	if tse.AlreadyDone {
+		return fmt.Sprintf(
+			"[%v] not submitted; already done by someone else: [%v]",
+			tse.Method,
+			tse.Reason,
+		)
+	}
+
+	return fmt.Sprintf(
+		"[%v] not submitted; transaction would be rejected: [%v]",
+		tse.Method,
+		tse.Reason,
+	)
+}
+
+// IsAlreadyDone returns true if the error means the transaction has not
+// been submitted because the work it was supposed to do has already been
+// done by someone else.
+func IsAlreadyDone(err error) bool {
+	skippedErr, ok := err.(*TransactionSkippedError)
+	return ok && skippedErr.AlreadyDone
+}
@@ -12,13 +12,21 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
 var logger = log.Logger("keep-dkg")
 
-// ExecuteDKG runs the full distributed key generation lifecycle.
+// ExecuteDKG runs the full distributed key generation lifecycle. If the
+// checkpoint handler is not nil, it is called with a GJKR protocol checkpoint
+// each time the member enters a new protocol state. The checkpoint can be used
+// to resume the execution with ResumeDKG after the client restarts. If the
+// evidence handler is not nil, it is called with the GJKR evidence log once the
+// key generation is over. Statistics of the execution, including the outcome,
+// are recorded in the provided telemetry. States of the key generation and
+// the result publication are reported to the provided machine registry.
 func ExecuteDKG(
 	seed *big.Int,
 	index uint8, // starts with 0
@@ -30,10 +38,16 @@ func ExecuteDKG(
 	relayChain relayChain.Interface,
 	signing chain.Signing,
 	channel net.BroadcastChannel,
+	checkpointHandler gjkr.CheckpointHandler,
+	evidenceHandler gjkr.EvidenceHandler,
+	telemetry *Telemetry,
+	machines *state.Registry,
 ) (*ThresholdSigner, error) {
 	// The staker index should begin with 1
 	playerIndex := group.MemberIndex(index + 1)
 
+	execution := telemetry.startExecution(playerIndex)
+
 	gjkr.RegisterUnmarshallers(channel)
 	dkgResult.RegisterUnmarshallers(channel)
 
@@ -46,8 +60,13 @@ func ExecuteDKG(
 		seed,
 		membershipValidator,
 		startBlockHeight,
+		checkpointHandler,
+		execution.phaseCompleted,
+		evidenceHandler,
+		machines,
 	)
 	if err != nil {
+		execution.keyGenerationFailed(err)
 		return nil, fmt.Errorf(
 			"[member:%v] GJKR execution failed [%v]",
 			playerIndex,
@@ -55,6 +74,123 @@ func ExecuteDKG(
 		)
 	}
 
+	return publishResult(
+		playerIndex,
+		gjkrResult,
+		gjkrEndBlockHeight,
+		true,
+		membershipValidator,
+		blockCounter,
+		relayChain,
+		signing,
+		channel,
+		execution,
+		machines,
+	)
+}
+
+// ResumeDKG resumes the distributed key generation lifecycle from the GJKR
+// protocol checkpoint passed to the checkpoint handler of ExecuteDKG or
+// ResumeDKG. If the checkpoint has been taken once the key generation has been
+// completed and the result signing is already over, the member does not take
+// part in the result publication but waits for the result submitted by other
+// group members. ResumeDKG returns an error if the member cannot cleanly rejoin
+// the distributed key generation. Just like in ExecuteDKG, protocol states are
+// reported to the provided machine registry.
+func ResumeDKG(
+	checkpoint []byte,
+	index uint8, // starts with 0
+	membershipValidator group.MembershipValidator,
+	blockCounter chain.BlockCounter,
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+	channel net.BroadcastChannel,
+	checkpointHandler gjkr.CheckpointHandler,
+	evidenceHandler gjkr.EvidenceHandler,
+	telemetry *Telemetry,
+	machines *state.Registry,
+) (*ThresholdSigner, error) {
+	// The staker index should begin with 1
+	playerIndex := group.MemberIndex(index + 1)
+
+	execution := telemetry.startExecution(playerIndex)
+
+	gjkr.RegisterUnmarshallers(channel)
+	dkgResult.RegisterUnmarshallers(channel)
+
+	gjkrResult, gjkrEndBlockHeight, err := gjkr.Resume(
+		checkpoint,
+		blockCounter,
+		channel,
+		membershipValidator,
+		checkpointHandler,
+		execution.phaseCompleted,
+		evidenceHandler,
+		machines,
+	)
+	if err != nil {
+		execution.keyGenerationFailed(err)
+		return nil, fmt.Errorf(
+			"[member:%v] GJKR resumption failed [%v]",
+			playerIndex,
+			err,
+		)
+	}
+
+	currentBlockHeight, err := blockCounter.CurrentBlock()
+	if err != nil {
+		execution.resultPublicationCompleted(false, err)
+		return nil, err
+	}
+
+	// The member can take part in the result publication only if the result
+	// signing is not over yet. Otherwise, it can still wait for the result
+	// submitted by other members as long as the publication is not over.
+	canPublish := currentBlockHeight <
+		gjkrEndBlockHeight+dkgResult.PrePublicationBlocks()
+	if !canPublish &&
+		currentBlockHeight >= publicationTimeoutBlock(gjkrEndBlockHeight, relayChain) {
+		err := fmt.Errorf(
+			"[member:%v] DKG result publication ended before block [%v]",
+			playerIndex,
+			currentBlockHeight,
+		)
+		execution.resultPublicationCompleted(false, err)
+		return nil, err
+	}
+
+	return publishResult(
+		playerIndex,
+		gjkrResult,
+		gjkrEndBlockHeight,
+		canPublish,
+		membershipValidator,
+		blockCounter,
+		relayChain,
+		signing,
+		channel,
+		execution,
+		machines,
+	)
+}
+
+// publishResult publishes the result of the GJKR protocol execution or, if the
+// publication is not possible, waits for the result published by other group
+// members and decides whether the member can stay in the group. The outcome
+// of the publication is recorded in the provided execution telemetry.
+func publishResult(
+	playerIndex group.MemberIndex,
+	gjkrResult *gjkr.Result,
+	gjkrEndBlockHeight uint64,
+	canPublish bool,
+	membershipValidator group.MembershipValidator,
+	blockCounter chain.BlockCounter,
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+	channel net.BroadcastChannel,
+	execution *execution,
+	machines *state.Registry,
+) (*ThresholdSigner, error) {
 	startPublicationBlockHeight := gjkrEndBlockHeight
 
 	dkgResultChannel := make(chan *event.DKGResultSubmission)
@@ -65,17 +201,23 @@ func ExecuteDKG(
 	)
 	defer dkgResultSubscription.Unsubscribe()
 
-	err = dkgResult.Publish(
-		playerIndex,
-		gjkrResult.Group,
-		membershipValidator,
-		gjkrResult,
-		channel,
-		relayChain,
-		signing,
-		blockCounter,
-		startPublicationBlockHeight,
-	)
+	var err error
+	if canPublish {
+		err = dkgResult.Publish(
+			playerIndex,
+			gjkrResult.Group,
+			membershipValidator,
+			gjkrResult,
+			channel,
+			relayChain,
+			signing,
+			blockCounter,
+			startPublicationBlockHeight,
+			machines,
+		)
+	} else {
+		err = fmt.Errorf("result signing is already over")
+	}
 	if err != nil {
 		// Result publication failed. It means that either the result this
 		// member proposed is not supported by the majority of group members or
@@ -89,16 +231,20 @@ func ExecuteDKG(
 			err,
 		)
 
-		if err := decideMemberFate(
+		resultPublished, err := decideMemberFate(
 			playerIndex,
 			gjkrResult,
 			dkgResultChannel,
 			startPublicationBlockHeight,
 			relayChain,
 			blockCounter,
-		); err != nil {
+		)
+		execution.resultPublicationCompleted(resultPublished, err)
+		if err != nil {
 			return nil, err
 		}
+	} else {
+		execution.resultPublicationCompleted(true, nil)
 	}
 
 	return &ThresholdSigner{
@@ -112,7 +258,8 @@ func ExecuteDKG(
 // decideMemberFate decides what the member will do in case it failed
 // publishing its DKG result. Member can stay in the group if it
 // supports the same group public key as the one registered on-chain and
-// the member is not considered as misbehaving by the group.
+// the member is not considered as misbehaving by the group. The returned flag
+// tells whether any DKG result has been published on-chain.
 func decideMemberFate(
 	playerIndex group.MemberIndex,
 	gjkrResult *gjkr.Result,
@@ -120,7 +267,7 @@ func decideMemberFate(
 	startPublicationBlockHeight uint64,
 	relayChain relayChain.Interface,
 	blockCounter chain.BlockCounter,
-) error {
+) (bool, error) {
 	dkgResultEvent, err := waitForDkgResultEvent(
 		dkgResultChannel,
 		startPublicationBlockHeight,
@@ -128,18 +275,18 @@ func decideMemberFate(
 		blockCounter,
 	)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	groupPublicKey, err := gjkrResult.GroupPublicKeyBytes()
 	if err != nil {
-		return err
+		return true, err
 	}
 
 	// If member don't support the same group public key, it could not stay
 	// in the group.
 	if !bytes.Equal(groupPublicKey, dkgResultEvent.GroupPublicKey) {
-		return fmt.Errorf(
+		return true, fmt.Errorf(
 			"[member:%v] could not stay in the group because "+
 				"member do not support the same group public key",
 			playerIndex,
@@ -149,7 +296,7 @@ func decideMemberFate(
 	// If member is considered as misbehaved, it could not stay in the group.
 	for _, misbehaved := range dkgResultEvent.Misbehaved {
 		if playerIndex == misbehaved {
-			return fmt.Errorf(
+			return true, fmt.Errorf(
 				"[member:%v] could not stay in the group because "+
 					"member is considered as misbehaving",
 				playerIndex,
@@ -157,7 +304,7 @@ func decideMemberFate(
 		}
 	}
 
-	return nil
+	return true, nil
 }
 
 func waitForDkgResultEvent(
@@ -166,11 +313,10 @@ func waitForDkgResultEvent(
 	relayChain relayChain.Interface,
 	blockCounter chain.BlockCounter,
 ) (*event.DKGResultSubmission, error) {
-	config := relayChain.GetConfig()
-
-	timeoutBlock := startPublicationBlockHeight +
-		dkgResult.PrePublicationBlocks() +
-		(uint64(config.GroupSize) * config.ResultPublicationBlockStep)
+	timeoutBlock := publicationTimeoutBlock(
+		startPublicationBlockHeight,
+		relayChain,
+	)
 
 	timeoutBlockChannel, err := blockCounter.BlockHeightWaiter(timeoutBlock)
 	if err != nil {
@@ -184,3 +330,16 @@ func waitForDkgResultEvent(
 		return nil, fmt.Errorf("DKG result publication timed out")
 	}
 }
+
+// publicationTimeoutBlock returns the block at which the DKG result
+// publication started at the given block is over.
+func publicationTimeoutBlock(
+	startPublicationBlockHeight uint64,
+	relayChain relayChain.Interface,
+) uint64 {
+	config := relayChain.GetConfig()
+
+	return startPublicationBlockHeight +
+		dkgResult.PrePublicationBlocks() +
+		(uint64(config.GroupSize) * config.ResultPublicationBlockStep)
+}
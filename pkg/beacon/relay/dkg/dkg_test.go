@@ -42,7 +42,7 @@ func TestDecideMemberFate_HappyPath(t *testing.T) {
 		Misbehaved:     []byte{},
 	}
 
-	err := decideMemberFate(
+	resultPublished, err := decideMemberFate(
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
@@ -51,6 +51,14 @@ func TestDecideMemberFate_HappyPath(t *testing.T) {
 		blockCounter,
 	)
 
+	if resultPublished != true {
+		t.Errorf(
+			"unexpected result publication\nexpected: %v\nactual:   %v\n",
+			true,
+			resultPublished,
+		)
+	}
+
 	if err != nil {
 		t.Errorf(
 			"unexpected error\nexpected: %v\nactual:   %v\n",
@@ -69,7 +77,7 @@ func TestDecideMemberFate_NotSameGroupPublicKey(t *testing.T) {
 		Misbehaved:     []byte{},
 	}
 
-	err := decideMemberFate(
+	resultPublished, err := decideMemberFate(
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
@@ -78,6 +86,14 @@ func TestDecideMemberFate_NotSameGroupPublicKey(t *testing.T) {
 		blockCounter,
 	)
 
+	if resultPublished != true {
+		t.Errorf(
+			"unexpected result publication\nexpected: %v\nactual:   %v\n",
+			true,
+			resultPublished,
+		)
+	}
+
 	expectedError := fmt.Errorf(
 		"[member:%v] could not stay in the group because "+
 			"member do not support the same group public key",
@@ -100,7 +116,7 @@ func TestDecideMemberFate_MemberIsMisbehaved(t *testing.T) {
 		Misbehaved:     []byte{playerIndex},
 	}
 
-	err := decideMemberFate(
+	resultPublished, err := decideMemberFate(
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
@@ -109,6 +125,14 @@ func TestDecideMemberFate_MemberIsMisbehaved(t *testing.T) {
 		blockCounter,
 	)
 
+	if resultPublished != true {
+		t.Errorf(
+			"unexpected result publication\nexpected: %v\nactual:   %v\n",
+			true,
+			resultPublished,
+		)
+	}
+
 	expectedError := fmt.Errorf(
 		"[member:%v] could not stay in the group because "+
 			"member is considered as misbehaving",
@@ -126,7 +150,7 @@ func TestDecideMemberFate_MemberIsMisbehaved(t *testing.T) {
 func TestDecideMemberFate_Timeout(t *testing.T) {
 	setup()
 
-	err := decideMemberFate(
+	resultPublished, err := decideMemberFate(
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
@@ -135,6 +159,14 @@ func TestDecideMemberFate_Timeout(t *testing.T) {
 		blockCounter,
 	)
 
+	if resultPublished != false {
+		t.Errorf(
+			"unexpected result publication\nexpected: %v\nactual:   %v\n",
+			false,
+			resultPublished,
+		)
+	}
+
 	expectedError := fmt.Errorf("DKG result publication timed out")
 	if !reflect.DeepEqual(expectedError, err) {
 		t.Errorf(
@@ -5,6 +5,8 @@ import (
 	"math/big"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	entrypb "github.com/keep-network/keep-core/pkg/beacon/relay/entry/gen/pb"
+	gjkrpb "github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
 )
@@ -82,3 +84,122 @@ func unmarshalGroupPublicKeyShares(
 
 	return unmarshalled, nil
 }
+
+// Type returns a string describing a RefreshConfirmationMessage's type.
+func (*RefreshConfirmationMessage) Type() string {
+	return "relay/refresh/confirmation"
+}
+
+// Marshal converts this RefreshConfirmationMessage to a byte array suitable
+// for network communication. The message has the same wire format as
+// a relay entry signature share message.
+func (rcm *RefreshConfirmationMessage) Marshal() ([]byte, error) {
+	return (&entrypb.SignatureShare{
+		SenderID: uint32(rcm.senderID),
+		Share:    rcm.shareBytes,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// a RefreshConfirmationMessage.
+func (rcm *RefreshConfirmationMessage) Unmarshal(bytes []byte) error {
+	pbSignatureShare := entrypb.SignatureShare{}
+	if err := pbSignatureShare.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	// MemberIndex is represented as uint8 but protobuf has no uint8 type.
+	if pbSignatureShare.SenderID > 255 {
+		return fmt.Errorf(
+			"invalid member index value: [%v]",
+			pbSignatureShare.SenderID,
+		)
+	}
+
+	rcm.senderID = group.MemberIndex(pbSignatureShare.SenderID)
+	rcm.shareBytes = pbSignatureShare.Share
+
+	return nil
+}
+
+// Type returns a string describing a RefreshVoteMessage's type.
+func (*RefreshVoteMessage) Type() string {
+	return "relay/refresh/vote"
+}
+
+// Marshal converts this RefreshVoteMessage to a byte array suitable for
+// network communication. The message has the same wire format as a GJKR
+// secret shares accusations message.
+func (rvm *RefreshVoteMessage) Marshal() ([]byte, error) {
+	confirmations := make(map[uint32][]byte, len(rvm.confirmations))
+	for memberIndex, shareBytes := range rvm.confirmations {
+		confirmations[uint32(memberIndex)] = shareBytes
+	}
+
+	return (&gjkrpb.SecretSharesAccusations{
+		SenderID:           uint32(rvm.senderID),
+		AccusedMembersKeys: confirmations,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// a RefreshVoteMessage.
+func (rvm *RefreshVoteMessage) Unmarshal(bytes []byte) error {
+	pbMessage := gjkrpb.SecretSharesAccusations{}
+	if err := pbMessage.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	// MemberIndex is represented as uint8 but protobuf has no uint8 type.
+	if pbMessage.SenderID > 255 {
+		return fmt.Errorf(
+			"invalid member index value: [%v]",
+			pbMessage.SenderID,
+		)
+	}
+
+	confirmations := make(
+		map[group.MemberIndex][]byte,
+		len(pbMessage.AccusedMembersKeys),
+	)
+	for memberIndex, shareBytes := range pbMessage.AccusedMembersKeys {
+		if memberIndex > 255 {
+			return fmt.Errorf(
+				"invalid member index value: [%v]",
+				memberIndex,
+			)
+		}
+		confirmations[group.MemberIndex(memberIndex)] = shareBytes
+	}
+
+	rvm.senderID = group.MemberIndex(pbMessage.SenderID)
+	rvm.confirmations = confirmations
+
+	return nil
+}
+
+// Type returns a string describing a RefreshCommitMessage's type.
+func (*RefreshCommitMessage) Type() string {
+	return "relay/refresh/commit"
+}
+
+// Marshal converts this RefreshCommitMessage to a byte array suitable for
+// network communication. The message has the same wire format as
+// a RefreshVoteMessage.
+func (rcm *RefreshCommitMessage) Marshal() ([]byte, error) {
+	return NewRefreshVoteMessage(rcm.senderID, rcm.confirmations).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// a RefreshCommitMessage.
+func (rcm *RefreshCommitMessage) Unmarshal(bytes []byte) error {
+	vote := &RefreshVoteMessage{}
+	if err := vote.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	rcm.senderID = vote.senderID
+	rcm.confirmations = vote.confirmations
+
+	return nil
+}
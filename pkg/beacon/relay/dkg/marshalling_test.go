@@ -32,3 +32,60 @@ func TestThresholdSignerRoundtrip(t *testing.T) {
 		t.Fatalf("unexpected content of unmarshaled threshold signer")
 	}
 }
+
+func TestRefreshConfirmationMessageRoundtrip(t *testing.T) {
+	msg := &RefreshConfirmationMessage{
+		senderID:   group.MemberIndex(12),
+		shareBytes: new(bn256.G1).ScalarBaseMult(big.NewInt(1337)).Marshal(),
+	}
+	unmarshaled := &RefreshConfirmationMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestRefreshVoteMessageRoundtrip(t *testing.T) {
+	msg := &RefreshVoteMessage{
+		senderID: group.MemberIndex(3),
+		confirmations: map[group.MemberIndex][]byte{
+			group.MemberIndex(1): new(bn256.G1).ScalarBaseMult(big.NewInt(11)).Marshal(),
+			group.MemberIndex(3): new(bn256.G1).ScalarBaseMult(big.NewInt(13)).Marshal(),
+		},
+	}
+	unmarshaled := &RefreshVoteMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestRefreshCommitMessageRoundtrip(t *testing.T) {
+	msg := &RefreshCommitMessage{
+		senderID: group.MemberIndex(2),
+		confirmations: map[group.MemberIndex][]byte{
+			group.MemberIndex(1): new(bn256.G1).ScalarBaseMult(big.NewInt(11)).Marshal(),
+			group.MemberIndex(2): new(bn256.G1).ScalarBaseMult(big.NewInt(12)).Marshal(),
+		},
+	}
+	unmarshaled := &RefreshCommitMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
@@ -0,0 +1,68 @@
+package dkg
+
+import (
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// RefreshConfirmationMessage is a message payload that carries the sender's
+// signature share of the share refresh test message, calculated with the
+// sender's refreshed share of the group private key.
+type RefreshConfirmationMessage struct {
+	senderID   group.MemberIndex
+	shareBytes []byte
+}
+
+// NewRefreshConfirmationMessage creates a new RefreshConfirmationMessage.
+func NewRefreshConfirmationMessage(
+	senderID group.MemberIndex,
+	shareBytes []byte,
+) *RefreshConfirmationMessage {
+	return &RefreshConfirmationMessage{senderID, shareBytes}
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (rcm *RefreshConfirmationMessage) SenderID() group.MemberIndex {
+	return rcm.senderID
+}
+
+// RefreshVoteMessage is a message payload that carries refresh confirmations
+// the sender has received and verified, including its own one, mapped by
+// the index of the member that confirmed the refresh.
+type RefreshVoteMessage struct {
+	senderID      group.MemberIndex
+	confirmations map[group.MemberIndex][]byte
+}
+
+// NewRefreshVoteMessage creates a new RefreshVoteMessage.
+func NewRefreshVoteMessage(
+	senderID group.MemberIndex,
+	confirmations map[group.MemberIndex][]byte,
+) *RefreshVoteMessage {
+	return &RefreshVoteMessage{senderID, confirmations}
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (rvm *RefreshVoteMessage) SenderID() group.MemberIndex {
+	return rvm.senderID
+}
+
+// RefreshCommitMessage is a message payload that carries refresh confirmations
+// of all the members holding shares of the group private key, sent by
+// the member which committed the share refresh.
+type RefreshCommitMessage struct {
+	senderID      group.MemberIndex
+	confirmations map[group.MemberIndex][]byte
+}
+
+// NewRefreshCommitMessage creates a new RefreshCommitMessage.
+func NewRefreshCommitMessage(
+	senderID group.MemberIndex,
+	confirmations map[group.MemberIndex][]byte,
+) *RefreshCommitMessage {
+	return &RefreshCommitMessage{senderID, confirmations}
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (rcm *RefreshCommitMessage) SenderID() group.MemberIndex {
+	return rcm.senderID
+}
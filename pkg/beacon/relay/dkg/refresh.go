@@ -0,0 +1,623 @@
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/altbn128"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+const (
+	// refreshConfirmationDelayBlocks is the number of blocks after the end of
+	// the share refresh protocol after which members send confirmations of
+	// their refreshed shares. It gives all members time to start receiving
+	// confirmations.
+	refreshConfirmationDelayBlocks = 1
+	// refreshConfirmationActiveBlocks is the number of blocks during which
+	// members wait for confirmations of refreshed shares of other members.
+	refreshConfirmationActiveBlocks = 5
+	// refreshVoteActiveBlocks is the number of blocks after the confirmation
+	// period during which members wait for votes of other members.
+	refreshVoteActiveBlocks = 5
+	// refreshCommitActiveBlocks is the number of blocks after the vote period
+	// during which members wait for commit messages of other members.
+	refreshCommitActiveBlocks = 3
+)
+
+// RefreshShares runs the proactive share refresh for the given signer of an
+// existing group over the group's broadcast channel. All group members deal
+// sharings of zero with the GJKR protocol and add received shares to their
+// shares of the group private key. It returns a new signer holding the
+// refreshed group private key share and group public key shares, along with
+// the block at which the refresh protocol ended. The group public key does
+// not change.
+//
+// The refresh fails if any member holding a share of the group private key
+// did not take part in it. Such a member would be left with a share which is
+// no longer compatible with shares of the rest of the group, so in that case
+// all members keep their previous shares. The returned signer must not be
+// used before the refresh is committed by the group with ConfirmRefresh.
+// States of the refresh protocol are reported to the provided machine
+// registry.
+func RefreshShares(
+	signer *ThresholdSigner,
+	seed *big.Int,
+	groupSize int,
+	dishonestThreshold int,
+	membershipValidator group.MembershipValidator,
+	startBlockHeight uint64,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	machines *state.Registry,
+) (*ThresholdSigner, uint64, error) {
+	gjkr.RegisterUnmarshallers(channel)
+
+	gjkrResult, endBlockHeight, err := gjkr.ExecuteRefresh(
+		signer.memberIndex,
+		groupSize,
+		blockCounter,
+		channel,
+		dishonestThreshold,
+		seed,
+		membershipValidator,
+		startBlockHeight,
+		machines,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"[member:%v] GJKR share refresh failed [%v]",
+			signer.memberIndex,
+			err,
+		)
+	}
+
+	refreshedSigner, err := refreshSigner(
+		signer,
+		gjkrResult.GroupPrivateKeyShare,
+		gjkrResult.GroupPublicKeyShares(),
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"[member:%v] share refresh incomplete: [%v]",
+			signer.memberIndex,
+			err,
+		)
+	}
+
+	return refreshedSigner, endBlockHeight, nil
+}
+
+// RefreshCommitBlockHeight returns the block at which the decision of members
+// whether to commit the share refresh protocol which ended at the given block
+// is final. Relay entries requested before that block are signed with previous
+// shares no matter what the decision is.
+func RefreshCommitBlockHeight(refreshEndBlockHeight uint64) uint64 {
+	return refreshVoteEndBlockHeight(refreshEndBlockHeight) +
+		refreshCommitActiveBlocks
+}
+
+// refreshVoteEndBlockHeight returns the block at which the vote period of
+// the share refresh protocol which ended at the given block ends.
+func refreshVoteEndBlockHeight(refreshEndBlockHeight uint64) uint64 {
+	return refreshEndBlockHeight +
+		refreshConfirmationDelayBlocks +
+		refreshConfirmationActiveBlocks +
+		refreshVoteActiveBlocks
+}
+
+// ConfirmRefresh lets the group agree on committing shares refreshed in
+// the share refresh with the given seed which ended at the given block.
+// It returns nil only if the refresh is committed and the given signer may
+// replace the previous one.
+//
+// The agreement has three rounds. In the first one, each member broadcasts its
+// signature share of a test message derived from the seed, calculated with
+// its refreshed group private key share, as the confirmation of the refresh.
+// The signature share is verified against the refreshed group public key share
+// of the sender, so it proves the sender has completed the refresh with
+// the same result. In the second one, each member broadcasts a vote carrying
+// all the confirmations it has received. At the end of the vote period,
+// a member which received votes with confirmations of all the members holding
+// shares of the group private key from all of them commits the refresh and
+// broadcasts a commit message carrying the confirmations.
+//
+// Members decide on their own which votes they received, so a member which
+// missed a vote would keep previous shares while the rest of the group
+// switches to refreshed ones. In the third round, such a member commits
+// the refresh as well once it receives a valid commit message from any other
+// member: confirmations carried by the message prove all the members hold
+// refreshed shares. The decision is final at the block returned by
+// RefreshCommitBlockHeight. A member which missed commit messages of all
+// the members which committed is still left with previous shares; if any
+// member voted for committing the refresh, the returned error names members
+// which may have committed it, so that the split of the group is reported.
+//
+// Refreshed shares are compatible only with refreshed shares, so members may
+// switch to them only if all members do. Members which failed to complete
+// the refresh on their own, including those unable to persist refreshed
+// shares, must not confirm it.
+func ConfirmRefresh(
+	signer *ThresholdSigner,
+	seed *big.Int,
+	refreshEndBlockHeight uint64,
+	membershipValidator group.MembershipValidator,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+) error {
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &RefreshConfirmationMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &RefreshVoteMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &RefreshCommitMessage{}
+	})
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	messagesChannel := make(chan net.Message, 64)
+	channel.Recv(ctx, func(netMessage net.Message) {
+		select {
+		case messagesChannel <- netMessage:
+		case <-ctx.Done():
+		}
+	})
+
+	err := blockCounter.WaitForBlockHeight(
+		refreshEndBlockHeight + refreshConfirmationDelayBlocks,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"[member:%v] could not wait for confirmation start: [%v]",
+			signer.memberIndex,
+			err,
+		)
+	}
+
+	confirmationEndChannel, err := blockCounter.BlockHeightWaiter(
+		refreshEndBlockHeight +
+			refreshConfirmationDelayBlocks +
+			refreshConfirmationActiveBlocks,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"[member:%v] could not wait for confirmation end: [%v]",
+			signer.memberIndex,
+			err,
+		)
+	}
+
+	voteEndChannel, err := blockCounter.BlockHeightWaiter(
+		refreshVoteEndBlockHeight(refreshEndBlockHeight),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"[member:%v] could not wait for vote end: [%v]",
+			signer.memberIndex,
+			err,
+		)
+	}
+
+	commitChannel, err := blockCounter.BlockHeightWaiter(
+		RefreshCommitBlockHeight(refreshEndBlockHeight),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"[member:%v] could not wait for commit block: [%v]",
+			signer.memberIndex,
+			err,
+		)
+	}
+
+	testMessage := refreshTestMessage(seed)
+	ownShareBytes := signer.CalculateSignatureShare(testMessage).Marshal()
+
+	err = channel.Send(ctx, NewRefreshConfirmationMessage(
+		signer.memberIndex,
+		ownShareBytes,
+	))
+	if err != nil {
+		return fmt.Errorf(
+			"[member:%v] could not send refresh confirmation: [%v]",
+			signer.memberIndex,
+			err,
+		)
+	}
+
+	confirmations := map[group.MemberIndex][]byte{
+		signer.memberIndex: ownShareBytes,
+	}
+	// Members whose votes carrying confirmations of all the members have
+	// been received.
+	approvingMembers := map[group.MemberIndex]bool{
+		signer.memberIndex: true,
+	}
+	// Other members whose commit messages have been received.
+	committingMembers := make([]group.MemberIndex, 0)
+
+	voteSent := false
+	sendVote := func() error {
+		voteSent = true
+		return channel.Send(
+			ctx,
+			NewRefreshVoteMessage(signer.memberIndex, confirmations),
+		)
+	}
+
+	// isFromMember checks whether the given message has been sent by other
+	// member holding a share of the group private key.
+	isFromMember := func(
+		senderID group.MemberIndex,
+		netMessage net.Message,
+	) bool {
+		if _, ok := signer.groupPublicKeyShares[senderID]; !ok {
+			return false
+		}
+		if senderID == signer.memberIndex {
+			return false
+		}
+
+		if !membershipValidator.IsValidMembership(
+			senderID,
+			netMessage.SenderPublicKey(),
+		) {
+			logger.Warningf(
+				"[member:%v] refresh message of member [%v] "+
+					"sent by other party",
+				signer.memberIndex,
+				senderID,
+			)
+			return false
+		}
+
+		return true
+	}
+
+	committed := false
+	var notApprovingMembers []group.MemberIndex
+
+	// decide commits the refresh at the end of the vote period if votes of
+	// all the members approve it.
+	decide := func() {
+		// The channel is closed once the block is reached.
+		voteEndChannel = nil
+
+		notApprovingMembers = make([]group.MemberIndex, 0)
+		for memberIndex := range signer.groupPublicKeyShares {
+			if !approvingMembers[memberIndex] {
+				notApprovingMembers = append(
+					notApprovingMembers,
+					memberIndex,
+				)
+			}
+		}
+		sortMemberIndexes(notApprovingMembers)
+
+		if len(notApprovingMembers) > 0 {
+			return
+		}
+
+		committed = true
+
+		err := channel.Send(
+			ctx,
+			NewRefreshCommitMessage(signer.memberIndex, confirmations),
+		)
+		if err != nil {
+			logger.Warningf(
+				"[member:%v] could not send refresh commit message: [%v]",
+				signer.memberIndex,
+				err,
+			)
+		}
+	}
+
+	for {
+		select {
+		case netMessage := <-messagesChannel:
+			switch message := netMessage.Payload().(type) {
+			case *RefreshConfirmationMessage:
+				if voteSent {
+					continue
+				}
+
+				if !signer.verifyRefreshConfirmation(message, testMessage) {
+					continue
+				}
+
+				confirmations[message.senderID] = message.shareBytes
+
+				if coversAllMembers(signer, confirmations) {
+					if err := sendVote(); err != nil {
+						return fmt.Errorf(
+							"[member:%v] could not send refresh vote: [%v]",
+							signer.memberIndex,
+							err,
+						)
+					}
+				}
+			case *RefreshVoteMessage:
+				if !isFromMember(message.senderID, netMessage) {
+					continue
+				}
+
+				approvingMembers[message.senderID] = signer.verifyAllConfirmations(
+					message.confirmations,
+					testMessage,
+					ownShareBytes,
+				)
+			case *RefreshCommitMessage:
+				if !isFromMember(message.senderID, netMessage) {
+					continue
+				}
+
+				if !signer.verifyAllConfirmations(
+					message.confirmations,
+					testMessage,
+					ownShareBytes,
+				) {
+					logger.Warningf(
+						"[member:%v] invalid refresh commit message "+
+							"from member [%v]",
+						signer.memberIndex,
+						message.senderID,
+					)
+					continue
+				}
+
+				committingMembers = append(
+					committingMembers,
+					message.senderID,
+				)
+			}
+		case <-confirmationEndChannel:
+			// The channel is closed once the block is reached.
+			confirmationEndChannel = nil
+
+			if voteSent {
+				continue
+			}
+
+			// Other members are let know the refresh can not be committed
+			// as they would otherwise wait for the vote until the end of
+			// the vote period anyway. No member commits the refresh without
+			// the approving vote of this member.
+			if err := sendVote(); err != nil {
+				logger.Warningf(
+					"[member:%v] could not send refresh vote: [%v]",
+					signer.memberIndex,
+					err,
+				)
+			}
+
+			return fmt.Errorf(
+				"[member:%v] members %v did not confirm the share refresh",
+				signer.memberIndex,
+				missingMembers(signer, confirmations),
+			)
+		case <-voteEndChannel:
+			decide()
+		case <-commitChannel:
+			// Both blocks may be reached at once.
+			if voteEndChannel != nil {
+				decide()
+			}
+
+			if committed {
+				return nil
+			}
+
+			if len(committingMembers) > 0 {
+				sortMemberIndexes(committingMembers)
+
+				logger.Warningf(
+					"[member:%v] members %v did not vote for committing "+
+						"the share refresh but members %v committed it; "+
+						"committing the share refresh as well",
+					signer.memberIndex,
+					notApprovingMembers,
+					committingMembers,
+				)
+
+				return nil
+			}
+
+			possiblyCommittingMembers := make([]group.MemberIndex, 0)
+			for memberIndex, approving := range approvingMembers {
+				if approving && memberIndex != signer.memberIndex {
+					possiblyCommittingMembers = append(
+						possiblyCommittingMembers,
+						memberIndex,
+					)
+				}
+			}
+
+			if len(possiblyCommittingMembers) > 0 {
+				sortMemberIndexes(possiblyCommittingMembers)
+
+				return fmt.Errorf(
+					"[member:%v] members %v did not vote for committing "+
+						"the share refresh; members %v voted for committing "+
+						"it and may have committed it, leaving the group split",
+					signer.memberIndex,
+					notApprovingMembers,
+					possiblyCommittingMembers,
+				)
+			}
+
+			return fmt.Errorf(
+				"[member:%v] members %v did not vote for committing "+
+					"the share refresh",
+				signer.memberIndex,
+				notApprovingMembers,
+			)
+		}
+	}
+}
+
+// verifyRefreshConfirmation checks whether the given confirmation comes from
+// other member holding a share of the group private key and carries
+// the member's valid signature share of the given test message, calculated
+// with the member's refreshed share.
+func (ts *ThresholdSigner) verifyRefreshConfirmation(
+	message *RefreshConfirmationMessage,
+	testMessage *bn256.G1,
+) bool {
+	publicKeyShare, ok := ts.groupPublicKeyShares[message.senderID]
+	if !ok || message.senderID == ts.memberIndex {
+		return false
+	}
+
+	share := new(bn256.G1)
+	_, err := share.Unmarshal(message.shareBytes)
+	if err != nil || !bls.VerifyG1(publicKeyShare, testMessage, share) {
+		logger.Warningf(
+			"[member:%v] invalid refresh confirmation from member [%v]",
+			ts.memberIndex,
+			message.senderID,
+		)
+		return false
+	}
+
+	return true
+}
+
+// verifyAllConfirmations checks whether the given confirmations come from
+// all the members holding shares of the group private key and are all valid.
+// The confirmation of the signer itself has to be the one the signer has
+// broadcast.
+func (ts *ThresholdSigner) verifyAllConfirmations(
+	confirmations map[group.MemberIndex][]byte,
+	testMessage *bn256.G1,
+	ownShareBytes []byte,
+) bool {
+	if !coversAllMembers(ts, confirmations) {
+		return false
+	}
+
+	for memberIndex, shareBytes := range confirmations {
+		if memberIndex == ts.memberIndex {
+			if !bytes.Equal(shareBytes, ownShareBytes) {
+				return false
+			}
+			continue
+		}
+
+		if !ts.verifyRefreshConfirmation(
+			NewRefreshConfirmationMessage(memberIndex, shareBytes),
+			testMessage,
+		) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// coversAllMembers checks whether the given confirmations come from all
+// the members of the given signer's group holding shares of the group private
+// key, including the signer.
+func coversAllMembers(
+	signer *ThresholdSigner,
+	confirmations map[group.MemberIndex][]byte,
+) bool {
+	return len(missingMembers(signer, confirmations)) == 0
+}
+
+// missingMembers returns indexes of members of the given signer's group
+// holding shares of the group private key, including the signer, whose
+// confirmations are missing, in ascending order.
+func missingMembers(
+	signer *ThresholdSigner,
+	confirmations map[group.MemberIndex][]byte,
+) []group.MemberIndex {
+	missing := make([]group.MemberIndex, 0)
+	if _, ok := confirmations[signer.memberIndex]; !ok {
+		missing = append(missing, signer.memberIndex)
+	}
+	// Group public key shares may not include the signer's own one.
+	for memberIndex := range signer.groupPublicKeyShares {
+		if memberIndex == signer.memberIndex {
+			continue
+		}
+		if _, ok := confirmations[memberIndex]; !ok {
+			missing = append(missing, memberIndex)
+		}
+	}
+	sortMemberIndexes(missing)
+
+	return missing
+}
+
+func sortMemberIndexes(memberIndexes []group.MemberIndex) {
+	sort.Slice(memberIndexes, func(i, j int) bool {
+		return memberIndexes[i] < memberIndexes[j]
+	})
+}
+
+// refreshTestMessage returns the message signed by members to confirm
+// the share refresh with the given seed.
+func refreshTestMessage(seed *big.Int) *bn256.G1 {
+	return altbn128.G1HashToPoint(
+		append([]byte("share refresh confirmation"), seed.Bytes()...),
+	)
+}
+
+// refreshSigner returns a new signer with the group private key share and
+// group public key shares of the given signer updated with deltas computed
+// from sharings of zero. It fails if there is no delta for any of the group
+// public key shares of the given signer, that is, if any member holding
+// a share did not take part in the refresh.
+func refreshSigner(
+	signer *ThresholdSigner,
+	groupPrivateKeyShareDelta *big.Int,
+	groupPublicKeySharesDeltas map[group.MemberIndex]*bn256.G2,
+) (*ThresholdSigner, error) {
+	groupPrivateKeyShare := new(big.Int).Add(
+		signer.groupPrivateKeyShare,
+		groupPrivateKeyShareDelta,
+	)
+	groupPrivateKeyShare.Mod(groupPrivateKeyShare, bn256.Order)
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	for memberIndex, groupPublicKeyShare := range signer.groupPublicKeyShares {
+		if memberIndex == signer.memberIndex {
+			groupPublicKeyShares[memberIndex] = new(bn256.G2).ScalarBaseMult(
+				groupPrivateKeyShare,
+			)
+			continue
+		}
+
+		delta, ok := groupPublicKeySharesDeltas[memberIndex]
+		if !ok {
+			return nil, fmt.Errorf(
+				"member [%v] did not take part in the refresh",
+				memberIndex,
+			)
+		}
+
+		groupPublicKeyShares[memberIndex] = new(bn256.G2).Add(
+			groupPublicKeyShare,
+			delta,
+		)
+	}
+
+	return &ThresholdSigner{
+		memberIndex:          signer.memberIndex,
+		groupPublicKey:       signer.groupPublicKey,
+		groupPrivateKeyShare: groupPrivateKeyShare,
+		groupPublicKeyShares: groupPublicKeyShares,
+	}, nil
+}
@@ -0,0 +1,394 @@
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestRefreshSigner(t *testing.T) {
+	groupSize := 5
+	honestThreshold := 3
+
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(1337))
+
+	groupPrivateKey := []*big.Int{big.NewInt(42), big.NewInt(5), big.NewInt(9)}
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupPrivateKey[0])
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	for i := 1; i <= groupSize; i++ {
+		groupPublicKeyShares[group.MemberIndex(i)] = bls.GetSecretKeyShare(
+			groupPrivateKey,
+			i,
+		).PublicKeyShare().V
+	}
+
+	deltas := zeroSharingDeltas(groupSize)
+
+	refreshedSigners := make(map[group.MemberIndex]*ThresholdSigner)
+	for memberIndex, delta := range deltas {
+		signer := NewThresholdSigner(
+			memberIndex,
+			groupPublicKey,
+			bls.GetSecretKeyShare(groupPrivateKey, int(memberIndex)).V,
+			groupPublicKeyShares,
+		)
+
+		publicKeySharesDeltas := make(map[group.MemberIndex]*bn256.G2)
+		for peerMemberIndex, peerDelta := range deltas {
+			if peerMemberIndex != memberIndex {
+				publicKeySharesDeltas[peerMemberIndex] = new(bn256.G2).
+					ScalarBaseMult(peerDelta)
+			}
+		}
+
+		refreshedSigner, err := refreshSigner(
+			signer,
+			delta,
+			publicKeySharesDeltas,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		refreshedSigners[memberIndex] = refreshedSigner
+
+		if refreshedSigners[memberIndex].groupPrivateKeyShare.Cmp(
+			signer.groupPrivateKeyShare,
+		) == 0 {
+			t.Errorf(
+				"group private key share of member [%v] has not been refreshed",
+				memberIndex,
+			)
+		}
+	}
+
+	signer := refreshedSigners[1]
+
+	if !bytes.Equal(
+		groupPublicKey.Marshal(),
+		signer.GroupPublicKeyBytes(),
+	) {
+		t.Errorf("group public key has changed")
+	}
+
+	if len(signer.GroupPublicKeyShares()) != groupSize {
+		t.Errorf(
+			"unexpected number of group public key shares\n"+
+				"expected: %v\nactual:   %v\n",
+			groupSize,
+			len(signer.GroupPublicKeyShares()),
+		)
+	}
+
+	for memberIndex, peerSigner := range refreshedSigners {
+		if memberIndex == signer.MemberID() {
+			continue
+		}
+
+		expectedPublicKeyShare := new(bn256.G2).ScalarBaseMult(
+			peerSigner.groupPrivateKeyShare,
+		)
+		if expectedPublicKeyShare.String() !=
+			signer.GroupPublicKeyShares()[memberIndex].String() {
+			t.Errorf(
+				"refreshed group public key share of member [%v] does not "+
+					"match refreshed group private key share",
+				memberIndex,
+			)
+		}
+	}
+
+	expectedSignature := bls.SignG1(groupPrivateKey[0], message)
+
+	for _, signingMembers := range [][]group.MemberIndex{
+		{1, 2, 3},
+		{3, 4, 5},
+	} {
+		var signatureShares []*bls.SignatureShare
+		for _, memberIndex := range signingMembers {
+			signatureShares = append(signatureShares, &bls.SignatureShare{
+				I: int(memberIndex),
+				V: refreshedSigners[memberIndex].CalculateSignatureShare(message),
+			})
+		}
+
+		signature, err := signer.CompleteSignature(
+			signatureShares,
+			honestThreshold,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if expectedSignature.String() != signature.String() {
+			t.Errorf(
+				"unexpected signature of members %v\nexpected: %v\nactual:   %v\n",
+				signingMembers,
+				expectedSignature,
+				signature,
+			)
+		}
+	}
+}
+
+func TestRefreshSignerWithMissingMember(t *testing.T) {
+	groupSize := 5
+
+	groupPrivateKey := []*big.Int{big.NewInt(42), big.NewInt(5), big.NewInt(9)}
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupPrivateKey[0])
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	for i := 1; i <= groupSize; i++ {
+		groupPublicKeyShares[group.MemberIndex(i)] = bls.GetSecretKeyShare(
+			groupPrivateKey,
+			i,
+		).PublicKeyShare().V
+	}
+
+	// Member 5 does not take part in the refresh.
+	deltas := zeroSharingDeltas(groupSize - 1)
+
+	signer := NewThresholdSigner(
+		1,
+		groupPublicKey,
+		bls.GetSecretKeyShare(groupPrivateKey, 1).V,
+		groupPublicKeyShares,
+	)
+
+	publicKeySharesDeltas := make(map[group.MemberIndex]*bn256.G2)
+	for peerMemberIndex, peerDelta := range deltas {
+		if peerMemberIndex != signer.MemberID() {
+			publicKeySharesDeltas[peerMemberIndex] = new(bn256.G2).
+				ScalarBaseMult(peerDelta)
+		}
+	}
+
+	_, err := refreshSigner(signer, deltas[1], publicKeySharesDeltas)
+	if err == nil {
+		t.Fatal("expected refresh to fail when a member did not take part")
+	}
+}
+
+func TestConfirmRefresh(t *testing.T) {
+	groupSize := 3
+
+	var tests = map[string]struct {
+		// dropped returns true if the given message is not delivered to
+		// the given member
+		dropped       func(receiver group.MemberIndex, payload interface{}) bool
+		expectedError map[group.MemberIndex]string
+	}{
+		"all messages delivered": {
+			dropped: func(group.MemberIndex, interface{}) bool {
+				return false
+			},
+			expectedError: map[group.MemberIndex]string{},
+		},
+		"member missed a confirmation": {
+			dropped: func(receiver group.MemberIndex, payload interface{}) bool {
+				message, ok := payload.(*RefreshConfirmationMessage)
+				return ok && receiver == 3 && message.senderID == 2
+			},
+			expectedError: map[group.MemberIndex]string{
+				1: "members [3] did not vote for committing",
+				2: "members [3] did not vote for committing",
+				3: "members [2] did not confirm the share refresh",
+			},
+		},
+		"member missed a vote": {
+			dropped: func(receiver group.MemberIndex, payload interface{}) bool {
+				message, ok := payload.(*RefreshVoteMessage)
+				return ok && receiver == 3 && message.senderID == 2
+			},
+			expectedError: map[group.MemberIndex]string{},
+		},
+		"member missed a vote and all commit messages": {
+			dropped: func(receiver group.MemberIndex, payload interface{}) bool {
+				if _, ok := payload.(*RefreshCommitMessage); ok {
+					return receiver == 3
+				}
+				message, ok := payload.(*RefreshVoteMessage)
+				return ok && receiver == 3 && message.senderID == 2
+			},
+			expectedError: map[group.MemberIndex]string{
+				3: "members [1] voted for committing it and may have " +
+					"committed it, leaving the group split",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		testName, test := testName, test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			groupPrivateKey := []*big.Int{big.NewInt(42), big.NewInt(5)}
+			groupPublicKey := new(bn256.G2).ScalarBaseMult(groupPrivateKey[0])
+
+			groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+			for i := 1; i <= groupSize; i++ {
+				groupPublicKeyShares[group.MemberIndex(i)] = bls.GetSecretKeyShare(
+					groupPrivateKey,
+					i,
+				).PublicKeyShare().V
+			}
+
+			blockCounter, err := local.Connect(5, 3, big.NewInt(10)).BlockCounter()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			refreshEndBlockHeight, err := blockCounter.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			provider := netLocal.Connect()
+
+			var wg sync.WaitGroup
+			errors := make(map[group.MemberIndex]error)
+			var errorsMutex sync.Mutex
+
+			for i := 1; i <= groupSize; i++ {
+				memberIndex := group.MemberIndex(i)
+
+				broadcastChannel, err := provider.BroadcastChannelFor(testName)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				channel := &droppingChannel{
+					BroadcastChannel: broadcastChannel,
+					dropped: func(payload interface{}) bool {
+						return test.dropped(memberIndex, payload)
+					},
+				}
+
+				signer := NewThresholdSigner(
+					memberIndex,
+					groupPublicKey,
+					bls.GetSecretKeyShare(groupPrivateKey, i).V,
+					groupPublicKeyShares,
+				)
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					err := ConfirmRefresh(
+						signer,
+						big.NewInt(1337),
+						refreshEndBlockHeight,
+						&acceptingMembershipValidator{},
+						blockCounter,
+						channel,
+					)
+
+					errorsMutex.Lock()
+					errors[memberIndex] = err
+					errorsMutex.Unlock()
+				}()
+			}
+
+			wg.Wait()
+
+			for i := 1; i <= groupSize; i++ {
+				memberIndex := group.MemberIndex(i)
+				err := errors[memberIndex]
+
+				expectedError, ok := test.expectedError[memberIndex]
+				if !ok {
+					if err != nil {
+						t.Errorf(
+							"member [%v] has not committed the refresh: [%v]",
+							memberIndex,
+							err,
+						)
+					}
+					continue
+				}
+
+				if err == nil || !strings.Contains(err.Error(), expectedError) {
+					t.Errorf(
+						"unexpected error of member [%v]\n"+
+							"expected: %v\nactual:   %v\n",
+						memberIndex,
+						expectedError,
+						err,
+					)
+				}
+			}
+		})
+	}
+}
+
+// droppingChannel is a broadcast channel which does not deliver messages
+// whose payload is dropped.
+type droppingChannel struct {
+	net.BroadcastChannel
+	dropped func(payload interface{}) bool
+}
+
+func (dc *droppingChannel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+) {
+	dc.BroadcastChannel.Recv(ctx, func(message net.Message) {
+		if !dc.dropped(message.Payload()) {
+			handler(message)
+		}
+	})
+}
+
+// acceptingMembershipValidator accepts messages of all parties as all test
+// members share the same network key.
+type acceptingMembershipValidator struct{}
+
+func (amv *acceptingMembershipValidator) IsInGroup(
+	publicKey *ecdsa.PublicKey,
+) bool {
+	return true
+}
+
+func (amv *acceptingMembershipValidator) IsValidMembership(
+	memberID group.MemberIndex,
+	publicKey []byte,
+) bool {
+	return true
+}
+
+// zeroSharingDeltas returns deltas of group private key shares of the given
+// number of members, each dealing a sharing of zero with a polynomial of
+// degree two.
+func zeroSharingDeltas(refreshingMembers int) map[group.MemberIndex]*big.Int {
+	zeroSharings := make([][]*big.Int, refreshingMembers)
+	for i := range zeroSharings {
+		zeroSharings[i] = []*big.Int{
+			big.NewInt(0),
+			big.NewInt(int64(7 + 6*i)),
+			big.NewInt(int64(11 + 4*i)),
+		}
+	}
+
+	deltas := make(map[group.MemberIndex]*big.Int)
+	for i := 1; i <= refreshingMembers; i++ {
+		delta := big.NewInt(0)
+		for _, zeroSharing := range zeroSharings {
+			delta.Add(delta, bls.GetSecretKeyShare(zeroSharing, i).V)
+		}
+		deltas[group.MemberIndex(i)] = delta
+	}
+
+	return deltas
+}
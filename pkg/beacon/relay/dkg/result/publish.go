@@ -25,7 +25,8 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // chosen result is hashed, signed, and sent over a broadcast channel. Then, all
 // other signatures and results are received and accounted for. Those that match
 // our own result and added to the list of votes. Finally, we submit the result
-// along with everyone's votes.
+// along with everyone's votes. If the machine registry is not nil, the state
+// of the publication is reported to it for as long as it is executed.
 func Publish(
 	memberIndex group.MemberIndex,
 	dkgGroup *group.Group,
@@ -36,6 +37,7 @@ func Publish(
 	signing chain.Signing,
 	blockCounter chain.BlockCounter,
 	startBlockHeight uint64,
+	machines *state.Registry,
 ) error {
 	initialState := &resultSigningState{
 		channel:                 channel,
@@ -49,6 +51,9 @@ func Publish(
 	}
 
 	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	if machines != nil {
+		stateMachine.SetRegistry(machines)
+	}
 
 	lastState, _, err := stateMachine.Execute(startBlockHeight)
 	if err != nil {
@@ -39,7 +39,10 @@ func NewSubmittingMember(
 //
 // If a result is submitted by another member and it's accepted by the chain,
 // the current member finishes the phase immediately, without submitting
-// their own result.
+// their own result. The member does not wait for the submission of another
+// member to be confirmed; any other submission would revert anyway. If the
+// submission seen gets reorged out, no other member submits the result and the
+// group is not registered, just like when the submission fails on-chain.
 //
 // It returns the on-chain block height of the moment when the result was
 // successfully submitted on chain by the member. In case of failure or result
@@ -69,7 +72,7 @@ func (sm *SubmittingMember) SubmitDKGResult(
 
 	onSubmittedResultChan := make(chan uint64)
 
-	subscription := chainRelay.OnDKGResultSubmitted(
+	subscription := chainRelay.OnUnconfirmedDKGResultSubmitted(
 		func(event *event.DKGResultSubmission) {
 			onSubmittedResultChan <- event.BlockNumber
 		},
@@ -137,7 +140,18 @@ func (sm *SubmittingMember) SubmitDKGResult(
 				) {
 					errorChannel <- err
 				})
-			return <-errorChannel
+
+			err := <-errorChannel
+			if relayChain.IsAlreadyDone(err) {
+				logger.Infof(
+					"[member:%v] leaving; DKG result already submitted "+
+						"by other member",
+					sm.index,
+				)
+				return nil
+			}
+
+			return err
 		case blockNumber := <-onSubmittedResultChan:
 			logger.Infof(
 				"[member:%v] leaving; DKG result submitted by other member at block [%v]",
@@ -224,6 +224,80 @@ func TestConcurrentPublishResult(t *testing.T) {
 	}
 }
 
+// This test runs result publication by two members when DKG result
+// submission events require confirmations. The member with the higher index
+// should not wait for the submission of the member with the lower index to be
+// confirmed and should leave before it becomes eligible to submit.
+func TestPublishResultNotWaitingForConfirmations(t *testing.T) {
+	honestThreshold := 3
+	groupSize := 5
+
+	chainHandle := local.Connect(groupSize, honestThreshold, big.NewInt(200))
+	chainHandle.SetEventConfirmations(6)
+
+	blockCounter, err := chainHandle.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initialBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainRelay := chainHandle.ThresholdRelay()
+	tStep := chainRelay.GetConfig().ResultPublicationBlockStep
+
+	result := &relayChain.DKGResult{
+		GroupPublicKey: []byte{101},
+	}
+	signatures := map[group.MemberIndex][]byte{
+		1: []byte{101},
+		2: []byte{102},
+		3: []byte{103},
+		4: []byte{104},
+	}
+
+	member1 := &SubmittingMember{index: group.MemberIndex(1)}
+	member2 := &SubmittingMember{index: group.MemberIndex(2)}
+
+	member1Done := make(chan error, 1)
+	go func() {
+		member1Done <- member1.SubmitDKGResult(
+			result,
+			signatures,
+			chainRelay,
+			blockCounter,
+			initialBlock,
+		)
+	}()
+
+	err = member2.SubmitDKGResult(
+		result,
+		signatures,
+		chainRelay,
+		blockCounter,
+		initialBlock,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currentBlock, _ := blockCounter.CurrentBlock()
+	if currentBlock >= initialBlock+tStep {
+		t.Errorf(
+			"member left after becoming eligible\n"+
+				"expected: < %v\nactual:     %v\n",
+			initialBlock+tStep,
+			currentBlock,
+		)
+	}
+
+	if err := <-member1Done; err != nil {
+		t.Fatal(err)
+	}
+}
+
 func initChainHandle(honestThreshold int, groupSize int) (chain.Handle, uint64, error) {
 	chainHandle := local.Connect(groupSize, honestThreshold, big.NewInt(200))
 
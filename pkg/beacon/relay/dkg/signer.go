@@ -1,7 +1,9 @@
 package dkg
 
 import (
+	"fmt"
 	"math/big"
+	"sort"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/altbn128"
@@ -76,3 +78,100 @@ func (ts *ThresholdSigner) CompleteSignature(
 func (ts *ThresholdSigner) GroupPublicKeyShares() map[group.MemberIndex]*bn256.G2 {
 	return ts.groupPublicKeyShares
 }
+
+// VerifyShares checks the consistency of the signer's key shares. The public
+// key share of the signer, if present in the group public key shares, must
+// match its private key share and all the group public key shares, together
+// with the signer's one, must interpolate to the group public key. At least
+// the given honest threshold of shares is required.
+func (ts *ThresholdSigner) VerifyShares(honestThreshold int) error {
+	ownPublicKeyShare := new(bn256.G2).ScalarBaseMult(ts.groupPrivateKeyShare)
+
+	if publicKeyShare, ok := ts.groupPublicKeyShares[ts.memberIndex]; ok &&
+		publicKeyShare.String() != ownPublicKeyShare.String() {
+		return fmt.Errorf(
+			"private key share does not match public key share of member [%v]",
+			ts.memberIndex,
+		)
+	}
+
+	shares := ts.publicKeyShares(ownPublicKeyShare)
+
+	if len(shares) < honestThreshold {
+		return fmt.Errorf(
+			"[%v] public key shares available but honest threshold is [%v]",
+			len(shares),
+			honestThreshold,
+		)
+	}
+
+	// All the shares are interpolated, not just the threshold of them, so
+	// that any inconsistent share is detected.
+	recoveredPublicKey, err := bls.RecoverPublicKey(shares, len(shares))
+	if err != nil {
+		return fmt.Errorf("could not recover group public key: [%v]", err)
+	}
+
+	if recoveredPublicKey.String() != ts.groupPublicKey.String() {
+		return fmt.Errorf(
+			"public key shares do not interpolate to the group public key",
+		)
+	}
+
+	return nil
+}
+
+// HonestThreshold returns the honest threshold of the group recovered from
+// the signer's key shares. It is the smallest number of public key shares,
+// together with the signer's one, interpolating to the group public key.
+// An error is returned if all the available shares do not interpolate to
+// the group public key.
+func (ts *ThresholdSigner) HonestThreshold() (int, error) {
+	shares := ts.publicKeyShares(
+		new(bn256.G2).ScalarBaseMult(ts.groupPrivateKeyShare),
+	)
+
+	recovers := func(threshold int) bool {
+		recoveredPublicKey, err := bls.RecoverPublicKey(shares, threshold)
+		return err == nil &&
+			recoveredPublicKey.String() == ts.groupPublicKey.String()
+	}
+
+	if !recovers(len(shares)) {
+		return 0, fmt.Errorf(
+			"public key shares do not interpolate to the group public key",
+		)
+	}
+
+	// Fewer shares than the threshold of the polynomial the shares were
+	// evaluated from interpolate to a different point, so the threshold is
+	// the smallest number of shares interpolating to the group public key.
+	return sort.Search(len(shares), func(i int) bool {
+		return recovers(i + 1)
+	}) + 1, nil
+}
+
+// publicKeyShares returns group public key shares ordered by member index,
+// with the public key share of the signer set to the given one.
+func (ts *ThresholdSigner) publicKeyShares(
+	ownPublicKeyShare *bn256.G2,
+) []*bls.PublicKeyShare {
+	shares := []*bls.PublicKeyShare{
+		{I: int(ts.memberIndex), V: ownPublicKeyShare},
+	}
+	for memberIndex, publicKeyShare := range ts.groupPublicKeyShares {
+		if memberIndex == ts.memberIndex {
+			continue
+		}
+		shares = append(shares, &bls.PublicKeyShare{
+			I: int(memberIndex),
+			V: publicKeyShare,
+		})
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].I < shares[j].I
+	})
+
+	return shares
+}
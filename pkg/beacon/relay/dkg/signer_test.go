@@ -115,3 +115,170 @@ func TestSignAndComplete(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyShares(t *testing.T) {
+	// shares of the polynomial 10 + 7x
+	privateKeyShare := func(memberIndex int64) *big.Int {
+		return big.NewInt(10 + 7*memberIndex)
+	}
+	publicKeyShare := func(memberIndex int64) *bn256.G2 {
+		return new(bn256.G2).ScalarBaseMult(privateKeyShare(memberIndex))
+	}
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(big.NewInt(10))
+
+	var tests = map[string]struct {
+		privateKeyShare      *big.Int
+		groupPublicKeyShares map[group.MemberIndex]*bn256.G2
+		honestThreshold      int
+		expectedError        string
+	}{
+		"consistent shares": {
+			privateKeyShare: privateKeyShare(1),
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{
+				2: publicKeyShare(2),
+				3: publicKeyShare(3),
+			},
+			honestThreshold: 2,
+		},
+		"consistent shares including own public key share": {
+			privateKeyShare: privateKeyShare(1),
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{
+				1: publicKeyShare(1),
+				2: publicKeyShare(2),
+				3: publicKeyShare(3),
+			},
+			honestThreshold: 2,
+		},
+		"private key share not matching own public key share": {
+			privateKeyShare: big.NewInt(1),
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{
+				1: publicKeyShare(1),
+				2: publicKeyShare(2),
+			},
+			honestThreshold: 2,
+			expectedError:   "private key share does not match public key share of member [1]",
+		},
+		"private key share not interpolating to group public key": {
+			privateKeyShare: big.NewInt(1),
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{
+				2: publicKeyShare(2),
+				3: publicKeyShare(3),
+			},
+			honestThreshold: 2,
+			expectedError:   "public key shares do not interpolate to the group public key",
+		},
+		"public key share of another member not interpolating": {
+			privateKeyShare: privateKeyShare(1),
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{
+				2: publicKeyShare(2),
+				3: publicKeyShare(4),
+			},
+			honestThreshold: 2,
+			expectedError:   "public key shares do not interpolate to the group public key",
+		},
+		"not enough shares": {
+			privateKeyShare:      privateKeyShare(1),
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{},
+			honestThreshold:      2,
+			expectedError:        "[1] public key shares available but honest threshold is [2]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			signer := NewThresholdSigner(
+				group.MemberIndex(1),
+				groupPublicKey,
+				test.privateKeyShare,
+				test.groupPublicKeyShares,
+			)
+
+			err := signer.VerifyShares(test.honestThreshold)
+
+			actualError := ""
+			if err != nil {
+				actualError = err.Error()
+			}
+			if actualError != test.expectedError {
+				t.Errorf(
+					"unexpected error\nexpected: %v\nactual:   %v",
+					test.expectedError,
+					actualError,
+				)
+			}
+		})
+	}
+}
+
+func TestHonestThreshold(t *testing.T) {
+	// shares of the polynomial 10 + 7x + 3x^2
+	privateKeyShare := func(memberIndex int64) *big.Int {
+		return big.NewInt(10 + 7*memberIndex + 3*memberIndex*memberIndex)
+	}
+	publicKeyShare := func(memberIndex int64) *bn256.G2 {
+		return new(bn256.G2).ScalarBaseMult(privateKeyShare(memberIndex))
+	}
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(big.NewInt(10))
+
+	var tests = map[string]struct {
+		groupPublicKeyShares    map[group.MemberIndex]*bn256.G2
+		expectedHonestThreshold int
+		expectedError           string
+	}{
+		"all shares": {
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{
+				2: publicKeyShare(2),
+				3: publicKeyShare(3),
+				4: publicKeyShare(4),
+				5: publicKeyShare(5),
+			},
+			expectedHonestThreshold: 3,
+		},
+		"threshold of shares": {
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{
+				3: publicKeyShare(3),
+				5: publicKeyShare(5),
+			},
+			expectedHonestThreshold: 3,
+		},
+		"not enough shares": {
+			groupPublicKeyShares: map[group.MemberIndex]*bn256.G2{
+				2: publicKeyShare(2),
+			},
+			expectedError: "public key shares do not interpolate to the group public key",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			signer := NewThresholdSigner(
+				group.MemberIndex(1),
+				groupPublicKey,
+				privateKeyShare(1),
+				test.groupPublicKeyShares,
+			)
+
+			honestThreshold, err := signer.HonestThreshold()
+
+			actualError := ""
+			if err != nil {
+				actualError = err.Error()
+			}
+			if actualError != test.expectedError {
+				t.Errorf(
+					"unexpected error\nexpected: %v\nactual:   %v",
+					test.expectedError,
+					actualError,
+				)
+			}
+
+			if honestThreshold != test.expectedHonestThreshold {
+				t.Errorf(
+					"unexpected honest threshold\nexpected: %v\nactual:   %v",
+					test.expectedHonestThreshold,
+					honestThreshold,
+				)
+			}
+		})
+	}
+}
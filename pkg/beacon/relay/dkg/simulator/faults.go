@@ -0,0 +1,457 @@
+package simulator
+
+import (
+	"bufio"
+	"crypto/rand"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Phases of the distributed key generation in which group members broadcast
+// messages. Messages sent in other phases can not be altered by faults.
+const (
+	ephemeralKeysPhase     = 1
+	commitmentsPhase       = 3
+	sharesAccusationsPhase = 4
+	sharePointsPhase       = 7
+	pointsAccusationsPhase = 8
+	keyRevealPhase         = 10
+	resultPublicationPhase = 13
+)
+
+// FaultKind determines how the faulty member misbehaves.
+type FaultKind int
+
+const (
+	// SilentAfterPhase faulty member does not send any message in phases
+	// following the fault phase.
+	SilentAfterPhase FaultKind = iota
+	// SilentInPhase faulty member does not send any message in the fault
+	// phase but is active in all other phases.
+	SilentInPhase
+	// InvalidShares faulty member sends shares which can not be decrypted
+	// by their receivers.
+	InvalidShares
+	// InvalidCommitments faulty member sends commitments not matching the
+	// shares.
+	InvalidCommitments
+	// InvalidPublicKeySharePoints faulty member sends public key share points
+	// not matching the shares.
+	InvalidPublicKeySharePoints
+)
+
+// Fault describes misbehaviour of one of the simulated group members.
+type Fault struct {
+	Kind FaultKind
+	// Index of the faulty member.
+	Member group.MemberIndex
+	// Phase in which the fault happens.
+	Phase int
+	// Index of the member receiving invalid shares. Zero if shares sent to all
+	// members are invalid.
+	Receiver group.MemberIndex
+}
+
+// String returns the fault in the form accepted by ParseFaultScript.
+func (f *Fault) String() string {
+	switch f.Kind {
+	case SilentAfterPhase:
+		return fmt.Sprintf("member %v goes silent after phase %v", f.Member, f.Phase)
+	case SilentInPhase:
+		return fmt.Sprintf("member %v goes silent in phase %v", f.Member, f.Phase)
+	case InvalidShares:
+		if f.Receiver != 0 {
+			return fmt.Sprintf(
+				"member %v sends invalid shares to member %v in phase %v",
+				f.Member,
+				f.Receiver,
+				f.Phase,
+			)
+		}
+		return fmt.Sprintf("member %v sends invalid shares in phase %v", f.Member, f.Phase)
+	case InvalidCommitments:
+		return fmt.Sprintf("member %v sends invalid commitments in phase %v", f.Member, f.Phase)
+	case InvalidPublicKeySharePoints:
+		return fmt.Sprintf(
+			"member %v sends invalid public key share points in phase %v",
+			f.Member,
+			f.Phase,
+		)
+	default:
+		return fmt.Sprintf("unknown fault of member %v in phase %v", f.Member, f.Phase)
+	}
+}
+
+var (
+	silentAfterPhasePattern = regexp.MustCompile(
+		`^member (\d+) goes silent after phase (\d+)$`,
+	)
+	silentInPhasePattern = regexp.MustCompile(
+		`^member (\d+) goes silent in phase (\d+)$`,
+	)
+	invalidSharesPattern = regexp.MustCompile(
+		`^member (\d+) sends invalid shares(?: to member (\d+))? in phase (\d+)$`,
+	)
+	invalidCommitmentsPattern = regexp.MustCompile(
+		`^member (\d+) sends invalid commitments in phase (\d+)$`,
+	)
+	invalidPublicKeySharePointsPattern = regexp.MustCompile(
+		`^member (\d+) sends invalid public key share points in phase (\d+)$`,
+	)
+)
+
+// ParseFaultScript parses a declarative fault script. Each fault is a separate
+// statement; statements are separated with new lines or semicolons. Text
+// following '#' up to the end of the line is ignored. Supported statements
+// are:
+//
+//	member <N> goes silent after phase <P>
+//	member <N> goes silent in phase <P>
+//	member <N> sends invalid shares [to member <M>] in phase 3
+//	member <N> sends invalid commitments in phase 3
+//	member <N> sends invalid public key share points in phase 7
+//
+// Statements are case-insensitive.
+func ParseFaultScript(script string) ([]*Fault, error) {
+	faults := make([]*Fault, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(script))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := scanner.Text()
+		if commentStart := strings.Index(line, "#"); commentStart >= 0 {
+			line = line[:commentStart]
+		}
+
+		for _, statement := range strings.Split(line, ";") {
+			statement = strings.ToLower(strings.Join(strings.Fields(statement), " "))
+			if statement == "" {
+				continue
+			}
+
+			fault, err := parseFault(statement)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"invalid fault in line [%v]: [%v]",
+					lineNumber,
+					err,
+				)
+			}
+
+			faults = append(faults, fault)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read fault script: [%v]", err)
+	}
+
+	return faults, nil
+}
+
+func parseFault(statement string) (*Fault, error) {
+	if match := silentAfterPhasePattern.FindStringSubmatch(statement); match != nil {
+		return newFault(SilentAfterPhase, match[1], "", match[2])
+	}
+	if match := silentInPhasePattern.FindStringSubmatch(statement); match != nil {
+		return newFault(SilentInPhase, match[1], "", match[2])
+	}
+	if match := invalidSharesPattern.FindStringSubmatch(statement); match != nil {
+		return newFault(InvalidShares, match[1], match[2], match[3])
+	}
+	if match := invalidCommitmentsPattern.FindStringSubmatch(statement); match != nil {
+		return newFault(InvalidCommitments, match[1], "", match[2])
+	}
+	if match := invalidPublicKeySharePointsPattern.FindStringSubmatch(statement); match != nil {
+		return newFault(InvalidPublicKeySharePoints, match[1], "", match[2])
+	}
+
+	return nil, fmt.Errorf("unrecognized statement [%v]", statement)
+}
+
+func newFault(kind FaultKind, member, receiver, phase string) (*Fault, error) {
+	memberIndex, err := parseMemberIndex(member)
+	if err != nil {
+		return nil, err
+	}
+
+	var receiverIndex group.MemberIndex
+	if receiver != "" {
+		receiverIndex, err = parseMemberIndex(receiver)
+		if err != nil {
+			return nil, err
+		}
+		if receiverIndex == memberIndex {
+			return nil, fmt.Errorf(
+				"member [%v] can not send shares to itself",
+				memberIndex,
+			)
+		}
+	}
+
+	phaseNumber, err := strconv.Atoi(phase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid phase [%v]: [%v]", phase, err)
+	}
+
+	fault := &Fault{
+		Kind:     kind,
+		Member:   memberIndex,
+		Phase:    phaseNumber,
+		Receiver: receiverIndex,
+	}
+
+	if err := fault.validatePhase(); err != nil {
+		return nil, err
+	}
+
+	return fault, nil
+}
+
+func parseMemberIndex(member string) (group.MemberIndex, error) {
+	index, err := strconv.ParseUint(member, 10, 8)
+	if err != nil || index == 0 {
+		return 0, fmt.Errorf("invalid member index [%v]", member)
+	}
+
+	return group.MemberIndex(index), nil
+}
+
+func (f *Fault) validatePhase() error {
+	var valid bool
+	switch f.Kind {
+	case SilentAfterPhase:
+		valid = f.Phase >= ephemeralKeysPhase &&
+			f.Phase < resultPublicationPhase
+	case SilentInPhase:
+		switch f.Phase {
+		case ephemeralKeysPhase,
+			commitmentsPhase,
+			sharesAccusationsPhase,
+			sharePointsPhase,
+			pointsAccusationsPhase,
+			keyRevealPhase,
+			resultPublicationPhase:
+			valid = true
+		}
+	case InvalidShares, InvalidCommitments:
+		valid = f.Phase == commitmentsPhase
+	case InvalidPublicKeySharePoints:
+		valid = f.Phase == sharePointsPhase
+	}
+
+	if !valid {
+		return fmt.Errorf("fault [%v] can not happen in phase [%v]", f, f.Phase)
+	}
+
+	return nil
+}
+
+// Rules returns interception rules altering messages sent by faulty members
+// as described by the given faults.
+func Rules(faults []*Fault) interception.Rules {
+	return func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		phase, sender, ok := messagePhase(msg)
+		if !ok {
+			return msg
+		}
+
+		for _, fault := range faults {
+			if fault.Member != sender {
+				continue
+			}
+
+			altered, err := fault.apply(msg, phase)
+			if err != nil {
+				logger.Errorf(
+					"could not apply fault [%v] to message of type [%v]: [%v]",
+					fault,
+					msg.Type(),
+					err,
+				)
+				continue
+			}
+
+			if altered == nil {
+				return nil
+			}
+
+			msg = altered
+		}
+
+		return msg
+	}
+}
+
+// messagePhase returns the phase in which the given message is sent along
+// with the message sender.
+func messagePhase(msg net.TaggedMarshaler) (int, group.MemberIndex, bool) {
+	switch m := msg.(type) {
+	case *gjkr.EphemeralPublicKeyMessage:
+		return ephemeralKeysPhase, m.SenderID(), true
+	case *gjkr.MemberCommitmentsMessage:
+		return commitmentsPhase, m.SenderID(), true
+	case *gjkr.PeerSharesMessage:
+		return commitmentsPhase, m.SenderID(), true
+	case *gjkr.SecretSharesAccusationsMessage:
+		return sharesAccusationsPhase, m.SenderID(), true
+	case *gjkr.MemberPublicKeySharePointsMessage:
+		return sharePointsPhase, m.SenderID(), true
+	case *gjkr.PointsAccusationsMessage:
+		return pointsAccusationsPhase, m.SenderID(), true
+	case *gjkr.MisbehavedEphemeralKeysMessage:
+		return keyRevealPhase, m.SenderID(), true
+	case *result.DKGResultHashSignatureMessage:
+		return resultPublicationPhase, m.SenderID(), true
+	default:
+		return 0, 0, false
+	}
+}
+
+// apply returns the message altered by the fault. If the message should be
+// dropped, nil is returned.
+func (f *Fault) apply(
+	msg net.TaggedMarshaler,
+	phase int,
+) (net.TaggedMarshaler, error) {
+	switch f.Kind {
+	case SilentAfterPhase:
+		if phase > f.Phase {
+			return nil, nil
+		}
+	case SilentInPhase:
+		if phase == f.Phase {
+			return nil, nil
+		}
+	case InvalidShares:
+		if sharesMessage, ok := msg.(*gjkr.PeerSharesMessage); ok {
+			return corruptShares(sharesMessage, f.Receiver)
+		}
+	case InvalidCommitments:
+		if commitmentsMessage, ok := msg.(*gjkr.MemberCommitmentsMessage); ok {
+			return corruptCommitments(commitmentsMessage)
+		}
+	case InvalidPublicKeySharePoints:
+		if pointsMessage, ok := msg.(*gjkr.MemberPublicKeySharePointsMessage); ok {
+			return corruptPublicKeySharePoints(pointsMessage)
+		}
+	}
+
+	return msg, nil
+}
+
+// corruptShares returns a copy of the message with encrypted shares for the
+// given receiver altered so that they can not be decrypted. If the receiver
+// is zero, shares for all receivers are altered.
+func corruptShares(
+	message *gjkr.PeerSharesMessage,
+	receiver group.MemberIndex,
+) (*gjkr.PeerSharesMessage, error) {
+	pbMessage := &pb.PeerShares{}
+	if err := roundtrip(message, pbMessage); err != nil {
+		return nil, err
+	}
+
+	for receiverID, shares := range pbMessage.Shares {
+		if receiver != 0 && group.MemberIndex(receiverID) != receiver {
+			continue
+		}
+
+		shares.EncryptedShareS = corruptBytes(shares.EncryptedShareS)
+		shares.EncryptedShareT = corruptBytes(shares.EncryptedShareT)
+	}
+
+	corrupted := &gjkr.PeerSharesMessage{}
+	if err := roundtrip(pbMessage, corrupted); err != nil {
+		return nil, err
+	}
+
+	return corrupted, nil
+}
+
+// corruptCommitments returns a copy of the message with the first commitment
+// replaced by a random point.
+func corruptCommitments(
+	message *gjkr.MemberCommitmentsMessage,
+) (*gjkr.MemberCommitmentsMessage, error) {
+	pbMessage := &pb.MemberCommitments{}
+	if err := roundtrip(message, pbMessage); err != nil {
+		return nil, err
+	}
+
+	if len(pbMessage.Commitments) > 0 {
+		_, point, err := bn256.RandomG1(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		pbMessage.Commitments[0] = point.Marshal()
+	}
+
+	corrupted := &gjkr.MemberCommitmentsMessage{}
+	if err := roundtrip(pbMessage, corrupted); err != nil {
+		return nil, err
+	}
+
+	return corrupted, nil
+}
+
+// corruptPublicKeySharePoints returns a copy of the message with the first
+// public key share point replaced by a random point.
+func corruptPublicKeySharePoints(
+	message *gjkr.MemberPublicKeySharePointsMessage,
+) (*gjkr.MemberPublicKeySharePointsMessage, error) {
+	pbMessage := &pb.MemberPublicKeySharePoints{}
+	if err := roundtrip(message, pbMessage); err != nil {
+		return nil, err
+	}
+
+	if len(pbMessage.PublicKeySharePoints) > 0 {
+		_, point, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		pbMessage.PublicKeySharePoints[0] = point.Marshal()
+	}
+
+	corrupted := &gjkr.MemberPublicKeySharePointsMessage{}
+	if err := roundtrip(pbMessage, corrupted); err != nil {
+		return nil, err
+	}
+
+	return corrupted, nil
+}
+
+func roundtrip(
+	from interface{ Marshal() ([]byte, error) },
+	to interface{ Unmarshal([]byte) error },
+) error {
+	bytes, err := from.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return to.Unmarshal(bytes)
+}
+
+func corruptBytes(bytes []byte) []byte {
+	corrupted := make([]byte, len(bytes))
+	copy(corrupted, bytes)
+
+	if len(corrupted) > 0 {
+		corrupted[len(corrupted)-1] ^= 0xff
+	}
+
+	return corrupted
+}
@@ -0,0 +1,316 @@
+package simulator
+
+import (
+	"bytes"
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestParseFaultScript(t *testing.T) {
+	var tests = map[string]struct {
+		script         string
+		expectedFaults []*Fault
+		expectedError  bool
+	}{
+		"empty script": {
+			script:         "  \n# no faults\n",
+			expectedFaults: []*Fault{},
+		},
+		"silent after phase": {
+			script: "member 5 goes silent after phase 4",
+			expectedFaults: []*Fault{
+				{Kind: SilentAfterPhase, Member: 5, Phase: 4},
+			},
+		},
+		"silent in phase": {
+			script: "member 2 goes silent in phase 13",
+			expectedFaults: []*Fault{
+				{Kind: SilentInPhase, Member: 2, Phase: 13},
+			},
+		},
+		"invalid shares to all members": {
+			script: "Member 3 sends  invalid shares in phase 3 # comment",
+			expectedFaults: []*Fault{
+				{Kind: InvalidShares, Member: 3, Phase: 3},
+			},
+		},
+		"invalid shares to one member": {
+			script: "member 3 sends invalid shares to member 1 in phase 3",
+			expectedFaults: []*Fault{
+				{Kind: InvalidShares, Member: 3, Phase: 3, Receiver: 1},
+			},
+		},
+		"multiple faults": {
+			script: "member 1 sends invalid commitments in phase 3; " +
+				"member 2 sends invalid public key share points in phase 7\n" +
+				"member 4 goes silent after phase 1",
+			expectedFaults: []*Fault{
+				{Kind: InvalidCommitments, Member: 1, Phase: 3},
+				{Kind: InvalidPublicKeySharePoints, Member: 2, Phase: 7},
+				{Kind: SilentAfterPhase, Member: 4, Phase: 1},
+			},
+		},
+		"unknown statement": {
+			script:        "member 1 sends invalid signature in phase 13",
+			expectedError: true,
+		},
+		"invalid member index": {
+			script:        "member 0 goes silent after phase 1",
+			expectedError: true,
+		},
+		"member index out of range": {
+			script:        "member 256 goes silent after phase 1",
+			expectedError: true,
+		},
+		"shares sent to itself": {
+			script:        "member 1 sends invalid shares to member 1 in phase 3",
+			expectedError: true,
+		},
+		"silent after last phase": {
+			script:        "member 1 goes silent after phase 13",
+			expectedError: true,
+		},
+		"silent in phase without messages": {
+			script:        "member 1 goes silent in phase 2",
+			expectedError: true,
+		},
+		"invalid shares in wrong phase": {
+			script:        "member 1 sends invalid shares in phase 7",
+			expectedError: true,
+		},
+		"invalid points in wrong phase": {
+			script:        "member 1 sends invalid public key share points in phase 3",
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			faults, err := ParseFaultScript(test.script)
+
+			if test.expectedError {
+				if err == nil {
+					t.Fatalf("expected an error for script [%v]", test.script)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedFaults, faults) {
+				t.Errorf(
+					"unexpected faults\nexpected: %v\nactual:   %v\n",
+					test.expectedFaults,
+					faults,
+				)
+			}
+		})
+	}
+}
+
+func TestFaultStringRoundtrip(t *testing.T) {
+	script := "member 5 goes silent after phase 4\n" +
+		"member 2 goes silent in phase 1\n" +
+		"member 3 sends invalid shares to member 1 in phase 3\n" +
+		"member 3 sends invalid shares in phase 3\n" +
+		"member 1 sends invalid commitments in phase 3\n" +
+		"member 2 sends invalid public key share points in phase 7"
+
+	faults, err := ParseFaultScript(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fault := range faults {
+		parsed, err := ParseFaultScript(fault.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual([]*Fault{fault}, parsed) {
+			t.Errorf(
+				"unexpected fault\nexpected: %v\nactual:   %v\n",
+				fault,
+				parsed[0],
+			)
+		}
+	}
+}
+
+func TestRules_SilentMember(t *testing.T) {
+	rules := Rules([]*Fault{
+		{Kind: SilentAfterPhase, Member: 1, Phase: 3},
+		{Kind: SilentInPhase, Member: 2, Phase: 7},
+	})
+
+	var tests = map[string]struct {
+		sender          group.MemberIndex
+		message         func(t *testing.T, sender group.MemberIndex) net.TaggedMarshaler
+		expectedDropped bool
+	}{
+		"member silent after phase sends message before the phase": {
+			sender:          1,
+			message:         newCommitmentsMessage,
+			expectedDropped: false,
+		},
+		"member silent after phase sends message after the phase": {
+			sender:          1,
+			message:         newSharePointsMessage,
+			expectedDropped: true,
+		},
+		"member silent in phase sends message in the phase": {
+			sender:          2,
+			message:         newSharePointsMessage,
+			expectedDropped: true,
+		},
+		"member silent in phase sends message in other phase": {
+			sender:          2,
+			message:         newCommitmentsMessage,
+			expectedDropped: false,
+		},
+		"honest member": {
+			sender:          3,
+			message:         newSharePointsMessage,
+			expectedDropped: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			dropped := rules(test.message(t, test.sender)) == nil
+			if dropped != test.expectedDropped {
+				t.Errorf(
+					"unexpected drop decision\nexpected: %v\nactual:   %v\n",
+					test.expectedDropped,
+					dropped,
+				)
+			}
+		})
+	}
+}
+
+func TestRules_InvalidShares(t *testing.T) {
+	rules := Rules([]*Fault{
+		{Kind: InvalidShares, Member: 1, Phase: 3, Receiver: 2},
+	})
+
+	pbMessage := &pb.PeerShares{
+		SenderID: 1,
+		Shares: map[uint32]*pb.PeerShares_Shares{
+			2: {EncryptedShareS: []byte{1, 2}, EncryptedShareT: []byte{3, 4}},
+			3: {EncryptedShareS: []byte{5, 6}, EncryptedShareT: []byte{7, 8}},
+		},
+	}
+	message := &gjkr.PeerSharesMessage{}
+	if err := roundtrip(pbMessage, message); err != nil {
+		t.Fatal(err)
+	}
+
+	altered, ok := rules(message).(*gjkr.PeerSharesMessage)
+	if !ok {
+		t.Fatal("expected altered peer shares message")
+	}
+
+	alteredPbMessage := &pb.PeerShares{}
+	if err := roundtrip(altered, alteredPbMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	corruptedShares := alteredPbMessage.Shares[2]
+	if bytes.Equal(corruptedShares.EncryptedShareS, []byte{1, 2}) ||
+		bytes.Equal(corruptedShares.EncryptedShareT, []byte{3, 4}) {
+		t.Errorf("shares for member [2] should be corrupted")
+	}
+
+	if !reflect.DeepEqual(pbMessage.Shares[3], alteredPbMessage.Shares[3]) {
+		t.Errorf("shares for member [3] should not be corrupted")
+	}
+
+	originalPbMessage := &pb.PeerShares{}
+	if err := roundtrip(message, originalPbMessage); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pbMessage, originalPbMessage) {
+		t.Errorf("original message should not be modified")
+	}
+}
+
+func TestRules_InvalidPublicKeySharePoints(t *testing.T) {
+	rules := Rules([]*Fault{
+		{Kind: InvalidPublicKeySharePoints, Member: 1, Phase: 7},
+	})
+
+	message := newSharePointsMessage(t, 1)
+
+	altered, ok := rules(message).(*gjkr.MemberPublicKeySharePointsMessage)
+	if !ok {
+		t.Fatal("expected altered public key share points message")
+	}
+
+	pbMessage := &pb.MemberPublicKeySharePoints{}
+	if err := roundtrip(message, pbMessage); err != nil {
+		t.Fatal(err)
+	}
+	alteredPbMessage := &pb.MemberPublicKeySharePoints{}
+	if err := roundtrip(altered, alteredPbMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(
+		pbMessage.PublicKeySharePoints[0],
+		alteredPbMessage.PublicKeySharePoints[0],
+	) {
+		t.Errorf("first public key share point should be replaced")
+	}
+}
+
+func newCommitmentsMessage(
+	t *testing.T,
+	sender group.MemberIndex,
+) net.TaggedMarshaler {
+	_, point, err := bn256.RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := &gjkr.MemberCommitmentsMessage{}
+	err = roundtrip(&pb.MemberCommitments{
+		SenderID:    uint32(sender),
+		Commitments: [][]byte{point.Marshal()},
+	}, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return message
+}
+
+func newSharePointsMessage(
+	t *testing.T,
+	sender group.MemberIndex,
+) net.TaggedMarshaler {
+	_, point, err := bn256.RandomG2(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := &gjkr.MemberPublicKeySharePointsMessage{}
+	err = roundtrip(&pb.MemberPublicKeySharePoints{
+		SenderID:             uint32(sender),
+		PublicKeySharePoints: [][]byte{point.Marshal()},
+	}, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return message
+}
@@ -0,0 +1,63 @@
+// Package simulator executes the full distributed key generation on the local
+// chain and network with group members misbehaving according to declared
+// faults, showing how the protocol reacts to misbehaviour without running a
+// real network.
+package simulator
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
+)
+
+var logger = log.Logger("keep-dkg-simulator")
+
+// maxGroupSize is the maximum number of members in a group; member indexes
+// are one byte long.
+const maxGroupSize = 255
+
+// Simulate executes the distributed key generation, including the result
+// signing and publication, for a group of the given size and honest threshold
+// on the local chain and network. Messages of faulty members are altered
+// according to the given faults.
+func Simulate(
+	groupSize int,
+	honestThreshold int,
+	seed *big.Int,
+	faults []*Fault,
+) (*dkgtest.Result, error) {
+	if groupSize < 1 || groupSize > maxGroupSize {
+		return nil, fmt.Errorf(
+			"group size [%v] must be between 1 and %v",
+			groupSize,
+			maxGroupSize,
+		)
+	}
+
+	if honestThreshold < 1 || honestThreshold > groupSize {
+		return nil, fmt.Errorf(
+			"honest threshold [%v] must be between 1 and group size [%v]",
+			honestThreshold,
+			groupSize,
+		)
+	}
+
+	for _, fault := range faults {
+		if int(fault.Member) > groupSize {
+			return nil, fmt.Errorf(
+				"fault [%v] refers to member outside of the group",
+				fault,
+			)
+		}
+		if int(fault.Receiver) > groupSize {
+			return nil, fmt.Errorf(
+				"fault [%v] refers to receiver outside of the group",
+				fault,
+			)
+		}
+	}
+
+	return dkgtest.RunTest(groupSize, honestThreshold, seed, Rules(faults))
+}
@@ -0,0 +1,60 @@
+package simulator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
+)
+
+func TestSimulate_InvalidSharesAndSilentMember(t *testing.T) {
+	groupSize := 7
+	honestThreshold := 4
+	seed := dkgtest.RandomSeed(t)
+
+	faults, err := ParseFaultScript(
+		"member 3 sends invalid shares to member 1 in phase 3\n" +
+			"member 5 goes silent after phase 4",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Simulate(groupSize, honestThreshold, seed, faults)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, result)
+	dkgtest.AssertSuccessfulSigners(t, result, []group.MemberIndex{1, 2, 4, 6, 7}...)
+	dkgtest.AssertMisbehavingMembers(t, result, group.MemberIndex(3), group.MemberIndex(5))
+	dkgtest.AssertValidGroupPublicKey(t, result)
+
+	expectedInactive := []group.MemberIndex{5}
+	if !reflect.DeepEqual(expectedInactive, result.GetInactiveMembers()) {
+		t.Errorf(
+			"unexpected inactive members\nexpected: %v\nactual:   %v\n",
+			expectedInactive,
+			result.GetInactiveMembers(),
+		)
+	}
+
+	expectedDisqualified := []group.MemberIndex{3}
+	if !reflect.DeepEqual(expectedDisqualified, result.GetDisqualifiedMembers()) {
+		t.Errorf(
+			"unexpected disqualified members\nexpected: %v\nactual:   %v\n",
+			expectedDisqualified,
+			result.GetDisqualifiedMembers(),
+		)
+	}
+}
+
+func TestSimulate_FaultyMemberOutsideOfGroup(t *testing.T) {
+	faults := []*Fault{{Kind: SilentAfterPhase, Member: 6, Phase: 1}}
+
+	_, err := Simulate(5, 3, dkgtest.RandomSeed(t), faults)
+	if err == nil {
+		t.Fatal("expected an error for faulty member outside of the group")
+	}
+}
@@ -0,0 +1,174 @@
+package dkg
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// resultPublicationPhase is the number of the protocol phase in which the
+// DKG result is signed and published.
+const resultPublicationPhase = 13
+
+// Summary describes a single distributed key generation executed by
+// the member, including its outcome.
+type Summary struct {
+	// MemberIndex is the index of the member executing the key generation.
+	MemberIndex group.MemberIndex `json:"memberIndex"`
+	// Phases are reports of all protocol phases completed by the member.
+	Phases []*gjkr.PhaseReport `json:"phases"`
+	// ResultPublished is true if the DKG result has been published on-chain.
+	ResultPublished bool `json:"resultPublished"`
+	// Excluded is true if the member did not end up in the final group.
+	Excluded bool `json:"excluded"`
+	// FailedPhase is the number of the protocol phase which failed for
+	// the member. It is zero if the member has not been excluded.
+	FailedPhase uint32 `json:"failedPhase,omitempty"`
+	// Reason explains why the member has been excluded.
+	Reason string `json:"reason,omitempty"`
+}
+
+// TelemetryStats are statistics of distributed key generations executed by
+// the client.
+type TelemetryStats struct {
+	// Executions is the number of completed key generations.
+	Executions uint64
+	// ResultsPublished is the number of key generations which ended with
+	// the result published on-chain.
+	ResultsPublished uint64
+	// MembersExcluded is the number of key generations after which
+	// the member did not end up in the final group.
+	MembersExcluded uint64
+	// LastSummary is the summary of the most recently completed key
+	// generation. It is nil if no key generation has been completed yet.
+	LastSummary *Summary
+}
+
+// Telemetry collects statistics of distributed key generations executed by
+// the client.
+type Telemetry struct {
+	mutex sync.RWMutex
+	stats TelemetryStats
+}
+
+// NewTelemetry creates a new, empty DKG telemetry.
+func NewTelemetry() *Telemetry {
+	return &Telemetry{}
+}
+
+// Stats returns statistics of all the key generations completed so far.
+func (t *Telemetry) Stats() TelemetryStats {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.stats
+}
+
+func (t *Telemetry) record(summary *Summary) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.stats.Executions++
+	if summary.ResultPublished {
+		t.stats.ResultsPublished++
+	}
+	if summary.Excluded {
+		t.stats.MembersExcluded++
+	}
+	t.stats.LastSummary = summary
+}
+
+// execution collects telemetry of a single key generation.
+type execution struct {
+	telemetry *Telemetry
+
+	mutex   sync.Mutex
+	summary *Summary
+}
+
+func (t *Telemetry) startExecution(memberIndex group.MemberIndex) *execution {
+	return &execution{
+		telemetry: t,
+		summary: &Summary{
+			MemberIndex: memberIndex,
+			Phases:      make([]*gjkr.PhaseReport, 0),
+		},
+	}
+}
+
+func (e *execution) phaseCompleted(report *gjkr.PhaseReport) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.summary.Phases = append(e.summary.Phases, report)
+
+	logger.Debugf(
+		"[member:%v] completed phase [%v] in [%v] blocks; "+
+			"received [%v] messages; "+
+			"marked members [%v] as inactive and [%v] as disqualified",
+		report.MemberIndex,
+		report.Phase,
+		report.Blocks(),
+		report.ReceivedMessages,
+		report.InactiveMembers,
+		report.DisqualifiedMembers,
+	)
+}
+
+// keyGenerationFailed completes the execution which failed before
+// the result publication.
+func (e *execution) keyGenerationFailed(err error) {
+	e.mutex.Lock()
+	failedPhase := uint32(1)
+	if len(e.summary.Phases) > 0 {
+		failedPhase = e.summary.Phases[len(e.summary.Phases)-1].Phase + 1
+	}
+	e.mutex.Unlock()
+
+	e.complete(false, true, failedPhase, err)
+}
+
+// resultPublicationCompleted completes the execution once the result
+// publication is over. If the error is not nil, the member has been excluded
+// from the group.
+func (e *execution) resultPublicationCompleted(resultPublished bool, err error) {
+	if err != nil {
+		e.complete(resultPublished, true, resultPublicationPhase, err)
+		return
+	}
+
+	e.complete(resultPublished, false, 0, nil)
+}
+
+func (e *execution) complete(
+	resultPublished bool,
+	excluded bool,
+	failedPhase uint32,
+	err error,
+) {
+	e.mutex.Lock()
+	e.summary.ResultPublished = resultPublished
+	e.summary.Excluded = excluded
+	e.summary.FailedPhase = failedPhase
+	if err != nil {
+		e.summary.Reason = err.Error()
+	}
+	summary := e.summary
+	e.mutex.Unlock()
+
+	e.telemetry.record(summary)
+
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		logger.Warningf(
+			"[member:%v] could not serialize DKG summary: [%v]",
+			summary.MemberIndex,
+			err,
+		)
+		return
+	}
+
+	logger.Infof("[member:%v] DKG summary: %s", summary.MemberIndex, summaryJSON)
+}
@@ -0,0 +1,133 @@
+package dkg
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+)
+
+func TestTelemetry(t *testing.T) {
+	var tests = map[string]struct {
+		completedPhases []uint32
+		complete        func(execution *execution)
+		expectedSummary *Summary
+		expectedStats   TelemetryStats
+	}{
+		"key generation failed": {
+			completedPhases: []uint32{1, 2, 3},
+			complete: func(execution *execution) {
+				execution.keyGenerationFailed(fmt.Errorf("too many disqualified"))
+			},
+			expectedSummary: &Summary{
+				MemberIndex:     1,
+				ResultPublished: false,
+				Excluded:        true,
+				FailedPhase:     4,
+				Reason:          "too many disqualified",
+			},
+			expectedStats: TelemetryStats{
+				Executions:       1,
+				ResultsPublished: 0,
+				MembersExcluded:  1,
+			},
+		},
+		"key generation failed in the first phase": {
+			complete: func(execution *execution) {
+				execution.keyGenerationFailed(fmt.Errorf("could not initiate"))
+			},
+			expectedSummary: &Summary{
+				MemberIndex:     1,
+				ResultPublished: false,
+				Excluded:        true,
+				FailedPhase:     1,
+				Reason:          "could not initiate",
+			},
+			expectedStats: TelemetryStats{
+				Executions:       1,
+				ResultsPublished: 0,
+				MembersExcluded:  1,
+			},
+		},
+		"member considered as misbehaving": {
+			completedPhases: []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+			complete: func(execution *execution) {
+				execution.resultPublicationCompleted(
+					true,
+					fmt.Errorf("member is considered as misbehaving"),
+				)
+			},
+			expectedSummary: &Summary{
+				MemberIndex:     1,
+				ResultPublished: true,
+				Excluded:        true,
+				FailedPhase:     13,
+				Reason:          "member is considered as misbehaving",
+			},
+			expectedStats: TelemetryStats{
+				Executions:       1,
+				ResultsPublished: 1,
+				MembersExcluded:  1,
+			},
+		},
+		"result published": {
+			completedPhases: []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+			complete: func(execution *execution) {
+				execution.resultPublicationCompleted(true, nil)
+			},
+			expectedSummary: &Summary{
+				MemberIndex:     1,
+				ResultPublished: true,
+				Excluded:        false,
+			},
+			expectedStats: TelemetryStats{
+				Executions:       1,
+				ResultsPublished: 1,
+				MembersExcluded:  0,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			telemetry := NewTelemetry()
+
+			if telemetry.Stats().LastSummary != nil {
+				t.Fatal("there should be no summary before any execution")
+			}
+
+			execution := telemetry.startExecution(1)
+
+			test.expectedSummary.Phases = make([]*gjkr.PhaseReport, 0)
+			for _, phase := range test.completedPhases {
+				report := &gjkr.PhaseReport{MemberIndex: 1, Phase: phase}
+				execution.phaseCompleted(report)
+				test.expectedSummary.Phases = append(
+					test.expectedSummary.Phases,
+					report,
+				)
+			}
+
+			test.complete(execution)
+
+			stats := telemetry.Stats()
+			if !reflect.DeepEqual(test.expectedSummary, stats.LastSummary) {
+				t.Errorf(
+					"unexpected summary\nexpected: %+v\nactual:   %+v\n",
+					test.expectedSummary,
+					stats.LastSummary,
+				)
+			}
+
+			stats.LastSummary = nil
+			if !reflect.DeepEqual(test.expectedStats, stats) {
+				t.Errorf(
+					"unexpected stats\nexpected: %+v\nactual:   %+v\n",
+					test.expectedStats,
+					stats,
+				)
+			}
+		})
+	}
+}
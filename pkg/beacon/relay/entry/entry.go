@@ -3,6 +3,7 @@ package entry
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
@@ -26,20 +27,36 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &SignatureShareMessage{}
 	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &SignatureSharesMessage{}
+	})
 }
 
 // SignAndSubmit triggers the threshold signature process for the
 // previous relay entry and publishes the signature to the chain as
 // a new relay entry.
+//
+// All the provided signers have to be members of the same group controlled
+// by this node. Signature shares of all of them are calculated locally and
+// broadcast in a single message. Shares received from other members are
+// verified once per session and at most one relay entry is submitted, on
+// behalf of the signer eligible to submit as the first one. Received shares
+// are taken into account only if their sender has been selected to the group
+// at the position of the member that produced the share.
 func SignAndSubmit(
 	blockCounter chain.BlockCounter,
 	channel net.BroadcastChannel,
 	relayChain relayChain.Interface,
+	membershipValidator group.MembershipValidator,
 	previousEntryBytes []byte,
 	honestThreshold int,
-	signer *dkg.ThresholdSigner,
+	signers []*dkg.ThresholdSigner,
 	startBlockHeight uint64,
 ) error {
+	if len(signers) == 0 {
+		return fmt.Errorf("no signers provided")
+	}
+
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
 
@@ -66,19 +83,32 @@ func SignAndSubmit(
 		return err
 	}
 
-	selfShare := signer.CalculateSignatureShare(previousEntry)
+	// The signer with the lowest member index is the first one eligible
+	// to submit the relay entry so it submits on behalf of all the signers.
+	submittingSigner := signers[0]
+	for _, signer := range signers {
+		if signer.MemberID() < submittingSigner.MemberID() {
+			submittingSigner = signer
+		}
+	}
 
-	go broadcastShare(ctx, signer.MemberID(), selfShare, channel)
+	membersLog := membersLogPrefix(signers)
+
+	receivedValidShares := make(map[group.MemberIndex]*bn256.G1)
+	selfShares := make(map[group.MemberIndex][]byte)
+	for _, signer := range signers {
+		selfShare := signer.CalculateSignatureShare(previousEntry)
+		receivedValidShares[signer.MemberID()] = selfShare
+		selfShares[signer.MemberID()] = selfShare.Marshal()
+	}
+
+	go broadcastShares(ctx, membersLog, selfShares, channel)
 
 	receiveChannel := make(chan net.Message, 64)
 	channel.Recv(ctx, func(netMessage net.Message) {
 		receiveChannel <- netMessage
 	})
 
-	receivedValidShares := map[group.MemberIndex]*bn256.G1{
-		signer.MemberID(): selfShare,
-	}
-
 	// Run the message loop until the number of received and valid signature
 	// shares is equal to the honest threshold. Message loop will be also
 	// terminated if an other member submits the result or the relay entry
@@ -86,39 +116,67 @@ func SignAndSubmit(
 	for len(receivedValidShares) < honestThreshold {
 		select {
 		case netMessage := <-receiveChannel:
-			message, ok := netMessage.Payload().(*SignatureShareMessage)
-			if !ok || group.IsMessageFromSelf(signer.MemberID(), message) {
-				continue
+			// Collect shares from all the messages received so far to verify
+			// them in a single batch. All the candidate shares of a member
+			// are kept so that an invalid share can not displace a valid one
+			// received in the same batch.
+			pendingShares := make(map[group.MemberIndex][][]byte)
+			collectShares := func(netMessage net.Message) {
+				shares := extractShares(netMessage, membershipValidator)
+				for senderID, shareBytes := range shares {
+					// Shares of our own signers and shares already accepted
+					// do not need to be verified again.
+					if _, ok := receivedValidShares[senderID]; ok {
+						continue
+					}
+					pendingShares[senderID] = append(
+						pendingShares[senderID],
+						shareBytes,
+					)
+				}
 			}
 
-			share, err := extractAndValidateShare(
-				message,
-				signer.GroupPublicKeyShares(),
+			collectShares(netMessage)
+		drain:
+			for {
+				select {
+				case netMessage := <-receiveChannel:
+					collectShares(netMessage)
+				default:
+					break drain
+				}
+			}
+
+			validShares, rejectedShares := validateShares(
+				pendingShares,
+				submittingSigner.GroupPublicKeyShares(),
 				previousEntry,
 			)
-			if err != nil {
+
+			for senderID, err := range rejectedShares {
 				logger.Warningf(
-					"[member:%v] rejecting signature share from "+
+					"[%v] rejecting signature share from "+
 						"member [%v]: [%v]",
-					signer.MemberID(),
-					message.senderID,
+					membersLog,
+					senderID,
 					err,
 				)
-				continue
 			}
 
-			logger.Debugf(
-				"[member:%v] accepting signature share from member [%v]",
-				signer.MemberID(),
-				message.senderID,
-			)
+			for senderID, share := range validShares {
+				logger.Debugf(
+					"[%v] accepting signature share from member [%v]",
+					membersLog,
+					senderID,
+				)
 
-			receivedValidShares[message.senderID] = share
+				receivedValidShares[senderID] = share
+			}
 		case blockNumber := <-relayEntrySubmittedChannel:
 			logger.Infof(
-				"[member:%v] leaving message loop; "+
+				"[%v] leaving message loop; "+
 					"relay entry submitted by other member at block [%v]",
-				signer.MemberID(),
+				membersLog,
 				blockNumber,
 			)
 			return nil
@@ -131,7 +189,11 @@ func SignAndSubmit(
 		}
 	}
 
-	signature, err := completeSignature(signer, receivedValidShares, honestThreshold)
+	signature, err := completeSignature(
+		submittingSigner,
+		receivedValidShares,
+		honestThreshold,
+	)
 	if err != nil {
 		return err
 	}
@@ -139,7 +201,7 @@ func SignAndSubmit(
 	submitter := &relayEntrySubmitter{
 		chain:        relayChain,
 		blockCounter: blockCounter,
-		index:        signer.MemberID(),
+		index:        submittingSigner.MemberID(),
 	}
 
 	// relayEntrySubmittedChannel and relayEntryTimeoutChannel are passed to
@@ -149,60 +211,181 @@ func SignAndSubmit(
 	// must be aware of them and break the execution if they occur.
 	return submitter.submitRelayEntry(
 		signature.Marshal(),
-		signer.GroupPublicKeyBytes(),
+		submittingSigner.GroupPublicKeyBytes(),
 		startBlockHeight,
 		relayEntrySubmittedChannel,
 		relayEntryTimeoutChannel,
 	)
 }
 
-func broadcastShare(
+// membersLogPrefix returns a log prefix listing indexes of all the provided
+// signers, in ascending order.
+func membersLogPrefix(signers []*dkg.ThresholdSigner) string {
+	memberIndexes := make([]int, len(signers))
+	for i, signer := range signers {
+		memberIndexes[i] = int(signer.MemberID())
+	}
+	sort.Ints(memberIndexes)
+
+	if len(memberIndexes) == 1 {
+		return fmt.Sprintf("member:%v", memberIndexes[0])
+	}
+
+	return fmt.Sprintf("members:%v", memberIndexes)
+}
+
+// broadcastShares sends the provided signature shares of local signers to
+// the group. Each share is sent in a single-share message, which is the only
+// one understood by peers running previous client versions. If there is more
+// than one share, all of them are sent in a multi-share message as well, so
+// that upgraded peers can take them at once. Upgraded peers skip shares they
+// have already received.
+func broadcastShares(
 	ctx context.Context,
-	memberID group.MemberIndex,
-	share *bn256.G1,
+	membersLog string,
+	shares map[group.MemberIndex][]byte,
 	channel net.BroadcastChannel,
 ) {
-	message := &SignatureShareMessage{
-		memberID,
-		share.Marshal(),
+	if len(shares) > 1 {
+		if err := channel.Send(ctx, &SignatureSharesMessage{shares}); err != nil {
+			logger.Errorf(
+				"[%v] could not send signature shares: [%v]",
+				membersLog,
+				err,
+			)
+		}
 	}
 
-	if err := channel.Send(ctx, message); err != nil {
-		logger.Errorf(
-			"[member:%v] could not send signature share: [%v]",
-			memberID,
-			err,
-		)
+	for senderID, shareBytes := range shares {
+		message := &SignatureShareMessage{senderID, shareBytes}
+
+		if err := channel.Send(ctx, message); err != nil {
+			logger.Errorf(
+				"[%v] could not send signature share of member [%v]: [%v]",
+				membersLog,
+				senderID,
+				err,
+			)
+		}
 	}
 }
 
-func extractAndValidateShare(
-	message *SignatureShareMessage,
+// extractShares returns signature shares carried by the given network
+// message, mapped by the index of the member that produced the share.
+// Both single-share messages and multi-share messages are supported.
+// Shares of members whose position in the group is not held by the network
+// sender of the message are dropped.
+func extractShares(
+	netMessage net.Message,
+	membershipValidator group.MembershipValidator,
+) map[group.MemberIndex][]byte {
+	var shares map[group.MemberIndex][]byte
+	switch message := netMessage.Payload().(type) {
+	case *SignatureSharesMessage:
+		shares = message.shares
+	case *SignatureShareMessage:
+		shares = map[group.MemberIndex][]byte{
+			message.senderID: message.shareBytes,
+		}
+	default:
+		return nil
+	}
+
+	ownedShares := make(map[group.MemberIndex][]byte, len(shares))
+	for senderID, shareBytes := range shares {
+		if !membershipValidator.IsValidMembership(
+			senderID,
+			netMessage.SenderPublicKey(),
+		) {
+			logger.Warningf(
+				"dropping signature share of member [%v]; "+
+					"sender is not the member",
+				senderID,
+			)
+			continue
+		}
+
+		ownedShares[senderID] = shareBytes
+	}
+
+	return ownedShares
+}
+
+// validateShares verifies the provided candidate signature shares against
+// public key shares of their senders. All the candidates are verified in
+// a single batch; only if the batch verification fails, candidates are
+// verified one by one to find the invalid ones. A share of a member is
+// accepted if any of its candidates is valid. The function returns valid
+// shares and errors for members none of whose candidates has been accepted,
+// both mapped by the sender index.
+func validateShares(
+	shares map[group.MemberIndex][][]byte,
 	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
 	previousEntry *bn256.G1,
-) (*bn256.G1, error) {
-	share := new(bn256.G1)
-	_, err := share.Unmarshal(message.shareBytes)
+) (map[group.MemberIndex]*bn256.G1, map[group.MemberIndex]error) {
+	validShares := make(map[group.MemberIndex]*bn256.G1)
+	rejectedShares := make(map[group.MemberIndex]error)
+
+	senderIDs := make([]group.MemberIndex, 0, len(shares))
+	publicKeyShares := make([]*bn256.G2, 0, len(shares))
+	signatureShares := make([]*bn256.G1, 0, len(shares))
+
+	for senderID, candidates := range shares {
+		publicKeyShare, ok := groupPublicKeyShares[senderID]
+		if !ok {
+			rejectedShares[senderID] = fmt.Errorf(
+				"could not validate signature share; " +
+					"group public key share for sender not found",
+			)
+			continue
+		}
+
+		for _, shareBytes := range candidates {
+			share := new(bn256.G1)
+			_, err := share.Unmarshal(shareBytes)
+			if err != nil {
+				rejectedShares[senderID] = fmt.Errorf(
+					"could not unmarshal signature share: [%v]",
+					err,
+				)
+				continue
+			}
+
+			senderIDs = append(senderIDs, senderID)
+			publicKeyShares = append(publicKeyShares, publicKeyShare)
+			signatureShares = append(signatureShares, share)
+		}
+	}
+
+	batchValid, err := bls.VerifyG1Batch(
+		publicKeyShares,
+		previousEntry,
+		signatureShares,
+	)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"could not unmarshal signature share: [%v]",
+		logger.Warningf(
+			"could not verify signature shares in batch: [%v]; "+
+				"verifying shares one by one",
 			err,
 		)
 	}
 
-	publicKeyShare, ok := groupPublicKeyShares[message.senderID]
-	if !ok {
-		return nil, fmt.Errorf(
-			"could not validate signature share; " +
-				"group public key share for sender not found",
-		)
-	}
+	for i, senderID := range senderIDs {
+		if _, ok := validShares[senderID]; ok {
+			continue
+		}
+
+		if !batchValid &&
+			!bls.VerifyG1(publicKeyShares[i], previousEntry, signatureShares[i]) {
+			rejectedShares[senderID] = fmt.Errorf("invalid signature share")
+			continue
+		}
 
-	if !bls.VerifyG1(publicKeyShare, previousEntry, share) {
-		return nil, fmt.Errorf("invalid signature share")
+		validShares[senderID] = signatureShares[i]
+		delete(rejectedShares, senderID)
 	}
 
-	return share, nil
+	return validShares, rejectedShares
 }
 
 func completeSignature(
@@ -0,0 +1,187 @@
+package entry
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestValidateShares(t *testing.T) {
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(1328472189))
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	validShares := make(map[group.MemberIndex][]byte)
+	for memberIndex := group.MemberIndex(1); memberIndex <= 5; memberIndex++ {
+		secretKeyShare, publicKeyShare, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		groupPublicKeyShares[memberIndex] = publicKeyShare
+		validShares[memberIndex] = bls.SignG1(
+			secretKeyShare,
+			previousEntry,
+		).Marshal()
+	}
+
+	_, randomG1, err := bn256.RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withShares := func(
+		overrides map[group.MemberIndex][][]byte,
+	) map[group.MemberIndex][][]byte {
+		shares := make(map[group.MemberIndex][][]byte)
+		for memberIndex, share := range validShares {
+			shares[memberIndex] = [][]byte{share}
+		}
+		for memberIndex, candidates := range overrides {
+			shares[memberIndex] = candidates
+		}
+		return shares
+	}
+
+	var tests = map[string]struct {
+		shares                 map[group.MemberIndex][][]byte
+		expectedRejectedShares []group.MemberIndex
+	}{
+		"all shares valid": {
+			shares: withShares(nil),
+		},
+		"share not being a G1 point": {
+			shares:                 withShares(map[group.MemberIndex][][]byte{2: {{0, 1}}}),
+			expectedRejectedShares: []group.MemberIndex{2},
+		},
+		"invalid shares": {
+			shares: withShares(map[group.MemberIndex][][]byte{
+				1: {randomG1.Marshal()},
+				4: {validShares[5]},
+			}),
+			expectedRejectedShares: []group.MemberIndex{1, 4},
+		},
+		"share from unknown member": {
+			shares:                 withShares(map[group.MemberIndex][][]byte{6: {validShares[1]}}),
+			expectedRejectedShares: []group.MemberIndex{6},
+		},
+		"invalid candidates next to valid ones": {
+			shares: withShares(map[group.MemberIndex][][]byte{
+				2: {randomG1.Marshal(), validShares[2]},
+				3: {validShares[3], {0, 1}},
+			}),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			accepted, rejected := validateShares(
+				test.shares,
+				groupPublicKeyShares,
+				previousEntry,
+			)
+
+			if len(rejected) != len(test.expectedRejectedShares) {
+				t.Fatalf(
+					"unexpected number of rejected shares\n"+
+						"expected: [%v]\nactual:   [%v]",
+					len(test.expectedRejectedShares),
+					len(rejected),
+				)
+			}
+
+			for _, memberIndex := range test.expectedRejectedShares {
+				if _, ok := rejected[memberIndex]; !ok {
+					t.Errorf("share of member [%v] should be rejected", memberIndex)
+				}
+			}
+
+			expectedAccepted := len(test.shares) - len(test.expectedRejectedShares)
+			if len(accepted) != expectedAccepted {
+				t.Errorf(
+					"unexpected number of accepted shares\n"+
+						"expected: [%v]\nactual:   [%v]",
+					expectedAccepted,
+					len(accepted),
+				)
+			}
+		})
+	}
+}
+
+func TestExtractSharesDropsSharesOfOtherMembers(t *testing.T) {
+	senderPublicKey := []byte("member 2 and 3 key")
+	validator := &mockMembershipValidator{
+		positions: map[group.MemberIndex][]byte{
+			2: senderPublicKey,
+			3: senderPublicKey,
+		},
+	}
+
+	message := &mockNetMessage{
+		payload: &SignatureSharesMessage{
+			shares: map[group.MemberIndex][]byte{
+				1: {1},
+				2: {2},
+				3: {3},
+			},
+		},
+		senderPublicKey: senderPublicKey,
+	}
+
+	shares := extractShares(message, validator)
+
+	expectedShares := map[group.MemberIndex][]byte{2: {2}, 3: {3}}
+	if !reflect.DeepEqual(expectedShares, shares) {
+		t.Errorf(
+			"unexpected shares\nexpected: [%v]\nactual:   [%v]",
+			expectedShares,
+			shares,
+		)
+	}
+}
+
+type mockMembershipValidator struct {
+	positions map[group.MemberIndex][]byte
+}
+
+func (mmv *mockMembershipValidator) IsInGroup(
+	publicKey *ecdsa.PublicKey,
+) bool {
+	panic("not implemented")
+}
+
+func (mmv *mockMembershipValidator) IsValidMembership(
+	memberID group.MemberIndex,
+	publicKey []byte,
+) bool {
+	return bytes.Equal(mmv.positions[memberID], publicKey)
+}
+
+type mockNetMessage struct {
+	payload         interface{}
+	senderPublicKey []byte
+}
+
+func (mnm *mockNetMessage) TransportSenderID() net.TransportIdentifier {
+	panic("not implemented")
+}
+func (mnm *mockNetMessage) Payload() interface{} {
+	return mnm.payload
+}
+func (mnm *mockNetMessage) Type() string {
+	panic("not implemented")
+}
+func (mnm *mockNetMessage) SenderPublicKey() []byte {
+	return mnm.senderPublicKey
+}
+func (mnm *mockNetMessage) Seqno() uint64 {
+	panic("not implemented")
+}
@@ -2,6 +2,7 @@ package entry
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry/gen/pb"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
@@ -52,3 +53,69 @@ func (ssm *SignatureShareMessage) Unmarshal(bytes []byte) error {
 
 	return nil
 }
+
+// Type returns a string describing a SignatureSharesMessage's type.
+func (*SignatureSharesMessage) Type() string {
+	return "relay/signature/shares"
+}
+
+// Marshal converts this SignatureSharesMessage to a byte array suitable for
+// network communication.
+func (ssm *SignatureSharesMessage) Marshal() ([]byte, error) {
+	memberIndexes := make([]group.MemberIndex, 0, len(ssm.shares))
+	for memberIndex := range ssm.shares {
+		memberIndexes = append(memberIndexes, memberIndex)
+	}
+	sort.Slice(memberIndexes, func(i, j int) bool {
+		return memberIndexes[i] < memberIndexes[j]
+	})
+
+	pbSignatureShares := pb.SignatureShares{
+		Shares: make([]*pb.SignatureShare, 0, len(memberIndexes)),
+	}
+	for _, memberIndex := range memberIndexes {
+		pbSignatureShares.Shares = append(
+			pbSignatureShares.Shares,
+			&pb.SignatureShare{
+				SenderID: uint32(memberIndex),
+				Share:    ssm.shares[memberIndex],
+			},
+		)
+	}
+
+	return pbSignatureShares.Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a
+// SignatureSharesMessage.
+func (ssm *SignatureSharesMessage) Unmarshal(bytes []byte) error {
+	pbSignatureShares := pb.SignatureShares{}
+	err := pbSignatureShares.Unmarshal(bytes)
+	if err != nil {
+		return err
+	}
+
+	shares := make(map[group.MemberIndex][]byte, len(pbSignatureShares.Shares))
+	for _, pbSignatureShare := range pbSignatureShares.Shares {
+		if pbSignatureShare == nil {
+			return fmt.Errorf("nil signature share")
+		}
+
+		if err := validateMemberIndex(pbSignatureShare.SenderID); err != nil {
+			return err
+		}
+
+		memberIndex := group.MemberIndex(pbSignatureShare.SenderID)
+		if _, ok := shares[memberIndex]; ok {
+			return fmt.Errorf(
+				"duplicate signature share for member [%v]",
+				memberIndex,
+			)
+		}
+
+		shares[memberIndex] = pbSignatureShare.Share
+	}
+	ssm.shares = shares
+
+	return nil
+}
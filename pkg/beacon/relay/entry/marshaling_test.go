@@ -54,3 +54,51 @@ func TestFuzzSignatureShareMessageRoundtrip(t *testing.T) {
 func TestFuzzSignatureShareMessageUnmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&SignatureShareMessage{})
 }
+
+func TestSignatureSharesMessageRoundTrip(t *testing.T) {
+	msg := &SignatureSharesMessage{
+		map[group.MemberIndex][]byte{
+			3:  {0x01, 0x02},
+			1:  {0x03},
+			64: make([]byte, 0),
+		},
+	}
+	unmarshaled := &SignatureSharesMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(msg.shares) != len(unmarshaled.shares) {
+		t.Fatalf(
+			"unexpected number of shares\nexpected: [%v]\nactual:   [%v]",
+			len(msg.shares),
+			len(unmarshaled.shares),
+		)
+	}
+
+	for senderID, share := range msg.shares {
+		testutils.AssertBytesEqual(t, share, unmarshaled.shares[senderID])
+	}
+}
+
+func TestFuzzSignatureSharesMessageRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var shares map[group.MemberIndex][]byte
+
+		f := fuzz.New().NilChance(0.1).NumElements(0, 64)
+
+		f.Fuzz(&shares)
+
+		message := &SignatureSharesMessage{
+			shares: shares,
+		}
+
+		_ = pbutils.RoundTrip(message, &SignatureSharesMessage{})
+	}
+}
+
+func TestFuzzSignatureSharesMessageUnmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&SignatureSharesMessage{})
+}
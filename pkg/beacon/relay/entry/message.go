@@ -22,3 +22,22 @@ func NewSignatureShareMessage(
 func (ssm *SignatureShareMessage) SenderID() group.MemberIndex {
 	return ssm.senderID
 }
+
+// SignatureSharesMessage is a message payload that carries signature shares
+// of all the group members controlled by the sender. It lets an operator
+// holding multiple seats in the group publish all its shares at once.
+type SignatureSharesMessage struct {
+	shares map[group.MemberIndex][]byte
+}
+
+func NewSignatureSharesMessage(
+	shares map[group.MemberIndex][]byte,
+) *SignatureSharesMessage {
+	return &SignatureSharesMessage{shares}
+}
+
+// Shares returns signature shares carried by the message, mapped by
+// the index of the member that produced the share.
+func (ssm *SignatureSharesMessage) Shares() map[group.MemberIndex][]byte {
+	return ssm.shares
+}
@@ -74,6 +74,14 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 
 			entryErr := <-errorChannel
 
+			if relayChain.IsAlreadyDone(entryErr) {
+				logger.Infof(
+					"[member:%v] relay entry already submitted",
+					res.index,
+				)
+				return nil
+			}
+
 			if entryErr != nil {
 				isEntryInProgress, err := res.chain.IsEntryInProgress()
 				if err != nil {
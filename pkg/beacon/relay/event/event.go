@@ -28,6 +28,13 @@ type Request struct {
 	BlockNumber    uint64
 }
 
+// RelayEntryTimeoutReport represents an event of reporting that the group
+// selected to produce a relay entry did not deliver it on time.
+type RelayEntryTimeoutReport struct {
+	GroupIndex  *big.Int
+	BlockNumber uint64
+}
+
 // GroupSelectionStart represents a group selection start event.
 type GroupSelectionStart struct {
 	NewEntry    *big.Int
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"sort"
 	"sync"
 )
 
@@ -38,6 +39,15 @@ func (gst *GroupSelectionTrack) Remove(entry string) {
 	delete(gst.Data, entry)
 }
 
+// Entries returns sorted entries used as seeds for group selections that are
+// currently in progress.
+func (gst *GroupSelectionTrack) Entries() []string {
+	gst.Mutex.Lock()
+	defer gst.Mutex.Unlock()
+
+	return sortedKeys(gst.Data)
+}
+
 // RelayRequestTrack is used to track requests for new entries after RelayEntryRequested
 // event is received. It is used to ensure that the process execution
 // is not duplicated, i.e. when the client receives the same event multiple times.
@@ -71,3 +81,23 @@ func (rrt *RelayRequestTrack) Remove(previousEntry string) {
 
 	delete(rrt.Data, previousEntry)
 }
+
+// Entries returns sorted previous entries for which new relay entries are
+// currently being generated.
+func (rrt *RelayRequestTrack) Entries() []string {
+	rrt.Mutex.Lock()
+	defer rrt.Mutex.Unlock()
+
+	return sortedKeys(rrt.Data)
+}
+
+func sortedKeys(data map[string]bool) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -74,6 +75,27 @@ func TestGroupSelectionTrack_WhenEmpty(t *testing.T) {
 	}
 }
 
+func TestGroupSelectionTrackEntries(t *testing.T) {
+	gst := &GroupSelectionTrack{
+		Data:  make(map[string]bool),
+		Mutex: &sync.Mutex{},
+	}
+
+	gst.Add("0x67891")
+	gst.Add("0x12345")
+	gst.Add("0x55555")
+	gst.Remove("0x55555")
+
+	expectedEntries := []string{"0x12345", "0x67891"}
+	if !reflect.DeepEqual(expectedEntries, gst.Entries()) {
+		t.Errorf(
+			"unexpected entries\nexpected: %v\nactual:   %v",
+			expectedEntries,
+			gst.Entries(),
+		)
+	}
+}
+
 func TestRelayRequestTrack_Add(t *testing.T) {
 	previousEntry1 := "0x12345"
 	previousEntry2 := "0x67891"
@@ -142,3 +164,26 @@ func TestRelayRequestTrack_WhenEmpty(t *testing.T) {
 		t.Error("RelayEntryRequested event wasn't emitted before; should be added successfully")
 	}
 }
+
+func TestRelayRequestTrackEntries(t *testing.T) {
+	rrt := &RelayRequestTrack{
+		Data:  make(map[string]bool),
+		Mutex: &sync.Mutex{},
+	}
+
+	if len(rrt.Entries()) != 0 {
+		t.Errorf("no entries expected; has: [%v]", rrt.Entries())
+	}
+
+	rrt.Add("0x67891")
+	rrt.Add("0x12345")
+
+	expectedEntries := []string{"0x12345", "0x67891"}
+	if !reflect.DeepEqual(expectedEntries, rrt.Entries()) {
+		t.Errorf(
+			"unexpected entries\nexpected: %v\nactual:   %v",
+			expectedEntries,
+			rrt.Entries(),
+		)
+	}
+}
@@ -0,0 +1,865 @@
+package gjkr
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// CheckpointHandler is called with a marshalled protocol checkpoint each time
+// the member enters a new protocol state and after the state has been
+// initiated. The checkpoint contains member's secret values and must be
+// persisted encrypted. It can be passed to Resume to continue the protocol
+// execution after the client restarts.
+type CheckpointHandler func(checkpoint []byte) error
+
+// checkpointer implements state.Checkpointer for the GJKR state machine.
+type checkpointer struct {
+	seed    *big.Int
+	handler CheckpointHandler
+}
+
+func (c *checkpointer) Checkpoint(
+	currentState state.State,
+	lastStateEndBlockHeight uint64,
+	initiated bool,
+) error {
+	checkpoint, err := snapshotState(currentState)
+	if err != nil {
+		return fmt.Errorf("could not snapshot state: [%v]", err)
+	}
+
+	checkpoint.Initiated = initiated
+	checkpoint.LastStateEndBlockHeight = lastStateEndBlockHeight
+	checkpoint.Seed = c.seed.Bytes()
+
+	checkpointBytes, err := checkpoint.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal checkpoint: [%v]", err)
+	}
+
+	return c.handler(checkpointBytes)
+}
+
+// snapshotState captures the phase, the member and the messages received in
+// the previous phase for the given protocol state.
+func snapshotState(currentState state.State) (*pb.Checkpoint, error) {
+	checkpoint := &pb.Checkpoint{Phase: statePhase(currentState)}
+
+	var err error
+	switch s := currentState.(type) {
+	case *ephemeralKeyPairGenerationState:
+		err = snapshotEphemeralKeyPairGeneratingMember(checkpoint, s.member)
+	case *symmetricKeyGenerationState:
+		err = snapshotEphemeralKeyPairGeneratingMember(
+			checkpoint,
+			s.member.EphemeralKeyPairGeneratingMember,
+		)
+		for _, message := range s.previousPhaseMessages {
+			err = appendPhaseMessage(err, checkpoint, message)
+		}
+	case *commitmentState:
+		err = snapshotCommittingMember(checkpoint, s.member)
+	case *commitmentsVerificationState:
+		err = snapshotCommitmentsVerifyingMember(checkpoint, s.member)
+		for _, message := range s.previousPhaseSharesMessages {
+			err = appendPhaseMessage(err, checkpoint, message)
+		}
+		for _, message := range s.previousPhaseCommitmentsMessages {
+			err = appendPhaseMessage(err, checkpoint, message)
+		}
+	case *sharesJustificationState:
+		err = snapshotCommitmentsVerifyingMember(
+			checkpoint,
+			s.member.CommitmentsVerifyingMember,
+		)
+		for _, message := range s.previousPhaseAccusationsMessages {
+			err = appendPhaseMessage(err, checkpoint, message)
+		}
+	case *qualificationState:
+		err = snapshotQualifiedMember(checkpoint, s.member)
+	case *pointsShareState:
+		err = snapshotSharingMember(checkpoint, s.member)
+	case *pointsValidationState:
+		err = snapshotSharingMember(checkpoint, s.member)
+		for _, message := range s.previousPhaseMessages {
+			err = appendPhaseMessage(err, checkpoint, message)
+		}
+	case *pointsJustificationState:
+		err = snapshotSharingMember(checkpoint, s.member.SharingMember)
+		for _, message := range s.previousPhaseMessages {
+			err = appendPhaseMessage(err, checkpoint, message)
+		}
+	case *keyRevealState:
+		err = snapshotRevealingMember(checkpoint, s.member)
+	case *reconstructionState:
+		err = snapshotReconstructingMember(checkpoint, s.member)
+		for _, message := range s.previousPhaseMessages {
+			err = appendPhaseMessage(err, checkpoint, message)
+		}
+	case *combinationState:
+		err = snapshotCombiningMember(checkpoint, s.member)
+	case *finalizationState:
+		err = snapshotCombiningMember(checkpoint, s.member.CombiningMember)
+	default:
+		return nil, fmt.Errorf("unknown protocol state [%T]", currentState)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// appendPhaseMessage appends the given message to the previous phase messages
+// of the checkpoint unless an earlier error occurred.
+func appendPhaseMessage(
+	err error,
+	checkpoint *pb.Checkpoint,
+	message net.TaggedMarshaler,
+) error {
+	if err != nil {
+		return err
+	}
+
+	payload, err := message.Marshal()
+	if err != nil {
+		return err
+	}
+
+	checkpoint.PreviousPhaseMessages = append(
+		checkpoint.PreviousPhaseMessages,
+		&pb.Checkpoint_PhaseMessage{
+			Type:    message.Type(),
+			Payload: payload,
+		},
+	)
+
+	return nil
+}
+
+func snapshotLocalMember(checkpoint *pb.Checkpoint, member *LocalMember) error {
+	checkpoint.MemberID = uint32(member.ID)
+	checkpoint.GroupSize = uint32(member.group.GroupSize())
+	checkpoint.DishonestThreshold = uint32(member.group.DishonestThreshold())
+	checkpoint.InactiveMemberIDs = marshalMemberIndexes(
+		member.group.InactiveMemberIDs(),
+	)
+	checkpoint.DisqualifiedMemberIDs = marshalMemberIndexes(
+		member.group.DisqualifiedMemberIDs(),
+	)
+
+	evidence, err := snapshotEvidence(member)
+	if err != nil {
+		return err
+	}
+	checkpoint.EvidenceEphemeralPublicKeyMessages =
+		evidence.EphemeralPublicKeyMessages
+	checkpoint.EvidencePeerSharesMessages = evidence.PeerSharesMessages
+	checkpoint.EvidenceSecretSharesAccusationsMessages =
+		evidence.SecretSharesAccusationsMessages
+	checkpoint.EvidencePointsAccusationsMessages =
+		evidence.PointsAccusationsMessages
+
+	return nil
+}
+
+func snapshotEphemeralKeyPairGeneratingMember(
+	checkpoint *pb.Checkpoint,
+	member *EphemeralKeyPairGeneratingMember,
+) error {
+	if err := snapshotLocalMember(checkpoint, member.LocalMember); err != nil {
+		return err
+	}
+
+	privateKeys := make(
+		map[group.MemberIndex]*ephemeral.PrivateKey,
+		len(member.ephemeralKeyPairs),
+	)
+	for memberID, keyPair := range member.ephemeralKeyPairs {
+		privateKeys[memberID] = keyPair.PrivateKey
+	}
+
+	ephemeralPrivateKeys, err := marshalPrivateKeyMap(privateKeys)
+	if err != nil {
+		return err
+	}
+	checkpoint.EphemeralPrivateKeys = ephemeralPrivateKeys
+
+	return nil
+}
+
+func snapshotCommittingMember(
+	checkpoint *pb.Checkpoint,
+	member *CommittingMember,
+) error {
+	if err := snapshotEphemeralKeyPairGeneratingMember(
+		checkpoint,
+		member.EphemeralKeyPairGeneratingMember,
+	); err != nil {
+		return err
+	}
+
+	for _, coefficient := range member.secretCoefficients {
+		checkpoint.SecretCoefficients = append(
+			checkpoint.SecretCoefficients,
+			coefficient.Bytes(),
+		)
+	}
+	checkpoint.SelfSecretShareS = marshalBigInt(member.selfSecretShareS)
+	checkpoint.SelfSecretShareT = marshalBigInt(member.selfSecretShareT)
+
+	return nil
+}
+
+func snapshotCommitmentsVerifyingMember(
+	checkpoint *pb.Checkpoint,
+	member *CommitmentsVerifyingMember,
+) error {
+	if err := snapshotCommittingMember(
+		checkpoint,
+		member.CommittingMember,
+	); err != nil {
+		return err
+	}
+
+	checkpoint.ReceivedQualifiedSharesS = marshalBigIntMap(
+		member.receivedQualifiedSharesS,
+	)
+	checkpoint.ReceivedQualifiedSharesT = marshalBigIntMap(
+		member.receivedQualifiedSharesT,
+	)
+
+	checkpoint.ReceivedPeerCommitments = make(
+		map[uint32]*pb.Checkpoint_Points,
+		len(member.receivedPeerCommitments),
+	)
+	for memberID, commitments := range member.receivedPeerCommitments {
+		points := &pb.Checkpoint_Points{}
+		for _, commitment := range commitments {
+			points.Points = append(points.Points, commitment.Marshal())
+		}
+		checkpoint.ReceivedPeerCommitments[uint32(memberID)] = points
+	}
+
+	return nil
+}
+
+func snapshotQualifiedMember(
+	checkpoint *pb.Checkpoint,
+	member *QualifiedMember,
+) error {
+	if err := snapshotCommitmentsVerifyingMember(
+		checkpoint,
+		member.CommitmentsVerifyingMember,
+	); err != nil {
+		return err
+	}
+
+	checkpoint.GroupPrivateKeyShare = marshalBigInt(member.groupPrivateKeyShare)
+
+	return nil
+}
+
+func snapshotSharingMember(
+	checkpoint *pb.Checkpoint,
+	member *SharingMember,
+) error {
+	if err := snapshotQualifiedMember(
+		checkpoint,
+		member.QualifiedMember,
+	); err != nil {
+		return err
+	}
+
+	checkpoint.PublicKeySharePoints = marshalG2Points(member.publicKeySharePoints)
+
+	checkpoint.ReceivedValidPeerPublicKeySharePoints = make(
+		map[uint32]*pb.Checkpoint_Points,
+		len(member.receivedValidPeerPublicKeySharePoints),
+	)
+	for memberID, sharePoints := range member.receivedValidPeerPublicKeySharePoints {
+		checkpoint.ReceivedValidPeerPublicKeySharePoints[uint32(memberID)] =
+			&pb.Checkpoint_Points{Points: marshalG2Points(sharePoints)}
+	}
+
+	return nil
+}
+
+func snapshotRevealingMember(
+	checkpoint *pb.Checkpoint,
+	member *RevealingMember,
+) error {
+	if err := snapshotSharingMember(
+		checkpoint,
+		member.SharingMember,
+	); err != nil {
+		return err
+	}
+
+	checkpoint.ExpectedMembersForReconstruction = marshalMemberIndexes(
+		member.expectedMembersForReconstruction,
+	)
+
+	return nil
+}
+
+func snapshotReconstructingMember(
+	checkpoint *pb.Checkpoint,
+	member *ReconstructingMember,
+) error {
+	if err := snapshotRevealingMember(
+		checkpoint,
+		member.RevealingMember,
+	); err != nil {
+		return err
+	}
+
+	for _, shares := range member.revealedMisbehavedMembersShares {
+		checkpoint.RevealedMisbehavedMembersShares = append(
+			checkpoint.RevealedMisbehavedMembersShares,
+			&pb.Checkpoint_MisbehavedShares{
+				MisbehavedMemberID: uint32(shares.misbehavedMemberID),
+				PeerSharesS:        marshalBigIntMap(shares.peerSharesS),
+			},
+		)
+	}
+
+	checkpoint.ReconstructedIndividualPrivateKeys = marshalBigIntMap(
+		member.reconstructedIndividualPrivateKeys,
+	)
+
+	checkpoint.ReconstructedIndividualPublicKeys = make(
+		map[uint32][]byte,
+		len(member.reconstructedIndividualPublicKeys),
+	)
+	for memberID, publicKey := range member.reconstructedIndividualPublicKeys {
+		checkpoint.ReconstructedIndividualPublicKeys[uint32(memberID)] =
+			publicKey.Marshal()
+	}
+
+	return nil
+}
+
+func snapshotCombiningMember(
+	checkpoint *pb.Checkpoint,
+	member *CombiningMember,
+) error {
+	if err := snapshotReconstructingMember(
+		checkpoint,
+		member.ReconstructingMember,
+	); err != nil {
+		return err
+	}
+
+	if member.groupPublicKey != nil {
+		checkpoint.GroupPublicKey = member.groupPublicKey.Marshal()
+	}
+
+	return nil
+}
+
+// restoredCheckpoint is a protocol state restored from a checkpoint along with
+// the information where the state machine should resume the execution.
+type restoredCheckpoint struct {
+	seed                    *big.Int
+	member                  *LocalMember
+	state                   keyGenerationState
+	lastStateEndBlockHeight uint64
+	initiated               bool
+}
+
+// restoreCheckpoint rebuilds the protocol state captured in the given
+// marshalled checkpoint.
+func restoreCheckpoint(
+	checkpointBytes []byte,
+	channel net.BroadcastChannel,
+	membershipValidator group.MembershipValidator,
+) (*restoredCheckpoint, error) {
+	checkpoint := &pb.Checkpoint{}
+	if err := checkpoint.Unmarshal(checkpointBytes); err != nil {
+		return nil, fmt.Errorf("could not unmarshal checkpoint: [%v]", err)
+	}
+
+	member, err := restoreMember(checkpoint, membershipValidator)
+	if err != nil {
+		return nil, fmt.Errorf("could not restore member: [%v]", err)
+	}
+
+	messages, err := unmarshalPhaseMessages(checkpoint.PreviousPhaseMessages)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not restore previous phase messages: [%v]",
+			err,
+		)
+	}
+
+	var restoredState keyGenerationState
+	switch checkpoint.Phase {
+	case 1:
+		restoredState = &ephemeralKeyPairGenerationState{
+			channel: channel,
+			member:  member.EphemeralKeyPairGeneratingMember,
+		}
+	case 2:
+		previousPhaseMessages := make([]*EphemeralPublicKeyMessage, 0)
+		for _, message := range messages {
+			if m, ok := message.(*EphemeralPublicKeyMessage); ok {
+				previousPhaseMessages = append(previousPhaseMessages, m)
+			}
+		}
+		restoredState = &symmetricKeyGenerationState{
+			channel:               channel,
+			member:                member.SymmetricKeyGeneratingMember,
+			previousPhaseMessages: previousPhaseMessages,
+		}
+	case 3:
+		restoredState = &commitmentState{
+			channel: channel,
+			member:  member.CommittingMember,
+		}
+	case 4:
+		sharesMessages := make([]*PeerSharesMessage, 0)
+		commitmentsMessages := make([]*MemberCommitmentsMessage, 0)
+		for _, message := range messages {
+			switch m := message.(type) {
+			case *PeerSharesMessage:
+				sharesMessages = append(sharesMessages, m)
+			case *MemberCommitmentsMessage:
+				commitmentsMessages = append(commitmentsMessages, m)
+			}
+		}
+		restoredState = &commitmentsVerificationState{
+			channel:                          channel,
+			member:                           member.CommitmentsVerifyingMember,
+			previousPhaseSharesMessages:      sharesMessages,
+			previousPhaseCommitmentsMessages: commitmentsMessages,
+		}
+	case 5:
+		accusationsMessages := make([]*SecretSharesAccusationsMessage, 0)
+		for _, message := range messages {
+			if m, ok := message.(*SecretSharesAccusationsMessage); ok {
+				accusationsMessages = append(accusationsMessages, m)
+			}
+		}
+		restoredState = &sharesJustificationState{
+			channel:                          channel,
+			member:                           member.SharesJustifyingMember,
+			previousPhaseAccusationsMessages: accusationsMessages,
+		}
+	case 6:
+		restoredState = &qualificationState{
+			channel: channel,
+			member:  member.QualifiedMember,
+		}
+	case 7:
+		restoredState = &pointsShareState{
+			channel: channel,
+			member:  member.SharingMember,
+		}
+	case 8:
+		previousPhaseMessages := make([]*MemberPublicKeySharePointsMessage, 0)
+		for _, message := range messages {
+			if m, ok := message.(*MemberPublicKeySharePointsMessage); ok {
+				previousPhaseMessages = append(previousPhaseMessages, m)
+			}
+		}
+		restoredState = &pointsValidationState{
+			channel:               channel,
+			member:                member.SharingMember,
+			previousPhaseMessages: previousPhaseMessages,
+		}
+	case 9:
+		previousPhaseMessages := make([]*PointsAccusationsMessage, 0)
+		for _, message := range messages {
+			if m, ok := message.(*PointsAccusationsMessage); ok {
+				previousPhaseMessages = append(previousPhaseMessages, m)
+			}
+		}
+		restoredState = &pointsJustificationState{
+			channel:               channel,
+			member:                member.PointsJustifyingMember,
+			previousPhaseMessages: previousPhaseMessages,
+		}
+	case 10:
+		restoredState = &keyRevealState{
+			channel: channel,
+			member:  member.RevealingMember,
+		}
+	case 11:
+		previousPhaseMessages := make([]*MisbehavedEphemeralKeysMessage, 0)
+		for _, message := range messages {
+			if m, ok := message.(*MisbehavedEphemeralKeysMessage); ok {
+				previousPhaseMessages = append(previousPhaseMessages, m)
+			}
+		}
+		restoredState = &reconstructionState{
+			channel:               channel,
+			member:                member.ReconstructingMember,
+			previousPhaseMessages: previousPhaseMessages,
+		}
+	case 12:
+		restoredState = &combinationState{
+			channel: channel,
+			member:  member,
+		}
+	case 13:
+		restoredState = &finalizationState{
+			channel: channel,
+			member:  member.InitializeFinalization(),
+		}
+	default:
+		return nil, fmt.Errorf("unknown protocol phase [%v]", checkpoint.Phase)
+	}
+
+	return &restoredCheckpoint{
+		seed:                    new(big.Int).SetBytes(checkpoint.Seed),
+		member:                  member.LocalMember,
+		state:                   restoredState,
+		lastStateEndBlockHeight: checkpoint.LastStateEndBlockHeight,
+		initiated:               checkpoint.Initiated,
+	}, nil
+}
+
+// restoreMember rebuilds the whole chain of protocol members from the given
+// checkpoint. Values not yet computed at the checkpointed phase are left empty,
+// exactly as they would be in a member which reached the given phase.
+func restoreMember(
+	checkpoint *pb.Checkpoint,
+	membershipValidator group.MembershipValidator,
+) (*CombiningMember, error) {
+	if err := validateMemberIndex(checkpoint.MemberID); err != nil {
+		return nil, err
+	}
+
+	localMember, err := NewMember(
+		group.MemberIndex(checkpoint.MemberID),
+		int(checkpoint.GroupSize),
+		int(checkpoint.DishonestThreshold),
+		membershipValidator,
+		new(big.Int).SetBytes(checkpoint.Seed),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inactiveMemberIDs, err := unmarshalMemberIndexes(checkpoint.InactiveMemberIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, memberID := range inactiveMemberIDs {
+		localMember.group.MarkMemberAsInactive(memberID)
+	}
+
+	disqualifiedMemberIDs, err := unmarshalMemberIndexes(
+		checkpoint.DisqualifiedMemberIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, memberID := range disqualifiedMemberIDs {
+		localMember.group.MarkMemberAsDisqualified(memberID)
+	}
+
+	evidence := &pb.Evidence{
+		EphemeralPublicKeyMessages:      checkpoint.EvidenceEphemeralPublicKeyMessages,
+		PeerSharesMessages:              checkpoint.EvidencePeerSharesMessages,
+		SecretSharesAccusationsMessages: checkpoint.EvidenceSecretSharesAccusationsMessages,
+		PointsAccusationsMessages:       checkpoint.EvidencePointsAccusationsMessages,
+	}
+	if err := restoreEvidence(localMember.evidenceLog, evidence); err != nil {
+		return nil, err
+	}
+
+	ephemeralKeyPairGeneratingMember := localMember.InitializeEphemeralKeysGeneration()
+
+	privateKeys, err := unmarshalPrivateKeyMap(checkpoint.EphemeralPrivateKeys)
+	if err != nil {
+		return nil, err
+	}
+	for memberID, privateKey := range privateKeys {
+		ephemeralKeyPairGeneratingMember.ephemeralKeyPairs[memberID] =
+			&ephemeral.KeyPair{
+				PrivateKey: privateKey,
+				PublicKey:  (*ephemeral.PublicKey)(&privateKey.PublicKey),
+			}
+	}
+
+	symmetricKeyGeneratingMember := ephemeralKeyPairGeneratingMember.
+		InitializeSymmetricKeyGeneration()
+
+	// Symmetric keys are not a part of the checkpoint. They are established
+	// again from the ephemeral public keys stored in the evidence log, the same
+	// way they were established in phase 2.
+	for memberID, keyPair := range ephemeralKeyPairGeneratingMember.ephemeralKeyPairs {
+		message := localMember.evidenceLog.ephemeralPublicKeyMessage(memberID)
+		if message == nil {
+			continue
+		}
+
+		publicKey, ok := message.ephemeralPublicKeys[localMember.ID]
+		if !ok {
+			return nil, fmt.Errorf(
+				"no ephemeral public key from member [%v] in evidence log",
+				memberID,
+			)
+		}
+
+		symmetricKeyGeneratingMember.symmetricKeys[memberID] =
+			keyPair.PrivateKey.Ecdh(publicKey)
+	}
+
+	committingMember := symmetricKeyGeneratingMember.InitializeCommitting()
+	for _, coefficientBytes := range checkpoint.SecretCoefficients {
+		committingMember.secretCoefficients = append(
+			committingMember.secretCoefficients,
+			new(big.Int).SetBytes(coefficientBytes),
+		)
+	}
+	committingMember.selfSecretShareS = unmarshalBigInt(checkpoint.SelfSecretShareS)
+	committingMember.selfSecretShareT = unmarshalBigInt(checkpoint.SelfSecretShareT)
+
+	commitmentsVerifyingMember := committingMember.InitializeCommitmentsVerification()
+
+	if commitmentsVerifyingMember.receivedQualifiedSharesS, err =
+		unmarshalBigIntMap(checkpoint.ReceivedQualifiedSharesS); err != nil {
+		return nil, err
+	}
+	if commitmentsVerifyingMember.receivedQualifiedSharesT, err =
+		unmarshalBigIntMap(checkpoint.ReceivedQualifiedSharesT); err != nil {
+		return nil, err
+	}
+	for memberID, points := range checkpoint.ReceivedPeerCommitments {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+
+		commitments := make([]*bn256.G1, 0, len(points.Points))
+		for _, pointBytes := range points.Points {
+			commitment := new(bn256.G1)
+			if _, err := commitment.Unmarshal(pointBytes); err != nil {
+				return nil, fmt.Errorf(
+					"could not unmarshal commitment: [%v]",
+					err,
+				)
+			}
+			commitments = append(commitments, commitment)
+		}
+		commitmentsVerifyingMember.receivedPeerCommitments[group.MemberIndex(memberID)] =
+			commitments
+	}
+
+	qualifiedMember := commitmentsVerifyingMember.
+		InitializeSharesJustification().
+		InitializeQualified()
+	qualifiedMember.groupPrivateKeyShare = unmarshalBigInt(
+		checkpoint.GroupPrivateKeyShare,
+	)
+
+	sharingMember := qualifiedMember.InitializeSharing()
+	if sharingMember.publicKeySharePoints, err =
+		unmarshalG2Points(checkpoint.PublicKeySharePoints); err != nil {
+		return nil, err
+	}
+	for memberID, points := range checkpoint.ReceivedValidPeerPublicKeySharePoints {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+
+		sharePoints, err := unmarshalG2Points(points.Points)
+		if err != nil {
+			return nil, err
+		}
+		sharingMember.receivedValidPeerPublicKeySharePoints[group.MemberIndex(memberID)] =
+			sharePoints
+	}
+
+	revealingMember := sharingMember.
+		InitializePointsJustification().
+		InitializeRevealing()
+	expectedMembersForReconstruction, err := unmarshalMemberIndexes(
+		checkpoint.ExpectedMembersForReconstruction,
+	)
+	if err != nil {
+		return nil, err
+	}
+	revealingMember.expectedMembersForReconstruction = append(
+		revealingMember.expectedMembersForReconstruction,
+		expectedMembersForReconstruction...,
+	)
+
+	reconstructingMember := revealingMember.InitializeReconstruction()
+	for _, shares := range checkpoint.RevealedMisbehavedMembersShares {
+		if err := validateMemberIndex(shares.MisbehavedMemberID); err != nil {
+			return nil, err
+		}
+
+		peerSharesS, err := unmarshalBigIntMap(shares.PeerSharesS)
+		if err != nil {
+			return nil, err
+		}
+
+		reconstructingMember.revealedMisbehavedMembersShares = append(
+			reconstructingMember.revealedMisbehavedMembersShares,
+			&misbehavedShares{
+				misbehavedMemberID: group.MemberIndex(shares.MisbehavedMemberID),
+				peerSharesS:        peerSharesS,
+			},
+		)
+	}
+	if reconstructingMember.reconstructedIndividualPrivateKeys, err =
+		unmarshalBigIntMap(checkpoint.ReconstructedIndividualPrivateKeys); err != nil {
+		return nil, err
+	}
+	for memberID, publicKeyBytes := range checkpoint.ReconstructedIndividualPublicKeys {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+
+		publicKey := new(bn256.G2)
+		if _, err := publicKey.Unmarshal(publicKeyBytes); err != nil {
+			return nil, fmt.Errorf(
+				"could not unmarshal reconstructed public key: [%v]",
+				err,
+			)
+		}
+		reconstructingMember.reconstructedIndividualPublicKeys[group.MemberIndex(memberID)] =
+			publicKey
+	}
+
+	combiningMember := reconstructingMember.InitializeCombining()
+	if len(checkpoint.GroupPublicKey) > 0 {
+		combiningMember.groupPublicKey = new(bn256.G2)
+		if _, err := combiningMember.groupPublicKey.Unmarshal(
+			checkpoint.GroupPublicKey,
+		); err != nil {
+			return nil, fmt.Errorf(
+				"could not unmarshal group public key: [%v]",
+				err,
+			)
+		}
+	}
+
+	return combiningMember, nil
+}
+
+func unmarshalPhaseMessages(
+	phaseMessages []*pb.Checkpoint_PhaseMessage,
+) ([]net.TaggedUnmarshaler, error) {
+	unmarshalers := map[string]func() net.TaggedUnmarshaler{}
+	for _, unmarshaler := range []func() net.TaggedUnmarshaler{
+		func() net.TaggedUnmarshaler { return &EphemeralPublicKeyMessage{} },
+		func() net.TaggedUnmarshaler { return &MemberCommitmentsMessage{} },
+		func() net.TaggedUnmarshaler { return &PeerSharesMessage{} },
+		func() net.TaggedUnmarshaler { return &SecretSharesAccusationsMessage{} },
+		func() net.TaggedUnmarshaler { return &MemberPublicKeySharePointsMessage{} },
+		func() net.TaggedUnmarshaler { return &PointsAccusationsMessage{} },
+		func() net.TaggedUnmarshaler { return &MisbehavedEphemeralKeysMessage{} },
+	} {
+		unmarshalers[unmarshaler().Type()] = unmarshaler
+	}
+
+	messages := make([]net.TaggedUnmarshaler, 0, len(phaseMessages))
+	for _, phaseMessage := range phaseMessages {
+		unmarshaler, ok := unmarshalers[phaseMessage.Type]
+		if !ok {
+			return nil, fmt.Errorf(
+				"unknown message type [%v]",
+				phaseMessage.Type,
+			)
+		}
+
+		message := unmarshaler()
+		if err := message.Unmarshal(phaseMessage.Payload); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func marshalMemberIndexes(memberIndexes []group.MemberIndex) []uint32 {
+	marshalled := make([]uint32, 0, len(memberIndexes))
+	for _, memberIndex := range memberIndexes {
+		marshalled = append(marshalled, uint32(memberIndex))
+	}
+	return marshalled
+}
+
+func unmarshalMemberIndexes(memberIndexes []uint32) ([]group.MemberIndex, error) {
+	unmarshalled := make([]group.MemberIndex, 0, len(memberIndexes))
+	for _, memberIndex := range memberIndexes {
+		if err := validateMemberIndex(memberIndex); err != nil {
+			return nil, err
+		}
+		unmarshalled = append(unmarshalled, group.MemberIndex(memberIndex))
+	}
+	return unmarshalled, nil
+}
+
+func marshalBigInt(value *big.Int) []byte {
+	if value == nil {
+		return nil
+	}
+	return value.Bytes()
+}
+
+func unmarshalBigInt(bytes []byte) *big.Int {
+	if bytes == nil {
+		return nil
+	}
+	return new(big.Int).SetBytes(bytes)
+}
+
+func marshalBigIntMap(values map[group.MemberIndex]*big.Int) map[uint32][]byte {
+	marshalled := make(map[uint32][]byte, len(values))
+	for memberID, value := range values {
+		marshalled[uint32(memberID)] = value.Bytes()
+	}
+	return marshalled
+}
+
+func unmarshalBigIntMap(
+	values map[uint32][]byte,
+) (map[group.MemberIndex]*big.Int, error) {
+	unmarshalled := make(map[group.MemberIndex]*big.Int, len(values))
+	for memberID, valueBytes := range values {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+		unmarshalled[group.MemberIndex(memberID)] = new(big.Int).SetBytes(valueBytes)
+	}
+	return unmarshalled, nil
+}
+
+func marshalG2Points(points []*bn256.G2) [][]byte {
+	marshalled := make([][]byte, 0, len(points))
+	for _, point := range points {
+		marshalled = append(marshalled, point.Marshal())
+	}
+	return marshalled
+}
+
+func unmarshalG2Points(pointsBytes [][]byte) ([]*bn256.G2, error) {
+	if len(pointsBytes) == 0 {
+		return nil, nil
+	}
+
+	points := make([]*bn256.G2, 0, len(pointsBytes))
+	for _, pointBytes := range pointsBytes {
+		point := new(bn256.G2)
+		if _, err := point.Unmarshal(pointBytes); err != nil {
+			return nil, fmt.Errorf("could not unmarshal G2 point: [%v]", err)
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
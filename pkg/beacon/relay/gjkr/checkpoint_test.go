@@ -0,0 +1,429 @@
+package gjkr
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	dishonestThreshold := 1
+	groupSize := 3
+
+	members, err := initializeCombiningMembersGroup(dishonestThreshold, groupSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	member := members[0]
+
+	member.group.MarkMemberAsInactive(3)
+	member.expectedMembersForReconstruction = []group.MemberIndex{3}
+	member.revealedMisbehavedMembersShares = []*misbehavedShares{
+		{
+			misbehavedMemberID: 3,
+			peerSharesS: map[group.MemberIndex]*big.Int{
+				1: big.NewInt(31),
+				2: big.NewInt(32),
+			},
+		},
+	}
+	member.reconstructedIndividualPrivateKeys[3] = big.NewInt(33)
+	member.reconstructedIndividualPublicKeys[3] = new(bn256.G2).ScalarBaseMult(
+		big.NewInt(33),
+	)
+	member.CombineGroupPublicKey()
+
+	var checkpointBytes []byte
+	checkpointer := &checkpointer{
+		seed: big.NewInt(18313131145),
+		handler: func(checkpoint []byte) error {
+			checkpointBytes = checkpoint
+			return nil
+		},
+	}
+
+	err = checkpointer.Checkpoint(
+		&combinationState{member: member},
+		100,
+		false,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := restoreCheckpoint(checkpointBytes, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.lastStateEndBlockHeight != 100 {
+		t.Errorf(
+			"unexpected last state end block height\nexpected: %v\nactual:   %v\n",
+			100,
+			restored.lastStateEndBlockHeight,
+		)
+	}
+	if restored.initiated {
+		t.Errorf("restored state should not be initiated")
+	}
+	if restored.seed.Cmp(checkpointer.seed) != 0 {
+		t.Errorf(
+			"unexpected seed\nexpected: %v\nactual:   %v\n",
+			checkpointer.seed,
+			restored.seed,
+		)
+	}
+
+	restoredState, ok := restored.state.(*combinationState)
+	if !ok {
+		t.Fatalf("unexpected restored state [%T]", restored.state)
+	}
+	restoredMember := restoredState.member
+
+	if restoredMember.ID != member.ID {
+		t.Errorf(
+			"unexpected member ID\nexpected: %v\nactual:   %v\n",
+			member.ID,
+			restoredMember.ID,
+		)
+	}
+
+	if !reflect.DeepEqual(
+		member.group.InactiveMemberIDs(),
+		restoredMember.group.InactiveMemberIDs(),
+	) {
+		t.Errorf(
+			"unexpected inactive members\nexpected: %v\nactual:   %v\n",
+			member.group.InactiveMemberIDs(),
+			restoredMember.group.InactiveMemberIDs(),
+		)
+	}
+
+	assertEphemeralKeyPairs(
+		t,
+		member.ephemeralKeyPairs,
+		restoredMember.ephemeralKeyPairs,
+	)
+	assertBigInts(
+		t,
+		"secret coefficients",
+		member.secretCoefficients,
+		restoredMember.secretCoefficients,
+	)
+	assertBigInts(
+		t,
+		"self secret shares",
+		[]*big.Int{member.selfSecretShareS, member.selfSecretShareT},
+		[]*big.Int{restoredMember.selfSecretShareS, restoredMember.selfSecretShareT},
+	)
+	assertBigIntMaps(
+		t,
+		"received qualified shares S",
+		member.receivedQualifiedSharesS,
+		restoredMember.receivedQualifiedSharesS,
+	)
+	assertBigIntMaps(
+		t,
+		"received qualified shares T",
+		member.receivedQualifiedSharesT,
+		restoredMember.receivedQualifiedSharesT,
+	)
+	assertBigInts(
+		t,
+		"group private key share",
+		[]*big.Int{member.groupPrivateKeyShare},
+		[]*big.Int{restoredMember.groupPrivateKeyShare},
+	)
+	assertBigIntMaps(
+		t,
+		"revealed misbehaved member shares",
+		member.revealedMisbehavedMembersShares[0].peerSharesS,
+		restoredMember.revealedMisbehavedMembersShares[0].peerSharesS,
+	)
+	assertBigIntMaps(
+		t,
+		"reconstructed individual private keys",
+		member.reconstructedIndividualPrivateKeys,
+		restoredMember.reconstructedIndividualPrivateKeys,
+	)
+
+	if fmt.Sprint(member.receivedPeerCommitments) !=
+		fmt.Sprint(restoredMember.receivedPeerCommitments) {
+		t.Errorf(
+			"unexpected received peer commitments\nexpected: %v\nactual:   %v\n",
+			member.receivedPeerCommitments,
+			restoredMember.receivedPeerCommitments,
+		)
+	}
+
+	if fmt.Sprint(member.publicKeySharePoints) !=
+		fmt.Sprint(restoredMember.publicKeySharePoints) {
+		t.Errorf(
+			"unexpected public key share points\nexpected: %v\nactual:   %v\n",
+			member.publicKeySharePoints,
+			restoredMember.publicKeySharePoints,
+		)
+	}
+
+	if fmt.Sprint(member.receivedValidPeerPublicKeySharePoints) !=
+		fmt.Sprint(restoredMember.receivedValidPeerPublicKeySharePoints) {
+		t.Errorf(
+			"unexpected received peer public key share points\n"+
+				"expected: %v\nactual:   %v\n",
+			member.receivedValidPeerPublicKeySharePoints,
+			restoredMember.receivedValidPeerPublicKeySharePoints,
+		)
+	}
+
+	if !reflect.DeepEqual(
+		member.expectedMembersForReconstruction,
+		restoredMember.expectedMembersForReconstruction,
+	) {
+		t.Errorf(
+			"unexpected members expected for reconstruction\n"+
+				"expected: %v\nactual:   %v\n",
+			member.expectedMembersForReconstruction,
+			restoredMember.expectedMembersForReconstruction,
+		)
+	}
+
+	if member.groupPublicKey.String() != restoredMember.groupPublicKey.String() {
+		t.Errorf(
+			"unexpected group public key\nexpected: %v\nactual:   %v\n",
+			member.groupPublicKey,
+			restoredMember.groupPublicKey,
+		)
+	}
+}
+
+func TestCheckpointRestoresSymmetricKeys(t *testing.T) {
+	dishonestThreshold := 1
+	groupSize := 3
+
+	members := initializeEphemeralKeyPairMembersGroup(dishonestThreshold, groupSize)
+
+	var messages []*EphemeralPublicKeyMessage
+	for _, member := range members {
+		member.memberCore.group = group.NewDkgGroup(dishonestThreshold, groupSize)
+
+		message, err := member.GenerateEphemeralKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+	}
+
+	member := members[0].InitializeSymmetricKeyGeneration()
+	if err := member.GenerateSymmetricKeys(messages[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	var checkpointBytes []byte
+	checkpointer := &checkpointer{
+		seed: big.NewInt(18313131145),
+		handler: func(checkpoint []byte) error {
+			checkpointBytes = checkpoint
+			return nil
+		},
+	}
+
+	err := checkpointer.Checkpoint(
+		&symmetricKeyGenerationState{
+			member:                member,
+			previousPhaseMessages: messages[1:],
+		},
+		10,
+		true,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := restoreCheckpoint(checkpointBytes, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restoredState, ok := restored.state.(*symmetricKeyGenerationState)
+	if !ok {
+		t.Fatalf("unexpected restored state [%T]", restored.state)
+	}
+
+	if len(restoredState.previousPhaseMessages) != len(messages[1:]) {
+		t.Errorf(
+			"unexpected number of previous phase messages\n"+
+				"expected: %v\nactual:   %v\n",
+			len(messages[1:]),
+			len(restoredState.previousPhaseMessages),
+		)
+	}
+
+	plaintext := []byte("keep")
+	for memberID, symmetricKey := range member.symmetricKeys {
+		restoredSymmetricKey, ok := restoredState.member.symmetricKeys[memberID]
+		if !ok {
+			t.Fatalf("symmetric key for member [%v] not restored", memberID)
+		}
+
+		ciphertext, err := symmetricKey.Encrypt(plaintext)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decrypted, err := restoredSymmetricKey.Decrypt(ciphertext)
+		if err != nil {
+			t.Fatalf(
+				"restored symmetric key for member [%v] is invalid: [%v]",
+				memberID,
+				err,
+			)
+		}
+
+		if string(decrypted) != string(plaintext) {
+			t.Errorf(
+				"unexpected decrypted text\nexpected: %s\nactual:   %s\n",
+				plaintext,
+				decrypted,
+			)
+		}
+	}
+}
+
+func TestValidateRejoin(t *testing.T) {
+	var tests = map[string]struct {
+		state                   keyGenerationState
+		lastStateEndBlockHeight uint64
+		initiated               bool
+		currentBlockHeight      uint64
+		expectedError           bool
+	}{
+		"state not yet initiated": {
+			state:                   &commitmentsVerificationState{},
+			lastStateEndBlockHeight: 10,
+			currentBlockHeight:      10,
+		},
+		"state in progress": {
+			state:                   &commitmentsVerificationState{},
+			lastStateEndBlockHeight: 10,
+			initiated:               true,
+			currentBlockHeight:      21,
+		},
+		"state over": {
+			state:                   &commitmentsVerificationState{},
+			lastStateEndBlockHeight: 10,
+			initiated:               true,
+			currentBlockHeight:      22,
+			expectedError:           true,
+		},
+		"randomness not yet generated": {
+			state:                   &commitmentState{},
+			lastStateEndBlockHeight: 10,
+			currentBlockHeight:      11,
+		},
+		"randomness possibly generated": {
+			state:                   &commitmentState{},
+			lastStateEndBlockHeight: 10,
+			currentBlockHeight:      12,
+			expectedError:           true,
+		},
+		"randomness generated": {
+			state:                   &ephemeralKeyPairGenerationState{},
+			lastStateEndBlockHeight: 10,
+			initiated:               true,
+			currentBlockHeight:      12,
+		},
+		"final state": {
+			state:                   &finalizationState{},
+			lastStateEndBlockHeight: 10,
+			initiated:               true,
+			currentBlockHeight:      50,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := validateRejoin(
+				&restoredCheckpoint{
+					state:                   test.state,
+					lastStateEndBlockHeight: test.lastStateEndBlockHeight,
+					initiated:               test.initiated,
+				},
+				test.currentBlockHeight,
+			)
+
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: %v\nactual:         %v\n",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func assertEphemeralKeyPairs(
+	t *testing.T,
+	expected map[group.MemberIndex]*ephemeral.KeyPair,
+	actual map[group.MemberIndex]*ephemeral.KeyPair,
+) {
+	if len(expected) != len(actual) {
+		t.Fatalf(
+			"unexpected number of ephemeral key pairs\nexpected: %v\nactual:   %v\n",
+			len(expected),
+			len(actual),
+		)
+	}
+
+	for memberID, keyPair := range expected {
+		if !reflect.DeepEqual(
+			keyPair.PublicKey.Marshal(),
+			actual[memberID].PublicKey.Marshal(),
+		) {
+			t.Errorf("unexpected ephemeral public key for member [%v]", memberID)
+		}
+		if !reflect.DeepEqual(
+			keyPair.PrivateKey.Marshal(),
+			actual[memberID].PrivateKey.Marshal(),
+		) {
+			t.Errorf("unexpected ephemeral private key for member [%v]", memberID)
+		}
+	}
+}
+
+func assertBigInts(
+	t *testing.T,
+	description string,
+	expected []*big.Int,
+	actual []*big.Int,
+) {
+	if fmt.Sprint(expected) != fmt.Sprint(actual) {
+		t.Errorf(
+			"unexpected %v\nexpected: %v\nactual:   %v\n",
+			description,
+			expected,
+			actual,
+		)
+	}
+}
+
+func assertBigIntMaps(
+	t *testing.T,
+	description string,
+	expected map[group.MemberIndex]*big.Int,
+	actual map[group.MemberIndex]*big.Int,
+) {
+	if fmt.Sprint(expected) != fmt.Sprint(actual) {
+		t.Errorf(
+			"unexpected %v\nexpected: %v\nactual:   %v\n",
+			description,
+			expected,
+			actual,
+		)
+	}
+}
@@ -0,0 +1,361 @@
+package gjkr
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// EvidenceHandler is called with the marshalled evidence log of the member
+// once the protocol execution is over, no matter if the execution succeeded.
+// The evidence log contains messages broadcast by other group members which
+// are needed to prove who misbehaved during the key generation. It can be
+// decoded with DecodeEvidence.
+type EvidenceHandler func(evidence []byte) error
+
+// Evidence is the decoded evidence log of a member. All keys, encrypted shares
+// and decrypted shares are hex-encoded so that the evidence can be exported
+// for the dispute analysis.
+type Evidence struct {
+	MemberIndex             group.MemberIndex              `json:"memberIndex"`
+	EphemeralPublicKeys     []*EphemeralPublicKeysEvidence `json:"ephemeralPublicKeys"`
+	PeerShares              []*PeerSharesEvidence          `json:"peerShares"`
+	SecretSharesAccusations []*AccusationEvidence          `json:"secretSharesAccusations"`
+	PointsAccusations       []*AccusationEvidence          `json:"pointsAccusations"`
+}
+
+// EphemeralPublicKeysEvidence contains ephemeral public keys broadcast by the
+// sender in phase 1 of the protocol, one for each receiver.
+type EphemeralPublicKeysEvidence struct {
+	Sender     group.MemberIndex            `json:"sender"`
+	PublicKeys map[group.MemberIndex]string `json:"publicKeys"`
+}
+
+// PeerSharesEvidence contains encrypted shares broadcast by the sender in
+// phase 3 of the protocol, one pair of shares for each receiver.
+type PeerSharesEvidence struct {
+	Sender group.MemberIndex                      `json:"sender"`
+	Shares map[group.MemberIndex]*EncryptedShares `json:"shares"`
+}
+
+// EncryptedShares is a pair of shares encrypted with the symmetric key
+// established between the sender and the receiver.
+type EncryptedShares struct {
+	ShareS string `json:"shareS"`
+	ShareT string `json:"shareT"`
+}
+
+// AccusationEvidence describes a single accusation broadcast in phase 4 or
+// phase 8 of the protocol together with the outcome of its resolution based on
+// the evidence log.
+type AccusationEvidence struct {
+	Accuser group.MemberIndex `json:"accuser"`
+	Accused group.MemberIndex `json:"accused"`
+	// RevealedPrivateKey is the ephemeral private key the accuser generated
+	// for the accused member, revealed along with the accusation.
+	RevealedPrivateKey string `json:"revealedPrivateKey"`
+	// RevealedKeyMatching is false if the revealed private key does not match
+	// the ephemeral public key broadcast by the accuser in phase 1. In such
+	// case, the accuser misbehaved.
+	RevealedKeyMatching bool `json:"revealedKeyMatching"`
+	// ShareS and ShareT are shares the accused member sent to the accuser,
+	// decrypted with the symmetric key recovered from the revealed private
+	// key. They are empty if the shares could not be decrypted.
+	ShareS string `json:"shareS,omitempty"`
+	ShareT string `json:"shareT,omitempty"`
+	// Error explains why the accusation could not be resolved.
+	Error string `json:"error,omitempty"`
+}
+
+// handleEvidence passes the marshalled evidence log of the given member to the
+// evidence handler if the handler is set. Failures are only logged as they
+// must not affect the outcome of the protocol.
+func handleEvidence(member *LocalMember, handler EvidenceHandler) {
+	if handler == nil {
+		return
+	}
+
+	evidence, err := snapshotEvidence(member)
+	if err != nil {
+		logger.Errorf(
+			"[member:%v] could not snapshot evidence log: [%v]",
+			member.ID,
+			err,
+		)
+		return
+	}
+
+	evidenceBytes, err := evidence.Marshal()
+	if err != nil {
+		logger.Errorf(
+			"[member:%v] could not marshal evidence log: [%v]",
+			member.ID,
+			err,
+		)
+		return
+	}
+
+	if err := handler(evidenceBytes); err != nil {
+		logger.Errorf(
+			"[member:%v] could not handle evidence log: [%v]",
+			member.ID,
+			err,
+		)
+	}
+}
+
+// snapshotEvidence captures all messages stored in the evidence log of the
+// given member, ordered by the sender.
+func snapshotEvidence(member *LocalMember) (*pb.Evidence, error) {
+	evidence := &pb.Evidence{MemberID: uint32(member.ID)}
+
+	for _, memberID := range member.group.MemberIDs() {
+		if message := member.evidenceLog.ephemeralPublicKeyMessage(memberID); message != nil {
+			messageBytes, err := message.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			evidence.EphemeralPublicKeyMessages = append(
+				evidence.EphemeralPublicKeyMessages,
+				messageBytes,
+			)
+		}
+
+		if message := member.evidenceLog.peerSharesMessage(memberID); message != nil {
+			messageBytes, err := message.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			evidence.PeerSharesMessages = append(
+				evidence.PeerSharesMessages,
+				messageBytes,
+			)
+		}
+
+		if message := member.evidenceLog.secretSharesAccusationsMessage(memberID); message != nil {
+			messageBytes, err := message.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			evidence.SecretSharesAccusationsMessages = append(
+				evidence.SecretSharesAccusationsMessages,
+				messageBytes,
+			)
+		}
+
+		if message := member.evidenceLog.pointsAccusationsMessage(memberID); message != nil {
+			messageBytes, err := message.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			evidence.PointsAccusationsMessages = append(
+				evidence.PointsAccusationsMessages,
+				messageBytes,
+			)
+		}
+	}
+
+	return evidence, nil
+}
+
+// restoreEvidence puts all messages captured in the given evidence snapshot
+// to the evidence log.
+func restoreEvidence(evidenceLog evidenceLog, evidence *pb.Evidence) error {
+	for _, messageBytes := range evidence.EphemeralPublicKeyMessages {
+		message := &EphemeralPublicKeyMessage{}
+		if err := message.Unmarshal(messageBytes); err != nil {
+			return err
+		}
+		if err := evidenceLog.PutEphemeralMessage(message); err != nil {
+			return err
+		}
+	}
+
+	for _, messageBytes := range evidence.PeerSharesMessages {
+		message := &PeerSharesMessage{}
+		if err := message.Unmarshal(messageBytes); err != nil {
+			return err
+		}
+		if err := evidenceLog.PutPeerSharesMessage(message); err != nil {
+			return err
+		}
+	}
+
+	for _, messageBytes := range evidence.SecretSharesAccusationsMessages {
+		message := &SecretSharesAccusationsMessage{}
+		if err := message.Unmarshal(messageBytes); err != nil {
+			return err
+		}
+		if err := evidenceLog.PutSecretSharesAccusationsMessage(message); err != nil {
+			return err
+		}
+	}
+
+	for _, messageBytes := range evidence.PointsAccusationsMessages {
+		message := &PointsAccusationsMessage{}
+		if err := message.Unmarshal(messageBytes); err != nil {
+			return err
+		}
+		if err := evidenceLog.PutPointsAccusationsMessage(message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeEvidence decodes the evidence log passed to the evidence handler of
+// Execute or Resume. Each accusation stored in the log is resolved the same
+// way it is resolved in the protocol: the revealed private key is checked
+// against the public key broadcast by the accuser and shares sent by the
+// accused member are decrypted with the recovered symmetric key.
+func DecodeEvidence(evidenceBytes []byte) (*Evidence, error) {
+	pbEvidence := &pb.Evidence{}
+	if err := pbEvidence.Unmarshal(evidenceBytes); err != nil {
+		return nil, fmt.Errorf("could not unmarshal evidence: [%v]", err)
+	}
+
+	if err := validateMemberIndex(pbEvidence.MemberID); err != nil {
+		return nil, err
+	}
+
+	evidenceLog := newDkgEvidenceLog()
+	if err := restoreEvidence(evidenceLog, pbEvidence); err != nil {
+		return nil, fmt.Errorf("could not restore evidence log: [%v]", err)
+	}
+
+	evidence := &Evidence{
+		MemberIndex:             group.MemberIndex(pbEvidence.MemberID),
+		EphemeralPublicKeys:     make([]*EphemeralPublicKeysEvidence, 0),
+		PeerShares:              make([]*PeerSharesEvidence, 0),
+		SecretSharesAccusations: make([]*AccusationEvidence, 0),
+		PointsAccusations:       make([]*AccusationEvidence, 0),
+	}
+
+	for _, sender := range evidenceLog.pubKeyMessageLog.senders() {
+		message := evidenceLog.ephemeralPublicKeyMessage(sender)
+
+		publicKeys := make(map[group.MemberIndex]string)
+		for receiver, publicKey := range message.ephemeralPublicKeys {
+			publicKeys[receiver] = hex.EncodeToString(publicKey.Marshal())
+		}
+
+		evidence.EphemeralPublicKeys = append(
+			evidence.EphemeralPublicKeys,
+			&EphemeralPublicKeysEvidence{
+				Sender:     sender,
+				PublicKeys: publicKeys,
+			},
+		)
+	}
+
+	for _, sender := range evidenceLog.peerSharesMessageLog.senders() {
+		message := evidenceLog.peerSharesMessage(sender)
+
+		shares := make(map[group.MemberIndex]*EncryptedShares)
+		for receiver, peerShares := range message.shares {
+			shares[receiver] = &EncryptedShares{
+				ShareS: hex.EncodeToString(peerShares.encryptedShareS),
+				ShareT: hex.EncodeToString(peerShares.encryptedShareT),
+			}
+		}
+
+		evidence.PeerShares = append(
+			evidence.PeerShares,
+			&PeerSharesEvidence{
+				Sender: sender,
+				Shares: shares,
+			},
+		)
+	}
+
+	for _, sender := range evidenceLog.secretSharesAccusationsMessageLog.senders() {
+		message := evidenceLog.secretSharesAccusationsMessage(sender)
+		evidence.SecretSharesAccusations = append(
+			evidence.SecretSharesAccusations,
+			decodeAccusations(evidenceLog, sender, message.accusedMembersKeys)...,
+		)
+	}
+
+	for _, sender := range evidenceLog.pointsAccusationsMessageLog.senders() {
+		message := evidenceLog.pointsAccusationsMessage(sender)
+		evidence.PointsAccusations = append(
+			evidence.PointsAccusations,
+			decodeAccusations(evidenceLog, sender, message.accusedMembersKeys)...,
+		)
+	}
+
+	return evidence, nil
+}
+
+// decodeAccusations resolves accusations made by the given accuser against
+// the evidence log. Accusations are ordered by the accused member.
+func decodeAccusations(
+	evidenceLog evidenceLog,
+	accuserID group.MemberIndex,
+	accusedMembersKeys map[group.MemberIndex]*ephemeral.PrivateKey,
+) []*AccusationEvidence {
+	accusedIDs := make([]group.MemberIndex, 0, len(accusedMembersKeys))
+	for accusedID := range accusedMembersKeys {
+		accusedIDs = append(accusedIDs, accusedID)
+	}
+	sort.Slice(accusedIDs, func(i, j int) bool {
+		return accusedIDs[i] < accusedIDs[j]
+	})
+
+	accusations := make([]*AccusationEvidence, 0, len(accusedIDs))
+	for _, accusedID := range accusedIDs {
+		revealedAccuserPrivateKey := accusedMembersKeys[accusedID]
+
+		accusation := &AccusationEvidence{
+			Accuser: accuserID,
+			Accused: accusedID,
+			RevealedPrivateKey: hex.EncodeToString(
+				revealedAccuserPrivateKey.Marshal(),
+			),
+		}
+		accusations = append(accusations, accusation)
+
+		accuserPublicKey := findPublicKey(evidenceLog, accuserID, accusedID)
+		if accuserPublicKey == nil {
+			accusation.Error = "no ephemeral public key of the accuser " +
+				"generated for the accused member"
+			continue
+		}
+		accusation.RevealedKeyMatching = accuserPublicKey.IsKeyMatching(
+			revealedAccuserPrivateKey,
+		)
+
+		accusedPublicKey := findPublicKey(evidenceLog, accusedID, accuserID)
+		if accusedPublicKey == nil {
+			accusation.Error = "no ephemeral public key of the accused " +
+				"member generated for the accuser"
+			continue
+		}
+		symmetricKey := revealedAccuserPrivateKey.Ecdh(accusedPublicKey)
+
+		accusedSharesMessage := evidenceLog.peerSharesMessage(accusedID)
+		if accusedSharesMessage == nil {
+			accusation.Error = "no peer shares message of the accused member"
+			continue
+		}
+
+		shareS, shareT, err := accusedSharesMessage.decryptShares(
+			accuserID,
+			symmetricKey,
+		)
+		if err != nil {
+			accusation.Error = err.Error()
+			continue
+		}
+
+		accusation.ShareS = shareS.Text(16)
+		accusation.ShareT = shareT.Text(16)
+	}
+
+	return accusations
+}
@@ -2,6 +2,7 @@ package gjkr
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
@@ -29,6 +30,10 @@ import (
 // sent by the accused party. To do this, they read the round 3 message from the
 // log, and decrypt it using the symmetric key used between the accuser and
 // accused party. The key is publicly revealed by the accuser.
+//
+// Accusations broadcast in phases 4 and 8 are stored in the log as well. They
+// are not needed to resolve complaints but together with the messages above
+// they prove which members misbehaved once the protocol is over.
 type evidenceLog interface {
 	// ephemeralPublicKeyMessage returns the `EphemeralPublicKeyMessage`
 	// broadcast in the first protocol round by the given sender.
@@ -38,6 +43,17 @@ type evidenceLog interface {
 	// protocol round by the given sender.
 	peerSharesMessage(sender group.MemberIndex) *PeerSharesMessage
 
+	// secretSharesAccusationsMessage returns the
+	// `SecretSharesAccusationsMessage` broadcast in the fourth protocol round
+	// by the given sender.
+	secretSharesAccusationsMessage(
+		sender group.MemberIndex,
+	) *SecretSharesAccusationsMessage
+
+	// pointsAccusationsMessage returns the `PointsAccusationsMessage`
+	// broadcast in the eighth protocol round by the given sender.
+	pointsAccusationsMessage(sender group.MemberIndex) *PointsAccusationsMessage
+
 	// PutEphemeralMessage is a function that takes a single
 	// EphemeralPubKeyMessage, and stores that as evidence for future
 	// accusation trials for a given (sender, receiver) pair. If a message
@@ -49,6 +65,22 @@ type evidenceLog interface {
 	// accusation trials for a given (sender, receiver) pair. If a message
 	// already exists for the given sender, we return an error to the user.
 	PutPeerSharesMessage(sharesMessage *PeerSharesMessage) error
+
+	// PutSecretSharesAccusationsMessage is a function that takes a single
+	// SecretSharesAccusationsMessage and stores it as evidence of the
+	// accusations made by the sender. If a message already exists for the
+	// given sender, we return an error to the user.
+	PutSecretSharesAccusationsMessage(
+		accusationsMessage *SecretSharesAccusationsMessage,
+	) error
+
+	// PutPointsAccusationsMessage is a function that takes a single
+	// PointsAccusationsMessage and stores it as evidence of the accusations
+	// made by the sender. If a message already exists for the given sender,
+	// we return an error to the user.
+	PutPointsAccusationsMessage(
+		accusationsMessage *PointsAccusationsMessage,
+	) error
 }
 
 // dkgEvidenceLog is an implementation of an evidenceLog.
@@ -58,14 +90,23 @@ type dkgEvidenceLog struct {
 
 	// senderID -> *PeerSharesMessage
 	peerSharesMessageLog *messageStorage
+
+	// senderID -> *SecretSharesAccusationsMessage
+	secretSharesAccusationsMessageLog *messageStorage
+
+	// senderID -> *PointsAccusationsMessage
+	pointsAccusationsMessageLog *messageStorage
 }
 
 // NewDkgEvidenceLog returns a dkgEvidenceLog with backing stores for future
-// accusations against EphemeralPublicKeyMessages and PeerShareMessages.
+// accusations against EphemeralPublicKeyMessages and PeerShareMessages, and
+// for the accusations themselves.
 func newDkgEvidenceLog() *dkgEvidenceLog {
 	return &dkgEvidenceLog{
-		pubKeyMessageLog:     newMessageStorage(),
-		peerSharesMessageLog: newMessageStorage(),
+		pubKeyMessageLog:                  newMessageStorage(),
+		peerSharesMessageLog:              newMessageStorage(),
+		secretSharesAccusationsMessageLog: newMessageStorage(),
+		pointsAccusationsMessageLog:       newMessageStorage(),
 	}
 }
 
@@ -87,6 +128,24 @@ func (d *dkgEvidenceLog) PutPeerSharesMessage(
 	)
 }
 
+func (d *dkgEvidenceLog) PutSecretSharesAccusationsMessage(
+	accusationsMessage *SecretSharesAccusationsMessage,
+) error {
+	return d.secretSharesAccusationsMessageLog.putMessage(
+		accusationsMessage.senderID,
+		accusationsMessage,
+	)
+}
+
+func (d *dkgEvidenceLog) PutPointsAccusationsMessage(
+	accusationsMessage *PointsAccusationsMessage,
+) error {
+	return d.pointsAccusationsMessageLog.putMessage(
+		accusationsMessage.senderID,
+		accusationsMessage,
+	)
+}
+
 func (d *dkgEvidenceLog) ephemeralPublicKeyMessage(
 	sender group.MemberIndex,
 ) *EphemeralPublicKeyMessage {
@@ -109,6 +168,28 @@ func (d *dkgEvidenceLog) peerSharesMessage(
 	return nil
 }
 
+func (d *dkgEvidenceLog) secretSharesAccusationsMessage(
+	sender group.MemberIndex,
+) *SecretSharesAccusationsMessage {
+	storedMessage := d.secretSharesAccusationsMessageLog.getMessage(sender)
+	switch message := storedMessage.(type) {
+	case *SecretSharesAccusationsMessage:
+		return message
+	}
+	return nil
+}
+
+func (d *dkgEvidenceLog) pointsAccusationsMessage(
+	sender group.MemberIndex,
+) *PointsAccusationsMessage {
+	storedMessage := d.pointsAccusationsMessageLog.getMessage(sender)
+	switch message := storedMessage.(type) {
+	case *PointsAccusationsMessage:
+		return message
+	}
+	return nil
+}
+
 // messageStorage is the underlying cache used by our evidenceLog implementation
 // it implements a generic get and put of messages through a mapping of a
 // sender.
@@ -135,6 +216,23 @@ func (ms *messageStorage) getMessage(sender group.MemberIndex) interface{} {
 	return message
 }
 
+// senders returns members who have a message in the storage, in ascending
+// order.
+func (ms *messageStorage) senders() []group.MemberIndex {
+	ms.cacheLock.Lock()
+	defer ms.cacheLock.Unlock()
+
+	senders := make([]group.MemberIndex, 0, len(ms.cache))
+	for sender := range ms.cache {
+		senders = append(senders, sender)
+	}
+	sort.Slice(senders, func(i, j int) bool {
+		return senders[i] < senders[j]
+	})
+
+	return senders
+}
+
 func (ms *messageStorage) putMessage(
 	sender group.MemberIndex, message interface{},
 ) error {
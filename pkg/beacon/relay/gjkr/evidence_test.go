@@ -0,0 +1,207 @@
+package gjkr
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+func TestDecodeEvidence(t *testing.T) {
+	accuserID := group.MemberIndex(1)
+	accusedID := group.MemberIndex(2)
+	falseAccuserID := group.MemberIndex(3)
+
+	accuserKeyPair := generateEphemeralKeyPair(t)
+	accusedKeyPair := generateEphemeralKeyPair(t)
+	falseAccuserKeyPair := generateEphemeralKeyPair(t)
+	unrelatedKeyPair := generateEphemeralKeyPair(t)
+
+	shareS := big.NewInt(11)
+	shareT := big.NewInt(12)
+
+	sharesMessage := newPeerSharesMessage(accusedID)
+	err := sharesMessage.addShares(
+		accuserID,
+		shareS,
+		shareT,
+		accuserKeyPair.PrivateKey.Ecdh(accusedKeyPair.PublicKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	member, err := NewMember(group.MemberIndex(4), 4, 1, nil, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []interface{}{
+		&EphemeralPublicKeyMessage{
+			senderID: accuserID,
+			ephemeralPublicKeys: map[group.MemberIndex]*ephemeral.PublicKey{
+				accusedID: accuserKeyPair.PublicKey,
+			},
+		},
+		&EphemeralPublicKeyMessage{
+			senderID: accusedID,
+			ephemeralPublicKeys: map[group.MemberIndex]*ephemeral.PublicKey{
+				accuserID: accusedKeyPair.PublicKey,
+			},
+		},
+		&EphemeralPublicKeyMessage{
+			senderID: falseAccuserID,
+			ephemeralPublicKeys: map[group.MemberIndex]*ephemeral.PublicKey{
+				accusedID: falseAccuserKeyPair.PublicKey,
+			},
+		},
+		sharesMessage,
+		&SecretSharesAccusationsMessage{
+			senderID: accuserID,
+			accusedMembersKeys: map[group.MemberIndex]*ephemeral.PrivateKey{
+				accusedID: accuserKeyPair.PrivateKey,
+			},
+		},
+		&PointsAccusationsMessage{
+			senderID: falseAccuserID,
+			accusedMembersKeys: map[group.MemberIndex]*ephemeral.PrivateKey{
+				accusedID: unrelatedKeyPair.PrivateKey,
+			},
+		},
+	}
+	for _, message := range messages {
+		switch m := message.(type) {
+		case *EphemeralPublicKeyMessage:
+			err = member.evidenceLog.PutEphemeralMessage(m)
+		case *PeerSharesMessage:
+			err = member.evidenceLog.PutPeerSharesMessage(m)
+		case *SecretSharesAccusationsMessage:
+			err = member.evidenceLog.PutSecretSharesAccusationsMessage(m)
+		case *PointsAccusationsMessage:
+			err = member.evidenceLog.PutPointsAccusationsMessage(m)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var evidenceBytes []byte
+	handleEvidence(member, func(evidence []byte) error {
+		evidenceBytes = evidence
+		return nil
+	})
+
+	evidence, err := DecodeEvidence(evidenceBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if evidence.MemberIndex != member.ID {
+		t.Errorf(
+			"unexpected member index\nexpected: %v\nactual:   %v\n",
+			member.ID,
+			evidence.MemberIndex,
+		)
+	}
+
+	if len(evidence.EphemeralPublicKeys) != 3 {
+		t.Errorf(
+			"unexpected number of ephemeral public keys messages\n"+
+				"expected: %v\nactual:   %v\n",
+			3,
+			len(evidence.EphemeralPublicKeys),
+		)
+	}
+
+	expectedPeerShares := []*PeerSharesEvidence{
+		{
+			Sender: accusedID,
+			Shares: map[group.MemberIndex]*EncryptedShares{
+				accuserID: {
+					ShareS: hex.EncodeToString(
+						sharesMessage.shares[accuserID].encryptedShareS,
+					),
+					ShareT: hex.EncodeToString(
+						sharesMessage.shares[accuserID].encryptedShareT,
+					),
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expectedPeerShares, evidence.PeerShares) {
+		t.Errorf("unexpected peer shares evidence")
+	}
+
+	expectedSecretSharesAccusations := []*AccusationEvidence{
+		{
+			Accuser: accuserID,
+			Accused: accusedID,
+			RevealedPrivateKey: hex.EncodeToString(
+				accuserKeyPair.PrivateKey.Marshal(),
+			),
+			RevealedKeyMatching: true,
+			ShareS:              shareS.Text(16),
+			ShareT:              shareT.Text(16),
+		},
+	}
+	if !reflect.DeepEqual(
+		expectedSecretSharesAccusations,
+		evidence.SecretSharesAccusations,
+	) {
+		t.Errorf(
+			"unexpected secret shares accusations\nexpected: %+v\nactual:   %+v\n",
+			expectedSecretSharesAccusations[0],
+			evidence.SecretSharesAccusations,
+		)
+	}
+
+	if len(evidence.PointsAccusations) != 1 {
+		t.Fatalf(
+			"unexpected number of points accusations\nexpected: %v\nactual:   %v\n",
+			1,
+			len(evidence.PointsAccusations),
+		)
+	}
+	pointsAccusation := evidence.PointsAccusations[0]
+	if pointsAccusation.Accuser != falseAccuserID ||
+		pointsAccusation.Accused != accusedID {
+		t.Errorf("unexpected points accusation parties")
+	}
+	if pointsAccusation.RevealedKeyMatching {
+		t.Errorf("revealed private key should not match the public key")
+	}
+	if pointsAccusation.ShareS != "" || pointsAccusation.Error == "" {
+		t.Errorf(
+			"shares sent to other member should not be decrypted; error: [%v]",
+			pointsAccusation.Error,
+		)
+	}
+}
+
+func TestDecodeEvidence_CorruptedMessage(t *testing.T) {
+	evidenceBytes, err := (&pb.Evidence{
+		MemberID:           1,
+		PeerSharesMessages: [][]byte{{0xff}},
+	}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = DecodeEvidence(evidenceBytes)
+	if err == nil {
+		t.Fatal("expected an error for evidence with corrupted message")
+	}
+}
+
+func generateEphemeralKeyPair(t *testing.T) *ephemeral.KeyPair {
+	keyPair, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keyPair
+}
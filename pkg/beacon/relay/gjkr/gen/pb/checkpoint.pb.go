@@ -0,0 +1,4046 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pb/checkpoint.proto
+
+package pb
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type Checkpoint struct {
+	Phase                                   uint32                         `protobuf:"varint,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	Initiated                               bool                           `protobuf:"varint,2,opt,name=initiated,proto3" json:"initiated,omitempty"`
+	LastStateEndBlockHeight                 uint64                         `protobuf:"varint,3,opt,name=lastStateEndBlockHeight,proto3" json:"lastStateEndBlockHeight,omitempty"`
+	MemberID                                uint32                         `protobuf:"varint,4,opt,name=memberID,proto3" json:"memberID,omitempty"`
+	Seed                                    []byte                         `protobuf:"bytes,5,opt,name=seed,proto3" json:"seed,omitempty"`
+	GroupSize                               uint32                         `protobuf:"varint,6,opt,name=groupSize,proto3" json:"groupSize,omitempty"`
+	DishonestThreshold                      uint32                         `protobuf:"varint,7,opt,name=dishonestThreshold,proto3" json:"dishonestThreshold,omitempty"`
+	InactiveMemberIDs                       []uint32                       `protobuf:"varint,8,rep,packed,name=inactiveMemberIDs,proto3" json:"inactiveMemberIDs,omitempty"`
+	DisqualifiedMemberIDs                   []uint32                       `protobuf:"varint,9,rep,packed,name=disqualifiedMemberIDs,proto3" json:"disqualifiedMemberIDs,omitempty"`
+	EvidenceEphemeralPublicKeyMessages      [][]byte                       `protobuf:"bytes,10,rep,name=evidenceEphemeralPublicKeyMessages,proto3" json:"evidenceEphemeralPublicKeyMessages,omitempty"`
+	EvidencePeerSharesMessages              [][]byte                       `protobuf:"bytes,11,rep,name=evidencePeerSharesMessages,proto3" json:"evidencePeerSharesMessages,omitempty"`
+	EvidenceSecretSharesAccusationsMessages [][]byte                       `protobuf:"bytes,28,rep,name=evidenceSecretSharesAccusationsMessages,proto3" json:"evidenceSecretSharesAccusationsMessages,omitempty"`
+	EvidencePointsAccusationsMessages       [][]byte                       `protobuf:"bytes,29,rep,name=evidencePointsAccusationsMessages,proto3" json:"evidencePointsAccusationsMessages,omitempty"`
+	EphemeralPrivateKeys                    map[uint32][]byte              `protobuf:"bytes,12,rep,name=ephemeralPrivateKeys,proto3" json:"ephemeralPrivateKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SecretCoefficients                      [][]byte                       `protobuf:"bytes,13,rep,name=secretCoefficients,proto3" json:"secretCoefficients,omitempty"`
+	SelfSecretShareS                        []byte                         `protobuf:"bytes,14,opt,name=selfSecretShareS,proto3" json:"selfSecretShareS,omitempty"`
+	SelfSecretShareT                        []byte                         `protobuf:"bytes,15,opt,name=selfSecretShareT,proto3" json:"selfSecretShareT,omitempty"`
+	ReceivedQualifiedSharesS                map[uint32][]byte              `protobuf:"bytes,16,rep,name=receivedQualifiedSharesS,proto3" json:"receivedQualifiedSharesS,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReceivedQualifiedSharesT                map[uint32][]byte              `protobuf:"bytes,17,rep,name=receivedQualifiedSharesT,proto3" json:"receivedQualifiedSharesT,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReceivedPeerCommitments                 map[uint32]*Checkpoint_Points  `protobuf:"bytes,18,rep,name=receivedPeerCommitments,proto3" json:"receivedPeerCommitments,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	GroupPrivateKeyShare                    []byte                         `protobuf:"bytes,19,opt,name=groupPrivateKeyShare,proto3" json:"groupPrivateKeyShare,omitempty"`
+	PublicKeySharePoints                    [][]byte                       `protobuf:"bytes,20,rep,name=publicKeySharePoints,proto3" json:"publicKeySharePoints,omitempty"`
+	ReceivedValidPeerPublicKeySharePoints   map[uint32]*Checkpoint_Points  `protobuf:"bytes,21,rep,name=receivedValidPeerPublicKeySharePoints,proto3" json:"receivedValidPeerPublicKeySharePoints,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ExpectedMembersForReconstruction        []uint32                       `protobuf:"varint,22,rep,packed,name=expectedMembersForReconstruction,proto3" json:"expectedMembersForReconstruction,omitempty"`
+	RevealedMisbehavedMembersShares         []*Checkpoint_MisbehavedShares `protobuf:"bytes,23,rep,name=revealedMisbehavedMembersShares,proto3" json:"revealedMisbehavedMembersShares,omitempty"`
+	ReconstructedIndividualPrivateKeys      map[uint32][]byte              `protobuf:"bytes,24,rep,name=reconstructedIndividualPrivateKeys,proto3" json:"reconstructedIndividualPrivateKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReconstructedIndividualPublicKeys       map[uint32][]byte              `protobuf:"bytes,25,rep,name=reconstructedIndividualPublicKeys,proto3" json:"reconstructedIndividualPublicKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	GroupPublicKey                          []byte                         `protobuf:"bytes,26,opt,name=groupPublicKey,proto3" json:"groupPublicKey,omitempty"`
+	PreviousPhaseMessages                   []*Checkpoint_PhaseMessage     `protobuf:"bytes,27,rep,name=previousPhaseMessages,proto3" json:"previousPhaseMessages,omitempty"`
+}
+
+func (m *Checkpoint) Reset()      { *m = Checkpoint{} }
+func (*Checkpoint) ProtoMessage() {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{0}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(m, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+func (m *Checkpoint) GetPhase() uint32 {
+	if m != nil {
+		return m.Phase
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetInitiated() bool {
+	if m != nil {
+		return m.Initiated
+	}
+	return false
+}
+
+func (m *Checkpoint) GetLastStateEndBlockHeight() uint64 {
+	if m != nil {
+		return m.LastStateEndBlockHeight
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetMemberID() uint32 {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetGroupSize() uint32 {
+	if m != nil {
+		return m.GroupSize
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetDishonestThreshold() uint32 {
+	if m != nil {
+		return m.DishonestThreshold
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetInactiveMemberIDs() []uint32 {
+	if m != nil {
+		return m.InactiveMemberIDs
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetDisqualifiedMemberIDs() []uint32 {
+	if m != nil {
+		return m.DisqualifiedMemberIDs
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetEvidenceEphemeralPublicKeyMessages() [][]byte {
+	if m != nil {
+		return m.EvidenceEphemeralPublicKeyMessages
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetEvidencePeerSharesMessages() [][]byte {
+	if m != nil {
+		return m.EvidencePeerSharesMessages
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetEvidenceSecretSharesAccusationsMessages() [][]byte {
+	if m != nil {
+		return m.EvidenceSecretSharesAccusationsMessages
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetEvidencePointsAccusationsMessages() [][]byte {
+	if m != nil {
+		return m.EvidencePointsAccusationsMessages
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetEphemeralPrivateKeys() map[uint32][]byte {
+	if m != nil {
+		return m.EphemeralPrivateKeys
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetSecretCoefficients() [][]byte {
+	if m != nil {
+		return m.SecretCoefficients
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetSelfSecretShareS() []byte {
+	if m != nil {
+		return m.SelfSecretShareS
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetSelfSecretShareT() []byte {
+	if m != nil {
+		return m.SelfSecretShareT
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetReceivedQualifiedSharesS() map[uint32][]byte {
+	if m != nil {
+		return m.ReceivedQualifiedSharesS
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetReceivedQualifiedSharesT() map[uint32][]byte {
+	if m != nil {
+		return m.ReceivedQualifiedSharesT
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetReceivedPeerCommitments() map[uint32]*Checkpoint_Points {
+	if m != nil {
+		return m.ReceivedPeerCommitments
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetGroupPrivateKeyShare() []byte {
+	if m != nil {
+		return m.GroupPrivateKeyShare
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetPublicKeySharePoints() [][]byte {
+	if m != nil {
+		return m.PublicKeySharePoints
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetReceivedValidPeerPublicKeySharePoints() map[uint32]*Checkpoint_Points {
+	if m != nil {
+		return m.ReceivedValidPeerPublicKeySharePoints
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetExpectedMembersForReconstruction() []uint32 {
+	if m != nil {
+		return m.ExpectedMembersForReconstruction
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetRevealedMisbehavedMembersShares() []*Checkpoint_MisbehavedShares {
+	if m != nil {
+		return m.RevealedMisbehavedMembersShares
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetReconstructedIndividualPrivateKeys() map[uint32][]byte {
+	if m != nil {
+		return m.ReconstructedIndividualPrivateKeys
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetReconstructedIndividualPublicKeys() map[uint32][]byte {
+	if m != nil {
+		return m.ReconstructedIndividualPublicKeys
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetGroupPublicKey() []byte {
+	if m != nil {
+		return m.GroupPublicKey
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetPreviousPhaseMessages() []*Checkpoint_PhaseMessage {
+	if m != nil {
+		return m.PreviousPhaseMessages
+	}
+	return nil
+}
+
+type Checkpoint_Points struct {
+	Points [][]byte `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+}
+
+func (m *Checkpoint_Points) Reset()      { *m = Checkpoint_Points{} }
+func (*Checkpoint_Points) ProtoMessage() {}
+func (*Checkpoint_Points) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{0, 0}
+}
+func (m *Checkpoint_Points) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint_Points) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint_Points.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint_Points) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint_Points.Merge(m, src)
+}
+func (m *Checkpoint_Points) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint_Points) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint_Points.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint_Points proto.InternalMessageInfo
+
+func (m *Checkpoint_Points) GetPoints() [][]byte {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+type Checkpoint_MisbehavedShares struct {
+	MisbehavedMemberID uint32            `protobuf:"varint,1,opt,name=misbehavedMemberID,proto3" json:"misbehavedMemberID,omitempty"`
+	PeerSharesS        map[uint32][]byte `protobuf:"bytes,2,rep,name=peerSharesS,proto3" json:"peerSharesS,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *Checkpoint_MisbehavedShares) Reset()      { *m = Checkpoint_MisbehavedShares{} }
+func (*Checkpoint_MisbehavedShares) ProtoMessage() {}
+func (*Checkpoint_MisbehavedShares) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{0, 1}
+}
+func (m *Checkpoint_MisbehavedShares) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint_MisbehavedShares) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint_MisbehavedShares.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint_MisbehavedShares) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint_MisbehavedShares.Merge(m, src)
+}
+func (m *Checkpoint_MisbehavedShares) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint_MisbehavedShares) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint_MisbehavedShares.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint_MisbehavedShares proto.InternalMessageInfo
+
+func (m *Checkpoint_MisbehavedShares) GetMisbehavedMemberID() uint32 {
+	if m != nil {
+		return m.MisbehavedMemberID
+	}
+	return 0
+}
+
+func (m *Checkpoint_MisbehavedShares) GetPeerSharesS() map[uint32][]byte {
+	if m != nil {
+		return m.PeerSharesS
+	}
+	return nil
+}
+
+type Checkpoint_PhaseMessage struct {
+	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *Checkpoint_PhaseMessage) Reset()      { *m = Checkpoint_PhaseMessage{} }
+func (*Checkpoint_PhaseMessage) ProtoMessage() {}
+func (*Checkpoint_PhaseMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{0, 2}
+}
+func (m *Checkpoint_PhaseMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint_PhaseMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint_PhaseMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint_PhaseMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint_PhaseMessage.Merge(m, src)
+}
+func (m *Checkpoint_PhaseMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint_PhaseMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint_PhaseMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint_PhaseMessage proto.InternalMessageInfo
+
+func (m *Checkpoint_PhaseMessage) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Checkpoint_PhaseMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Checkpoint)(nil), "gjkr.Checkpoint")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Checkpoint.EphemeralPrivateKeysEntry")
+	proto.RegisterMapType((map[uint32]*Checkpoint_Points)(nil), "gjkr.Checkpoint.ReceivedPeerCommitmentsEntry")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Checkpoint.ReceivedQualifiedSharesSEntry")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Checkpoint.ReceivedQualifiedSharesTEntry")
+	proto.RegisterMapType((map[uint32]*Checkpoint_Points)(nil), "gjkr.Checkpoint.ReceivedValidPeerPublicKeySharePointsEntry")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Checkpoint.ReconstructedIndividualPrivateKeysEntry")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Checkpoint.ReconstructedIndividualPublicKeysEntry")
+	proto.RegisterType((*Checkpoint_Points)(nil), "gjkr.Checkpoint.Points")
+	proto.RegisterType((*Checkpoint_MisbehavedShares)(nil), "gjkr.Checkpoint.MisbehavedShares")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Checkpoint.MisbehavedShares.PeerSharesSEntry")
+	proto.RegisterType((*Checkpoint_PhaseMessage)(nil), "gjkr.Checkpoint.PhaseMessage")
+}
+
+func init() { proto.RegisterFile("pb/checkpoint.proto", fileDescriptor_9ed4d4b848f0d729) }
+
+var fileDescriptor_9ed4d4b848f0d729 = []byte{
+	// 980 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xf7, 0xc4, 0x69, 0x9a, 0xbc, 0x38, 0xc5, 0x9d, 0x26, 0xf5, 0xd4, 0x24, 0x8b, 0x13, 0x89,
+	0xd6, 0x8a, 0xa8, 0x91, 0x02, 0x12, 0x11, 0xa0, 0x4a, 0x34, 0x0d, 0x10, 0x42, 0x50, 0x58, 0x1b,
+	0x84, 0x38, 0x20, 0xad, 0x77, 0x5f, 0xec, 0xa9, 0xd7, 0xbb, 0xdb, 0x9d, 0xb1, 0x85, 0x39, 0x71,
+	0x41, 0xe2, 0x08, 0x1f, 0x02, 0x89, 0x8f, 0xc2, 0x31, 0xc7, 0x1c, 0x89, 0x73, 0xe1, 0xd8, 0x8f,
+	0x80, 0x76, 0xd6, 0xbb, 0xeb, 0xda, 0xeb, 0x7f, 0x52, 0x2e, 0x96, 0x67, 0xde, 0xef, 0xfd, 0x7e,
+	0xef, 0xbd, 0x79, 0xfb, 0x66, 0xe0, 0x81, 0x57, 0x7f, 0xdf, 0x6c, 0xa2, 0xd9, 0xf2, 0x5c, 0xee,
+	0xc8, 0x8a, 0xe7, 0xbb, 0xd2, 0xa5, 0xcb, 0x8d, 0x97, 0x2d, 0x7f, 0xef, 0xaf, 0x02, 0xc0, 0x51,
+	0x6c, 0xa2, 0x9b, 0x70, 0xc7, 0x6b, 0x1a, 0x02, 0x19, 0x29, 0x91, 0xf2, 0x86, 0x1e, 0x2e, 0xe8,
+	0x36, 0xac, 0x71, 0x87, 0x4b, 0x6e, 0x48, 0xb4, 0xd8, 0x52, 0x89, 0x94, 0x57, 0xf5, 0x64, 0x83,
+	0x1e, 0x42, 0xc1, 0x36, 0x84, 0xac, 0x4a, 0x43, 0xe2, 0xb1, 0x63, 0x3d, 0xb7, 0x5d, 0xb3, 0xf5,
+	0x25, 0xf2, 0x46, 0x53, 0xb2, 0x6c, 0x89, 0x94, 0x97, 0xf5, 0x49, 0x66, 0x5a, 0x84, 0xd5, 0x36,
+	0xb6, 0xeb, 0xe8, 0x9f, 0xbc, 0x60, 0xcb, 0x4a, 0x30, 0x5e, 0x53, 0x0a, 0xcb, 0x02, 0xd1, 0x62,
+	0x77, 0x4a, 0xa4, 0x9c, 0xd3, 0xd5, 0xff, 0x20, 0x8e, 0x86, 0xef, 0x76, 0xbc, 0x2a, 0xff, 0x05,
+	0xd9, 0x8a, 0x72, 0x48, 0x36, 0x68, 0x05, 0xa8, 0xc5, 0x45, 0xd3, 0x75, 0x50, 0xc8, 0x5a, 0xd3,
+	0x47, 0xd1, 0x74, 0x6d, 0x8b, 0xdd, 0x55, 0xb0, 0x14, 0x0b, 0x7d, 0x0f, 0xee, 0x73, 0xc7, 0x30,
+	0x25, 0xef, 0xe2, 0xd9, 0x40, 0x55, 0xb0, 0xd5, 0x52, 0xb6, 0xbc, 0xa1, 0x8f, 0x1b, 0xe8, 0x87,
+	0xb0, 0x65, 0x71, 0xf1, 0xaa, 0x63, 0xd8, 0xfc, 0x82, 0xa3, 0x95, 0x78, 0xac, 0x29, 0x8f, 0x74,
+	0x23, 0xfd, 0x06, 0xf6, 0xb0, 0xcb, 0x2d, 0x74, 0x4c, 0x3c, 0xf6, 0x9a, 0xd8, 0x46, 0xdf, 0xb0,
+	0xcf, 0x3b, 0x75, 0x9b, 0x9b, 0xa7, 0xd8, 0x3b, 0x43, 0x21, 0x8c, 0x06, 0x0a, 0x06, 0xa5, 0x6c,
+	0x39, 0xa7, 0xcf, 0x81, 0xa4, 0xcf, 0xa0, 0x18, 0xa1, 0xce, 0x11, 0xfd, 0x6a, 0xd3, 0xf0, 0x51,
+	0xc4, 0x3c, 0xeb, 0x8a, 0x67, 0x0a, 0x82, 0xfe, 0x00, 0x4f, 0x22, 0x6b, 0x15, 0x4d, 0x1f, 0x65,
+	0x68, 0xff, 0xcc, 0x34, 0x3b, 0xc2, 0x90, 0xdc, 0x75, 0x12, 0xb2, 0x6d, 0x45, 0x36, 0x2f, 0x9c,
+	0x7e, 0x0d, 0xbb, 0xb1, 0x6e, 0xd0, 0x4a, 0xa9, 0x9c, 0x3b, 0x8a, 0x73, 0x36, 0x90, 0xfe, 0x04,
+	0x9b, 0x18, 0x57, 0xc1, 0xe7, 0x5d, 0x43, 0xe2, 0x29, 0xf6, 0x04, 0xcb, 0x95, 0xb2, 0xe5, 0xf5,
+	0x83, 0xfd, 0x4a, 0xd0, 0xbb, 0x95, 0xa4, 0x6f, 0x2b, 0xc7, 0x29, 0xe0, 0x63, 0x47, 0xfa, 0x3d,
+	0x3d, 0x95, 0x27, 0xe8, 0x15, 0xa1, 0x12, 0x3a, 0x72, 0xf1, 0xe2, 0x82, 0x9b, 0x1c, 0x1d, 0x29,
+	0xd8, 0x86, 0x0a, 0x2f, 0xc5, 0x42, 0xf7, 0x21, 0x2f, 0xd0, 0xbe, 0x18, 0x2a, 0x42, 0x95, 0xdd,
+	0x53, 0x9d, 0x39, 0xb6, 0x9f, 0x82, 0xad, 0xb1, 0xb7, 0x52, 0xb1, 0x35, 0xfa, 0x12, 0x98, 0x8f,
+	0x26, 0xf2, 0x2e, 0x5a, 0xdf, 0x46, 0xdd, 0xa3, 0x4c, 0xa2, 0xca, 0xf2, 0x2a, 0xd7, 0xca, 0x58,
+	0xae, 0xfa, 0x04, 0x87, 0x30, 0xdf, 0x89, 0x7c, 0x53, 0xb4, 0x6a, 0xec, 0xfe, 0x62, 0x5a, 0xb5,
+	0xe9, 0x5a, 0x35, 0xda, 0x80, 0x42, 0x64, 0x0b, 0xba, 0xf0, 0xc8, 0x6d, 0xb7, 0xb9, 0x6c, 0xab,
+	0x22, 0x53, 0x25, 0xf5, 0x74, 0xa2, 0xd4, 0x08, 0x3e, 0x54, 0x9a, 0xc4, 0x46, 0x0f, 0x60, 0x53,
+	0x4d, 0x80, 0xe4, 0x70, 0x55, 0x08, 0xec, 0x81, 0x2a, 0x78, 0xaa, 0x2d, 0xf0, 0xf1, 0xa2, 0x2f,
+	0x4b, 0xed, 0x84, 0x7d, 0xc8, 0x36, 0xd5, 0xf1, 0xa7, 0xda, 0xe8, 0x9f, 0x04, 0xde, 0x8d, 0x62,
+	0xf8, 0xde, 0xb0, 0xb9, 0x0a, 0xe4, 0x3c, 0x8d, 0x65, 0x4b, 0xe5, 0xf7, 0xc9, 0xc4, 0xfc, 0xa6,
+	0x7a, 0x87, 0xd9, 0xce, 0xa7, 0x44, 0xbf, 0x82, 0x12, 0xfe, 0xec, 0xa1, 0x29, 0xa3, 0x89, 0x23,
+	0x3e, 0x77, 0x7d, 0x1d, 0x4d, 0xd7, 0x11, 0xd2, 0xef, 0x98, 0xc1, 0x17, 0xc5, 0x1e, 0xaa, 0xe9,
+	0x34, 0x13, 0x47, 0x5b, 0xf0, 0x8e, 0x8f, 0x5d, 0x34, 0x6c, 0xb4, 0xce, 0xb8, 0xa8, 0x63, 0xd3,
+	0xe8, 0xc6, 0xe8, 0xf0, 0x50, 0x59, 0x41, 0x25, 0xb6, 0x3b, 0x96, 0x58, 0x82, 0x0f, 0x81, 0xfa,
+	0x2c, 0x26, 0xfa, 0x3b, 0x81, 0x3d, 0x3f, 0xd1, 0x47, 0xeb, 0xc4, 0xb1, 0x78, 0x97, 0x5b, 0x9d,
+	0x37, 0x3f, 0x76, 0xa6, 0x04, 0x0f, 0xd3, 0x2a, 0x39, 0xc3, 0x35, 0x2c, 0xe3, 0x1c, 0x1a, 0xf4,
+	0x37, 0x02, 0xbb, 0x93, 0x60, 0x51, 0xcd, 0x05, 0x7b, 0xa4, 0x22, 0xf9, 0x68, 0xee, 0x48, 0x62,
+	0xcf, 0x30, 0x90, 0xd9, 0x0a, 0xf4, 0x31, 0xdc, 0x0b, 0x7b, 0x35, 0xda, 0x62, 0x45, 0xd5, 0xc1,
+	0x23, 0xbb, 0xb4, 0x0a, 0x5b, 0x9e, 0x8f, 0x5d, 0xee, 0x76, 0xc4, 0x79, 0x70, 0x37, 0xc7, 0xa3,
+	0xf5, 0x6d, 0x15, 0xe2, 0xce, 0x58, 0x88, 0xc3, 0x28, 0x3d, 0xdd, 0xb7, 0x58, 0x82, 0x95, 0x41,
+	0x4b, 0x3d, 0x84, 0x15, 0xe5, 0x26, 0x18, 0x51, 0x1f, 0xc3, 0x60, 0x55, 0xbc, 0x22, 0x90, 0x1f,
+	0x3d, 0xe7, 0x60, 0x88, 0xb6, 0x47, 0x4e, 0xf8, 0xe4, 0xc5, 0xe0, 0xe5, 0x90, 0x62, 0xa1, 0x35,
+	0x58, 0xf7, 0xe2, 0x2b, 0xa9, 0xca, 0x96, 0x54, 0xc4, 0x07, 0x33, 0xfb, 0xa9, 0x92, 0xdc, 0x63,
+	0x83, 0x19, 0x37, 0x4c, 0x53, 0x7c, 0x06, 0xf9, 0x51, 0x00, 0xcd, 0x43, 0xb6, 0x85, 0xbd, 0x41,
+	0x28, 0xc1, 0xdf, 0xe0, 0x61, 0xd3, 0x35, 0xec, 0x0e, 0xaa, 0xe7, 0x4b, 0x4e, 0x0f, 0x17, 0x1f,
+	0x2f, 0x1d, 0x92, 0xe2, 0xa7, 0x90, 0x1b, 0xae, 0x46, 0xf0, 0xf0, 0x90, 0x3d, 0x2f, 0x7c, 0x01,
+	0xad, 0xe9, 0xea, 0x3f, 0x65, 0x70, 0xd7, 0x33, 0x7a, 0xb6, 0x6b, 0x58, 0x03, 0xff, 0x68, 0x59,
+	0xfc, 0x02, 0x1e, 0x4d, 0xbc, 0x7b, 0x16, 0x0a, 0xe3, 0x14, 0x76, 0xa6, 0x0e, 0xf6, 0x5b, 0x22,
+	0xab, 0x2d, 0x4e, 0x66, 0xc2, 0xf6, 0xb4, 0xd9, 0x9c, 0xc2, 0xf5, 0x74, 0x98, 0x6b, 0xfd, 0xa0,
+	0x30, 0xde, 0x94, 0xc1, 0xaf, 0x18, 0x16, 0x79, 0x05, 0xfb, 0xf3, 0x0f, 0xc8, 0xdb, 0x91, 0xfc,
+	0x0e, 0x9e, 0xcc, 0x39, 0x49, 0x16, 0x2a, 0x57, 0x0d, 0x1e, 0xcf, 0x37, 0x16, 0x16, 0x61, 0x7d,
+	0x7e, 0x78, 0x79, 0xad, 0x65, 0xae, 0xae, 0xb5, 0xcc, 0xeb, 0x6b, 0x8d, 0xfc, 0xda, 0xd7, 0xc8,
+	0xdf, 0x7d, 0x8d, 0xfc, 0xd3, 0xd7, 0xc8, 0x65, 0x5f, 0x23, 0xff, 0xf6, 0x35, 0xf2, 0x5f, 0x5f,
+	0xcb, 0xbc, 0xee, 0x6b, 0xe4, 0x8f, 0x1b, 0x2d, 0x73, 0x79, 0xa3, 0x65, 0xae, 0x6e, 0xb4, 0xcc,
+	0x8f, 0x4b, 0x5e, 0xbd, 0xbe, 0xa2, 0x9e, 0xfb, 0x1f, 0xfc, 0x3f, 0x00, 0x62, 0x1a, 0xf0, 0xb7,
+	0x05, 0x0c, 0x00, 0x00,
+}
+
+func (this *Checkpoint) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint)
+	if !ok {
+		that2, ok := that.(Checkpoint)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Phase != that1.Phase {
+		return false
+	}
+	if this.Initiated != that1.Initiated {
+		return false
+	}
+	if this.LastStateEndBlockHeight != that1.LastStateEndBlockHeight {
+		return false
+	}
+	if this.MemberID != that1.MemberID {
+		return false
+	}
+	if !bytes.Equal(this.Seed, that1.Seed) {
+		return false
+	}
+	if this.GroupSize != that1.GroupSize {
+		return false
+	}
+	if this.DishonestThreshold != that1.DishonestThreshold {
+		return false
+	}
+	if len(this.InactiveMemberIDs) != len(that1.InactiveMemberIDs) {
+		return false
+	}
+	for i := range this.InactiveMemberIDs {
+		if this.InactiveMemberIDs[i] != that1.InactiveMemberIDs[i] {
+			return false
+		}
+	}
+	if len(this.DisqualifiedMemberIDs) != len(that1.DisqualifiedMemberIDs) {
+		return false
+	}
+	for i := range this.DisqualifiedMemberIDs {
+		if this.DisqualifiedMemberIDs[i] != that1.DisqualifiedMemberIDs[i] {
+			return false
+		}
+	}
+	if len(this.EvidenceEphemeralPublicKeyMessages) != len(that1.EvidenceEphemeralPublicKeyMessages) {
+		return false
+	}
+	for i := range this.EvidenceEphemeralPublicKeyMessages {
+		if !bytes.Equal(this.EvidenceEphemeralPublicKeyMessages[i], that1.EvidenceEphemeralPublicKeyMessages[i]) {
+			return false
+		}
+	}
+	if len(this.EvidencePeerSharesMessages) != len(that1.EvidencePeerSharesMessages) {
+		return false
+	}
+	for i := range this.EvidencePeerSharesMessages {
+		if !bytes.Equal(this.EvidencePeerSharesMessages[i], that1.EvidencePeerSharesMessages[i]) {
+			return false
+		}
+	}
+	if len(this.EvidenceSecretSharesAccusationsMessages) != len(that1.EvidenceSecretSharesAccusationsMessages) {
+		return false
+	}
+	for i := range this.EvidenceSecretSharesAccusationsMessages {
+		if !bytes.Equal(this.EvidenceSecretSharesAccusationsMessages[i], that1.EvidenceSecretSharesAccusationsMessages[i]) {
+			return false
+		}
+	}
+	if len(this.EvidencePointsAccusationsMessages) != len(that1.EvidencePointsAccusationsMessages) {
+		return false
+	}
+	for i := range this.EvidencePointsAccusationsMessages {
+		if !bytes.Equal(this.EvidencePointsAccusationsMessages[i], that1.EvidencePointsAccusationsMessages[i]) {
+			return false
+		}
+	}
+	if len(this.EphemeralPrivateKeys) != len(that1.EphemeralPrivateKeys) {
+		return false
+	}
+	for i := range this.EphemeralPrivateKeys {
+		if !bytes.Equal(this.EphemeralPrivateKeys[i], that1.EphemeralPrivateKeys[i]) {
+			return false
+		}
+	}
+	if len(this.SecretCoefficients) != len(that1.SecretCoefficients) {
+		return false
+	}
+	for i := range this.SecretCoefficients {
+		if !bytes.Equal(this.SecretCoefficients[i], that1.SecretCoefficients[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.SelfSecretShareS, that1.SelfSecretShareS) {
+		return false
+	}
+	if !bytes.Equal(this.SelfSecretShareT, that1.SelfSecretShareT) {
+		return false
+	}
+	if len(this.ReceivedQualifiedSharesS) != len(that1.ReceivedQualifiedSharesS) {
+		return false
+	}
+	for i := range this.ReceivedQualifiedSharesS {
+		if !bytes.Equal(this.ReceivedQualifiedSharesS[i], that1.ReceivedQualifiedSharesS[i]) {
+			return false
+		}
+	}
+	if len(this.ReceivedQualifiedSharesT) != len(that1.ReceivedQualifiedSharesT) {
+		return false
+	}
+	for i := range this.ReceivedQualifiedSharesT {
+		if !bytes.Equal(this.ReceivedQualifiedSharesT[i], that1.ReceivedQualifiedSharesT[i]) {
+			return false
+		}
+	}
+	if len(this.ReceivedPeerCommitments) != len(that1.ReceivedPeerCommitments) {
+		return false
+	}
+	for i := range this.ReceivedPeerCommitments {
+		if !this.ReceivedPeerCommitments[i].Equal(that1.ReceivedPeerCommitments[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.GroupPrivateKeyShare, that1.GroupPrivateKeyShare) {
+		return false
+	}
+	if len(this.PublicKeySharePoints) != len(that1.PublicKeySharePoints) {
+		return false
+	}
+	for i := range this.PublicKeySharePoints {
+		if !bytes.Equal(this.PublicKeySharePoints[i], that1.PublicKeySharePoints[i]) {
+			return false
+		}
+	}
+	if len(this.ReceivedValidPeerPublicKeySharePoints) != len(that1.ReceivedValidPeerPublicKeySharePoints) {
+		return false
+	}
+	for i := range this.ReceivedValidPeerPublicKeySharePoints {
+		if !this.ReceivedValidPeerPublicKeySharePoints[i].Equal(that1.ReceivedValidPeerPublicKeySharePoints[i]) {
+			return false
+		}
+	}
+	if len(this.ExpectedMembersForReconstruction) != len(that1.ExpectedMembersForReconstruction) {
+		return false
+	}
+	for i := range this.ExpectedMembersForReconstruction {
+		if this.ExpectedMembersForReconstruction[i] != that1.ExpectedMembersForReconstruction[i] {
+			return false
+		}
+	}
+	if len(this.RevealedMisbehavedMembersShares) != len(that1.RevealedMisbehavedMembersShares) {
+		return false
+	}
+	for i := range this.RevealedMisbehavedMembersShares {
+		if !this.RevealedMisbehavedMembersShares[i].Equal(that1.RevealedMisbehavedMembersShares[i]) {
+			return false
+		}
+	}
+	if len(this.ReconstructedIndividualPrivateKeys) != len(that1.ReconstructedIndividualPrivateKeys) {
+		return false
+	}
+	for i := range this.ReconstructedIndividualPrivateKeys {
+		if !bytes.Equal(this.ReconstructedIndividualPrivateKeys[i], that1.ReconstructedIndividualPrivateKeys[i]) {
+			return false
+		}
+	}
+	if len(this.ReconstructedIndividualPublicKeys) != len(that1.ReconstructedIndividualPublicKeys) {
+		return false
+	}
+	for i := range this.ReconstructedIndividualPublicKeys {
+		if !bytes.Equal(this.ReconstructedIndividualPublicKeys[i], that1.ReconstructedIndividualPublicKeys[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.GroupPublicKey, that1.GroupPublicKey) {
+		return false
+	}
+	if len(this.PreviousPhaseMessages) != len(that1.PreviousPhaseMessages) {
+		return false
+	}
+	for i := range this.PreviousPhaseMessages {
+		if !this.PreviousPhaseMessages[i].Equal(that1.PreviousPhaseMessages[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Checkpoint_Points) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint_Points)
+	if !ok {
+		that2, ok := that.(Checkpoint_Points)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Points) != len(that1.Points) {
+		return false
+	}
+	for i := range this.Points {
+		if !bytes.Equal(this.Points[i], that1.Points[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Checkpoint_MisbehavedShares) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint_MisbehavedShares)
+	if !ok {
+		that2, ok := that.(Checkpoint_MisbehavedShares)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MisbehavedMemberID != that1.MisbehavedMemberID {
+		return false
+	}
+	if len(this.PeerSharesS) != len(that1.PeerSharesS) {
+		return false
+	}
+	for i := range this.PeerSharesS {
+		if !bytes.Equal(this.PeerSharesS[i], that1.PeerSharesS[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Checkpoint_PhaseMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint_PhaseMessage)
+	if !ok {
+		that2, ok := that.(Checkpoint_PhaseMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
+	}
+	return true
+}
+func (this *Checkpoint) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 33)
+	s = append(s, "&pb.Checkpoint{")
+	s = append(s, "Phase: "+fmt.Sprintf("%#v", this.Phase)+",\n")
+	s = append(s, "Initiated: "+fmt.Sprintf("%#v", this.Initiated)+",\n")
+	s = append(s, "LastStateEndBlockHeight: "+fmt.Sprintf("%#v", this.LastStateEndBlockHeight)+",\n")
+	s = append(s, "MemberID: "+fmt.Sprintf("%#v", this.MemberID)+",\n")
+	s = append(s, "Seed: "+fmt.Sprintf("%#v", this.Seed)+",\n")
+	s = append(s, "GroupSize: "+fmt.Sprintf("%#v", this.GroupSize)+",\n")
+	s = append(s, "DishonestThreshold: "+fmt.Sprintf("%#v", this.DishonestThreshold)+",\n")
+	s = append(s, "InactiveMemberIDs: "+fmt.Sprintf("%#v", this.InactiveMemberIDs)+",\n")
+	s = append(s, "DisqualifiedMemberIDs: "+fmt.Sprintf("%#v", this.DisqualifiedMemberIDs)+",\n")
+	s = append(s, "EvidenceEphemeralPublicKeyMessages: "+fmt.Sprintf("%#v", this.EvidenceEphemeralPublicKeyMessages)+",\n")
+	s = append(s, "EvidencePeerSharesMessages: "+fmt.Sprintf("%#v", this.EvidencePeerSharesMessages)+",\n")
+	s = append(s, "EvidenceSecretSharesAccusationsMessages: "+fmt.Sprintf("%#v", this.EvidenceSecretSharesAccusationsMessages)+",\n")
+	s = append(s, "EvidencePointsAccusationsMessages: "+fmt.Sprintf("%#v", this.EvidencePointsAccusationsMessages)+",\n")
+	keysForEphemeralPrivateKeys := make([]uint32, 0, len(this.EphemeralPrivateKeys))
+	for k, _ := range this.EphemeralPrivateKeys {
+		keysForEphemeralPrivateKeys = append(keysForEphemeralPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForEphemeralPrivateKeys)
+	mapStringForEphemeralPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForEphemeralPrivateKeys {
+		mapStringForEphemeralPrivateKeys += fmt.Sprintf("%#v: %#v,", k, this.EphemeralPrivateKeys[k])
+	}
+	mapStringForEphemeralPrivateKeys += "}"
+	if this.EphemeralPrivateKeys != nil {
+		s = append(s, "EphemeralPrivateKeys: "+mapStringForEphemeralPrivateKeys+",\n")
+	}
+	s = append(s, "SecretCoefficients: "+fmt.Sprintf("%#v", this.SecretCoefficients)+",\n")
+	s = append(s, "SelfSecretShareS: "+fmt.Sprintf("%#v", this.SelfSecretShareS)+",\n")
+	s = append(s, "SelfSecretShareT: "+fmt.Sprintf("%#v", this.SelfSecretShareT)+",\n")
+	keysForReceivedQualifiedSharesS := make([]uint32, 0, len(this.ReceivedQualifiedSharesS))
+	for k, _ := range this.ReceivedQualifiedSharesS {
+		keysForReceivedQualifiedSharesS = append(keysForReceivedQualifiedSharesS, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedQualifiedSharesS)
+	mapStringForReceivedQualifiedSharesS := "map[uint32][]byte{"
+	for _, k := range keysForReceivedQualifiedSharesS {
+		mapStringForReceivedQualifiedSharesS += fmt.Sprintf("%#v: %#v,", k, this.ReceivedQualifiedSharesS[k])
+	}
+	mapStringForReceivedQualifiedSharesS += "}"
+	if this.ReceivedQualifiedSharesS != nil {
+		s = append(s, "ReceivedQualifiedSharesS: "+mapStringForReceivedQualifiedSharesS+",\n")
+	}
+	keysForReceivedQualifiedSharesT := make([]uint32, 0, len(this.ReceivedQualifiedSharesT))
+	for k, _ := range this.ReceivedQualifiedSharesT {
+		keysForReceivedQualifiedSharesT = append(keysForReceivedQualifiedSharesT, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedQualifiedSharesT)
+	mapStringForReceivedQualifiedSharesT := "map[uint32][]byte{"
+	for _, k := range keysForReceivedQualifiedSharesT {
+		mapStringForReceivedQualifiedSharesT += fmt.Sprintf("%#v: %#v,", k, this.ReceivedQualifiedSharesT[k])
+	}
+	mapStringForReceivedQualifiedSharesT += "}"
+	if this.ReceivedQualifiedSharesT != nil {
+		s = append(s, "ReceivedQualifiedSharesT: "+mapStringForReceivedQualifiedSharesT+",\n")
+	}
+	keysForReceivedPeerCommitments := make([]uint32, 0, len(this.ReceivedPeerCommitments))
+	for k, _ := range this.ReceivedPeerCommitments {
+		keysForReceivedPeerCommitments = append(keysForReceivedPeerCommitments, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedPeerCommitments)
+	mapStringForReceivedPeerCommitments := "map[uint32]*Checkpoint_Points{"
+	for _, k := range keysForReceivedPeerCommitments {
+		mapStringForReceivedPeerCommitments += fmt.Sprintf("%#v: %#v,", k, this.ReceivedPeerCommitments[k])
+	}
+	mapStringForReceivedPeerCommitments += "}"
+	if this.ReceivedPeerCommitments != nil {
+		s = append(s, "ReceivedPeerCommitments: "+mapStringForReceivedPeerCommitments+",\n")
+	}
+	s = append(s, "GroupPrivateKeyShare: "+fmt.Sprintf("%#v", this.GroupPrivateKeyShare)+",\n")
+	s = append(s, "PublicKeySharePoints: "+fmt.Sprintf("%#v", this.PublicKeySharePoints)+",\n")
+	keysForReceivedValidPeerPublicKeySharePoints := make([]uint32, 0, len(this.ReceivedValidPeerPublicKeySharePoints))
+	for k, _ := range this.ReceivedValidPeerPublicKeySharePoints {
+		keysForReceivedValidPeerPublicKeySharePoints = append(keysForReceivedValidPeerPublicKeySharePoints, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedValidPeerPublicKeySharePoints)
+	mapStringForReceivedValidPeerPublicKeySharePoints := "map[uint32]*Checkpoint_Points{"
+	for _, k := range keysForReceivedValidPeerPublicKeySharePoints {
+		mapStringForReceivedValidPeerPublicKeySharePoints += fmt.Sprintf("%#v: %#v,", k, this.ReceivedValidPeerPublicKeySharePoints[k])
+	}
+	mapStringForReceivedValidPeerPublicKeySharePoints += "}"
+	if this.ReceivedValidPeerPublicKeySharePoints != nil {
+		s = append(s, "ReceivedValidPeerPublicKeySharePoints: "+mapStringForReceivedValidPeerPublicKeySharePoints+",\n")
+	}
+	s = append(s, "ExpectedMembersForReconstruction: "+fmt.Sprintf("%#v", this.ExpectedMembersForReconstruction)+",\n")
+	if this.RevealedMisbehavedMembersShares != nil {
+		s = append(s, "RevealedMisbehavedMembersShares: "+fmt.Sprintf("%#v", this.RevealedMisbehavedMembersShares)+",\n")
+	}
+	keysForReconstructedIndividualPrivateKeys := make([]uint32, 0, len(this.ReconstructedIndividualPrivateKeys))
+	for k, _ := range this.ReconstructedIndividualPrivateKeys {
+		keysForReconstructedIndividualPrivateKeys = append(keysForReconstructedIndividualPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReconstructedIndividualPrivateKeys)
+	mapStringForReconstructedIndividualPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForReconstructedIndividualPrivateKeys {
+		mapStringForReconstructedIndividualPrivateKeys += fmt.Sprintf("%#v: %#v,", k, this.ReconstructedIndividualPrivateKeys[k])
+	}
+	mapStringForReconstructedIndividualPrivateKeys += "}"
+	if this.ReconstructedIndividualPrivateKeys != nil {
+		s = append(s, "ReconstructedIndividualPrivateKeys: "+mapStringForReconstructedIndividualPrivateKeys+",\n")
+	}
+	keysForReconstructedIndividualPublicKeys := make([]uint32, 0, len(this.ReconstructedIndividualPublicKeys))
+	for k, _ := range this.ReconstructedIndividualPublicKeys {
+		keysForReconstructedIndividualPublicKeys = append(keysForReconstructedIndividualPublicKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReconstructedIndividualPublicKeys)
+	mapStringForReconstructedIndividualPublicKeys := "map[uint32][]byte{"
+	for _, k := range keysForReconstructedIndividualPublicKeys {
+		mapStringForReconstructedIndividualPublicKeys += fmt.Sprintf("%#v: %#v,", k, this.ReconstructedIndividualPublicKeys[k])
+	}
+	mapStringForReconstructedIndividualPublicKeys += "}"
+	if this.ReconstructedIndividualPublicKeys != nil {
+		s = append(s, "ReconstructedIndividualPublicKeys: "+mapStringForReconstructedIndividualPublicKeys+",\n")
+	}
+	s = append(s, "GroupPublicKey: "+fmt.Sprintf("%#v", this.GroupPublicKey)+",\n")
+	if this.PreviousPhaseMessages != nil {
+		s = append(s, "PreviousPhaseMessages: "+fmt.Sprintf("%#v", this.PreviousPhaseMessages)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Checkpoint_Points) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.Checkpoint_Points{")
+	s = append(s, "Points: "+fmt.Sprintf("%#v", this.Points)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Checkpoint_MisbehavedShares) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.Checkpoint_MisbehavedShares{")
+	s = append(s, "MisbehavedMemberID: "+fmt.Sprintf("%#v", this.MisbehavedMemberID)+",\n")
+	keysForPeerSharesS := make([]uint32, 0, len(this.PeerSharesS))
+	for k, _ := range this.PeerSharesS {
+		keysForPeerSharesS = append(keysForPeerSharesS, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForPeerSharesS)
+	mapStringForPeerSharesS := "map[uint32][]byte{"
+	for _, k := range keysForPeerSharesS {
+		mapStringForPeerSharesS += fmt.Sprintf("%#v: %#v,", k, this.PeerSharesS[k])
+	}
+	mapStringForPeerSharesS += "}"
+	if this.PeerSharesS != nil {
+		s = append(s, "PeerSharesS: "+mapStringForPeerSharesS+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Checkpoint_PhaseMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.Checkpoint_PhaseMessage{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringCheckpoint(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *Checkpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EvidencePointsAccusationsMessages) > 0 {
+		for iNdEx := len(m.EvidencePointsAccusationsMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EvidencePointsAccusationsMessages[iNdEx])
+			copy(dAtA[i:], m.EvidencePointsAccusationsMessages[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.EvidencePointsAccusationsMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xea
+		}
+	}
+	if len(m.EvidenceSecretSharesAccusationsMessages) > 0 {
+		for iNdEx := len(m.EvidenceSecretSharesAccusationsMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EvidenceSecretSharesAccusationsMessages[iNdEx])
+			copy(dAtA[i:], m.EvidenceSecretSharesAccusationsMessages[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.EvidenceSecretSharesAccusationsMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xe2
+		}
+	}
+	if len(m.PreviousPhaseMessages) > 0 {
+		for iNdEx := len(m.PreviousPhaseMessages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PreviousPhaseMessages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xda
+		}
+	}
+	if len(m.GroupPublicKey) > 0 {
+		i -= len(m.GroupPublicKey)
+		copy(dAtA[i:], m.GroupPublicKey)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.GroupPublicKey)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd2
+	}
+	if len(m.ReconstructedIndividualPublicKeys) > 0 {
+		for k := range m.ReconstructedIndividualPublicKeys {
+			v := m.ReconstructedIndividualPublicKeys[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xca
+		}
+	}
+	if len(m.ReconstructedIndividualPrivateKeys) > 0 {
+		for k := range m.ReconstructedIndividualPrivateKeys {
+			v := m.ReconstructedIndividualPrivateKeys[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xc2
+		}
+	}
+	if len(m.RevealedMisbehavedMembersShares) > 0 {
+		for iNdEx := len(m.RevealedMisbehavedMembersShares) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RevealedMisbehavedMembersShares[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xba
+		}
+	}
+	if len(m.ExpectedMembersForReconstruction) > 0 {
+		dAtA2 := make([]byte, len(m.ExpectedMembersForReconstruction)*10)
+		var j1 int
+		for _, num := range m.ExpectedMembersForReconstruction {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintCheckpoint(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb2
+	}
+	if len(m.ReceivedValidPeerPublicKeySharePoints) > 0 {
+		for k := range m.ReceivedValidPeerPublicKeySharePoints {
+			v := m.ReceivedValidPeerPublicKeySharePoints[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xaa
+		}
+	}
+	if len(m.PublicKeySharePoints) > 0 {
+		for iNdEx := len(m.PublicKeySharePoints) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PublicKeySharePoints[iNdEx])
+			copy(dAtA[i:], m.PublicKeySharePoints[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.PublicKeySharePoints[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if len(m.GroupPrivateKeyShare) > 0 {
+		i -= len(m.GroupPrivateKeyShare)
+		copy(dAtA[i:], m.GroupPrivateKeyShare)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.GroupPrivateKeyShare)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if len(m.ReceivedPeerCommitments) > 0 {
+		for k := range m.ReceivedPeerCommitments {
+			v := m.ReceivedPeerCommitments[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	if len(m.ReceivedQualifiedSharesT) > 0 {
+		for k := range m.ReceivedQualifiedSharesT {
+			v := m.ReceivedQualifiedSharesT[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if len(m.ReceivedQualifiedSharesS) > 0 {
+		for k := range m.ReceivedQualifiedSharesS {
+			v := m.ReceivedQualifiedSharesS[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if len(m.SelfSecretShareT) > 0 {
+		i -= len(m.SelfSecretShareT)
+		copy(dAtA[i:], m.SelfSecretShareT)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.SelfSecretShareT)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if len(m.SelfSecretShareS) > 0 {
+		i -= len(m.SelfSecretShareS)
+		copy(dAtA[i:], m.SelfSecretShareS)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.SelfSecretShareS)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if len(m.SecretCoefficients) > 0 {
+		for iNdEx := len(m.SecretCoefficients) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SecretCoefficients[iNdEx])
+			copy(dAtA[i:], m.SecretCoefficients[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.SecretCoefficients[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.EphemeralPrivateKeys) > 0 {
+		for k := range m.EphemeralPrivateKeys {
+			v := m.EphemeralPrivateKeys[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if len(m.EvidencePeerSharesMessages) > 0 {
+		for iNdEx := len(m.EvidencePeerSharesMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EvidencePeerSharesMessages[iNdEx])
+			copy(dAtA[i:], m.EvidencePeerSharesMessages[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.EvidencePeerSharesMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.EvidenceEphemeralPublicKeyMessages) > 0 {
+		for iNdEx := len(m.EvidenceEphemeralPublicKeyMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EvidenceEphemeralPublicKeyMessages[iNdEx])
+			copy(dAtA[i:], m.EvidenceEphemeralPublicKeyMessages[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.EvidenceEphemeralPublicKeyMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.DisqualifiedMemberIDs) > 0 {
+		dAtA6 := make([]byte, len(m.DisqualifiedMemberIDs)*10)
+		var j5 int
+		for _, num := range m.DisqualifiedMemberIDs {
+			for num >= 1<<7 {
+				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j5++
+			}
+			dAtA6[j5] = uint8(num)
+			j5++
+		}
+		i -= j5
+		copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintCheckpoint(dAtA, i, uint64(j5))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.InactiveMemberIDs) > 0 {
+		dAtA8 := make([]byte, len(m.InactiveMemberIDs)*10)
+		var j7 int
+		for _, num := range m.InactiveMemberIDs {
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
+			}
+			dAtA8[j7] = uint8(num)
+			j7++
+		}
+		i -= j7
+		copy(dAtA[i:], dAtA8[:j7])
+		i = encodeVarintCheckpoint(dAtA, i, uint64(j7))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.DishonestThreshold != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.DishonestThreshold))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.GroupSize != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.GroupSize))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Seed) > 0 {
+		i -= len(m.Seed)
+		copy(dAtA[i:], m.Seed)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Seed)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.LastStateEndBlockHeight != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.LastStateEndBlockHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Initiated {
+		i--
+		if m.Initiated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Phase != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Phase))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Checkpoint_Points) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint_Points) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint_Points) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Points) > 0 {
+		for iNdEx := len(m.Points) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Points[iNdEx])
+			copy(dAtA[i:], m.Points[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Points[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Checkpoint_MisbehavedShares) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint_MisbehavedShares) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint_MisbehavedShares) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PeerSharesS) > 0 {
+		for k := range m.PeerSharesS {
+			v := m.PeerSharesS[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.MisbehavedMemberID != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.MisbehavedMemberID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Checkpoint_PhaseMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint_PhaseMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint_PhaseMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintCheckpoint(dAtA []byte, offset int, v uint64) int {
+	offset -= sovCheckpoint(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Checkpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Phase != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Phase))
+	}
+	if m.Initiated {
+		n += 2
+	}
+	if m.LastStateEndBlockHeight != 0 {
+		n += 1 + sovCheckpoint(uint64(m.LastStateEndBlockHeight))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovCheckpoint(uint64(m.MemberID))
+	}
+	l = len(m.Seed)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if m.GroupSize != 0 {
+		n += 1 + sovCheckpoint(uint64(m.GroupSize))
+	}
+	if m.DishonestThreshold != 0 {
+		n += 1 + sovCheckpoint(uint64(m.DishonestThreshold))
+	}
+	if len(m.InactiveMemberIDs) > 0 {
+		l = 0
+		for _, e := range m.InactiveMemberIDs {
+			l += sovCheckpoint(uint64(e))
+		}
+		n += 1 + sovCheckpoint(uint64(l)) + l
+	}
+	if len(m.DisqualifiedMemberIDs) > 0 {
+		l = 0
+		for _, e := range m.DisqualifiedMemberIDs {
+			l += sovCheckpoint(uint64(e))
+		}
+		n += 1 + sovCheckpoint(uint64(l)) + l
+	}
+	if len(m.EvidenceEphemeralPublicKeyMessages) > 0 {
+		for _, b := range m.EvidenceEphemeralPublicKeyMessages {
+			l = len(b)
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.EvidencePeerSharesMessages) > 0 {
+		for _, b := range m.EvidencePeerSharesMessages {
+			l = len(b)
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.EphemeralPrivateKeys) > 0 {
+		for k, v := range m.EphemeralPrivateKeys {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 1 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.SecretCoefficients) > 0 {
+		for _, b := range m.SecretCoefficients {
+			l = len(b)
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	l = len(m.SelfSecretShareS)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	l = len(m.SelfSecretShareT)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if len(m.ReceivedQualifiedSharesS) > 0 {
+		for k, v := range m.ReceivedQualifiedSharesS {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ReceivedQualifiedSharesT) > 0 {
+		for k, v := range m.ReceivedQualifiedSharesT {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ReceivedPeerCommitments) > 0 {
+		for k, v := range m.ReceivedPeerCommitments {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovCheckpoint(uint64(l))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.GroupPrivateKeyShare)
+	if l > 0 {
+		n += 2 + l + sovCheckpoint(uint64(l))
+	}
+	if len(m.PublicKeySharePoints) > 0 {
+		for _, b := range m.PublicKeySharePoints {
+			l = len(b)
+			n += 2 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.ReceivedValidPeerPublicKeySharePoints) > 0 {
+		for k, v := range m.ReceivedValidPeerPublicKeySharePoints {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovCheckpoint(uint64(l))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ExpectedMembersForReconstruction) > 0 {
+		l = 0
+		for _, e := range m.ExpectedMembersForReconstruction {
+			l += sovCheckpoint(uint64(e))
+		}
+		n += 2 + sovCheckpoint(uint64(l)) + l
+	}
+	if len(m.RevealedMisbehavedMembersShares) > 0 {
+		for _, e := range m.RevealedMisbehavedMembersShares {
+			l = e.Size()
+			n += 2 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.ReconstructedIndividualPrivateKeys) > 0 {
+		for k, v := range m.ReconstructedIndividualPrivateKeys {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ReconstructedIndividualPublicKeys) > 0 {
+		for k, v := range m.ReconstructedIndividualPublicKeys {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.GroupPublicKey)
+	if l > 0 {
+		n += 2 + l + sovCheckpoint(uint64(l))
+	}
+	if len(m.PreviousPhaseMessages) > 0 {
+		for _, e := range m.PreviousPhaseMessages {
+			l = e.Size()
+			n += 2 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.EvidenceSecretSharesAccusationsMessages) > 0 {
+		for _, b := range m.EvidenceSecretSharesAccusationsMessages {
+			l = len(b)
+			n += 2 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.EvidencePointsAccusationsMessages) > 0 {
+		for _, b := range m.EvidencePointsAccusationsMessages {
+			l = len(b)
+			n += 2 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Checkpoint_Points) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Points) > 0 {
+		for _, b := range m.Points {
+			l = len(b)
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Checkpoint_MisbehavedShares) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MisbehavedMemberID != 0 {
+		n += 1 + sovCheckpoint(uint64(m.MisbehavedMemberID))
+	}
+	if len(m.PeerSharesS) > 0 {
+		for k, v := range m.PeerSharesS {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 1 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *Checkpoint_PhaseMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	return n
+}
+
+func sovCheckpoint(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozCheckpoint(x uint64) (n int) {
+	return sovCheckpoint(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Checkpoint) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRevealedMisbehavedMembersShares := "[]*Checkpoint_MisbehavedShares{"
+	for _, f := range this.RevealedMisbehavedMembersShares {
+		repeatedStringForRevealedMisbehavedMembersShares += strings.Replace(fmt.Sprintf("%v", f), "Checkpoint_MisbehavedShares", "Checkpoint_MisbehavedShares", 1) + ","
+	}
+	repeatedStringForRevealedMisbehavedMembersShares += "}"
+	repeatedStringForPreviousPhaseMessages := "[]*Checkpoint_PhaseMessage{"
+	for _, f := range this.PreviousPhaseMessages {
+		repeatedStringForPreviousPhaseMessages += strings.Replace(fmt.Sprintf("%v", f), "Checkpoint_PhaseMessage", "Checkpoint_PhaseMessage", 1) + ","
+	}
+	repeatedStringForPreviousPhaseMessages += "}"
+	keysForEphemeralPrivateKeys := make([]uint32, 0, len(this.EphemeralPrivateKeys))
+	for k, _ := range this.EphemeralPrivateKeys {
+		keysForEphemeralPrivateKeys = append(keysForEphemeralPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForEphemeralPrivateKeys)
+	mapStringForEphemeralPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForEphemeralPrivateKeys {
+		mapStringForEphemeralPrivateKeys += fmt.Sprintf("%v: %v,", k, this.EphemeralPrivateKeys[k])
+	}
+	mapStringForEphemeralPrivateKeys += "}"
+	keysForReceivedQualifiedSharesS := make([]uint32, 0, len(this.ReceivedQualifiedSharesS))
+	for k, _ := range this.ReceivedQualifiedSharesS {
+		keysForReceivedQualifiedSharesS = append(keysForReceivedQualifiedSharesS, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedQualifiedSharesS)
+	mapStringForReceivedQualifiedSharesS := "map[uint32][]byte{"
+	for _, k := range keysForReceivedQualifiedSharesS {
+		mapStringForReceivedQualifiedSharesS += fmt.Sprintf("%v: %v,", k, this.ReceivedQualifiedSharesS[k])
+	}
+	mapStringForReceivedQualifiedSharesS += "}"
+	keysForReceivedQualifiedSharesT := make([]uint32, 0, len(this.ReceivedQualifiedSharesT))
+	for k, _ := range this.ReceivedQualifiedSharesT {
+		keysForReceivedQualifiedSharesT = append(keysForReceivedQualifiedSharesT, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedQualifiedSharesT)
+	mapStringForReceivedQualifiedSharesT := "map[uint32][]byte{"
+	for _, k := range keysForReceivedQualifiedSharesT {
+		mapStringForReceivedQualifiedSharesT += fmt.Sprintf("%v: %v,", k, this.ReceivedQualifiedSharesT[k])
+	}
+	mapStringForReceivedQualifiedSharesT += "}"
+	keysForReceivedPeerCommitments := make([]uint32, 0, len(this.ReceivedPeerCommitments))
+	for k, _ := range this.ReceivedPeerCommitments {
+		keysForReceivedPeerCommitments = append(keysForReceivedPeerCommitments, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedPeerCommitments)
+	mapStringForReceivedPeerCommitments := "map[uint32]*Checkpoint_Points{"
+	for _, k := range keysForReceivedPeerCommitments {
+		mapStringForReceivedPeerCommitments += fmt.Sprintf("%v: %v,", k, this.ReceivedPeerCommitments[k])
+	}
+	mapStringForReceivedPeerCommitments += "}"
+	keysForReceivedValidPeerPublicKeySharePoints := make([]uint32, 0, len(this.ReceivedValidPeerPublicKeySharePoints))
+	for k, _ := range this.ReceivedValidPeerPublicKeySharePoints {
+		keysForReceivedValidPeerPublicKeySharePoints = append(keysForReceivedValidPeerPublicKeySharePoints, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedValidPeerPublicKeySharePoints)
+	mapStringForReceivedValidPeerPublicKeySharePoints := "map[uint32]*Checkpoint_Points{"
+	for _, k := range keysForReceivedValidPeerPublicKeySharePoints {
+		mapStringForReceivedValidPeerPublicKeySharePoints += fmt.Sprintf("%v: %v,", k, this.ReceivedValidPeerPublicKeySharePoints[k])
+	}
+	mapStringForReceivedValidPeerPublicKeySharePoints += "}"
+	keysForReconstructedIndividualPrivateKeys := make([]uint32, 0, len(this.ReconstructedIndividualPrivateKeys))
+	for k, _ := range this.ReconstructedIndividualPrivateKeys {
+		keysForReconstructedIndividualPrivateKeys = append(keysForReconstructedIndividualPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReconstructedIndividualPrivateKeys)
+	mapStringForReconstructedIndividualPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForReconstructedIndividualPrivateKeys {
+		mapStringForReconstructedIndividualPrivateKeys += fmt.Sprintf("%v: %v,", k, this.ReconstructedIndividualPrivateKeys[k])
+	}
+	mapStringForReconstructedIndividualPrivateKeys += "}"
+	keysForReconstructedIndividualPublicKeys := make([]uint32, 0, len(this.ReconstructedIndividualPublicKeys))
+	for k, _ := range this.ReconstructedIndividualPublicKeys {
+		keysForReconstructedIndividualPublicKeys = append(keysForReconstructedIndividualPublicKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReconstructedIndividualPublicKeys)
+	mapStringForReconstructedIndividualPublicKeys := "map[uint32][]byte{"
+	for _, k := range keysForReconstructedIndividualPublicKeys {
+		mapStringForReconstructedIndividualPublicKeys += fmt.Sprintf("%v: %v,", k, this.ReconstructedIndividualPublicKeys[k])
+	}
+	mapStringForReconstructedIndividualPublicKeys += "}"
+	s := strings.Join([]string{`&Checkpoint{`,
+		`Phase:` + fmt.Sprintf("%v", this.Phase) + `,`,
+		`Initiated:` + fmt.Sprintf("%v", this.Initiated) + `,`,
+		`LastStateEndBlockHeight:` + fmt.Sprintf("%v", this.LastStateEndBlockHeight) + `,`,
+		`MemberID:` + fmt.Sprintf("%v", this.MemberID) + `,`,
+		`Seed:` + fmt.Sprintf("%v", this.Seed) + `,`,
+		`GroupSize:` + fmt.Sprintf("%v", this.GroupSize) + `,`,
+		`DishonestThreshold:` + fmt.Sprintf("%v", this.DishonestThreshold) + `,`,
+		`InactiveMemberIDs:` + fmt.Sprintf("%v", this.InactiveMemberIDs) + `,`,
+		`DisqualifiedMemberIDs:` + fmt.Sprintf("%v", this.DisqualifiedMemberIDs) + `,`,
+		`EvidenceEphemeralPublicKeyMessages:` + fmt.Sprintf("%v", this.EvidenceEphemeralPublicKeyMessages) + `,`,
+		`EvidencePeerSharesMessages:` + fmt.Sprintf("%v", this.EvidencePeerSharesMessages) + `,`,
+		`EphemeralPrivateKeys:` + mapStringForEphemeralPrivateKeys + `,`,
+		`SecretCoefficients:` + fmt.Sprintf("%v", this.SecretCoefficients) + `,`,
+		`SelfSecretShareS:` + fmt.Sprintf("%v", this.SelfSecretShareS) + `,`,
+		`SelfSecretShareT:` + fmt.Sprintf("%v", this.SelfSecretShareT) + `,`,
+		`ReceivedQualifiedSharesS:` + mapStringForReceivedQualifiedSharesS + `,`,
+		`ReceivedQualifiedSharesT:` + mapStringForReceivedQualifiedSharesT + `,`,
+		`ReceivedPeerCommitments:` + mapStringForReceivedPeerCommitments + `,`,
+		`GroupPrivateKeyShare:` + fmt.Sprintf("%v", this.GroupPrivateKeyShare) + `,`,
+		`PublicKeySharePoints:` + fmt.Sprintf("%v", this.PublicKeySharePoints) + `,`,
+		`ReceivedValidPeerPublicKeySharePoints:` + mapStringForReceivedValidPeerPublicKeySharePoints + `,`,
+		`ExpectedMembersForReconstruction:` + fmt.Sprintf("%v", this.ExpectedMembersForReconstruction) + `,`,
+		`RevealedMisbehavedMembersShares:` + repeatedStringForRevealedMisbehavedMembersShares + `,`,
+		`ReconstructedIndividualPrivateKeys:` + mapStringForReconstructedIndividualPrivateKeys + `,`,
+		`ReconstructedIndividualPublicKeys:` + mapStringForReconstructedIndividualPublicKeys + `,`,
+		`GroupPublicKey:` + fmt.Sprintf("%v", this.GroupPublicKey) + `,`,
+		`PreviousPhaseMessages:` + repeatedStringForPreviousPhaseMessages + `,`,
+		`EvidenceSecretSharesAccusationsMessages:` + fmt.Sprintf("%v", this.EvidenceSecretSharesAccusationsMessages) + `,`,
+		`EvidencePointsAccusationsMessages:` + fmt.Sprintf("%v", this.EvidencePointsAccusationsMessages) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Checkpoint_Points) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Checkpoint_Points{`,
+		`Points:` + fmt.Sprintf("%v", this.Points) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Checkpoint_MisbehavedShares) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForPeerSharesS := make([]uint32, 0, len(this.PeerSharesS))
+	for k, _ := range this.PeerSharesS {
+		keysForPeerSharesS = append(keysForPeerSharesS, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForPeerSharesS)
+	mapStringForPeerSharesS := "map[uint32][]byte{"
+	for _, k := range keysForPeerSharesS {
+		mapStringForPeerSharesS += fmt.Sprintf("%v: %v,", k, this.PeerSharesS[k])
+	}
+	mapStringForPeerSharesS += "}"
+	s := strings.Join([]string{`&Checkpoint_MisbehavedShares{`,
+		`MisbehavedMemberID:` + fmt.Sprintf("%v", this.MisbehavedMemberID) + `,`,
+		`PeerSharesS:` + mapStringForPeerSharesS + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Checkpoint_PhaseMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Checkpoint_PhaseMessage{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringCheckpoint(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Checkpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Checkpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Checkpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Phase", wireType)
+			}
+			m.Phase = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Phase |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Initiated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Initiated = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastStateEndBlockHeight", wireType)
+			}
+			m.LastStateEndBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastStateEndBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Seed = append(m.Seed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seed == nil {
+				m.Seed = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupSize", wireType)
+			}
+			m.GroupSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DishonestThreshold", wireType)
+			}
+			m.DishonestThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DishonestThreshold |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.InactiveMemberIDs = append(m.InactiveMemberIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.InactiveMemberIDs) == 0 {
+					m.InactiveMemberIDs = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.InactiveMemberIDs = append(m.InactiveMemberIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field InactiveMemberIDs", wireType)
+			}
+		case 9:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.DisqualifiedMemberIDs = append(m.DisqualifiedMemberIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.DisqualifiedMemberIDs) == 0 {
+					m.DisqualifiedMemberIDs = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.DisqualifiedMemberIDs = append(m.DisqualifiedMemberIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisqualifiedMemberIDs", wireType)
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvidenceEphemeralPublicKeyMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EvidenceEphemeralPublicKeyMessages = append(m.EvidenceEphemeralPublicKeyMessages, make([]byte, postIndex-iNdEx))
+			copy(m.EvidenceEphemeralPublicKeyMessages[len(m.EvidenceEphemeralPublicKeyMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvidencePeerSharesMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EvidencePeerSharesMessages = append(m.EvidencePeerSharesMessages, make([]byte, postIndex-iNdEx))
+			copy(m.EvidencePeerSharesMessages[len(m.EvidencePeerSharesMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EphemeralPrivateKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EphemeralPrivateKeys == nil {
+				m.EphemeralPrivateKeys = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EphemeralPrivateKeys[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretCoefficients", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecretCoefficients = append(m.SecretCoefficients, make([]byte, postIndex-iNdEx))
+			copy(m.SecretCoefficients[len(m.SecretCoefficients)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfSecretShareS", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SelfSecretShareS = append(m.SelfSecretShareS[:0], dAtA[iNdEx:postIndex]...)
+			if m.SelfSecretShareS == nil {
+				m.SelfSecretShareS = []byte{}
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfSecretShareT", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SelfSecretShareT = append(m.SelfSecretShareT[:0], dAtA[iNdEx:postIndex]...)
+			if m.SelfSecretShareT == nil {
+				m.SelfSecretShareT = []byte{}
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedQualifiedSharesS", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceivedQualifiedSharesS == nil {
+				m.ReceivedQualifiedSharesS = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceivedQualifiedSharesS[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedQualifiedSharesT", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceivedQualifiedSharesT == nil {
+				m.ReceivedQualifiedSharesT = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceivedQualifiedSharesT[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedPeerCommitments", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceivedPeerCommitments == nil {
+				m.ReceivedPeerCommitments = make(map[uint32]*Checkpoint_Points)
+			}
+			var mapkey uint32
+			var mapvalue *Checkpoint_Points
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &Checkpoint_Points{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceivedPeerCommitments[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupPrivateKeyShare", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupPrivateKeyShare = append(m.GroupPrivateKeyShare[:0], dAtA[iNdEx:postIndex]...)
+			if m.GroupPrivateKeyShare == nil {
+				m.GroupPrivateKeyShare = []byte{}
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKeySharePoints", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKeySharePoints = append(m.PublicKeySharePoints, make([]byte, postIndex-iNdEx))
+			copy(m.PublicKeySharePoints[len(m.PublicKeySharePoints)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedValidPeerPublicKeySharePoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceivedValidPeerPublicKeySharePoints == nil {
+				m.ReceivedValidPeerPublicKeySharePoints = make(map[uint32]*Checkpoint_Points)
+			}
+			var mapkey uint32
+			var mapvalue *Checkpoint_Points
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &Checkpoint_Points{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceivedValidPeerPublicKeySharePoints[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 22:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ExpectedMembersForReconstruction = append(m.ExpectedMembersForReconstruction, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ExpectedMembersForReconstruction) == 0 {
+					m.ExpectedMembersForReconstruction = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ExpectedMembersForReconstruction = append(m.ExpectedMembersForReconstruction, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedMembersForReconstruction", wireType)
+			}
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevealedMisbehavedMembersShares", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RevealedMisbehavedMembersShares = append(m.RevealedMisbehavedMembersShares, &Checkpoint_MisbehavedShares{})
+			if err := m.RevealedMisbehavedMembersShares[len(m.RevealedMisbehavedMembersShares)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReconstructedIndividualPrivateKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReconstructedIndividualPrivateKeys == nil {
+				m.ReconstructedIndividualPrivateKeys = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReconstructedIndividualPrivateKeys[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReconstructedIndividualPublicKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReconstructedIndividualPublicKeys == nil {
+				m.ReconstructedIndividualPublicKeys = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReconstructedIndividualPublicKeys[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupPublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupPublicKey = append(m.GroupPublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.GroupPublicKey == nil {
+				m.GroupPublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousPhaseMessages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousPhaseMessages = append(m.PreviousPhaseMessages, &Checkpoint_PhaseMessage{})
+			if err := m.PreviousPhaseMessages[len(m.PreviousPhaseMessages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvidenceSecretSharesAccusationsMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EvidenceSecretSharesAccusationsMessages = append(m.EvidenceSecretSharesAccusationsMessages, make([]byte, postIndex-iNdEx))
+			copy(m.EvidenceSecretSharesAccusationsMessages[len(m.EvidenceSecretSharesAccusationsMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 29:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvidencePointsAccusationsMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EvidencePointsAccusationsMessages = append(m.EvidencePointsAccusationsMessages, make([]byte, postIndex-iNdEx))
+			copy(m.EvidencePointsAccusationsMessages[len(m.EvidencePointsAccusationsMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Checkpoint_Points) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Points: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Points: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Points", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Points = append(m.Points, make([]byte, postIndex-iNdEx))
+			copy(m.Points[len(m.Points)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Checkpoint_MisbehavedShares) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MisbehavedShares: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MisbehavedShares: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MisbehavedMemberID", wireType)
+			}
+			m.MisbehavedMemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MisbehavedMemberID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerSharesS", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PeerSharesS == nil {
+				m.PeerSharesS = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.PeerSharesS[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Checkpoint_PhaseMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PhaseMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PhaseMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCheckpoint(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthCheckpoint
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupCheckpoint
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthCheckpoint
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthCheckpoint        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCheckpoint          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupCheckpoint = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,629 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pb/evidence.proto
+
+package pb
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type Evidence struct {
+	MemberID                        uint32   `protobuf:"varint,1,opt,name=memberID,proto3" json:"memberID,omitempty"`
+	EphemeralPublicKeyMessages      [][]byte `protobuf:"bytes,2,rep,name=ephemeralPublicKeyMessages,proto3" json:"ephemeralPublicKeyMessages,omitempty"`
+	PeerSharesMessages              [][]byte `protobuf:"bytes,3,rep,name=peerSharesMessages,proto3" json:"peerSharesMessages,omitempty"`
+	SecretSharesAccusationsMessages [][]byte `protobuf:"bytes,4,rep,name=secretSharesAccusationsMessages,proto3" json:"secretSharesAccusationsMessages,omitempty"`
+	PointsAccusationsMessages       [][]byte `protobuf:"bytes,5,rep,name=pointsAccusationsMessages,proto3" json:"pointsAccusationsMessages,omitempty"`
+}
+
+func (m *Evidence) Reset()      { *m = Evidence{} }
+func (*Evidence) ProtoMessage() {}
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ba8a26b4576199e6, []int{0}
+}
+func (m *Evidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evidence.Merge(m, src)
+}
+func (m *Evidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evidence proto.InternalMessageInfo
+
+func (m *Evidence) GetMemberID() uint32 {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
+}
+
+func (m *Evidence) GetEphemeralPublicKeyMessages() [][]byte {
+	if m != nil {
+		return m.EphemeralPublicKeyMessages
+	}
+	return nil
+}
+
+func (m *Evidence) GetPeerSharesMessages() [][]byte {
+	if m != nil {
+		return m.PeerSharesMessages
+	}
+	return nil
+}
+
+func (m *Evidence) GetSecretSharesAccusationsMessages() [][]byte {
+	if m != nil {
+		return m.SecretSharesAccusationsMessages
+	}
+	return nil
+}
+
+func (m *Evidence) GetPointsAccusationsMessages() [][]byte {
+	if m != nil {
+		return m.PointsAccusationsMessages
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Evidence)(nil), "gjkr.Evidence")
+}
+
+func init() { proto.RegisterFile("pb/evidence.proto", fileDescriptor_ba8a26b4576199e6) }
+
+var fileDescriptor_ba8a26b4576199e6 = []byte{
+	// 247 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2c, 0x48, 0xd2, 0x4f,
+	0x2d, 0xcb, 0x4c, 0x49, 0xcd, 0x4b, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x49,
+	0xcf, 0xca, 0x2e, 0x52, 0x9a, 0xc7, 0xc4, 0xc5, 0xe1, 0x0a, 0x95, 0x10, 0x92, 0xe2, 0xe2, 0xc8,
+	0x4d, 0xcd, 0x4d, 0x4a, 0x2d, 0xf2, 0x74, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0d, 0x82, 0xf3,
+	0x85, 0xec, 0xb8, 0xa4, 0x52, 0x0b, 0x32, 0x52, 0x73, 0x53, 0x8b, 0x12, 0x73, 0x02, 0x4a, 0x93,
+	0x72, 0x32, 0x93, 0xbd, 0x53, 0x2b, 0x7d, 0x53, 0x8b, 0x8b, 0x13, 0xd3, 0x53, 0x8b, 0x25, 0x98,
+	0x14, 0x98, 0x35, 0x78, 0x82, 0xf0, 0xa8, 0x10, 0xd2, 0xe3, 0x12, 0x2a, 0x48, 0x4d, 0x2d, 0x0a,
+	0xce, 0x48, 0x2c, 0x4a, 0x2d, 0x86, 0xeb, 0x63, 0x06, 0xeb, 0xc3, 0x22, 0x23, 0xe4, 0xc1, 0x25,
+	0x5f, 0x9c, 0x9a, 0x5c, 0x94, 0x5a, 0x02, 0x11, 0x77, 0x4c, 0x4e, 0x2e, 0x2d, 0x4e, 0x2c, 0xc9,
+	0xcc, 0xcf, 0x43, 0x68, 0x66, 0x01, 0x6b, 0x26, 0xa4, 0x4c, 0xc8, 0x86, 0x4b, 0xb2, 0x20, 0x3f,
+	0x33, 0xaf, 0x04, 0xab, 0x19, 0xac, 0x60, 0x33, 0x70, 0x2b, 0x70, 0xb2, 0xb8, 0xf0, 0x50, 0x8e,
+	0xe1, 0xc6, 0x43, 0x39, 0x86, 0x0f, 0x0f, 0xe5, 0x18, 0x1b, 0x1e, 0xc9, 0x31, 0xae, 0x78, 0x24,
+	0xc7, 0x78, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xbe, 0x78,
+	0x24, 0xc7, 0xf0, 0xe1, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc,
+	0x78, 0x2c, 0xc7, 0x10, 0xc5, 0x54, 0x90, 0x94, 0xc4, 0x06, 0x0e, 0x67, 0x63, 0xc0, 0x00, 0x50,
+	0x82, 0xa1, 0x73, 0x7c, 0x01, 0x00, 0x00,
+}
+
+func (this *Evidence) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Evidence)
+	if !ok {
+		that2, ok := that.(Evidence)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MemberID != that1.MemberID {
+		return false
+	}
+	if len(this.EphemeralPublicKeyMessages) != len(that1.EphemeralPublicKeyMessages) {
+		return false
+	}
+	for i := range this.EphemeralPublicKeyMessages {
+		if !bytes.Equal(this.EphemeralPublicKeyMessages[i], that1.EphemeralPublicKeyMessages[i]) {
+			return false
+		}
+	}
+	if len(this.PeerSharesMessages) != len(that1.PeerSharesMessages) {
+		return false
+	}
+	for i := range this.PeerSharesMessages {
+		if !bytes.Equal(this.PeerSharesMessages[i], that1.PeerSharesMessages[i]) {
+			return false
+		}
+	}
+	if len(this.SecretSharesAccusationsMessages) != len(that1.SecretSharesAccusationsMessages) {
+		return false
+	}
+	for i := range this.SecretSharesAccusationsMessages {
+		if !bytes.Equal(this.SecretSharesAccusationsMessages[i], that1.SecretSharesAccusationsMessages[i]) {
+			return false
+		}
+	}
+	if len(this.PointsAccusationsMessages) != len(that1.PointsAccusationsMessages) {
+		return false
+	}
+	for i := range this.PointsAccusationsMessages {
+		if !bytes.Equal(this.PointsAccusationsMessages[i], that1.PointsAccusationsMessages[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Evidence) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.Evidence{")
+	s = append(s, "MemberID: "+fmt.Sprintf("%#v", this.MemberID)+",\n")
+	s = append(s, "EphemeralPublicKeyMessages: "+fmt.Sprintf("%#v", this.EphemeralPublicKeyMessages)+",\n")
+	s = append(s, "PeerSharesMessages: "+fmt.Sprintf("%#v", this.PeerSharesMessages)+",\n")
+	s = append(s, "SecretSharesAccusationsMessages: "+fmt.Sprintf("%#v", this.SecretSharesAccusationsMessages)+",\n")
+	s = append(s, "PointsAccusationsMessages: "+fmt.Sprintf("%#v", this.PointsAccusationsMessages)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringEvidence(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *Evidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Evidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PointsAccusationsMessages) > 0 {
+		for iNdEx := len(m.PointsAccusationsMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PointsAccusationsMessages[iNdEx])
+			copy(dAtA[i:], m.PointsAccusationsMessages[iNdEx])
+			i = encodeVarintEvidence(dAtA, i, uint64(len(m.PointsAccusationsMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.SecretSharesAccusationsMessages) > 0 {
+		for iNdEx := len(m.SecretSharesAccusationsMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SecretSharesAccusationsMessages[iNdEx])
+			copy(dAtA[i:], m.SecretSharesAccusationsMessages[iNdEx])
+			i = encodeVarintEvidence(dAtA, i, uint64(len(m.SecretSharesAccusationsMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.PeerSharesMessages) > 0 {
+		for iNdEx := len(m.PeerSharesMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PeerSharesMessages[iNdEx])
+			copy(dAtA[i:], m.PeerSharesMessages[iNdEx])
+			i = encodeVarintEvidence(dAtA, i, uint64(len(m.PeerSharesMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.EphemeralPublicKeyMessages) > 0 {
+		for iNdEx := len(m.EphemeralPublicKeyMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EphemeralPublicKeyMessages[iNdEx])
+			copy(dAtA[i:], m.EphemeralPublicKeyMessages[iNdEx])
+			i = encodeVarintEvidence(dAtA, i, uint64(len(m.EphemeralPublicKeyMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintEvidence(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintEvidence(dAtA []byte, offset int, v uint64) int {
+	offset -= sovEvidence(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Evidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		n += 1 + sovEvidence(uint64(m.MemberID))
+	}
+	if len(m.EphemeralPublicKeyMessages) > 0 {
+		for _, b := range m.EphemeralPublicKeyMessages {
+			l = len(b)
+			n += 1 + l + sovEvidence(uint64(l))
+		}
+	}
+	if len(m.PeerSharesMessages) > 0 {
+		for _, b := range m.PeerSharesMessages {
+			l = len(b)
+			n += 1 + l + sovEvidence(uint64(l))
+		}
+	}
+	if len(m.SecretSharesAccusationsMessages) > 0 {
+		for _, b := range m.SecretSharesAccusationsMessages {
+			l = len(b)
+			n += 1 + l + sovEvidence(uint64(l))
+		}
+	}
+	if len(m.PointsAccusationsMessages) > 0 {
+		for _, b := range m.PointsAccusationsMessages {
+			l = len(b)
+			n += 1 + l + sovEvidence(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovEvidence(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozEvidence(x uint64) (n int) {
+	return sovEvidence(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Evidence) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Evidence{`,
+		`MemberID:` + fmt.Sprintf("%v", this.MemberID) + `,`,
+		`EphemeralPublicKeyMessages:` + fmt.Sprintf("%v", this.EphemeralPublicKeyMessages) + `,`,
+		`PeerSharesMessages:` + fmt.Sprintf("%v", this.PeerSharesMessages) + `,`,
+		`SecretSharesAccusationsMessages:` + fmt.Sprintf("%v", this.SecretSharesAccusationsMessages) + `,`,
+		`PointsAccusationsMessages:` + fmt.Sprintf("%v", this.PointsAccusationsMessages) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringEvidence(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Evidence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvidence
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EphemeralPublicKeyMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EphemeralPublicKeyMessages = append(m.EphemeralPublicKeyMessages, make([]byte, postIndex-iNdEx))
+			copy(m.EphemeralPublicKeyMessages[len(m.EphemeralPublicKeyMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerSharesMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerSharesMessages = append(m.PeerSharesMessages, make([]byte, postIndex-iNdEx))
+			copy(m.PeerSharesMessages[len(m.PeerSharesMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretSharesAccusationsMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecretSharesAccusationsMessages = append(m.SecretSharesAccusationsMessages, make([]byte, postIndex-iNdEx))
+			copy(m.SecretSharesAccusationsMessages[len(m.SecretSharesAccusationsMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PointsAccusationsMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PointsAccusationsMessages = append(m.PointsAccusationsMessages, make([]byte, postIndex-iNdEx))
+			copy(m.PointsAccusationsMessages[len(m.PointsAccusationsMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvidence(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipEvidence(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowEvidence
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthEvidence
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupEvidence
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthEvidence
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthEvidence        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowEvidence          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupEvidence = fmt.Errorf("proto: unexpected end of group")
+)
@@ -54,7 +54,13 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // when DKG protocol should start.
 // If the generation is successful, it returns a threshold group member which
 // can participate in the signing group; if the generation fails, it returns an
-// error.
+// error. If the checkpoint handler is not nil, it is called with a protocol
+// checkpoint each time the member enters a new protocol state. If the phase
+// handler is not nil, it is called with a report of each completed protocol
+// phase. If the evidence handler is not nil, it is called with the member's
+// evidence log once the execution is over, no matter if it succeeded. If
+// the machine registry is not nil, the protocol state is reported to it for
+// as long as the protocol is executed.
 func Execute(
 	memberIndex group.MemberIndex,
 	groupSize int,
@@ -64,6 +70,10 @@ func Execute(
 	seed *big.Int,
 	membershipValidator group.MembershipValidator,
 	startBlockHeight uint64,
+	checkpointHandler CheckpointHandler,
+	phaseHandler PhaseHandler,
+	evidenceHandler EvidenceHandler,
+	machines *state.Registry,
 ) (*Result, uint64, error) {
 	logger.Debugf("[member:%v] initializing member", memberIndex)
 
@@ -78,18 +88,236 @@ func Execute(
 		return nil, 0, fmt.Errorf("cannot create a new member: [%v]", err)
 	}
 
+	return execute(
+		member,
+		blockCounter,
+		channel,
+		seed,
+		startBlockHeight,
+		checkpointHandler,
+		phaseHandler,
+		evidenceHandler,
+		machines,
+	)
+}
+
+// ExecuteRefresh runs the GJKR protocol to refresh shares of an existing group
+// private key. It is executed by members of a registered group over the
+// group's broadcast channel, in the same way as the distributed key
+// generation, except that each member deals a sharing of zero. Private key
+// share and public key shares of the returned result are not shares of a new
+// key but deltas which have to be added to the existing shares of the group
+// private key. Since all qualified members deal sharings of zero, the group
+// public key of the result has to be the point at infinity; otherwise, the
+// refresh fails. If the machine registry is not nil, the protocol state is
+// reported to it for as long as the protocol is executed.
+func ExecuteRefresh(
+	memberIndex group.MemberIndex,
+	groupSize int,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	dishonestThreshold int,
+	seed *big.Int,
+	membershipValidator group.MembershipValidator,
+	startBlockHeight uint64,
+	machines *state.Registry,
+) (*Result, uint64, error) {
+	logger.Debugf("[member:%v] initializing refreshing member", memberIndex)
+
+	member, err := NewMember(
+		memberIndex,
+		groupSize,
+		dishonestThreshold,
+		membershipValidator,
+		seed,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot create a new member: [%v]", err)
+	}
+	member.zeroSharing = true
+
+	result, endBlockHeight, err := execute(
+		member,
+		blockCounter,
+		channel,
+		seed,
+		startBlockHeight,
+		nil,
+		nil,
+		nil,
+		machines,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !isInfinity(result.GroupPublicKey) {
+		return nil, 0, fmt.Errorf(
+			"sharings dealt by qualified members do not sum up to zero",
+		)
+	}
+
+	return result, endBlockHeight, nil
+}
+
+func execute(
+	member *LocalMember,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	seed *big.Int,
+	startBlockHeight uint64,
+	checkpointHandler CheckpointHandler,
+	phaseHandler PhaseHandler,
+	evidenceHandler EvidenceHandler,
+	machines *state.Registry,
+) (*Result, uint64, error) {
 	initialState := &ephemeralKeyPairGenerationState{
 		channel: channel,
 		member:  member.InitializeEphemeralKeysGeneration(),
 	}
 
 	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	if checkpointHandler != nil {
+		stateMachine.SetCheckpointer(&checkpointer{seed, checkpointHandler})
+	}
+	if phaseHandler != nil {
+		stateMachine.SetObserver(newPhaseObserver(member.group, phaseHandler))
+	}
+	if machines != nil {
+		stateMachine.SetRegistry(machines)
+	}
 
 	lastState, endBlockHeight, err := stateMachine.Execute(startBlockHeight)
+	handleEvidence(member, evidenceHandler)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	return finalize(lastState, endBlockHeight)
+}
+
+// Resume continues the GJKR distributed key generation protocol from the given
+// checkpoint produced by the checkpoint handler passed to Execute or Resume.
+// The execution is resumed at the checkpointed protocol state. If the member
+// cannot cleanly rejoin the protocol, because the checkpointed state is over
+// or it is not known if the member has already sent its messages for that
+// state, Resume returns an error and does not execute the protocol. Just like
+// in Execute, the phase handler, if not nil, is called with a report of each
+// completed protocol phase, the evidence handler, if not nil, is called with
+// the member's evidence log once the execution is over and the protocol state
+// is reported to the machine registry, if not nil.
+func Resume(
+	checkpoint []byte,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	membershipValidator group.MembershipValidator,
+	checkpointHandler CheckpointHandler,
+	phaseHandler PhaseHandler,
+	evidenceHandler EvidenceHandler,
+	machines *state.Registry,
+) (*Result, uint64, error) {
+	restored, err := restoreCheckpoint(checkpoint, channel, membershipValidator)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot restore checkpoint: [%v]", err)
+	}
+
+	currentBlockHeight, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	err = validateRejoin(restored, currentBlockHeight)
+	if err != nil {
+		return nil, 0, fmt.Errorf("clean rejoin is impossible: [%v]", err)
+	}
+
+	// Group public key shares are computed asynchronously when the combination
+	// state is initiated and are not a part of the checkpoint.
+	switch s := restored.state.(type) {
+	case *combinationState:
+		if restored.initiated {
+			s.member.ComputeGroupPublicKeyShares()
+		}
+	case *finalizationState:
+		s.member.ComputeGroupPublicKeyShares()
+	}
+
+	logger.Infof(
+		"[member:%v] resuming protocol at state [%T] entered at block [%v]",
+		restored.state.MemberIndex(),
+		restored.state,
+		restored.lastStateEndBlockHeight,
+	)
+
+	stateMachine := state.NewMachine(channel, blockCounter, restored.state)
+	if checkpointHandler != nil {
+		stateMachine.SetCheckpointer(
+			&checkpointer{restored.seed, checkpointHandler},
+		)
+	}
+	if phaseHandler != nil {
+		stateMachine.SetObserver(
+			newPhaseObserver(restored.member.group, phaseHandler),
+		)
+	}
+	if machines != nil {
+		stateMachine.SetRegistry(machines)
+	}
+
+	lastState, endBlockHeight, err := stateMachine.Resume(
+		restored.lastStateEndBlockHeight,
+		restored.initiated,
+	)
+	handleEvidence(restored.member, evidenceHandler)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return finalize(lastState, endBlockHeight)
+}
+
+// validateRejoin checks if the member can cleanly rejoin the protocol at the
+// restored state at the given block. Messages of the restored state are
+// retransmitted by other members only until the state is over, so the member
+// has to rejoin before that. The final state does not exchange any messages
+// and can always be rejoined.
+func validateRejoin(restored *restoredCheckpoint, currentBlockHeight uint64) error {
+	if _, ok := restored.state.(*finalizationState); ok {
+		return nil
+	}
+
+	initiateBlockHeight := restored.lastStateEndBlockHeight +
+		restored.state.DelayBlocks()
+	endBlockHeight := initiateBlockHeight + restored.state.ActiveBlocks()
+
+	if currentBlockHeight > endBlockHeight {
+		return fmt.Errorf(
+			"state [%T] ended at block [%v] and current block is [%v]",
+			restored.state,
+			endBlockHeight,
+			currentBlockHeight,
+		)
+	}
+
+	// States generating random values must not be initiated for the second
+	// time if the member could have already broadcast the values generated
+	// during the first initiation.
+	switch restored.state.(type) {
+	case *ephemeralKeyPairGenerationState, *commitmentState:
+		if !restored.initiated && currentBlockHeight > initiateBlockHeight {
+			return fmt.Errorf(
+				"state [%T] could have been initiated at block [%v] "+
+					"without being checkpointed",
+				restored.state,
+				initiateBlockHeight,
+			)
+		}
+	}
+
+	return nil
+}
+
+func finalize(lastState state.State, endBlockHeight uint64) (*Result, uint64, error) {
 	finalizationState, ok := lastState.(*finalizationState)
 	if !ok {
 		return nil, 0, fmt.Errorf("execution ended on state: %T", lastState)
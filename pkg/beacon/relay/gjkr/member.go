@@ -25,6 +25,11 @@ type memberCore struct {
 
 	// Cryptographic protocol parameters, the same for all members in the group.
 	protocolParameters *protocolParameters
+
+	// Set when the member refreshes shares of an existing group private key.
+	// Such a member deals a sharing of zero instead of a sharing of a random
+	// secret.
+	zeroSharing bool
 }
 
 // LocalMember represents one member in a threshold group, prior to the
@@ -238,6 +243,7 @@ func NewMember(
 			membershipValidator,
 			newDkgEvidenceLog(),
 			newProtocolParameters(seed),
+			false,
 		},
 	}, nil
 }
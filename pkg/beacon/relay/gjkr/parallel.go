@@ -0,0 +1,48 @@
+package gjkr
+
+import (
+	"runtime"
+	"sync"
+)
+
+// verificationWorkers is the maximum number of goroutines verifying shares
+// and public key share points of peer members at the same time. Verification
+// is CPU-bound, so there is no point in having more workers than goroutines
+// which can be executed simultaneously.
+var verificationWorkers = runtime.GOMAXPROCS(0)
+
+// parallelize calls the given function for each index in [0, count) on a pool
+// of at most verificationWorkers goroutines and returns once all the calls
+// completed. The function must be safe for concurrent use; to keep results
+// deterministic, it should only write the outcome for the given index to
+// a slot reserved for that index.
+func parallelize(count int, fn func(index int)) {
+	workers := verificationWorkers
+	if workers > count {
+		workers = count
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				fn(index)
+			}
+		}()
+	}
+
+	for index := 0; index < count; index++ {
+		indexes <- index
+	}
+	close(indexes)
+
+	wg.Wait()
+}
@@ -0,0 +1,86 @@
+package gjkr
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestParallelize(t *testing.T) {
+	var tests = map[string]struct {
+		workers int
+		count   int
+	}{
+		"more tasks than workers": {
+			workers: 3,
+			count:   50,
+		},
+		"less tasks than workers": {
+			workers: 8,
+			count:   2,
+		},
+		"single worker": {
+			workers: 1,
+			count:   10,
+		},
+		"no tasks": {
+			workers: 4,
+			count:   0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			defer withVerificationWorkers(test.workers)()
+
+			var mutex sync.Mutex
+			running := 0
+			maxRunning := 0
+
+			calls := make([]int, test.count)
+			parallelize(test.count, func(index int) {
+				mutex.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mutex.Unlock()
+
+				calls[index]++
+
+				mutex.Lock()
+				running--
+				mutex.Unlock()
+			})
+
+			for index, count := range calls {
+				if count != 1 {
+					t.Errorf(
+						"unexpected number of calls for index [%v]\n"+
+							"expected: %v\nactual:   %v\n",
+						index,
+						1,
+						count,
+					)
+				}
+			}
+
+			if maxRunning > test.workers {
+				t.Errorf(
+					"too many concurrent calls\nexpected: <= %v\nactual:   %v\n",
+					test.workers,
+					maxRunning,
+				)
+			}
+		})
+	}
+}
+
+// withVerificationWorkers sets the number of verification workers and returns
+// a function restoring the previous number.
+func withVerificationWorkers(workers int) func() {
+	previous := verificationWorkers
+	verificationWorkers = workers
+	return func() {
+		verificationWorkers = previous
+	}
+}
@@ -154,6 +154,10 @@ func (sm *SymmetricKeyGeneratingMember) isValidEphemeralPublicKeyMessage(
 // If there are no symmetric keys established with all other group members,
 // function yields an error.
 //
+// When the member refreshes shares of an existing group private key, the
+// constant coefficient of the first polynomial is zero, so the member deals
+// a sharing of zero.
+//
 // See Phase 3 of the protocol specification.
 func (cm *CommittingMember) CalculateMembersSharesAndCommitments() (
 	*PeerSharesMessage,
@@ -176,6 +180,10 @@ func (cm *CommittingMember) CalculateMembersSharesAndCommitments() (
 		)
 	}
 
+	if cm.zeroSharing {
+		coefficientsA[0] = big.NewInt(0)
+	}
+
 	cm.secretCoefficients = coefficientsA
 
 	// Calculate shares for other group members by evaluating polynomials
@@ -324,8 +332,13 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 		}
 	}
 
+	// Shares are decrypted and verified against commitments for all senders
+	// upfront, in parallel. Outcomes are then applied in the order of
+	// received messages, the same way as if they were verified one by one.
+	verifications := cvm.verifyReceivedShares(sharesMessages, commitmentsMessages)
+
 	accusedMembersKeys := make(map[group.MemberIndex]*ephemeral.PrivateKey)
-	for _, commitmentsMessage := range commitmentsMessages {
+	for i, commitmentsMessage := range commitmentsMessages {
 		if !cvm.isValidMemberCommitmentsMessage(commitmentsMessage) {
 			logger.Warningf(
 				"[member:%v] member [%v] disqualified because of "+
@@ -364,27 +377,23 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 				// in the second phase and we no longer accept messages from them.
 				// If the symmetric key is not available, we consider it as a
 				// fatal error. Such a situation should never happen.
-				symmetricKey, hasKey := cvm.symmetricKeys[sharesMessage.senderID]
-				if !hasKey {
+				if _, hasKey := cvm.symmetricKeys[sharesMessage.senderID]; !hasKey {
 					return nil, fmt.Errorf(
 						"no symmetric key for sender %v",
 						sharesMessage.senderID,
 					)
 				}
 
-				// Decrypt shares using symmetric key established with sender.
-				// Message validation performed earlier in this phase ensures
-				// that shares for all group members (including the current one)
-				// are in the message.
+				// Shares have been decrypted using symmetric key established
+				// with sender. Message validation performed earlier in this
+				// phase ensures that shares for all group members (including
+				// the current one) are in the message.
 				// The only reason possible why shares could not be decrypted
 				// here is because they are broken. If shares are broken,
 				// sender is disqualified and an accusation against the sender
 				// is published.
-				shareS, shareT, err := sharesMessage.decryptShares(
-					cvm.ID,
-					symmetricKey,
-				)
-				if err != nil {
+				verification := verifications[i]
+				if verification.decryptionErr != nil {
 					logger.Warningf(
 						"[member:%v] member [%v] disqualified because "+
 							"could not decrypt shares received from them",
@@ -397,12 +406,7 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 					break
 				}
 
-				if !cvm.areSharesValidAgainstCommitments(
-					shareS,                         // s_ji
-					shareT,                         // t_ji
-					commitmentsMessage.commitments, // C_j
-					cvm.ID,                         // i
-				) {
+				if !verification.valid {
 					logger.Warningf(
 						"[member:%v] shares from member [%v] invalid against "+
 							"commitments; disqualifying and accusing the member",
@@ -414,8 +418,8 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 						cvm.ephemeralKeyPairs[commitmentsMessage.senderID].PrivateKey
 					break
 				}
-				cvm.receivedQualifiedSharesS[commitmentsMessage.senderID] = shareS
-				cvm.receivedQualifiedSharesT[commitmentsMessage.senderID] = shareT
+				cvm.receivedQualifiedSharesS[commitmentsMessage.senderID] = verification.shareS
+				cvm.receivedQualifiedSharesT[commitmentsMessage.senderID] = verification.shareT
 				break
 			}
 		}
@@ -432,6 +436,66 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 	}, nil
 }
 
+// sharesVerification is the outcome of decrypting shares received from a peer
+// member and verifying them against commitments of that member.
+type sharesVerification struct {
+	shareS, shareT *big.Int // s_ji, t_ji
+	decryptionErr  error
+	valid          bool
+}
+
+// verifyReceivedShares decrypts shares and verifies them against commitments
+// for each commitments message with a matching shares message for which
+// a symmetric key is established. Verifications are executed in parallel and
+// returned in a slice aligned with commitments messages; the slot is nil if no
+// verification has been executed for the given commitments message.
+func (cvm *CommitmentsVerifyingMember) verifyReceivedShares(
+	sharesMessages []*PeerSharesMessage,
+	commitmentsMessages []*MemberCommitmentsMessage,
+) []*sharesVerification {
+	sharesMessagesToVerify := make([]*PeerSharesMessage, len(commitmentsMessages))
+	for i, commitmentsMessage := range commitmentsMessages {
+		for _, sharesMessage := range sharesMessages {
+			if sharesMessage.senderID == commitmentsMessage.senderID {
+				sharesMessagesToVerify[i] = sharesMessage
+				break
+			}
+		}
+	}
+
+	verifications := make([]*sharesVerification, len(commitmentsMessages))
+	parallelize(len(commitmentsMessages), func(i int) {
+		sharesMessage := sharesMessagesToVerify[i]
+		if sharesMessage == nil {
+			return
+		}
+
+		symmetricKey, hasKey := cvm.symmetricKeys[sharesMessage.senderID]
+		if !hasKey {
+			return
+		}
+
+		shareS, shareT, err := sharesMessage.decryptShares(cvm.ID, symmetricKey)
+		if err != nil {
+			verifications[i] = &sharesVerification{decryptionErr: err}
+			return
+		}
+
+		verifications[i] = &sharesVerification{
+			shareS: shareS,
+			shareT: shareT,
+			valid: cvm.areSharesValidAgainstCommitments(
+				shareS,                             // s_ji
+				shareT,                             // t_ji
+				commitmentsMessages[i].commitments, // C_j
+				cvm.ID,                             // i
+			),
+		}
+	})
+
+	return verifications
+}
+
 // isValidMemberCommitmentsMessage validates a given MemberCommitmentsMessage.
 // Message is considered valid if it contains an expected number of commitments.
 func (cvm *CommitmentsVerifyingMember) isValidMemberCommitmentsMessage(
@@ -559,6 +623,15 @@ func (sjm *SharesJustifyingMember) ResolveSecretSharesAccusationsMessages(
 	messages []*SecretSharesAccusationsMessage,
 ) error {
 	for _, message := range messages {
+		err := sjm.evidenceLog.PutSecretSharesAccusationsMessage(message)
+		if err != nil {
+			logger.Errorf(
+				"could not put secret shares accusations message to the "+
+					"evidence log: [%v]",
+				err,
+			)
+		}
+
 		accuserID := message.senderID
 		for accusedID, revealedAccuserPrivateKey := range message.accusedMembersKeys {
 			isAccusedIDValid := accusedID > 0 && int(accusedID) <= sjm.group.GroupSize()
@@ -841,10 +914,27 @@ func (sm *SharingMember) CalculatePublicKeySharePoints() *MemberPublicKeySharePo
 func (sm *SharingMember) VerifyPublicKeySharePoints(
 	messages []*MemberPublicKeySharePointsMessage,
 ) (*PointsAccusationsMessage, error) {
+	// Public key share points of all senders are verified upfront, in
+	// parallel. Outcomes are then applied in the order of received messages,
+	// the same way as if they were verified one by one.
+	validPoints := make([]bool, len(messages))
+	parallelize(len(messages), func(i int) {
+		shareS, ok := sm.receivedQualifiedSharesS[messages[i].senderID]
+		if !ok {
+			return
+		}
+
+		validPoints[i] = sm.isShareValidAgainstPublicKeySharePoints(
+			sm.ID,
+			shareS,
+			messages[i].publicKeySharePoints,
+		)
+	})
+
 	accusedMembersKeys := make(map[group.MemberIndex]*ephemeral.PrivateKey)
 	// `product = Π (A_j[k] ^ (i^k)) mod p` for k in [0..T],
 	// where: j is sender's ID, i is current member ID, T is dishonest threshold.
-	for _, message := range messages {
+	for i, message := range messages {
 		if !sm.isValidMemberPublicKeySharePointsMessage(message) {
 			logger.Warningf(
 				"[member:%v] member [%v] disqualified because of "+
@@ -856,11 +946,7 @@ func (sm *SharingMember) VerifyPublicKeySharePoints(
 			continue
 		}
 
-		if !sm.isShareValidAgainstPublicKeySharePoints(
-			sm.ID,
-			sm.receivedQualifiedSharesS[message.senderID],
-			message.publicKeySharePoints,
-		) {
+		if !validPoints[i] {
 			logger.Warningf(
 				"[member:%v] member [%v] disqualified because of "+
 					"invalid public key share points",
@@ -882,7 +968,8 @@ func (sm *SharingMember) VerifyPublicKeySharePoints(
 
 // isValidMemberPublicKeySharePointsMessage validates a given
 // MemberPublicKeySharePointsMessage. Message is considered valid if it
-// contains an expected number of public key share points.
+// contains an expected number of public key share points and, if shares are
+// refreshed, the point of the constant coefficient is the point at infinity.
 func (sm *SharingMember) isValidMemberPublicKeySharePointsMessage(
 	message *MemberPublicKeySharePointsMessage,
 ) bool {
@@ -904,9 +991,28 @@ func (sm *SharingMember) isValidMemberPublicKeySharePointsMessage(
 		return false
 	}
 
+	// Member refreshing shares deals a sharing of zero, so the public key
+	// share point of the constant coefficient has to be the point at infinity.
+	if sm.zeroSharing && !isInfinity(message.publicKeySharePoints[0]) {
+		logger.Warningf(
+			"[member:%v] member [%v] sent a message with a public key share "+
+				"point of a non-zero constant coefficient",
+			sm.ID,
+			message.senderID,
+		)
+		return false
+	}
+
 	return true
 }
 
+// isInfinity checks if the given G2 point is the point at infinity, which
+// is the public key of a zero private key.
+func isInfinity(point *bn256.G2) bool {
+	infinity := new(bn256.G2).ScalarBaseMult(big.NewInt(0))
+	return point.String() == infinity.String()
+}
+
 // isShareValidAgainstPublicKeySharePoints verifies if public key share points
 // are valid for passed share S generated for the specific member, denoted as
 // a share receiver.
@@ -996,6 +1102,15 @@ func (pjm *PointsJustifyingMember) ResolvePublicKeySharePointsAccusationsMessage
 	messages []*PointsAccusationsMessage,
 ) error {
 	for _, message := range messages {
+		err := pjm.evidenceLog.PutPointsAccusationsMessage(message)
+		if err != nil {
+			logger.Errorf(
+				"could not put points accusations message to the "+
+					"evidence log: [%v]",
+				err,
+			)
+		}
+
 		accuserID := message.senderID
 		for accusedID, revealedAccuserPrivateKey := range message.accusedMembersKeys {
 			isAccusedIDValid := accusedID > 0 && int(accusedID) <= pjm.group.GroupSize()
@@ -1337,7 +1452,12 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 		)
 	}
 
-	for _, message := range messages {
+	// Shares revealed in all messages are decrypted and verified against
+	// commitments upfront, in parallel. Outcomes are then applied in the order
+	// of received messages, the same way as if they were recovered one by one.
+	recoveries := rm.recoverRevealedShares(messages)
+
+	for messageIndex, message := range messages {
 		revealingMemberID := message.senderID
 
 		for misbehavedMemberID, revealedPrivateKey := range message.privateKeys {
@@ -1433,7 +1553,6 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 				rm.group.MarkMemberAsDisqualified(revealingMemberID)
 				continue
 			}
-			recoveredSymmetricKey := revealedPrivateKey.Ecdh(misbehavedMemberPublicKey)
 
 			// Get from the evidence log peer shares message sent by the member
 			// for which the private key has been revealed.
@@ -1461,11 +1580,19 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 			// has been revealed as disqualified earlier, in phase 5.
 			// Not reporting misbehaviour is also a protocol violation, so we
 			// disqualify the revealing member.
-			shareS, shareT, err := misbehavedMemberSharesMessage.decryptShares(
-				revealingMemberID,
-				recoveredSymmetricKey,
-			)
-			if err != nil {
+			recovery, ok := recoveries[revealedSharesKey{messageIndex, misbehavedMemberID}]
+			if !ok {
+				// Shares of a member disqualified while processing previous
+				// messages have not been recovered upfront.
+				recovery = rm.recoverShares(
+					revealingMemberID,
+					misbehavedMemberID,
+					revealedPrivateKey,
+					misbehavedMemberPublicKey,
+					misbehavedMemberSharesMessage,
+				)
+			}
+			if recovery.decryptionErr != nil {
 				logger.Warningf(
 					"[member:%v] member [%v] disqualified because of not "+
 						"reporting protocol violation in phase 3 by member [%v] - "+
@@ -1478,12 +1605,8 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 				continue
 			}
 
-			if rm.areSharesValidAgainstCommitments(
-				shareS, shareT,
-				rm.receivedPeerCommitments[misbehavedMemberID],
-				revealingMemberID,
-			) {
-				addShare(misbehavedMemberID, revealingMemberID, shareS)
+			if recovery.valid {
+				addShare(misbehavedMemberID, revealingMemberID, recovery.shareS)
 			} else {
 				// Similar situation as for shares that can not be decrypted.
 				// The revealing member knew about the fact shares are
@@ -1507,6 +1630,117 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 	return revealedMisbehavedShares, nil
 }
 
+// revealedSharesKey identifies shares of the misbehaved member recovered with
+// a private key revealed in the message with the given index.
+type revealedSharesKey struct {
+	messageIndex       int
+	misbehavedMemberID group.MemberIndex
+}
+
+// sharesRecovery is the outcome of decrypting shares sent by the misbehaved
+// member to the revealing member and verifying them against commitments of
+// the misbehaved member.
+type sharesRecovery struct {
+	shareS        *big.Int // s_mk
+	decryptionErr error
+	valid         bool
+}
+
+// recoverRevealedShares recovers, in parallel, shares of all misbehaved
+// members whose private keys have been revealed in the given messages and
+// for which all data needed for the recovery are present in the evidence log.
+// Validation of revealed keys is left to the caller.
+func (rm *ReconstructingMember) recoverRevealedShares(
+	messages []*MisbehavedEphemeralKeysMessage,
+) map[revealedSharesKey]*sharesRecovery {
+	type recoveryTask struct {
+		key                       revealedSharesKey
+		revealingMemberID         group.MemberIndex
+		revealedPrivateKey        *ephemeral.PrivateKey
+		misbehavedMemberPublicKey *ephemeral.PublicKey
+		sharesMessage             *PeerSharesMessage
+	}
+
+	tasks := make([]*recoveryTask, 0)
+	for messageIndex, message := range messages {
+		for misbehavedMemberID, revealedPrivateKey := range message.privateKeys {
+			if rm.ID == misbehavedMemberID || rm.group.IsOperating(misbehavedMemberID) {
+				continue
+			}
+
+			publicKeyMessage := rm.evidenceLog.ephemeralPublicKeyMessage(misbehavedMemberID)
+			if publicKeyMessage == nil {
+				continue
+			}
+			publicKey, ok := publicKeyMessage.ephemeralPublicKeys[message.senderID]
+			if !ok {
+				continue
+			}
+
+			sharesMessage := rm.evidenceLog.peerSharesMessage(misbehavedMemberID)
+			if sharesMessage == nil {
+				continue
+			}
+
+			tasks = append(tasks, &recoveryTask{
+				key:                       revealedSharesKey{messageIndex, misbehavedMemberID},
+				revealingMemberID:         message.senderID,
+				revealedPrivateKey:        revealedPrivateKey,
+				misbehavedMemberPublicKey: publicKey,
+				sharesMessage:             sharesMessage,
+			})
+		}
+	}
+
+	results := make([]*sharesRecovery, len(tasks))
+	parallelize(len(tasks), func(i int) {
+		task := tasks[i]
+		results[i] = rm.recoverShares(
+			task.revealingMemberID,
+			task.key.misbehavedMemberID,
+			task.revealedPrivateKey,
+			task.misbehavedMemberPublicKey,
+			task.sharesMessage,
+		)
+	})
+
+	recoveries := make(map[revealedSharesKey]*sharesRecovery, len(tasks))
+	for i, task := range tasks {
+		recoveries[task.key] = results[i]
+	}
+
+	return recoveries
+}
+
+// recoverShares decrypts shares sent by the misbehaved member to the revealing
+// member with a symmetric key recovered from the revealed private key and
+// verifies them against commitments of the misbehaved member.
+func (rm *ReconstructingMember) recoverShares(
+	revealingMemberID, misbehavedMemberID group.MemberIndex, // k, m
+	revealedPrivateKey *ephemeral.PrivateKey,
+	misbehavedMemberPublicKey *ephemeral.PublicKey,
+	sharesMessage *PeerSharesMessage,
+) *sharesRecovery {
+	recoveredSymmetricKey := revealedPrivateKey.Ecdh(misbehavedMemberPublicKey)
+
+	shareS, shareT, err := sharesMessage.decryptShares(
+		revealingMemberID,
+		recoveredSymmetricKey,
+	)
+	if err != nil {
+		return &sharesRecovery{decryptionErr: err}
+	}
+
+	return &sharesRecovery{
+		shareS: shareS,
+		valid: rm.areSharesValidAgainstCommitments(
+			shareS, shareT,
+			rm.receivedPeerCommitments[misbehavedMemberID],
+			revealingMemberID,
+		),
+	}
+}
+
 // isValidMisbehavedEphemeralKeysMessage validates a given
 // MisbehavedEphemeralKeysMessage. Message is considered valid if it reveals
 // all private keys generated for the sake of communication with all IA/DQ
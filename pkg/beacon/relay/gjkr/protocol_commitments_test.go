@@ -50,6 +50,36 @@ func TestCalculateSharesAndCommitments(t *testing.T) {
 	}
 }
 
+func TestCalculateZeroSharesAndCommitments(t *testing.T) {
+	dishonestThreshold := 2
+	groupSize := 5
+
+	members, err := initializeCommittingMembersGroup(dishonestThreshold, groupSize)
+	if err != nil {
+		t.Fatalf("group initialization failed [%s]", err)
+	}
+
+	member := members[0]
+	member.zeroSharing = true
+
+	if _, _, err := member.CalculateMembersSharesAndCommitments(); err != nil {
+		t.Fatalf("shares and commitments calculation failed [%s]", err)
+	}
+
+	if member.secretCoefficients[0].Sign() != 0 {
+		t.Errorf(
+			"\nexpected: zero constant coefficient\nactual:   %v\n",
+			member.secretCoefficients[0],
+		)
+	}
+
+	for _, coefficient := range member.secretCoefficients[1:] {
+		if coefficient.Sign() == 0 {
+			t.Errorf("unexpected zero non-constant coefficient")
+		}
+	}
+}
+
 func TestStoreSharesMessageForEvidence(t *testing.T) {
 	groupSize := 2
 
@@ -314,6 +344,66 @@ func TestGeneratePolynomial(t *testing.T) {
 	}
 }
 
+func BenchmarkVerifyReceivedSharesAndCommitmentsMessages_64Members(b *testing.B) {
+	benchmarkVerifyReceivedSharesAndCommitmentsMessages(b, verificationWorkers)
+}
+
+func BenchmarkVerifyReceivedSharesAndCommitmentsMessages_64Members_SingleWorker(
+	b *testing.B,
+) {
+	benchmarkVerifyReceivedSharesAndCommitmentsMessages(b, 1)
+}
+
+func benchmarkVerifyReceivedSharesAndCommitmentsMessages(b *testing.B, workers int) {
+	defer withVerificationWorkers(workers)()
+
+	dishonestThreshold := 31
+	groupSize := 64
+
+	members, err := initializeCommittingMembersGroup(dishonestThreshold, groupSize)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var sharesMessages []*PeerSharesMessage
+	var commitmentsMessages []*MemberCommitmentsMessage
+	for _, member := range members {
+		sharesMessage, commitmentsMessage, err :=
+			member.CalculateMembersSharesAndCommitments()
+		if err != nil {
+			b.Fatal(err)
+		}
+		sharesMessages = append(sharesMessages, sharesMessage)
+		commitmentsMessages = append(commitmentsMessages, commitmentsMessage)
+	}
+
+	verifyingMember := members[0].InitializeCommitmentsVerification()
+	sharesMessages = filterPeerSharesMessage(sharesMessages, verifyingMember.ID)
+	commitmentsMessages = filterMemberCommitmentsMessages(
+		commitmentsMessages,
+		verifyingMember.ID,
+	)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		// Peer shares messages can be put to the evidence log only once.
+		b.StopTimer()
+		verifyingMember.evidenceLog = newDkgEvidenceLog()
+		b.StartTimer()
+
+		accusations, err := verifyingMember.VerifyReceivedSharesAndCommitmentsMessages(
+			sharesMessages,
+			commitmentsMessages,
+		)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(accusations.accusedMembersKeys) != 0 {
+			b.Fatalf("unexpected accusations: [%v]", accusations.accusedMembersKeys)
+		}
+	}
+}
+
 func initializeCommittingMembersGroup(dishonestThreshold, groupSize int) (
 	[]*CommittingMember,
 	error,
@@ -157,6 +157,56 @@ func TestRevealMisbehavedMembersShares(t *testing.T) {
 	}
 }
 
+func BenchmarkRecoverMisbehavedShares_64Members(b *testing.B) {
+	benchmarkRecoverMisbehavedShares(b, verificationWorkers)
+}
+
+func BenchmarkRecoverMisbehavedShares_64Members_SingleWorker(b *testing.B) {
+	benchmarkRecoverMisbehavedShares(b, 1)
+}
+
+func benchmarkRecoverMisbehavedShares(b *testing.B, workers int) {
+	defer withVerificationWorkers(workers)()
+
+	dishonestThreshold := 31
+	groupSize := 64
+
+	members, err := initializeReconstructingMembersGroup(dishonestThreshold, groupSize)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// Simulated shares are consistent with commitments only when the number
+	// of revealing members is equal to the number of polynomial coefficients.
+	recoveringMember := members[0]
+	otherMembers := members[1 : dishonestThreshold+2]
+	disqualifiedMembers := members[groupSize-4:]
+
+	messages, err := generateMisbehavedEphemeralKeysMessages(
+		otherMembers,
+		disqualifiedMembers,
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+	generateDisqualifiedMemberShares(recoveringMember, otherMembers, disqualifiedMembers)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		recoveredShares, err := recoveringMember.recoverMisbehavedShares(messages)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(recoveredShares) != len(disqualifiedMembers) {
+			b.Fatalf(
+				"unexpected number of recovered shares\nexpected: %v\nactual:   %v\n",
+				len(disqualifiedMembers),
+				len(recoveredShares),
+			)
+		}
+	}
+}
+
 func generateMisbehavedEphemeralKeysMessages(
 	otherMembers, disqualifiedMembers []*ReconstructingMember,
 ) ([]*MisbehavedEphemeralKeysMessage, error) {
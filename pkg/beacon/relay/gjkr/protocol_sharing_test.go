@@ -184,6 +184,119 @@ func TestCalculateAndVerifyPublicKeySharePoints(t *testing.T) {
 	}
 }
 
+func TestVerifyZeroSharingPublicKeySharePoints(t *testing.T) {
+	dishonestThreshold := 2
+	groupSize := 5
+
+	sharingMembers, err := initializeSharingMembersGroup(dishonestThreshold, groupSize)
+	if err != nil {
+		t.Fatalf("group initialization failed [%s]", err)
+	}
+
+	// All members but member 3 deal a sharing of zero. Member 3 deals
+	// a sharing of a non-zero secret with valid public key share points.
+	nonZeroSharingMemberID := group.MemberIndex(3)
+	for _, dealer := range sharingMembers {
+		dealer.zeroSharing = true
+		if dealer.ID != nonZeroSharingMemberID {
+			dealer.secretCoefficients[0] = big.NewInt(0)
+		}
+	}
+	for _, receiver := range sharingMembers {
+		for _, dealer := range sharingMembers {
+			receiver.receivedQualifiedSharesS[dealer.ID] = dealer.evaluateMemberShare(
+				receiver.ID,
+				dealer.secretCoefficients,
+			)
+		}
+	}
+
+	messages := make([]*MemberPublicKeySharePointsMessage, groupSize)
+	for i, m := range sharingMembers {
+		messages[i] = m.CalculatePublicKeySharePoints()
+	}
+
+	sharingMember := sharingMembers[0]
+
+	accusedMessage, err := sharingMember.VerifyPublicKeySharePoints(
+		filterMemberPublicKeySharePointsMessages(messages, sharingMember.ID),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(accusedMessage.accusedMembersKeys) != 0 {
+		t.Errorf(
+			"unexpected accused members: [%v]",
+			accusedMessage.accusedMembersKeys,
+		)
+	}
+
+	expectedDisqualified := []group.MemberIndex{nonZeroSharingMemberID}
+	if !reflect.DeepEqual(
+		expectedDisqualified,
+		sharingMember.group.DisqualifiedMemberIDs(),
+	) {
+		t.Errorf(
+			"unexpected disqualified members\nexpected: %v\nactual:   %v\n",
+			expectedDisqualified,
+			sharingMember.group.DisqualifiedMemberIDs(),
+		)
+	}
+
+	for _, m := range sharingMembers[1:] {
+		_, ok := sharingMember.receivedValidPeerPublicKeySharePoints[m.ID]
+		if expected := m.ID != nonZeroSharingMemberID; ok != expected {
+			t.Errorf(
+				"unexpected validity of public key share points of member [%v]"+
+					"\nexpected: %v\nactual:   %v\n",
+				m.ID,
+				expected,
+				ok,
+			)
+		}
+	}
+}
+
+func BenchmarkVerifyPublicKeySharePoints_64Members(b *testing.B) {
+	benchmarkVerifyPublicKeySharePoints(b, verificationWorkers)
+}
+
+func BenchmarkVerifyPublicKeySharePoints_64Members_SingleWorker(b *testing.B) {
+	benchmarkVerifyPublicKeySharePoints(b, 1)
+}
+
+func benchmarkVerifyPublicKeySharePoints(b *testing.B, workers int) {
+	defer withVerificationWorkers(workers)()
+
+	dishonestThreshold := 31
+	groupSize := 64
+
+	members, err := initializeSharingMembersGroup(dishonestThreshold, groupSize)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var messages []*MemberPublicKeySharePointsMessage
+	for _, member := range members {
+		messages = append(messages, member.CalculatePublicKeySharePoints())
+	}
+
+	verifyingMember := members[0]
+	messages = filterMemberPublicKeySharePointsMessages(messages, verifyingMember.ID)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		accusations, err := verifyingMember.VerifyPublicKeySharePoints(messages)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(accusations.accusedMembersKeys) != 0 {
+			b.Fatalf("unexpected accusations: [%v]", accusations.accusedMembersKeys)
+		}
+	}
+}
+
 func initializeQualifiedMembersGroup(dishonestThreshold, groupSize int) (
 	[]*QualifiedMember,
 	error,
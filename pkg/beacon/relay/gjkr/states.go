@@ -714,3 +714,38 @@ func (fs *finalizationState) MemberIndex() group.MemberIndex {
 func (fs *finalizationState) result() *Result {
 	return fs.member.Result()
 }
+
+// statePhase returns the number of the protocol phase covered by the given
+// state. Zero is returned for states not being a part of the protocol.
+func statePhase(currentState keyGenerationState) uint32 {
+	switch currentState.(type) {
+	case *ephemeralKeyPairGenerationState:
+		return 1
+	case *symmetricKeyGenerationState:
+		return 2
+	case *commitmentState:
+		return 3
+	case *commitmentsVerificationState:
+		return 4
+	case *sharesJustificationState:
+		return 5
+	case *qualificationState:
+		return 6
+	case *pointsShareState:
+		return 7
+	case *pointsValidationState:
+		return 8
+	case *pointsJustificationState:
+		return 9
+	case *keyRevealState:
+		return 10
+	case *reconstructionState:
+		return 11
+	case *combinationState:
+		return 12
+	case *finalizationState:
+		return 13
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,114 @@
+package gjkr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+)
+
+// ProtocolPhases is the number of protocol phases covered by the GJKR
+// state machine.
+const ProtocolPhases = 13
+
+// PhaseReport describes the execution of a single protocol phase by the
+// member.
+type PhaseReport struct {
+	// MemberIndex is the index of the member executing the phase.
+	MemberIndex group.MemberIndex `json:"memberIndex"`
+	// Phase is the number of the protocol phase.
+	Phase uint32 `json:"phase"`
+	// State is the type name of the protocol state covering the phase.
+	State string `json:"state"`
+	// StartBlock is the block at which the phase started.
+	StartBlock uint64 `json:"startBlock"`
+	// EndBlock is the block at which the phase was over.
+	EndBlock uint64 `json:"endBlock"`
+	// Duration is the wall time the phase took.
+	Duration time.Duration `json:"duration"`
+	// ReceivedMessages is the number of messages received in the phase.
+	ReceivedMessages int `json:"receivedMessages"`
+	// InactiveMembers are members marked as inactive in the phase.
+	InactiveMembers []group.MemberIndex `json:"inactiveMembers"`
+	// DisqualifiedMembers are members marked as disqualified in the phase.
+	DisqualifiedMembers []group.MemberIndex `json:"disqualifiedMembers"`
+}
+
+// Blocks returns the duration of the phase in blocks.
+func (pr *PhaseReport) Blocks() uint64 {
+	return pr.EndBlock - pr.StartBlock
+}
+
+// PhaseHandler is called with a report each time the member completes
+// a protocol phase.
+type PhaseHandler func(report *PhaseReport)
+
+// phaseObserver implements state.Observer for the GJKR state machine. It turns
+// completed states into phase reports, including members marked as inactive
+// or disqualified during the phase.
+type phaseObserver struct {
+	group   *group.Group
+	handler PhaseHandler
+
+	inactiveMembers     map[group.MemberIndex]bool
+	disqualifiedMembers map[group.MemberIndex]bool
+}
+
+func newPhaseObserver(
+	memberGroup *group.Group,
+	handler PhaseHandler,
+) *phaseObserver {
+	return &phaseObserver{
+		group:               memberGroup,
+		handler:             handler,
+		inactiveMembers:     toSet(memberGroup.InactiveMemberIDs()),
+		disqualifiedMembers: toSet(memberGroup.DisqualifiedMemberIDs()),
+	}
+}
+
+func (po *phaseObserver) StateCompleted(report *state.StateReport) {
+	phaseReport := &PhaseReport{
+		MemberIndex:      report.State.MemberIndex(),
+		Phase:            statePhase(report.State),
+		State:            fmt.Sprintf("%T", report.State),
+		StartBlock:       report.StartBlock,
+		EndBlock:         report.EndBlock,
+		Duration:         report.EndTime.Sub(report.StartTime),
+		ReceivedMessages: report.ReceivedMessages,
+		InactiveMembers: markNewMembers(
+			po.inactiveMembers,
+			po.group.InactiveMemberIDs(),
+		),
+		DisqualifiedMembers: markNewMembers(
+			po.disqualifiedMembers,
+			po.group.DisqualifiedMemberIDs(),
+		),
+	}
+
+	po.handler(phaseReport)
+}
+
+// markNewMembers adds the given members to the set of already known members
+// and returns those of them which were not known before.
+func markNewMembers(
+	knownMembers map[group.MemberIndex]bool,
+	members []group.MemberIndex,
+) []group.MemberIndex {
+	newMembers := make([]group.MemberIndex, 0)
+	for _, member := range members {
+		if !knownMembers[member] {
+			knownMembers[member] = true
+			newMembers = append(newMembers, member)
+		}
+	}
+	return newMembers
+}
+
+func toSet(members []group.MemberIndex) map[group.MemberIndex]bool {
+	set := make(map[group.MemberIndex]bool, len(members))
+	for _, member := range members {
+		set[member] = true
+	}
+	return set
+}
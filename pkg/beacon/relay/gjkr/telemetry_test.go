@@ -0,0 +1,95 @@
+package gjkr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+)
+
+func TestPhaseObserver(t *testing.T) {
+	dkgGroup := group.NewDkgGroup(2, 5)
+	dkgGroup.MarkMemberAsInactive(5)
+
+	reports := make([]*PhaseReport, 0)
+	observer := newPhaseObserver(dkgGroup, func(report *PhaseReport) {
+		reports = append(reports, report)
+	})
+
+	member := &LocalMember{&memberCore{ID: 1, group: dkgGroup}}
+	startTime := time.Now()
+
+	dkgGroup.MarkMemberAsInactive(3)
+	observer.StateCompleted(&state.StateReport{
+		State: &ephemeralKeyPairGenerationState{
+			member: &EphemeralKeyPairGeneratingMember{LocalMember: member},
+		},
+		StartBlock:       10,
+		EndBlock:         16,
+		StartTime:        startTime,
+		EndTime:          startTime.Add(3 * time.Second),
+		ReceivedMessages: 3,
+	})
+
+	dkgGroup.MarkMemberAsDisqualified(2)
+	observer.StateCompleted(&state.StateReport{
+		State: &qualificationState{
+			member: &QualifiedMember{
+				SharesJustifyingMember: &SharesJustifyingMember{
+					CommitmentsVerifyingMember: &CommitmentsVerifyingMember{
+						CommittingMember: &CommittingMember{
+							SymmetricKeyGeneratingMember: &SymmetricKeyGeneratingMember{
+								EphemeralKeyPairGeneratingMember: &EphemeralKeyPairGeneratingMember{
+									LocalMember: member,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		StartBlock: 30,
+		EndBlock:   30,
+		StartTime:  startTime,
+		EndTime:    startTime,
+	})
+
+	expectedReports := []*PhaseReport{
+		{
+			MemberIndex:         1,
+			Phase:               1,
+			State:               "*gjkr.ephemeralKeyPairGenerationState",
+			StartBlock:          10,
+			EndBlock:            16,
+			Duration:            3 * time.Second,
+			ReceivedMessages:    3,
+			InactiveMembers:     []group.MemberIndex{3},
+			DisqualifiedMembers: []group.MemberIndex{},
+		},
+		{
+			MemberIndex:         1,
+			Phase:               6,
+			State:               "*gjkr.qualificationState",
+			StartBlock:          30,
+			EndBlock:            30,
+			Duration:            0,
+			ReceivedMessages:    0,
+			InactiveMembers:     []group.MemberIndex{},
+			DisqualifiedMembers: []group.MemberIndex{2},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedReports, reports) {
+		t.Errorf(
+			"unexpected phase reports\nexpected: %+v\nactual:   %+v\n",
+			expectedReports,
+			reports,
+		)
+	}
+
+	if reports[0].Blocks() != 6 {
+		t.Errorf("unexpected phase duration in blocks: [%v]", reports[0].Blocks())
+	}
+}
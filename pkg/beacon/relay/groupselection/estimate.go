@@ -0,0 +1,195 @@
+package groupselection
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// Staker is a staker taking part in an estimated group selection.
+type Staker struct {
+	Address []byte
+	Stake   *big.Int
+}
+
+// Estimate is the outcome of group selections estimated for one of the
+// stakers. All values are averaged over all estimated group selections.
+type Estimate struct {
+	// Selections is the number of estimated group selections.
+	Selections int
+	// TicketsPerRound is the expected number of tickets submitted by the
+	// staker in each ticket submission round.
+	TicketsPerRound []float64
+	// Seats is the expected number of seats won by the staker in the group.
+	Seats float64
+	// SelectionProbability is the probability of the staker winning at least
+	// one seat in the group.
+	SelectionProbability float64
+}
+
+// Tickets returns the expected number of tickets submitted by the staker in
+// all ticket submission rounds.
+func (e *Estimate) Tickets() float64 {
+	tickets := float64(0)
+	for _, roundTickets := range e.TicketsPerRound {
+		tickets += roundTickets
+	}
+	return tickets
+}
+
+// EstimateSelection estimates the outcome of group selections for the
+// operator staker being one of the given stakers. Group selections are
+// executed offline for the given number of consecutive seeds, starting from
+// the given one, using the same ticket generation and submission rules as
+// the client. All stakers are assumed to follow these rules and to see all
+// tickets submitted in previous rounds but none of the tickets submitted by
+// other stakers in the current round.
+func EstimateSelection(
+	seed *big.Int,
+	selections int,
+	operator int,
+	stakers []*Staker,
+	minimumStake *big.Int,
+	groupSize int,
+	ticketSubmissionTimeout uint64,
+) (*Estimate, error) {
+	if operator < 0 || operator >= len(stakers) {
+		return nil, fmt.Errorf("operator is not one of the stakers")
+	}
+	if selections < 1 {
+		return nil, fmt.Errorf("at least one group selection is required")
+	}
+	if minimumStake.Sign() <= 0 {
+		return nil, fmt.Errorf("minimum stake must be positive")
+	}
+	if groupSize < 1 {
+		return nil, fmt.Errorf("group size must be positive")
+	}
+
+	rounds, err := calculateRoundsCount(ticketSubmissionTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &Estimate{
+		Selections:      selections,
+		TicketsPerRound: make([]float64, rounds+1),
+	}
+
+	for i := 0; i < selections; i++ {
+		selectionSeed := new(big.Int).Add(seed, big.NewInt(int64(i)))
+
+		ticketsPerRound, seats, err := estimateSingleSelection(
+			selectionSeed,
+			operator,
+			stakers,
+			minimumStake,
+			groupSize,
+			rounds,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for round, tickets := range ticketsPerRound {
+			estimate.TicketsPerRound[round] += float64(tickets)
+		}
+		estimate.Seats += float64(seats)
+		if seats > 0 {
+			estimate.SelectionProbability++
+		}
+	}
+
+	for round := range estimate.TicketsPerRound {
+		estimate.TicketsPerRound[round] /= float64(selections)
+	}
+	estimate.Seats /= float64(selections)
+	estimate.SelectionProbability /= float64(selections)
+
+	return estimate, nil
+}
+
+// estimatedTicket is a ticket submitted in an estimated group selection.
+type estimatedTicket struct {
+	value  uint64
+	staker int
+}
+
+// estimateSingleSelection executes a group selection with the given seed and
+// returns the number of tickets submitted by the operator in each round and
+// the number of seats it won in the group.
+func estimateSingleSelection(
+	seed *big.Int,
+	operator int,
+	stakers []*Staker,
+	minimumStake *big.Int,
+	groupSize int,
+	rounds uint64,
+) ([]int, int, error) {
+	stakersTickets := make([][]*ticket, len(stakers))
+	for i, staker := range stakers {
+		tickets, err := generateTickets(
+			seed.Bytes(),
+			staker.Address,
+			staker.Stake,
+			minimumStake,
+			groupSize,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		stakersTickets[i] = tickets
+	}
+
+	operatorTicketsPerRound := make([]int, rounds+1)
+	chainTickets := make([]*estimatedTicket, 0)
+
+	for roundIndex := uint64(0); roundIndex <= rounds; roundIndex++ {
+		roundLeadingZeros := rounds - roundIndex
+
+		roundTickets := make([]*estimatedTicket, 0)
+		for staker, tickets := range stakersTickets {
+			submittedTickets := make([]uint64, len(chainTickets))
+			for i, chainTicket := range chainTickets {
+				submittedTickets[i] = chainTicket.value
+			}
+
+			candidateTickets := selectRoundCandidateTickets(
+				submittedTickets,
+				tickets,
+				roundIndex,
+				roundLeadingZeros,
+				groupSize,
+			)
+
+			for _, candidateTicket := range candidateTickets {
+				roundTickets = append(roundTickets, &estimatedTicket{
+					value:  candidateTicket.intValue().Uint64(),
+					staker: staker,
+				})
+			}
+
+			if staker == operator {
+				operatorTicketsPerRound[roundIndex] = len(candidateTickets)
+			}
+		}
+
+		// The chain keeps only group size of the lowest tickets.
+		chainTickets = append(chainTickets, roundTickets...)
+		sort.SliceStable(chainTickets, func(i, j int) bool {
+			return chainTickets[i].value < chainTickets[j].value
+		})
+		if len(chainTickets) > groupSize {
+			chainTickets = chainTickets[:groupSize]
+		}
+	}
+
+	seats := 0
+	for _, chainTicket := range chainTickets {
+		if chainTicket.staker == operator {
+			seats++
+		}
+	}
+
+	return operatorTicketsPerRound, seats, nil
+}
@@ -0,0 +1,139 @@
+package groupselection
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEstimateSelection(t *testing.T) {
+	minimumStake := big.NewInt(10)
+	groupSize := 8
+	ticketSubmissionTimeout := uint64(6*5 + 12)
+
+	var tests = map[string]struct {
+		stakes               []int64
+		expectedSeats        float64
+		expectedTickets      float64
+		expectedProbability  float64
+		expectAllSeatsFilled bool
+	}{
+		"operator is the only staker": {
+			stakes:               []int64{1000},
+			expectedSeats:        8,
+			expectedTickets:      8,
+			expectedProbability:  1,
+			expectAllSeatsFilled: true,
+		},
+		"operator stake below minimum stake": {
+			stakes:               []int64{9, 1000},
+			expectedSeats:        0,
+			expectedTickets:      0,
+			expectedProbability:  0,
+			expectAllSeatsFilled: true,
+		},
+		"not enough virtual stakers to fill the group": {
+			stakes:               []int64{30, 20},
+			expectedSeats:        3,
+			expectedTickets:      3,
+			expectedProbability:  1,
+			expectAllSeatsFilled: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			stakers := make([]*Staker, len(test.stakes))
+			for i, stake := range test.stakes {
+				stakers[i] = &Staker{
+					Address: []byte{byte(i + 1)},
+					Stake:   big.NewInt(stake),
+				}
+			}
+
+			estimate, err := EstimateSelection(
+				big.NewInt(1337),
+				10,
+				0,
+				stakers,
+				minimumStake,
+				groupSize,
+				ticketSubmissionTimeout,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if estimate.Seats != test.expectedSeats {
+				t.Errorf(
+					"unexpected seats\nexpected: %v\nactual:   %v\n",
+					test.expectedSeats,
+					estimate.Seats,
+				)
+			}
+			if estimate.Tickets() != test.expectedTickets {
+				t.Errorf(
+					"unexpected tickets\nexpected: %v\nactual:   %v\n",
+					test.expectedTickets,
+					estimate.Tickets(),
+				)
+			}
+			if estimate.SelectionProbability != test.expectedProbability {
+				t.Errorf(
+					"unexpected selection probability\nexpected: %v\nactual:   %v\n",
+					test.expectedProbability,
+					estimate.SelectionProbability,
+				)
+			}
+			if len(estimate.TicketsPerRound) != 6 {
+				t.Errorf(
+					"unexpected number of rounds\nexpected: %v\nactual:   %v\n",
+					6,
+					len(estimate.TicketsPerRound),
+				)
+			}
+
+			if test.expectAllSeatsFilled {
+				seats := float64(0)
+				for staker := range stakers {
+					estimate, err := EstimateSelection(
+						big.NewInt(1337),
+						10,
+						staker,
+						stakers,
+						minimumStake,
+						groupSize,
+						ticketSubmissionTimeout,
+					)
+					if err != nil {
+						t.Fatal(err)
+					}
+					seats += estimate.Seats
+				}
+
+				if seats != float64(groupSize) {
+					t.Errorf(
+						"unexpected number of seats won by all stakers\n"+
+							"expected: %v\nactual:   %v\n",
+						groupSize,
+						seats,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestEstimateSelectionOperatorNotStaker(t *testing.T) {
+	_, err := EstimateSelection(
+		big.NewInt(1337),
+		1,
+		1,
+		[]*Staker{{Address: []byte{0x01}, Stake: big.NewInt(100)}},
+		big.NewInt(10),
+		8,
+		6*5+12,
+	)
+	if err == nil {
+		t.Fatal("expected an error for operator not being a staker")
+	}
+}
@@ -4,6 +4,7 @@
 package groupselection
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"sort"
@@ -59,13 +60,21 @@ type Result struct {
 // After the last round, there is a 12 blocks mining lag allowing all
 // outstanding ticket submissions to have a higher chance of being
 // mined before the deadline.
+//
+// Before each candidate ticket is submitted, the submission policy decides
+// whether it is worth submitting it given tickets already submitted on-chain.
+//
+// When the provided context is done, ticket submission is abandoned and
+// onGroupSelected is never called.
 func CandidateToNewGroup(
+	ctx context.Context,
 	relayChain relaychain.Interface,
 	blockCounter chain.BlockCounter,
 	chainConfig *relaychain.Config,
 	staker chain.Staker,
 	newEntry *big.Int,
 	startBlockHeight uint64,
+	submissionPolicy SubmissionPolicy,
 	onGroupSelected func(*Result),
 ) error {
 	availableStake, err := staker.Stake()
@@ -83,24 +92,44 @@ func CandidateToNewGroup(
 		staker.Address(),
 		availableStake,
 		minimumStake,
+		chainConfig.GroupSize,
 	)
 	if err != nil {
 		return err
 	}
 
-	logger.Infof("starting ticket submission with [%v] tickets", len(tickets))
+	logger.Infof(
+		"starting ticket submission with [%v] candidate tickets",
+		len(tickets),
+	)
 
 	err = submitTickets(
+		ctx,
 		tickets,
 		relayChain,
 		blockCounter,
 		chainConfig,
 		startBlockHeight,
+		submissionPolicy,
 	)
 	if err != nil {
 		logger.Errorf("ticket submission terminated with error: [%v]", err)
 	}
 
+	submitted := 0
+	decisions := submissionPolicy.Decisions()
+	for _, decision := range decisions {
+		if decision.Submit {
+			submitted++
+		}
+	}
+	logger.Infof(
+		"submitted [%v] out of [%v] candidate tickets considered "+
+			"by the submission policy",
+		submitted,
+		len(decisions),
+	)
+
 	// Wait till the end of the ticket submission in case submitTickets failed
 	// in the middle and there is still a chance we qualified to a group.
 	ticketSubmissionTimeoutChannel, err := blockCounter.BlockHeightWaiter(
@@ -110,7 +139,15 @@ func CandidateToNewGroup(
 		return err
 	}
 
-	ticketSubmissionEndBlockHeight := <-ticketSubmissionTimeoutChannel
+	var ticketSubmissionEndBlockHeight uint64
+	select {
+	case ticketSubmissionEndBlockHeight = <-ticketSubmissionTimeoutChannel:
+	case <-ctx.Done():
+		return fmt.Errorf(
+			"ticket submission cancelled: [%v]",
+			ctx.Err(),
+		)
+	}
 
 	logger.Infof(
 		"ticket submission ended at block [%v]",
@@ -135,11 +172,13 @@ func CandidateToNewGroup(
 }
 
 func submitTickets(
+	ctx context.Context,
 	tickets []*ticket,
 	relayChain relaychain.GroupSelectionInterface,
 	blockCounter chain.BlockCounter,
 	chainConfig *relaychain.Config,
 	startBlockHeight uint64,
+	submissionPolicy SubmissionPolicy,
 ) error {
 	rounds, err := calculateRoundsCount(chainConfig.TicketSubmissionTimeout)
 	if err != nil {
@@ -159,21 +198,38 @@ func submitTickets(
 			roundLeadingZeros,
 		)
 
-		err := blockCounter.WaitForBlockHeight(roundStartBlock)
+		roundStartWaiter, err := blockCounter.BlockHeightWaiter(roundStartBlock)
 		if err != nil {
 			return err
 		}
 
-		candidateTickets, err := roundCandidateTickets(
-			relayChain,
+		select {
+		case <-roundStartWaiter:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// Tickets submitted on-chain are read once per round. If they could
+		// not be read, all round tickets are candidates and the submission
+		// policy still limits the spend on their submissions.
+		submittedTickets, err := relayChain.GetSubmittedTickets()
+		if err != nil {
+			logger.Warningf(
+				"could not get submitted tickets in round [%v]; "+
+					"deciding about submissions without them: [%v]",
+				roundIndex,
+				err,
+			)
+			submittedTickets = []uint64{}
+		}
+
+		candidateTickets := roundCandidateTickets(
+			submittedTickets,
 			tickets,
 			roundIndex,
 			roundLeadingZeros,
 			chainConfig.GroupSize,
 		)
-		if err != nil {
-			return err
-		}
 
 		logger.Infof(
 			"ticket submission round [%v] submitting "+
@@ -182,7 +238,12 @@ func submitTickets(
 			len(candidateTickets),
 		)
 
-		submitTicketsOnChain(candidateTickets, relayChain)
+		submitTicketsOnChain(
+			candidateTickets,
+			submittedTickets,
+			relayChain,
+			submissionPolicy,
+		)
 	}
 
 	return nil
@@ -201,29 +262,42 @@ func calculateRoundsCount(submissionTimeout uint64) (uint64, error) {
 }
 
 // roundCandidateTickets returns tickets which should be submitted in
-// the given ticket submission round.
+// the given ticket submission round given the unsorted values of tickets
+// submitted on-chain so far. The submitted tickets slice is not modified.
 //
 // Bear in mind that member tickets slice should be sorted in ascending
 // order by their value.
 func roundCandidateTickets(
-	relayChain relaychain.GroupSelectionInterface,
+	submittedTickets []uint64,
 	memberTickets []*ticket,
 	roundIndex uint64,
 	roundLeadingZeros uint64,
 	groupSize int,
-) ([]*ticket, error) {
-	// Get unsorted submitted tickets from the chain.
-	// This slice will be also filled by candidate tickets values in order to
-	// compare subsequent member ticket values against all submitted tickets
-	// so far and determine an optimal number of candidate tickets.
-	submittedTickets, err := relayChain.GetSubmittedTickets()
-	if err != nil {
-		return nil, fmt.Errorf(
-			"could not get submitted tickets: [%v]",
-			err,
-		)
-	}
+) []*ticket {
+	// The copy of submitted tickets will be also filled by candidate tickets
+	// values in order to compare subsequent member ticket values against all
+	// submitted tickets so far and determine an optimal number of candidate
+	// tickets.
+	return selectRoundCandidateTickets(
+		append([]uint64{}, submittedTickets...),
+		memberTickets,
+		roundIndex,
+		roundLeadingZeros,
+		groupSize,
+	)
+}
 
+// selectRoundCandidateTickets returns member tickets which should be
+// submitted in the given ticket submission round given the unsorted values
+// of tickets submitted on-chain so far. The submitted tickets slice is
+// modified in place.
+func selectRoundCandidateTickets(
+	submittedTickets []uint64,
+	memberTickets []*ticket,
+	roundIndex uint64,
+	roundLeadingZeros uint64,
+	groupSize int,
+) []*ticket {
 	candidateTickets := make([]*ticket, 0)
 
 	for _, candidateTicket := range memberTickets {
@@ -289,5 +363,5 @@ func roundCandidateTickets(
 		)
 	}
 
-	return candidateTickets, nil
+	return candidateTickets
 }
@@ -1,7 +1,9 @@
 package groupselection
 
 import (
+	"context"
 	"encoding/binary"
+	"fmt"
 	"math/big"
 	"reflect"
 	"sort"
@@ -16,9 +18,10 @@ import (
 
 func TestSubmitTickets(t *testing.T) {
 	var tests = map[string]struct {
-		groupSize                int
-		tickets                  []*ticket
-		expectedSubmittedTickets []uint64
+		groupSize                   int
+		failReadingSubmittedTickets bool
+		tickets                     []*ticket
+		expectedSubmittedTickets    []uint64
 	}{
 		// Client has the same number of tickets as the group size.
 		// All tickets should be submitted to the chain.
@@ -54,6 +57,18 @@ func TestSubmitTickets(t *testing.T) {
 			},
 			expectedSubmittedTickets: []uint64{1001, 1002},
 		},
+		// Submitted tickets could not be read from the chain.
+		// Tickets should still be submitted to the chain.
+		"submitted tickets could not be read": {
+			groupSize:                   2,
+			failReadingSubmittedTickets: true,
+			tickets: []*ticket{
+				newTestTicket(1, 1001),
+				newTestTicket(2, 1002),
+				newTestTicket(3, 1003),
+			},
+			expectedSubmittedTickets: []uint64{1001, 1002},
+		},
 	}
 
 	for testName, test := range tests {
@@ -64,7 +79,8 @@ func TestSubmitTickets(t *testing.T) {
 			}
 
 			chain := &stubGroupInterface{
-				groupSize: test.groupSize,
+				groupSize:                   test.groupSize,
+				failReadingSubmittedTickets: test.failReadingSubmittedTickets,
 			}
 
 			blockCounter, err := local.BlockCounter()
@@ -73,11 +89,13 @@ func TestSubmitTickets(t *testing.T) {
 			}
 
 			err = submitTickets(
+				context.Background(),
 				test.tickets,
 				chain,
 				blockCounter,
 				chainConfig,
 				0, // start block height
+				NewGasAwarePolicy(big.NewInt(0), nil)(test.groupSize),
 			)
 			if err != nil {
 				t.Fatal(err)
@@ -90,6 +108,8 @@ func TestSubmitTickets(t *testing.T) {
 				t.Fatal(err)
 			}
 
+			chain.failReadingSubmittedTickets = false
+
 			submittedTickets, err := chain.GetSubmittedTickets()
 			if err != nil {
 				t.Fatal(err)
@@ -274,16 +294,18 @@ func TestRoundCandidateTickets(t *testing.T) {
 			for roundIndex := uint64(0); roundIndex <= rounds; roundIndex++ {
 				roundLeadingZeros := rounds - roundIndex
 
-				candidateTickets, err := roundCandidateTickets(
-					relayChain,
+				submittedTickets, err := relayChain.GetSubmittedTickets()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				candidateTickets := roundCandidateTickets(
+					submittedTickets,
 					tickets,
 					roundIndex,
 					roundLeadingZeros,
 					groupSize,
 				)
-				if err != nil {
-					t.Fatal(err)
-				}
 
 				if !reflect.DeepEqual(
 					test.expectedCandidateTicketsPerRound[roundIndex],
@@ -316,8 +338,9 @@ func TestRoundCandidateTickets(t *testing.T) {
 }
 
 type stubGroupInterface struct {
-	groupSize        int
-	submittedTickets []*chain.Ticket
+	groupSize                   int
+	submittedTickets            []*chain.Ticket
+	failReadingSubmittedTickets bool
 }
 
 func (stg *stubGroupInterface) SubmitTicket(ticket *chain.Ticket) *async.EventGroupTicketSubmissionPromise {
@@ -345,7 +368,17 @@ func (stg *stubGroupInterface) SubmitTicket(ticket *chain.Ticket) *async.EventGr
 	return promise
 }
 
+func (stg *stubGroupInterface) EstimateTicketSubmissionCost(
+	ticket *chain.Ticket,
+) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
 func (stg *stubGroupInterface) GetSubmittedTickets() ([]uint64, error) {
+	if stg.failReadingSubmittedTickets {
+		return nil, fmt.Errorf("could not read submitted tickets")
+	}
+
 	tickets := make([]uint64, len(stg.submittedTickets))
 
 	for i := range tickets {
@@ -370,3 +403,9 @@ func (stg *stubGroupInterface) OnGroupSelectionStarted(
 ) subscription.EventSubscription {
 	panic("not implemented")
 }
+
+func (stg *stubGroupInterface) PastGroupSelectionStartedEvents(
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,178 @@
+package groupselection
+
+import (
+	"encoding/binary"
+	"math/big"
+	"sort"
+	"sync"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+)
+
+// SubmissionDecision is the decision of a submission policy about a single
+// candidate ticket.
+type SubmissionDecision struct {
+	// TicketValue is the value of the ticket the decision is about.
+	TicketValue uint64
+	// Submit is true if the ticket should be submitted on-chain.
+	Submit bool
+	// Reason explains why the ticket is or is not submitted.
+	Reason string
+	// SubmissionCost is the amount the submission is charged at. It is nil
+	// if the ticket is not submitted.
+	SubmissionCost *big.Int
+}
+
+// SubmissionPolicy decides, right before each candidate ticket is submitted,
+// whether the ticket should be submitted on-chain. A new policy is created
+// for every group selection so that it can keep track of the group selection
+// it decides about.
+type SubmissionPolicy interface {
+	// Decide is called with the candidate ticket, unsorted values of tickets
+	// submitted on-chain so far and the estimated cost of submitting the
+	// ticket. The submission cost is nil if it could not be estimated.
+	Decide(
+		ticket *relaychain.Ticket,
+		submittedTickets []uint64,
+		submissionCost *big.Int,
+	) *SubmissionDecision
+	// Refund is called with the decision to submit a ticket whose
+	// submission transaction has not been sent after all, for example
+	// because its simulation showed it would be rejected. Nothing has been
+	// paid for such a submission.
+	Refund(decision *SubmissionDecision)
+	// Decisions returns all decisions made so far in the order they were
+	// made.
+	Decisions() []*SubmissionDecision
+}
+
+// SubmissionPolicyFactory creates a submission policy for a new group
+// selection of a group with the given size.
+type SubmissionPolicyFactory func(groupSize int) SubmissionPolicy
+
+// NewGasAwarePolicy returns a factory of submission policies skipping tickets
+// which cannot make it to the group given the tickets already submitted
+// on-chain and limiting the amount spent on ticket submissions in a single
+// group selection. Each submission is charged at its estimated cost or, if
+// the cost could not be estimated, at the given maximum submission cost.
+// Submissions are charged when they are decided about, so that tickets
+// submitted one after another can not exceed the spend limit together, and
+// refunded if their transaction has not been sent. If the spend limit is nil
+// or zero, the spend is not limited, so a policy with
+// zero maximum submission cost and nil spend limit submits all the tickets
+// which can make it to the group.
+func NewGasAwarePolicy(
+	maxSubmissionCost *big.Int,
+	spendLimit *big.Int,
+) SubmissionPolicyFactory {
+	return func(groupSize int) SubmissionPolicy {
+		return &gasAwarePolicy{
+			groupSize:         groupSize,
+			maxSubmissionCost: maxSubmissionCost,
+			spendLimit:        spendLimit,
+			spent:             big.NewInt(0),
+			refunded:          make(map[*SubmissionDecision]bool),
+		}
+	}
+}
+
+// gasAwarePolicy is a submission policy of a single group selection
+// created by NewGasAwarePolicy.
+type gasAwarePolicy struct {
+	mutex sync.Mutex
+
+	groupSize         int
+	maxSubmissionCost *big.Int
+	spendLimit        *big.Int
+
+	spent     *big.Int
+	decisions []*SubmissionDecision
+	refunded  map[*SubmissionDecision]bool
+}
+
+func (gap *gasAwarePolicy) Decide(
+	ticket *relaychain.Ticket,
+	submittedTickets []uint64,
+	submissionCost *big.Int,
+) *SubmissionDecision {
+	gap.mutex.Lock()
+	defer gap.mutex.Unlock()
+
+	if submissionCost == nil {
+		submissionCost = gap.maxSubmissionCost
+	}
+
+	decision := gap.decide(
+		binary.BigEndian.Uint64(ticket.Value[:]),
+		submittedTickets,
+		submissionCost,
+	)
+	gap.decisions = append(gap.decisions, decision)
+
+	return decision
+}
+
+func (gap *gasAwarePolicy) decide(
+	ticketValue uint64,
+	submittedTickets []uint64,
+	submissionCost *big.Int,
+) *SubmissionDecision {
+	if len(submittedTickets) >= gap.groupSize {
+		sortedTickets := append([]uint64{}, submittedTickets...)
+		sort.Slice(sortedTickets, func(i, j int) bool {
+			return sortedTickets[i] < sortedTickets[j]
+		})
+
+		// Only the group size of the lowest tickets are selected to
+		// the group. A ticket not lower than the highest of them can not
+		// replace any of them.
+		if ticketValue >= sortedTickets[gap.groupSize-1] {
+			return &SubmissionDecision{
+				TicketValue: ticketValue,
+				Submit:      false,
+				Reason:      "ticket can not make it to the group",
+			}
+		}
+	}
+
+	spent := new(big.Int).Add(gap.spent, submissionCost)
+	if gap.isSpendLimited() && spent.Cmp(gap.spendLimit) > 0 {
+		return &SubmissionDecision{
+			TicketValue: ticketValue,
+			Submit:      false,
+			Reason:      "spend limit reached",
+		}
+	}
+	gap.spent = spent
+
+	return &SubmissionDecision{
+		TicketValue:    ticketValue,
+		Submit:         true,
+		Reason:         "ticket can make it to the group",
+		SubmissionCost: submissionCost,
+	}
+}
+
+func (gap *gasAwarePolicy) Refund(decision *SubmissionDecision) {
+	gap.mutex.Lock()
+	defer gap.mutex.Unlock()
+
+	if !decision.Submit || decision.SubmissionCost == nil ||
+		gap.refunded[decision] {
+		return
+	}
+
+	gap.spent = new(big.Int).Sub(gap.spent, decision.SubmissionCost)
+	gap.refunded[decision] = true
+}
+
+func (gap *gasAwarePolicy) isSpendLimited() bool {
+	return gap.spendLimit != nil && gap.spendLimit.Sign() > 0
+}
+
+func (gap *gasAwarePolicy) Decisions() []*SubmissionDecision {
+	gap.mutex.Lock()
+	defer gap.mutex.Unlock()
+
+	return append([]*SubmissionDecision{}, gap.decisions...)
+}
@@ -0,0 +1,142 @@
+package groupselection
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGasAwarePolicy(t *testing.T) {
+	groupSize := 3
+
+	var tests = map[string]struct {
+		spendLimit       *big.Int
+		submissionCost   *big.Int
+		submittedTickets []uint64
+		ticketValues     []uint64
+		expectedSubmit   []bool
+	}{
+		"less submitted tickets than group size": {
+			submittedTickets: []uint64{3000, 1000},
+			ticketValues:     []uint64{5000},
+			expectedSubmit:   []bool{true},
+		},
+		"ticket lower than the highest of group size of lowest tickets": {
+			submittedTickets: []uint64{3000, 1000, 4000, 2000},
+			ticketValues:     []uint64{2999},
+			expectedSubmit:   []bool{true},
+		},
+		"ticket equal to the highest of group size of lowest tickets": {
+			submittedTickets: []uint64{3000, 1000, 4000, 2000},
+			ticketValues:     []uint64{3000},
+			expectedSubmit:   []bool{false},
+		},
+		"ticket higher than the highest of group size of lowest tickets": {
+			submittedTickets: []uint64{3000, 1000, 4000, 2000},
+			ticketValues:     []uint64{3500},
+			expectedSubmit:   []bool{false},
+		},
+		"spend limit reached": {
+			spendLimit:       big.NewInt(20),
+			submittedTickets: []uint64{},
+			ticketValues:     []uint64{100, 200, 300},
+			expectedSubmit:   []bool{true, true, false},
+		},
+		"spend limit reached with estimated submission cost": {
+			spendLimit:       big.NewInt(20),
+			submissionCost:   big.NewInt(5),
+			submittedTickets: []uint64{},
+			ticketValues:     []uint64{100, 200, 300, 400, 500},
+			expectedSubmit:   []bool{true, true, true, true, false},
+		},
+		"zero spend limit does not limit the spend": {
+			spendLimit:       big.NewInt(0),
+			submittedTickets: []uint64{},
+			ticketValues:     []uint64{100, 200, 300},
+			expectedSubmit:   []bool{true, true, true},
+		},
+		"skipped tickets do not count towards spend limit": {
+			spendLimit:       big.NewInt(20),
+			submittedTickets: []uint64{1000, 2000, 3000},
+			ticketValues:     []uint64{5000, 100, 200},
+			expectedSubmit:   []bool{false, true, true},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy := NewGasAwarePolicy(big.NewInt(10), test.spendLimit)(
+				groupSize,
+			)
+
+			for i, ticketValue := range test.ticketValues {
+				chainTicket, err := toChainTicket(newTestTicket(1, ticketValue))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				decision := policy.Decide(
+					chainTicket,
+					test.submittedTickets,
+					test.submissionCost,
+				)
+				if decision.Submit != test.expectedSubmit[i] {
+					t.Errorf(
+						"unexpected decision for ticket [%v]: [%v]\n"+
+							"expected: [%v]\nactual:   [%v]",
+						ticketValue,
+						decision.Reason,
+						test.expectedSubmit[i],
+						decision.Submit,
+					)
+				}
+				if decision.TicketValue != ticketValue {
+					t.Errorf(
+						"unexpected ticket value\nexpected: [%v]\nactual:   [%v]",
+						ticketValue,
+						decision.TicketValue,
+					)
+				}
+			}
+
+			if len(policy.Decisions()) != len(test.ticketValues) {
+				t.Errorf(
+					"unexpected number of recorded decisions\n"+
+						"expected: [%v]\nactual:   [%v]",
+					len(test.ticketValues),
+					len(policy.Decisions()),
+				)
+			}
+		})
+	}
+}
+
+func TestGasAwarePolicyRefund(t *testing.T) {
+	policy := NewGasAwarePolicy(big.NewInt(10), big.NewInt(20))(3)
+
+	decide := func(ticketValue uint64) *SubmissionDecision {
+		chainTicket, err := toChainTicket(newTestTicket(1, ticketValue))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return policy.Decide(chainTicket, []uint64{}, nil)
+	}
+
+	refunded := decide(100)
+	decide(200)
+
+	if decide(300).Submit {
+		t.Fatal("ticket above the spend limit should not be submitted")
+	}
+
+	// Refunding the same submission twice does not raise the spend limit.
+	policy.Refund(refunded)
+	policy.Refund(refunded)
+
+	if !decide(400).Submit {
+		t.Error("ticket within the refunded spend should be submitted")
+	}
+	if decide(500).Submit {
+		t.Error("ticket above the spend limit should not be submitted")
+	}
+}
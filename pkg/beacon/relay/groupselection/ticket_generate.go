@@ -1,12 +1,24 @@
 package groupselection
 
 import (
+	"container/heap"
+	"encoding/binary"
 	"math/big"
+	"math/bits"
 	"sort"
 )
 
-// generateTickets generates a set of tickets for the given staker and relay
-// entry value given the specified stake parameters and natural threshold.
+// generateTickets generates tickets for the given staker and relay entry
+// value given the specified stake parameters and natural threshold, and
+// returns those of them which may be submitted in any ticket submission
+// round of a group of the given size.
+//
+// In each submission round, tickets with the given number of leading zeros
+// are considered in ascending order by their value and at most group size of
+// them are submitted. Hence, only group size of tickets with the lowest
+// values are retained for each possible number of leading zeros. Tickets are
+// generated one by one, so the memory used is bounded by the group size no
+// matter how large the stake is.
 //
 // Tickets are returned sorted in ascending order by their value.
 func generateTickets(
@@ -14,19 +26,113 @@ func generateTickets(
 	stakerValue []byte, // Q_j
 	availableStake *big.Int, // S_j
 	minimumStake *big.Int,
+	groupSize int,
 ) ([]*ticket, error) {
 	stakingWeight := new(big.Int).Quo(availableStake, minimumStake) // W_j
 
-	tickets := make([]*ticket, 0)
+	candidates := newCandidateTickets(groupSize)
 	for virtualStaker := int64(1); virtualStaker <= stakingWeight.Int64(); virtualStaker++ {
 		ticket, err := newTicket(beaconValue, stakerValue, big.NewInt(virtualStaker))
 		if err != nil {
 			return nil, err
 		}
-		tickets = append(tickets, ticket)
+		candidates.add(ticket)
+	}
+
+	return candidates.sorted(), nil
+}
+
+// candidateTickets retains, for each possible number of leading zeros of
+// a ticket value, up to the given number of tickets with the lowest values.
+type candidateTickets struct {
+	limit   int
+	buckets [65]ticketMaxHeap // indexed by the number of leading zeros
+}
+
+func newCandidateTickets(limit int) *candidateTickets {
+	return &candidateTickets{limit: limit}
+}
+
+// add retains the given ticket if it is among the lowest tickets with the
+// same number of leading zeros seen so far. Tickets should be added in
+// ascending order by their virtual staker index.
+func (ct *candidateTickets) add(ticket *ticket) {
+	if ct.limit <= 0 {
+		return
+	}
+
+	bucket := &ct.buckets[bits.LeadingZeros64(
+		binary.BigEndian.Uint64(ticket.value[:]),
+	)]
+
+	if bucket.Len() < ct.limit {
+		heap.Push(bucket, ticket)
+		return
+	}
+
+	// Tickets added later have higher virtual staker indexes, so a ticket
+	// with the same value as the highest retained one is not retained.
+	if lowerValue(ticket, (*bucket)[0]) {
+		(*bucket)[0] = ticket
+		heap.Fix(bucket, 0)
+	}
+}
+
+// sorted returns all retained tickets sorted in ascending order by their
+// value. Tickets with the same value are ordered by their virtual staker
+// index.
+func (ct *candidateTickets) sorted() []*ticket {
+	tickets := make([]*ticket, 0)
+	for _, bucket := range ct.buckets {
+		tickets = append(tickets, bucket...)
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		if tickets[i].value == tickets[j].value {
+			return tickets[i].proof.virtualStakerIndex.Cmp(
+				tickets[j].proof.virtualStakerIndex,
+			) < 0
+		}
+		return lowerValue(tickets[i], tickets[j])
+	})
+
+	return tickets
+}
+
+func lowerValue(ticket1, ticket2 *ticket) bool {
+	return binary.BigEndian.Uint64(ticket1.value[:]) <
+		binary.BigEndian.Uint64(ticket2.value[:])
+}
+
+// ticketMaxHeap implements heap.Interface keeping the ticket with the highest
+// value, and for equal values the highest virtual staker index, on top.
+type ticketMaxHeap []*ticket
+
+func (tmh ticketMaxHeap) Len() int {
+	return len(tmh)
+}
+
+func (tmh ticketMaxHeap) Less(i, j int) bool {
+	if tmh[i].value == tmh[j].value {
+		return tmh[i].proof.virtualStakerIndex.Cmp(
+			tmh[j].proof.virtualStakerIndex,
+		) > 0
 	}
+	return lowerValue(tmh[j], tmh[i])
+}
+
+func (tmh ticketMaxHeap) Swap(i, j int) {
+	tmh[i], tmh[j] = tmh[j], tmh[i]
+}
 
-	sort.Stable(byValue(tickets))
+func (tmh *ticketMaxHeap) Push(x interface{}) {
+	*tmh = append(*tmh, x.(*ticket))
+}
 
-	return tickets, nil
+func (tmh *ticketMaxHeap) Pop() interface{} {
+	old := *tmh
+	n := len(old)
+	ticket := old[n-1]
+	*tmh = old[:n-1]
+	return ticket
 }
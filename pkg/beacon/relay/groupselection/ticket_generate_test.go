@@ -2,6 +2,8 @@ package groupselection
 
 import (
 	"math/big"
+	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -18,6 +20,7 @@ func TestAllTicketsGenerated(t *testing.T) {
 		stakingAddress,
 		availableStake,
 		minimumStake,
+		int(virtualStakers),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -43,6 +46,7 @@ func TestTicketsGeneratedInOrder(t *testing.T) {
 		stakingAddress,
 		availableStake,
 		minimumStake,
+		64,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -58,3 +62,108 @@ func TestTicketsGeneratedInOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestTicketsGeneratedBoundedByGroupSize(t *testing.T) {
+	minimumStake := big.NewInt(1)
+	availableStake := big.NewInt(5000)
+	groupSize := 5
+
+	tickets, err := generateTickets(
+		previousBeaconOutput,
+		stakingAddress,
+		availableStake,
+		minimumStake,
+		groupSize,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticketsPerLeadingZeros := make(map[int]int)
+	for _, ticket := range tickets {
+		ticketsPerLeadingZeros[ticket.leadingZeros()]++
+	}
+
+	for leadingZeros, count := range ticketsPerLeadingZeros {
+		if count > groupSize {
+			t.Errorf(
+				"unexpected number of tickets with [%v] leading zeros\n"+
+					"expected at most: %v\nactual:           %v\n",
+				leadingZeros,
+				groupSize,
+				count,
+			)
+		}
+	}
+}
+
+func TestGeneratedTicketsCandidatesSameAsAllTickets(t *testing.T) {
+	minimumStake := big.NewInt(1)
+	availableStake := big.NewInt(5000)
+	rounds := uint64(12)
+
+	allTickets := make([]*ticket, 0)
+	for virtualStaker := int64(1); virtualStaker <= availableStake.Int64(); virtualStaker++ {
+		ticket, err := newTicket(
+			previousBeaconOutput,
+			stakingAddress,
+			big.NewInt(virtualStaker),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		allTickets = append(allTickets, ticket)
+	}
+	sort.Stable(byValue(allTickets))
+
+	for _, groupSize := range []int{1, 3, 10, 64} {
+		tickets, err := generateTickets(
+			previousBeaconOutput,
+			stakingAddress,
+			availableStake,
+			minimumStake,
+			groupSize,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		relayChain := &stubGroupInterface{groupSize: groupSize}
+
+		for roundIndex := uint64(0); roundIndex <= rounds; roundIndex++ {
+			roundLeadingZeros := rounds - roundIndex
+
+			submittedTickets, err := relayChain.GetSubmittedTickets()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedCandidateTickets := roundCandidateTickets(
+				submittedTickets,
+				allTickets,
+				roundIndex,
+				roundLeadingZeros,
+				groupSize,
+			)
+
+			candidateTickets := roundCandidateTickets(
+				submittedTickets,
+				tickets,
+				roundIndex,
+				roundLeadingZeros,
+				groupSize,
+			)
+
+			if !reflect.DeepEqual(expectedCandidateTickets, candidateTickets) {
+				t.Fatalf(
+					"unexpected candidate tickets for group size [%v] "+
+						"and round [%v]\nexpected: [%v]\nactual:   [%v]",
+					groupSize,
+					roundIndex,
+					expectedCandidateTickets,
+					candidateTickets,
+				)
+			}
+		}
+	}
+}
@@ -6,11 +6,21 @@ import (
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 )
 
-// submitTicketsOnChain submits tickets to the chain.
+// submitTicketsOnChain submits tickets to the chain. Right before each
+// submission, the submission policy decides whether the ticket should be
+// submitted given the tickets submitted so far and the estimated cost of the
+// submission. The given unsorted values of tickets submitted on-chain so far
+// are not read from the chain again; tickets submitted by this function are
+// added to a copy of them. Submissions whose transaction has not been sent
+// are refunded to the submission policy.
 func submitTicketsOnChain(
 	tickets []*ticket,
+	submittedTickets []uint64,
 	relayChain relaychain.GroupSelectionInterface,
+	submissionPolicy SubmissionPolicy,
 ) {
+	submittedTickets = append([]uint64{}, submittedTickets...)
+
 	for _, ticket := range tickets {
 		chainTicket, err := toChainTicket(ticket)
 		if err != nil {
@@ -21,14 +31,57 @@ func submitTicketsOnChain(
 			continue
 		}
 
+		submissionCost, err := relayChain.EstimateTicketSubmissionCost(
+			chainTicket,
+		)
+		if err != nil {
+			logger.Warningf(
+				"could not estimate cost of submitting ticket [%v]: [%v]",
+				ticket.intValue(),
+				err,
+			)
+			submissionCost = nil
+		}
+
+		decision := submissionPolicy.Decide(
+			chainTicket,
+			submittedTickets,
+			submissionCost,
+		)
+		if !decision.Submit {
+			logger.Infof(
+				"not submitting ticket [%v]: [%v]",
+				decision.TicketValue,
+				decision.Reason,
+			)
+			continue
+		}
+
+		logger.Debugf(
+			"submitting ticket [%v]: [%v]",
+			decision.TicketValue,
+			decision.Reason,
+		)
+
 		relayChain.SubmitTicket(chainTicket).OnFailure(
 			func(err error) {
+				if _, ok := err.(*relaychain.TransactionSkippedError); ok {
+					submissionPolicy.Refund(decision)
+				}
+
+				if relaychain.IsAlreadyDone(err) {
+					logger.Infof("ticket not submitted: [%v]", err)
+					return
+				}
+
 				logger.Errorf(
 					"ticket submission failed: [%v]",
 					err,
 				)
 			},
 		)
+
+		submittedTickets = append(submittedTickets, decision.TicketValue)
 	}
 }
 
@@ -1,12 +1,15 @@
 package groupselection
 
 import (
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/local"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/keep-network/keep-core/pkg/subscription"
 )
@@ -35,7 +38,12 @@ func TestSubmitTicketsOnChain(t *testing.T) {
 		},
 	}
 
-	submitTicketsOnChain(tickets, mockInterface)
+	submitTicketsOnChain(
+		tickets,
+		[]uint64{},
+		mockInterface,
+		NewGasAwarePolicy(big.NewInt(0), nil)(4),
+	)
 
 	if len(tickets) != len(submittedTickets) {
 		t.Errorf(
@@ -59,6 +67,182 @@ func TestSubmitTicketsOnChain(t *testing.T) {
 	}
 }
 
+func TestSubmitTicketsOnChainWithSubmissionPolicy(t *testing.T) {
+	groupSize := 3
+
+	var tests = map[string]struct {
+		spendLimit               *big.Int
+		submissionCost           *big.Int
+		tickets                  []*ticket
+		expectedSubmittedTickets []uint64
+		expectedDecisions        []bool
+	}{
+		"tickets which can not make it to the group are skipped": {
+			tickets: []*ticket{
+				newTestTicket(1, 500),
+				newTestTicket(2, 1500),
+				newTestTicket(3, 2500),
+			},
+			expectedSubmittedTickets: []uint64{500, 1000, 1500, 2000, 3000},
+			expectedDecisions:        []bool{true, true, false},
+		},
+		"tickets above the spend limit are skipped": {
+			spendLimit: big.NewInt(25),
+			tickets: []*ticket{
+				newTestTicket(1, 100),
+				newTestTicket(2, 200),
+				newTestTicket(3, 300),
+			},
+			expectedSubmittedTickets: []uint64{100, 200, 1000, 2000, 3000},
+			expectedDecisions:        []bool{true, true, false},
+		},
+		"tickets are charged at the estimated submission cost": {
+			spendLimit:     big.NewInt(25),
+			submissionCost: big.NewInt(8),
+			tickets: []*ticket{
+				newTestTicket(1, 100),
+				newTestTicket(2, 200),
+				newTestTicket(3, 300),
+			},
+			expectedSubmittedTickets: []uint64{100, 200, 300, 1000, 2000, 3000},
+			expectedDecisions:        []bool{true, true, true},
+		},
+		"submitted tickets are updated after each submission": {
+			tickets: []*ticket{
+				newTestTicket(1, 500),
+				newTestTicket(2, 600),
+				newTestTicket(3, 700),
+				newTestTicket(4, 800),
+			},
+			expectedSubmittedTickets: []uint64{500, 600, 700, 1000, 2000, 3000},
+			expectedDecisions:        []bool{true, true, true, false},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			localChain := local.Connect(groupSize, 2, big.NewInt(200))
+			relayChain := localChain.ThresholdRelay()
+
+			// The chain already has group size of tickets with values
+			// 1000, 2000 and 3000.
+			for i := 1; i <= groupSize; i++ {
+				chainTicket, err := toChainTicket(
+					newTestTicket(uint32(100+i), uint64(i*1000)),
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+				relayChain.SubmitTicket(chainTicket)
+			}
+
+			if test.submissionCost != nil {
+				localChain.SetTicketSubmissionCost(test.submissionCost)
+			} else {
+				localChain.SetTicketSubmissionCost(big.NewInt(10))
+			}
+
+			policy := NewGasAwarePolicy(big.NewInt(10), test.spendLimit)(
+				groupSize,
+			)
+
+			submittedTickets, err := relayChain.GetSubmittedTickets()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			submitTicketsOnChain(
+				test.tickets,
+				submittedTickets,
+				relayChain,
+				policy,
+			)
+
+			submittedTickets, err = relayChain.GetSubmittedTickets()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(
+				test.expectedSubmittedTickets,
+				submittedTickets,
+			) {
+				t.Errorf(
+					"unexpected submitted tickets\nexpected: [%v]\nactual:   [%v]",
+					test.expectedSubmittedTickets,
+					submittedTickets,
+				)
+			}
+
+			decisions := policy.Decisions()
+			if len(decisions) != len(test.expectedDecisions) {
+				t.Fatalf(
+					"unexpected number of decisions\nexpected: [%v]\nactual:   [%v]",
+					len(test.expectedDecisions),
+					len(decisions),
+				)
+			}
+			for i, decision := range decisions {
+				if decision.Submit != test.expectedDecisions[i] {
+					t.Errorf(
+						"unexpected decision for ticket [%v]\n"+
+							"expected: [%v]\nactual:   [%v]",
+						decision.TicketValue,
+						test.expectedDecisions[i],
+						decision.Submit,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestSubmitTicketsOnChainRefundsSkippedSubmissions(t *testing.T) {
+	groupSize := 3
+
+	localChain := local.Connect(groupSize, 2, big.NewInt(200))
+	relayChain := localChain.ThresholdRelay()
+	localChain.SetTicketSubmissionCost(big.NewInt(10))
+
+	chainTicket, err := toChainTicket(newTestTicket(1, 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	relayChain.SubmitTicket(chainTicket)
+
+	policy := NewGasAwarePolicy(big.NewInt(10), big.NewInt(25))(groupSize)
+
+	// The ticket has already been submitted, so its submission is skipped.
+	submitTicketsOnChain(
+		[]*ticket{newTestTicket(1, 1000)},
+		[]uint64{1000},
+		relayChain,
+		policy,
+	)
+
+	gasAwarePolicy := policy.(*gasAwarePolicy)
+	spent := func() *big.Int {
+		gasAwarePolicy.mutex.Lock()
+		defer gasAwarePolicy.mutex.Unlock()
+
+		return gasAwarePolicy.spent
+	}
+
+	// Failed submissions are reported asynchronously.
+	deadline := time.Now().Add(time.Second)
+	for spent().Sign() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if spent().Sign() != 0 {
+		t.Errorf(
+			"unexpected spend\nexpected: [%v]\nactual:   [%v]",
+			0,
+			spent(),
+		)
+	}
+}
+
 func fromChainTicket(chainTicket *chain.Ticket) *ticket {
 	return &ticket{
 		value: chainTicket.Value,
@@ -73,6 +257,12 @@ type mockGroupInterface struct {
 	mockSubmitTicketFn func(t *chain.Ticket) *async.EventGroupTicketSubmissionPromise
 }
 
+func (mgi *mockGroupInterface) EstimateTicketSubmissionCost(
+	ticket *chain.Ticket,
+) (*big.Int, error) {
+	return nil, fmt.Errorf("could not estimate")
+}
+
 func (mgi *mockGroupInterface) SubmitTicket(
 	ticket *chain.Ticket,
 ) *async.EventGroupTicketSubmissionPromise {
@@ -84,7 +274,7 @@ func (mgi *mockGroupInterface) SubmitTicket(
 }
 
 func (mgi *mockGroupInterface) GetSubmittedTickets() ([]uint64, error) {
-	panic("not implemented")
+	return []uint64{}, nil
 }
 
 func (mgi *mockGroupInterface) GetSelectedParticipants() ([]chain.StakerAddress, error) {
@@ -96,3 +286,9 @@ func (mgi *mockGroupInterface) OnGroupSelectionStarted(
 ) subscription.EventSubscription {
 	panic("not implemented")
 }
+
+func (mgi *mockGroupInterface) PastGroupSelectionStartedEvents(
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	panic("not implemented")
+}
@@ -3,6 +3,7 @@
 package relay_test
 
 import (
+	"bytes"
 	"crypto/rand"
 	"fmt"
 	"math/big"
@@ -11,6 +12,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/bls"
@@ -225,45 +227,223 @@ func TestInactivePointsAccusationsReconstructionAndSigning(t *testing.T) {
 func TestSigningWithInvalidSignatureShare(t *testing.T) {
 	t.Parallel()
 
+	// Member 1 sends shares which could not be unmarshalled as a G1 point.
+	// Member 2 sends a proper G1 point which is invalid in terms of
+	// the current relay entry request.
+	corruptShare := func(senderID group.MemberIndex, share []byte) []byte {
+		switch senderID {
+		case group.MemberIndex(1):
+			return []byte{0, 1}
+		case group.MemberIndex(2):
+			_, randomG1, err := bn256.RandomG1(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return randomG1.Marshal()
+		default:
+			return share
+		}
+	}
+
 	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
-		signatureShareMessage, ok := msg.(*entry.SignatureShareMessage)
+		switch message := msg.(type) {
+		case *entry.SignatureShareMessage:
+			senderID := message.SenderID()
+			if senderID != group.MemberIndex(1) &&
+				senderID != group.MemberIndex(2) {
+				return msg
+			}
 
-		// Member 1 sends shares which could not be unmarshalled as a G1 point.
-		if ok && signatureShareMessage.SenderID() == group.MemberIndex(1) {
 			return entry.NewSignatureShareMessage(
-				signatureShareMessage.SenderID(),
-				[]byte{0, 1},
+				senderID,
+				corruptShare(senderID, nil),
 			)
+		case *entry.SignatureSharesMessage:
+			shares := make(map[group.MemberIndex][]byte)
+			for senderID, share := range message.Shares() {
+				shares[senderID] = corruptShare(senderID, share)
+			}
+
+			return entry.NewSignatureSharesMessage(shares)
+		default:
+			return msg
 		}
+	}
 
-		// Member 2 sends a proper G1 point which is invalid in terms of
-		// the current relay entry request.
-		if ok && signatureShareMessage.SenderID() == group.MemberIndex(2) {
-			_, randomG1, err := bn256.RandomG1(rand.Reader)
+	signingMembersCount := groupSize
+	dkgResult, signingResult := runTestWithInterceptor(
+		t,
+		groupSize,
+		honestThreshold,
+		signingMembersCount,
+		interceptor,
+	)
+
+	dkgtest.AssertDkgResultPublished(t, dkgResult)
+	dkgtest.AssertSamePublicKey(t, dkgResult)
+	entrytest.AssertEntryPublished(t, signingResult)
+	entrytest.AssertNoSignerFailures(t, signingResult)
+
+	groupPublicKey, err := getFirstGroupPublicKey(dkgResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newEntry, err := signingResult.EntryValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bls.VerifyG1(groupPublicKey, previousEntryG1(), newEntry) {
+		t.Errorf("threshold signature failed BLS verification")
+	}
+}
+
+// Success: operators controlling multiple group members sign in a single
+// signing session per operator.
+func TestMultipleSeatsPerOperatorSigning(t *testing.T) {
+	t.Parallel()
+
+	var tests = map[string]struct {
+		seats []int
+	}{
+		"seats spread among operators": {
+			seats: []int{4, 3, 3},
+		},
+		"one operator holding the honest threshold": {
+			seats: []int{honestThreshold},
+		},
+	}
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	for testName, test := range tests {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			dkgSeed := dkgtest.RandomSeed(t)
+			dkgResult, err := dkgtest.RunTest(
+				groupSize,
+				honestThreshold,
+				dkgSeed,
+				interceptor,
+			)
 			if err != nil {
 				t.Fatal(err)
 			}
 
-			return entry.NewSignatureShareMessage(
-				signatureShareMessage.SenderID(),
-				randomG1.Marshal(),
+			signers := dkgResult.GetSigners()
+			operators := make([][]*dkg.ThresholdSigner, 0)
+			for _, seats := range test.seats {
+				operators = append(operators, signers[:seats])
+				signers = signers[seats:]
+			}
+
+			signingResult, err := entrytest.RunMultiSeatTest(
+				operators,
+				honestThreshold,
+				interceptor,
+				previousEntry(),
 			)
-		}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dkgtest.AssertDkgResultPublished(t, dkgResult)
+			entrytest.AssertEntryPublished(t, signingResult)
+			entrytest.AssertNoSignerFailures(t, signingResult)
+
+			groupPublicKey, err := getFirstGroupPublicKey(dkgResult)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			newEntry, err := signingResult.EntryValue()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bls.VerifyG1(groupPublicKey, previousEntryG1(), newEntry) {
+				t.Errorf("threshold signature failed BLS verification")
+			}
+		})
+	}
+}
+
+// Success: honest threshold of the signing group members participate in
+// signing with shares refreshed after the group has been created. The group
+// still signs with the same group public key.
+func TestRefreshedSharesSigning(t *testing.T) {
+	t.Parallel()
 
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
 		return msg
 	}
 
-	signingMembersCount := groupSize
-	dkgResult, signingResult := runTestWithInterceptor(
-		t,
+	dkgResult, err := dkgtest.RunTest(
 		groupSize,
 		honestThreshold,
-		signingMembersCount,
+		dkgtest.RandomSeed(t),
 		interceptor,
 	)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	dkgtest.AssertDkgResultPublished(t, dkgResult)
 	dkgtest.AssertSamePublicKey(t, dkgResult)
+
+	signers := dkgResult.GetSigners()
+	refreshResult, err := dkgtest.RunRefreshTest(
+		signers,
+		groupSize,
+		honestThreshold,
+		dkgtest.RandomSeed(t),
+		interceptor,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(refreshResult.GetMemberFailures()) != 0 {
+		t.Fatalf("unexpected failures: [%v]", refreshResult.GetMemberFailures())
+	}
+
+	refreshedSigners := refreshResult.GetSigners()
+	if len(refreshedSigners) != len(signers) {
+		t.Fatalf(
+			"unexpected number of refreshed signers\nexpected: %v\nactual:   %v\n",
+			len(signers),
+			len(refreshedSigners),
+		)
+	}
+
+	for _, refreshedSigner := range refreshedSigners {
+		if !bytes.Equal(
+			signers[0].GroupPublicKeyBytes(),
+			refreshedSigner.GroupPublicKeyBytes(),
+		) {
+			t.Fatalf(
+				"group public key of member [%v] has changed",
+				refreshedSigner.MemberID(),
+			)
+		}
+	}
+
+	signingResult, err := entrytest.RunTest(
+		refreshedSigners[groupSize-honestThreshold:],
+		honestThreshold,
+		interceptor,
+		previousEntry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	entrytest.AssertEntryPublished(t, signingResult)
 	entrytest.AssertNoSignerFailures(t, signingResult)
 
@@ -282,6 +462,135 @@ func TestSigningWithInvalidSignatureShare(t *testing.T) {
 	}
 }
 
+// Failure: one of the members does not take part in the share refresh.
+// Its shares would no longer be compatible with refreshed shares of the rest
+// of the group, so the refresh fails for all members and they keep their
+// previous shares.
+func TestRefreshWithMissingMember(t *testing.T) {
+	t.Parallel()
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	dkgResult, err := dkgtest.RunTest(
+		groupSize,
+		honestThreshold,
+		dkgtest.RandomSeed(t),
+		interceptor,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, dkgResult)
+
+	signers := dkgResult.GetSigners()
+	refreshResult, err := dkgtest.RunRefreshTest(
+		signers[1:],
+		groupSize,
+		honestThreshold,
+		dkgtest.RandomSeed(t),
+		interceptor,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(refreshResult.GetSigners()) != 0 {
+		t.Errorf(
+			"unexpected number of refreshed signers\nexpected: %v\nactual:   %v\n",
+			0,
+			len(refreshResult.GetSigners()),
+		)
+	}
+
+	if len(refreshResult.GetMemberFailures()) != len(signers)-1 {
+		t.Errorf(
+			"unexpected number of failures\nexpected: %v\nactual:   %v\n",
+			len(signers)-1,
+			len(refreshResult.GetMemberFailures()),
+		)
+	}
+}
+
+// Failure: one of the members fails right after completing the share refresh
+// protocol, before confirming the refresh to the rest of the group, for
+// example because it could not persist its refreshed shares. No member
+// switches to refreshed shares and the group still signs with previous
+// shares.
+func TestRefreshWithMemberFailingBeforeConfirmation(t *testing.T) {
+	t.Parallel()
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	dkgResult, err := dkgtest.RunTest(
+		groupSize,
+		honestThreshold,
+		dkgtest.RandomSeed(t),
+		interceptor,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, dkgResult)
+
+	failingMember := group.MemberIndex(1)
+	refreshInterceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		confirmation, ok := msg.(*dkg.RefreshConfirmationMessage)
+		if ok && confirmation.SenderID() == failingMember {
+			return nil
+		}
+		return msg
+	}
+
+	signers := dkgResult.GetSigners()
+	refreshResult, err := dkgtest.RunRefreshTest(
+		signers,
+		groupSize,
+		honestThreshold,
+		dkgtest.RandomSeed(t),
+		refreshInterceptor,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The failing member received confirmations of all the other members
+	// but they voted against committing the refresh, so it does not switch
+	// to refreshed shares either.
+	for _, refreshedSigner := range refreshResult.GetSigners() {
+		t.Errorf(
+			"member [%v] switched to refreshed shares",
+			refreshedSigner.MemberID(),
+		)
+	}
+
+	if len(refreshResult.GetMemberFailures()) != len(signers) {
+		t.Errorf(
+			"unexpected number of failures\nexpected: %v\nactual:   %v\n",
+			len(signers),
+			len(refreshResult.GetMemberFailures()),
+		)
+	}
+
+	signingResult, err := entrytest.RunTest(
+		signers[1:honestThreshold+1],
+		honestThreshold,
+		interceptor,
+		previousEntry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entrytest.AssertEntryPublished(t, signingResult)
+	entrytest.AssertNoSignerFailures(t, signingResult)
+}
+
 func runTest(t *testing.T, groupSize, honestThreshold, honestSignersCount int) (
 	*dkgtest.Result,
 	*entrytest.Result,
@@ -2,10 +2,12 @@ package relay
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/altbn128"
@@ -15,8 +17,10 @@ import (
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 )
@@ -34,7 +38,81 @@ type Node struct {
 	blockCounter chain.BlockCounter
 	chainConfig  *relaychain.Config
 
-	groupRegistry *registry.Groups
+	groupRegistry  *registry.Groups
+	dkgCheckpoints *registry.DKGCheckpoints
+	dkgEvidenceLog *registry.DKGEvidenceLog
+	dkgTelemetry   *dkg.Telemetry
+	// machines tracks states of key generation, result publication and
+	// share refresh protocols executed by the node.
+	machines *state.Registry
+
+	// stopping is set when the node is requested to stop. Once set, the node
+	// refuses to start any new DKG or signing work.
+	stopping bool
+	// protocols tracks DKG and signing executions currently running in the
+	// background, so that stopping node can wait for them to complete.
+	protocols *sync.WaitGroup
+	// signings holds statuses of relay entry signings currently executed
+	// by the node.
+	signings map[*SigningStatus]bool
+	// refreshes holds the latest share refresh executed by the node for each
+	// group, keyed by the hex-encoded group public key.
+	refreshes map[string]*shareRefresh
+}
+
+// DKGTelemetry returns statistics of distributed key generations executed by
+// the node.
+func (n *Node) DKGTelemetry() dkg.TelemetryStats {
+	return n.dkgTelemetry.Stats()
+}
+
+// RunningMachines returns statuses of state machines of all key generation,
+// result publication and share refresh protocols currently executed by
+// the node.
+func (n *Node) RunningMachines() []*state.MachineStatus {
+	return n.machines.RunningMachines()
+}
+
+// startProtocol registers a new protocol execution with the node. It returns
+// false if the node is stopping and the protocol must not be started. For every
+// call returning true, the caller is responsible for calling
+// protocols.Done once the protocol execution completes.
+func (n *Node) startProtocol() bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.stopping {
+		return false
+	}
+
+	n.protocols.Add(1)
+	return true
+}
+
+// Stop makes the node refuse any new DKG and signing work and waits for all
+// DKG and signing executions currently in progress to complete. If the provided
+// context is done before all executions completed, Stop returns the context
+// error.
+func (n *Node) Stop(ctx context.Context) error {
+	n.mutex.Lock()
+	n.stopping = true
+	n.mutex.Unlock()
+
+	completed := make(chan struct{})
+	go func() {
+		n.protocols.Wait()
+		close(completed)
+	}()
+
+	select {
+	case <-completed:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf(
+			"node stopped before in-progress protocols completed: [%v]",
+			ctx.Err(),
+		)
+	}
 }
 
 // IsInGroup checks if this node is a member of the group which was selected to
@@ -86,25 +164,28 @@ func (n *Node) JoinGroupIfEligible(
 			return
 		}
 
-		membershipValidator := group.NewStakersMembershipValidator(
+		membershipValidator := n.dkgMembershipValidator(
+			broadcastChannel,
 			groupSelectionResult.SelectedStakers,
 			signing,
 		)
 
-		err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
-		if err != nil {
-			logger.Errorf(
-				"could not set filter for channel [%v]: [%v]",
-				broadcastChannel.Name(),
-				err,
-			)
-		}
-
 		for _, index := range indexes {
 			// capture player index for goroutine
 			playerIndex := index
 
+			if !n.startProtocol() {
+				logger.Warningf(
+					"node is stopping; not executing DKG for member index [%v]",
+					playerIndex,
+				)
+				return
+			}
+
 			go func() {
+				defer n.protocols.Done()
+				defer n.archiveDKGCheckpoint(newEntry, playerIndex)
+
 				signer, err := dkg.ExecuteDKG(
 					newEntry,
 					playerIndex,
@@ -116,32 +197,274 @@ func (n *Node) JoinGroupIfEligible(
 					relayChain,
 					signing,
 					broadcastChannel,
+					n.dkgCheckpointHandler(
+						newEntry,
+						playerIndex,
+						groupSelectionResult.SelectedStakers,
+						dkgStartBlockHeight,
+					),
+					n.dkgEvidenceHandler(newEntry, playerIndex),
+					n.dkgTelemetry,
+					n.machines,
 				)
 				if err != nil {
 					logger.Errorf("failed to execute dkg: [%v]", err)
 					return
 				}
 
-				// final broadcast channel name for group is the compressed
-				// public key of the group
-				channelName := hex.EncodeToString(
-					signer.GroupPublicKeyBytesCompressed(),
+				n.registerGroup(
+					relayChain,
+					signer,
+					newEntry,
+					groupSelectionResult.SelectedStakers,
+					dkgStartBlockHeight,
 				)
+			}()
+		}
+	}
 
-				err = n.groupRegistry.RegisterGroup(signer, channelName)
-				if err != nil {
-					logger.Errorf("failed to register a group: [%v]", err)
-				}
+	return
+}
+
+// ResumeDKGIfEligible enables a client to rejoin distributed key generations
+// which were in progress when the client was stopped or crashed. Each
+// distributed key generation is resumed from its last checkpoint if its
+// member can still cleanly rejoin the protocol. Otherwise, the operator is
+// informed that the member could not rejoin and will most probably be
+// considered as misbehaving by the rest of the group.
+func (n *Node) ResumeDKGIfEligible(
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+) {
+	for _, checkpoint := range n.dkgCheckpoints.LoadExisting() {
+		// capture checkpoint for goroutine
+		checkpoint := checkpoint
+
+		logger.Infof(
+			"attempting to rejoin DKG for member index [%v] and seed [0x%x]",
+			checkpoint.Index,
+			checkpoint.Seed,
+		)
+
+		broadcastChannel, err := n.netProvider.BroadcastChannelFor(
+			checkpoint.Seed.Text(16),
+		)
+		if err != nil {
+			logger.Errorf(
+				"could not rejoin DKG for member index [%v]; "+
+					"failed to get broadcast channel: [%v]",
+				checkpoint.Index,
+				err,
+			)
+			continue
+		}
+
+		membershipValidator := n.dkgMembershipValidator(
+			broadcastChannel,
+			checkpoint.SelectedStakers,
+			signing,
+		)
+
+		if !n.startProtocol() {
+			logger.Warningf(
+				"node is stopping; not resuming DKG for member index [%v]",
+				checkpoint.Index,
+			)
+			return
+		}
+
+		go func() {
+			defer n.protocols.Done()
+			defer n.archiveDKGCheckpoint(checkpoint.Seed, checkpoint.Index)
 
-				logger.Infof(
-					"[member:%v] ready to operate in the group",
-					signer.MemberID(),
+			signer, err := dkg.ResumeDKG(
+				checkpoint.ProtocolCheckpoint,
+				checkpoint.Index,
+				membershipValidator,
+				n.blockCounter,
+				relayChain,
+				signing,
+				broadcastChannel,
+				n.dkgCheckpointHandler(
+					checkpoint.Seed,
+					checkpoint.Index,
+					checkpoint.SelectedStakers,
+					checkpoint.StartBlock,
+				),
+				n.dkgEvidenceHandler(checkpoint.Seed, checkpoint.Index),
+				n.dkgTelemetry,
+				n.machines,
+			)
+			if err != nil {
+				logger.Errorf(
+					"could not cleanly rejoin DKG for member index [%v] and "+
+						"seed [0x%x]; member will not join the group and may "+
+						"be considered as misbehaving: [%v]",
+					checkpoint.Index,
+					checkpoint.Seed,
+					err,
 				)
-			}()
+				return
+			}
+
+			n.registerGroup(
+				relayChain,
+				signer,
+				checkpoint.Seed,
+				checkpoint.SelectedStakers,
+				checkpoint.StartBlock,
+			)
+		}()
+	}
+}
+
+// dkgMembershipValidator creates a membership validator for the group
+// formed from the given selected stakers and sets it as a filter for the
+// given DKG broadcast channel.
+func (n *Node) dkgMembershipValidator(
+	broadcastChannel net.BroadcastChannel,
+	selectedStakers []relaychain.StakerAddress,
+	signing chain.Signing,
+) *group.StakersMembershipValidator {
+	membershipValidator := group.NewStakersMembershipValidator(
+		selectedStakers,
+		signing,
+	)
+
+	err := broadcastChannel.SetFilter(membershipValidator.IsInGroup)
+	if err != nil {
+		logger.Errorf(
+			"could not set filter for channel [%v]: [%v]",
+			broadcastChannel.Name(),
+			err,
+		)
+	}
+
+	return membershipValidator
+}
+
+// dkgCheckpointHandler returns a handler persisting GJKR protocol checkpoints
+// of the given member of the key generation started at the given block.
+func (n *Node) dkgCheckpointHandler(
+	seed *big.Int,
+	index uint8,
+	selectedStakers []relaychain.StakerAddress,
+	startBlock uint64,
+) gjkr.CheckpointHandler {
+	return func(protocolCheckpoint []byte) error {
+		return n.dkgCheckpoints.Save(&registry.DKGCheckpoint{
+			Seed:               seed,
+			Index:              index,
+			SelectedStakers:    selectedStakers,
+			ProtocolCheckpoint: protocolCheckpoint,
+			StartBlock:         startBlock,
+		})
+	}
+}
+
+// dkgEvidenceHandler returns a handler persisting the GJKR evidence log of the
+// given member. Each time a new evidence log is persisted, evidence logs whose
+// retention period is over are archived.
+func (n *Node) dkgEvidenceHandler(
+	seed *big.Int,
+	index uint8,
+) gjkr.EvidenceHandler {
+	return func(evidence []byte) error {
+		now := time.Now()
+
+		err := n.dkgEvidenceLog.Save(&registry.DKGEvidence{
+			Seed:     seed,
+			Index:    index,
+			StoredAt: now,
+			Evidence: evidence,
+		})
+		if err != nil {
+			return err
+		}
+
+		n.dkgEvidenceLog.ArchiveExpired(now)
+
+		return nil
+	}
+}
+
+// archiveDKGCheckpoint archives the checkpoint of the given member once its
+// distributed key generation is over.
+func (n *Node) archiveDKGCheckpoint(seed *big.Int, index uint8) {
+	if err := n.dkgCheckpoints.Archive(seed, index); err != nil {
+		logger.Warningf(
+			"could not archive DKG checkpoint for member index [%v]: [%v]",
+			index,
+			err,
+		)
+	}
+}
+
+// registerGroup registers the group of the given signer created in
+// a successful distributed key generation with the given seed and selected
+// stakers. The block at which the group was registered on-chain is looked up
+// in DKG result submissions emitted starting from the given block.
+func (n *Node) registerGroup(
+	relayChain relaychain.Interface,
+	signer *dkg.ThresholdSigner,
+	seed *big.Int,
+	selectedStakers []relaychain.StakerAddress,
+	dkgStartBlockHeight uint64,
+) {
+	// final broadcast channel name for group is the compressed
+	// public key of the group
+	channelName := hex.EncodeToString(
+		signer.GroupPublicKeyBytesCompressed(),
+	)
+
+	parameters := &registry.GroupParameters{
+		GroupSize:         n.chainConfig.GroupSize,
+		HonestThreshold:   n.chainConfig.HonestThreshold,
+		DKGSeed:           seed,
+		RegistrationBlock: registrationBlock(relayChain, signer, dkgStartBlockHeight),
+		Members:           selectedStakers,
+	}
+
+	err := n.groupRegistry.RegisterGroup(signer, channelName, parameters)
+	if err != nil {
+		logger.Errorf("failed to register a group: [%v]", err)
+	}
+
+	logger.Infof(
+		"[member:%v] ready to operate in the group",
+		signer.MemberID(),
+	)
+}
+
+// registrationBlock returns the block at which the DKG result of the group of
+// the given signer has been accepted on-chain or zero if it could not be
+// determined.
+func registrationBlock(
+	relayChain relaychain.Interface,
+	signer *dkg.ThresholdSigner,
+	fromBlock uint64,
+) uint64 {
+	submissions, err := relayChain.PastDKGResultSubmittedEvents(fromBlock)
+	if err != nil {
+		logger.Warningf(
+			"[member:%v] could not determine group registration block: [%v]",
+			signer.MemberID(),
+			err,
+		)
+		return 0
+	}
+
+	for _, submission := range submissions {
+		if bytes.Equal(submission.GroupPublicKey, signer.GroupPublicKeyBytes()) {
+			return submission.BlockNumber
 		}
 	}
 
-	return
+	logger.Warningf(
+		"[member:%v] could not find group registration on-chain",
+		signer.MemberID(),
+	)
+	return 0
 }
 
 // ForwardSignatureShares enables the ability to forward signature shares
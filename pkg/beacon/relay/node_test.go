@@ -0,0 +1,165 @@
+package relay
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+)
+
+func TestStopWaitsForProtocolsInProgress(t *testing.T) {
+	node := &Node{protocols: &sync.WaitGroup{}}
+
+	if !node.startProtocol() {
+		t.Fatal("protocol should be started on a running node")
+	}
+
+	protocolCompleted := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(protocolCompleted)
+		node.protocols.Done()
+	}()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Second)
+	defer cancelCtx()
+
+	err := node.Stop(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-protocolCompleted:
+	default:
+		t.Fatal("node stopped before protocol in progress completed")
+	}
+
+	if node.startProtocol() {
+		t.Fatal("protocol should not be started on a stopped node")
+	}
+}
+
+func TestStopTimesOut(t *testing.T) {
+	node := &Node{protocols: &sync.WaitGroup{}}
+
+	if !node.startProtocol() {
+		t.Fatal("protocol should be started on a running node")
+	}
+	defer node.protocols.Done()
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		50*time.Millisecond,
+	)
+	defer cancelCtx()
+
+	err := node.Stop(ctx)
+	if err == nil {
+		t.Fatal("expected stop to time out")
+	}
+}
+
+func TestDKGCheckpointHandlerStoresStartBlock(t *testing.T) {
+	node := &Node{
+		dkgCheckpoints: registry.NewDKGCheckpoints(&memoryPersistence{
+			files: make(map[string][]byte),
+		}),
+	}
+
+	handler := node.dkgCheckpointHandler(
+		big.NewInt(100),
+		1,
+		[]relaychain.StakerAddress{[]byte{0x01}, []byte{0x02}},
+		250,
+	)
+	if err := handler([]byte{0x03}); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoints := node.dkgCheckpoints.LoadExisting()
+	if len(checkpoints) != 1 {
+		t.Fatalf(
+			"unexpected number of checkpoints\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(checkpoints),
+		)
+	}
+
+	// The resumed key generation looks up the group registration starting
+	// from this block.
+	if checkpoints[0].StartBlock != 250 {
+		t.Errorf(
+			"unexpected start block\nexpected: [%v]\nactual:   [%v]",
+			250,
+			checkpoints[0].StartBlock,
+		)
+	}
+}
+
+// memoryPersistence keeps saved files in memory, keyed by directory and
+// file name.
+type memoryPersistence struct {
+	files map[string][]byte
+}
+
+func (mp *memoryPersistence) Save(data []byte, directory string, name string) error {
+	mp.files[directory+name] = data
+	return nil
+}
+
+func (mp *memoryPersistence) Snapshot(data []byte, directory string, name string) error {
+	return nil
+}
+
+func (mp *memoryPersistence) ReadAll() (<-chan persistence.DataDescriptor, <-chan error) {
+	descriptors := make(chan persistence.DataDescriptor, len(mp.files))
+	errors := make(chan error)
+
+	for path, content := range mp.files {
+		separator := strings.LastIndex(path, "/")
+		descriptors <- &memoryDescriptor{
+			name:      path[separator+1:],
+			directory: path[:separator],
+			content:   content,
+		}
+	}
+
+	close(descriptors)
+	close(errors)
+
+	return descriptors, errors
+}
+
+func (mp *memoryPersistence) Archive(directory string) error {
+	for path := range mp.files {
+		if strings.HasPrefix(path, directory+"/") {
+			delete(mp.files, path)
+		}
+	}
+	return nil
+}
+
+type memoryDescriptor struct {
+	name      string
+	directory string
+	content   []byte
+}
+
+func (md *memoryDescriptor) Name() string {
+	return md.name
+}
+
+func (md *memoryDescriptor) Directory() string {
+	return md.directory
+}
+
+func (md *memoryDescriptor) Content() ([]byte, error) {
+	return md.content, nil
+}
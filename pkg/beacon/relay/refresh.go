@@ -0,0 +1,477 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"sync"
+
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// shareRefreshInterval is the number of blocks between two consecutive share
+// refreshes of the same group, about a week of Ethereum blocks.
+const shareRefreshInterval = uint64(40320)
+
+// shareRefreshStartDelay is the number of blocks between the block at which
+// a share refresh of a group is scheduled and the block at which the refresh
+// protocol starts. It lets all group members notice the scheduled block even
+// if they see new blocks with some delay.
+const shareRefreshStartDelay = uint64(5)
+
+// MonitorShareRefreshes refreshes shares of the group private key held by
+// members of all non-stale groups this node is a member of, until the provided
+// context is done. Shares of each group are refreshed every
+// shareRefreshInterval blocks at an offset derived from the group public key,
+// so that all members of the group agree on when the refresh happens without
+// communicating. The refresh protocol starts shareRefreshStartDelay blocks
+// after the scheduled block; if the node notices the scheduled block only
+// after that, it does not take part in the refresh.
+func (n *Node) MonitorShareRefreshes(
+	ctx context.Context,
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+) {
+	lastBlock, err := n.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Errorf(
+			"could not read current block; share refreshes are disabled: [%v]",
+			err,
+		)
+		return
+	}
+
+	for currentBlock := range n.blockCounter.WatchBlocks(ctx) {
+		if currentBlock <= lastBlock {
+			continue
+		}
+
+		n.refreshScheduledGroups(relayChain, signing, lastBlock, currentBlock)
+		lastBlock = currentBlock
+	}
+}
+
+// refreshScheduledGroups starts share refreshes of groups scheduled after
+// fromBlock and no later than toBlock.
+func (n *Node) refreshScheduledGroups(
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+	fromBlock uint64,
+	toBlock uint64,
+) {
+	for _, memberships := range n.groupRegistry.GetGroups() {
+		if len(memberships) == 0 {
+			continue
+		}
+
+		groupPublicKey := memberships[0].Signer.GroupPublicKeyBytes()
+
+		if !hasGroupParameters(memberships) {
+			logger.Errorf(
+				"group [0x%x] has no group parameters; "+
+					"not refreshing its shares",
+				groupPublicKey,
+			)
+			continue
+		}
+
+		scheduledBlock, ok := scheduledShareRefresh(
+			groupPublicKey,
+			fromBlock,
+			toBlock,
+		)
+		if !ok {
+			continue
+		}
+
+		startBlockHeight := scheduledBlock + shareRefreshStartDelay
+		if toBlock >= startBlockHeight {
+			logger.Warningf(
+				"share refresh of group [0x%x] scheduled at block [%v] "+
+					"has already started; not taking part in it",
+				groupPublicKey,
+				scheduledBlock,
+			)
+			continue
+		}
+
+		isEntryRequested, err := isEntryRequestedFrom(relayChain, groupPublicKey)
+		if err != nil {
+			logger.Errorf(
+				"could not check if group [0x%x] has a relay entry "+
+					"requested: [%v]",
+				groupPublicKey,
+				err,
+			)
+			continue
+		}
+		if isEntryRequested {
+			logger.Warningf(
+				"group [0x%x] has a relay entry requested; "+
+					"not refreshing its shares",
+				groupPublicKey,
+			)
+			continue
+		}
+
+		isStaleGroup, err := relayChain.IsStaleGroup(groupPublicKey)
+		if err != nil {
+			logger.Errorf(
+				"could not check if group [0x%x] is stale: [%v]",
+				groupPublicKey,
+				err,
+			)
+			continue
+		}
+		if isStaleGroup {
+			continue
+		}
+
+		logger.Infof(
+			"refreshing shares of group [0x%x] starting at block [%v]",
+			groupPublicKey,
+			startBlockHeight,
+		)
+
+		n.refreshShares(
+			relayChain,
+			signing,
+			groupPublicKey,
+			memberships,
+			shareRefreshSeed(groupPublicKey, scheduledBlock),
+			startBlockHeight,
+		)
+	}
+}
+
+// isEntryRequestedFrom checks whether the group with the given public key is
+// expected to submit the relay entry currently in progress.
+func isEntryRequestedFrom(
+	relayChain relayChain.Interface,
+	groupPublicKey []byte,
+) (bool, error) {
+	isEntryInProgress, err := relayChain.IsEntryInProgress()
+	if err != nil {
+		return false, err
+	}
+	if !isEntryInProgress {
+		return false, nil
+	}
+
+	currentRequestGroupPublicKey, err := relayChain.CurrentRequestGroupPublicKey()
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(currentRequestGroupPublicKey, groupPublicKey), nil
+}
+
+// hasGroupParameters checks whether all the given memberships have group
+// parameters. Memberships persisted in the legacy format have no parameters
+// until they are migrated.
+func hasGroupParameters(memberships []*registry.Membership) bool {
+	for _, membership := range memberships {
+		if membership.Parameters == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// scheduledShareRefresh returns the latest block after fromBlock and no later
+// than toBlock at which a share refresh of the group with the given public key
+// is scheduled. The second returned value is false if there is no such block.
+func scheduledShareRefresh(
+	groupPublicKey []byte,
+	fromBlock uint64,
+	toBlock uint64,
+) (uint64, bool) {
+	offset := shareRefreshOffset(groupPublicKey)
+	if toBlock < offset {
+		return 0, false
+	}
+
+	scheduledBlock := toBlock - (toBlock-offset)%shareRefreshInterval
+	if scheduledBlock <= fromBlock {
+		return 0, false
+	}
+
+	return scheduledBlock, true
+}
+
+// shareRefreshOffset returns the offset within shareRefreshInterval of blocks
+// at which shares of the group with the given public key are refreshed.
+// Offsets are derived from group public keys so that refreshes of different
+// groups are spread over the interval.
+func shareRefreshOffset(groupPublicKey []byte) uint64 {
+	digest := sha256.Sum256(groupPublicKey)
+
+	return new(big.Int).Mod(
+		new(big.Int).SetBytes(digest[:]),
+		new(big.Int).SetUint64(shareRefreshInterval),
+	).Uint64()
+}
+
+// shareRefreshSeed returns the seed of the share refresh of the group with
+// the given public key scheduled at the given block.
+func shareRefreshSeed(groupPublicKey []byte, scheduledBlock uint64) *big.Int {
+	scheduledBlockBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(scheduledBlockBytes, scheduledBlock)
+
+	digest := sha256.Sum256(append(
+		append([]byte{}, groupPublicKey...),
+		scheduledBlockBytes...,
+	))
+
+	return new(big.Int).SetBytes(digest[:])
+}
+
+func (n *Node) refreshShares(
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+	groupPublicKey []byte,
+	memberships []*registry.Membership,
+	seed *big.Int,
+	startBlockHeight uint64,
+) {
+	channel, err := n.netProvider.BroadcastChannelFor(memberships[0].ChannelName)
+	if err != nil {
+		logger.Errorf("could not create broadcast channel: [%v]", err)
+		return
+	}
+
+	groupMembers, err := relayChain.GetGroupMembers(groupPublicKey)
+	if err != nil {
+		logger.Errorf("could not get group members: [%v]", err)
+		return
+	}
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		groupMembers,
+		signing,
+	)
+
+	err = channel.SetFilter(membershipValidator.IsInGroup)
+	if err != nil {
+		logger.Errorf(
+			"could not set filter for channel [%v]: [%v]",
+			channel.Name(),
+			err,
+		)
+	}
+
+	if !n.startRefresh(groupPublicKey, memberships) {
+		return
+	}
+
+	var refreshes sync.WaitGroup
+	for _, membership := range memberships {
+		if !n.startProtocol() {
+			logger.Warningf(
+				"node is stopping; not refreshing shares of group [0x%x]",
+				groupPublicKey,
+			)
+			break
+		}
+
+		// capture signer and group parameters for goroutine
+		signer := membership.Signer
+		parameters := membership.Parameters
+
+		refreshes.Add(1)
+		go func() {
+			defer n.protocols.Done()
+			defer refreshes.Done()
+
+			refreshedSigner, refreshEndBlockHeight, err := dkg.RefreshShares(
+				signer,
+				seed,
+				parameters.GroupSize,
+				parameters.DishonestThreshold(),
+				membershipValidator,
+				startBlockHeight,
+				n.blockCounter,
+				channel,
+				n.machines,
+			)
+			if err != nil {
+				logger.Errorf(
+					"failed to refresh shares of group [0x%x]; "+
+						"keeping previous shares: [%v]",
+					groupPublicKey,
+					err,
+				)
+				return
+			}
+
+			n.setRefreshCommitBlock(
+				groupPublicKey,
+				dkg.RefreshCommitBlockHeight(refreshEndBlockHeight),
+			)
+
+			// Refreshed shares are persisted as a pending refresh before they
+			// are confirmed to other members so that they are not lost if
+			// the client restarts after the group commits them. A member
+			// unable to persist them does not confirm the refresh, so the whole
+			// group keeps previous shares.
+			err = n.groupRegistry.SavePendingRefresh(refreshedSigner)
+			if err != nil {
+				logger.Errorf(
+					"failed to persist refreshed shares of group [0x%x]; "+
+						"keeping previous shares: [%v]",
+					groupPublicKey,
+					err,
+				)
+				return
+			}
+
+			err = dkg.ConfirmRefresh(
+				refreshedSigner,
+				seed,
+				refreshEndBlockHeight,
+				membershipValidator,
+				n.blockCounter,
+				channel,
+			)
+			if err != nil {
+				logger.Errorf(
+					"share refresh of group [0x%x] has not been committed "+
+						"by the group; keeping previous shares: [%v]",
+					groupPublicKey,
+					err,
+				)
+
+				err = n.groupRegistry.DiscardPendingRefresh(refreshedSigner)
+				if err != nil {
+					logger.Errorf(
+						"failed to discard pending share refresh "+
+							"of group [0x%x]: [%v]",
+						groupPublicKey,
+						err,
+					)
+				}
+				return
+			}
+
+			err = n.groupRegistry.RefreshMembership(refreshedSigner)
+			if err != nil {
+				logger.Errorf(
+					"failed to persist committed refreshed shares "+
+						"of group [0x%x]: [%v]",
+					groupPublicKey,
+					err,
+				)
+			}
+
+			logger.Infof(
+				"[member:%v] refreshed shares of group [0x%x]",
+				refreshedSigner.MemberID(),
+				groupPublicKey,
+			)
+		}()
+	}
+
+	go func() {
+		refreshes.Wait()
+		n.completeRefresh(groupPublicKey)
+	}()
+}
+
+// shareRefresh describes a share refresh of a group executed by the node.
+// Shares of the group must not change while signing, but relay requests must
+// not wait for the refresh either, so relay entries requested before the commit
+// block of the refresh are signed with memberships held before the refresh,
+// and those requested later with memberships the refresh left in the registry.
+type shareRefresh struct {
+	// previousMemberships are memberships of the group held by the node before
+	// the refresh started.
+	previousMemberships []*registry.Membership
+	// commitBlock is the block at which the group decides whether to commit
+	// the refresh. It is zero until the refresh protocol ends.
+	commitBlock uint64
+	// done is closed once the refresh completes.
+	done chan struct{}
+}
+
+// appliesTo checks whether the relay entry requested at the given block is
+// signed with shares the refresh left in the registry.
+func (sr *shareRefresh) appliesTo(requestBlock uint64) bool {
+	return sr.commitBlock != 0 && requestBlock >= sr.commitBlock
+}
+
+func (sr *shareRefresh) isDone() bool {
+	select {
+	case <-sr.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// startRefresh registers a share refresh of the group with the given public
+// key whose memberships before the refresh are the given ones. It returns false
+// if the refresh must not be started because the previous refresh of the group
+// is still in progress or because the group is signing a relay entry.
+func (n *Node) startRefresh(
+	groupPublicKey []byte,
+	memberships []*registry.Membership,
+) bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	key := hex.EncodeToString(groupPublicKey)
+	if refresh, ok := n.refreshes[key]; ok && !refresh.isDone() {
+		logger.Warningf(
+			"previous share refresh of group [0x%x] is still in progress",
+			groupPublicKey,
+		)
+		return false
+	}
+
+	for signing := range n.signings {
+		if hex.EncodeToString(signing.GroupPublicKey) == key {
+			logger.Warningf(
+				"group [0x%x] is signing a relay entry; "+
+					"not refreshing its shares",
+				groupPublicKey,
+			)
+			return false
+		}
+	}
+
+	n.refreshes[key] = &shareRefresh{
+		previousMemberships: memberships,
+		done:                make(chan struct{}),
+	}
+	return true
+}
+
+func (n *Node) setRefreshCommitBlock(groupPublicKey []byte, commitBlock uint64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if refresh, ok := n.refreshes[hex.EncodeToString(groupPublicKey)]; ok {
+		refresh.commitBlock = commitBlock
+	}
+}
+
+// completeRefresh marks the share refresh of the group with the given public
+// key as completed. The refresh stays registered so that relay entries
+// requested before its commit block are still signed with previous
+// memberships, even if the request is noticed late.
+func (n *Node) completeRefresh(groupPublicKey []byte) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if refresh, ok := n.refreshes[hex.EncodeToString(groupPublicKey)]; ok {
+		close(refresh.done)
+	}
+}
@@ -0,0 +1,305 @@
+package relay
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+)
+
+func TestScheduledShareRefresh(t *testing.T) {
+	groupPublicKey := []byte{0x01, 0x02, 0x03}
+	offset := shareRefreshOffset(groupPublicKey)
+
+	var tests = map[string]struct {
+		fromBlock             uint64
+		toBlock               uint64
+		expectedScheduled     bool
+		expectedScheduleBlock uint64
+	}{
+		"first scheduled block in range": {
+			fromBlock:             offset - 1,
+			toBlock:               offset,
+			expectedScheduled:     true,
+			expectedScheduleBlock: offset,
+		},
+		"later scheduled block in range": {
+			fromBlock:             offset + 3*shareRefreshInterval - 2,
+			toBlock:               offset + 3*shareRefreshInterval + 2,
+			expectedScheduled:     true,
+			expectedScheduleBlock: offset + 3*shareRefreshInterval,
+		},
+		"scheduled block is the range start": {
+			fromBlock:         offset + shareRefreshInterval,
+			toBlock:           offset + shareRefreshInterval + 1,
+			expectedScheduled: false,
+		},
+		"no scheduled block in range": {
+			fromBlock:         offset + 1,
+			toBlock:           offset + shareRefreshInterval - 1,
+			expectedScheduled: false,
+		},
+		"range before the first scheduled block": {
+			fromBlock:         0,
+			toBlock:           offset - 1,
+			expectedScheduled: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			scheduledBlock, scheduled := scheduledShareRefresh(
+				groupPublicKey,
+				test.fromBlock,
+				test.toBlock,
+			)
+
+			if test.expectedScheduled != scheduled {
+				t.Fatalf(
+					"unexpected scheduled\nexpected: [%v]\nactual:   [%v]",
+					test.expectedScheduled,
+					scheduled,
+				)
+			}
+
+			if scheduled && test.expectedScheduleBlock != scheduledBlock {
+				t.Errorf(
+					"unexpected scheduled block\nexpected: [%v]\nactual:   [%v]",
+					test.expectedScheduleBlock,
+					scheduledBlock,
+				)
+			}
+		})
+	}
+}
+
+func TestShareRefreshSeed(t *testing.T) {
+	groupPublicKey := []byte{0x01, 0x02, 0x03}
+
+	if shareRefreshSeed(groupPublicKey, 100).Cmp(
+		shareRefreshSeed(groupPublicKey, 100),
+	) != 0 {
+		t.Errorf("seeds of the same refresh should be equal")
+	}
+
+	if shareRefreshSeed(groupPublicKey, 100).Cmp(
+		shareRefreshSeed(groupPublicKey, 100+shareRefreshInterval),
+	) == 0 {
+		t.Errorf("seeds of consecutive refreshes should differ")
+	}
+
+	if shareRefreshSeed(groupPublicKey, 100).Cmp(
+		shareRefreshSeed([]byte{0x04}, 100),
+	) == 0 {
+		t.Errorf("seeds of refreshes of different groups should differ")
+	}
+}
+
+func TestHasGroupParameters(t *testing.T) {
+	parameters := &registry.GroupParameters{GroupSize: 5, HonestThreshold: 3}
+
+	var tests = map[string]struct {
+		memberships []*registry.Membership
+		expected    bool
+	}{
+		"all memberships have parameters": {
+			memberships: []*registry.Membership{
+				{Parameters: parameters},
+				{Parameters: parameters},
+			},
+			expected: true,
+		},
+		"legacy membership without parameters": {
+			memberships: []*registry.Membership{
+				{Parameters: parameters},
+				{Parameters: nil},
+			},
+			expected: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actual := hasGroupParameters(test.memberships)
+			if test.expected != actual {
+				t.Errorf(
+					"unexpected result\nexpected: [%v]\nactual:   [%v]",
+					test.expected,
+					actual,
+				)
+			}
+		})
+	}
+}
+
+func TestSigningDoesNotWaitForRefresh(t *testing.T) {
+	node := &Node{
+		groupRegistry: registry.NewGroupRegistry(nil, nil),
+		signings:      make(map[*SigningStatus]bool),
+		refreshes:     make(map[string]*shareRefresh),
+	}
+
+	groupPublicKey := []byte{0x01}
+	previousMemberships := []*registry.Membership{
+		{Signer: newTestSigner(1)},
+	}
+
+	if !node.startRefresh(groupPublicKey, previousMemberships) {
+		t.Fatal("refresh should start")
+	}
+
+	memberships, signings := node.startGroupSigning(
+		groupPublicKey,
+		[]byte{0x0a},
+		100,
+	)
+	for _, signing := range signings {
+		node.completeSigning(signing)
+	}
+
+	assertMemberships(t, previousMemberships, memberships)
+
+	node.setRefreshCommitBlock(groupPublicKey, 120)
+
+	memberships, signings = node.startGroupSigning(
+		groupPublicKey,
+		[]byte{0x0a},
+		110,
+	)
+	for _, signing := range signings {
+		node.completeSigning(signing)
+	}
+
+	assertMemberships(t, previousMemberships, memberships)
+}
+
+func TestSigningWaitsForCommittedRefresh(t *testing.T) {
+	node := &Node{
+		groupRegistry: registry.NewGroupRegistry(nil, nil),
+		signings:      make(map[*SigningStatus]bool),
+		refreshes:     make(map[string]*shareRefresh),
+	}
+
+	groupPublicKey := []byte{0x01}
+	previousMemberships := []*registry.Membership{
+		{Signer: newTestSigner(1)},
+	}
+
+	if !node.startRefresh(groupPublicKey, previousMemberships) {
+		t.Fatal("refresh should start")
+	}
+	node.setRefreshCommitBlock(groupPublicKey, 120)
+
+	type signingStart struct {
+		memberships []*registry.Membership
+		signings    []*SigningStatus
+	}
+
+	signingStarted := make(chan signingStart)
+	go func() {
+		memberships, signings := node.startGroupSigning(
+			groupPublicKey,
+			[]byte{0x0a},
+			120,
+		)
+		signingStarted <- signingStart{memberships, signings}
+	}()
+
+	select {
+	case <-signingStarted:
+		t.Fatal("signing should not start before the refresh is committed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	node.completeRefresh(groupPublicKey)
+
+	select {
+	case started := <-signingStarted:
+		for _, signing := range started.signings {
+			node.completeSigning(signing)
+		}
+
+		// The registry is empty as refreshed memberships are not
+		// registered in this test.
+		assertMemberships(t, []*registry.Membership{}, started.memberships)
+	case <-time.After(time.Second):
+		t.Fatal("signing should start once the refresh completes")
+	}
+
+	// Relay entries requested before the commit block are signed with
+	// previous shares even if noticed after the refresh completes.
+	memberships, signings := node.startGroupSigning(
+		groupPublicKey,
+		[]byte{0x0b},
+		119,
+	)
+	for _, signing := range signings {
+		node.completeSigning(signing)
+	}
+
+	assertMemberships(t, previousMemberships, memberships)
+}
+
+func TestRefreshNotStartedWhileSigning(t *testing.T) {
+	node := &Node{
+		signings:  make(map[*SigningStatus]bool),
+		refreshes: make(map[string]*shareRefresh),
+	}
+
+	groupPublicKey := []byte{0x01}
+
+	signing := &SigningStatus{GroupPublicKey: groupPublicKey, MemberIndex: 1}
+	node.startSigning(signing)
+
+	if node.startRefresh(groupPublicKey, nil) {
+		t.Fatal("refresh should not start while the group is signing")
+	}
+
+	if !node.startRefresh([]byte{0x02}, nil) {
+		t.Fatal("refresh of other group should start")
+	}
+
+	node.completeSigning(signing)
+
+	if !node.startRefresh(groupPublicKey, nil) {
+		t.Fatal("refresh should start once the signing completes")
+	}
+}
+
+func newTestSigner(memberIndex group.MemberIndex) *dkg.ThresholdSigner {
+	return dkg.NewThresholdSigner(
+		memberIndex,
+		new(bn256.G2).ScalarBaseMult(big.NewInt(10)),
+		big.NewInt(int64(memberIndex)),
+		make(map[group.MemberIndex]*bn256.G2),
+	)
+}
+
+func assertMemberships(
+	t *testing.T,
+	expected []*registry.Membership,
+	actual []*registry.Membership,
+) {
+	if len(expected) != len(actual) {
+		t.Fatalf(
+			"unexpected number of memberships\nexpected: [%v]\nactual:   [%v]",
+			len(expected),
+			len(actual),
+		)
+	}
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf(
+				"unexpected membership [%v]\nexpected: [%+v]\nactual:   [%+v]",
+				i,
+				expected[i],
+				actual[i],
+			)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package registry
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	dkgCheckpointDirectoryPrefix = "dkg_"
+	dkgCheckpointFileName        = "checkpoint"
+)
+
+// DKGCheckpoint represents a checkpoint of the distributed key generation
+// executed by one of the client's members.
+type DKGCheckpoint struct {
+	// Seed of the group selection which selected the member to the group.
+	Seed *big.Int
+	// Index of the member in the selected stakers list. Starts with 0.
+	Index uint8
+	// All stakers selected to the group.
+	SelectedStakers []relaychain.StakerAddress
+	// Checkpoint of the key generation protocol.
+	ProtocolCheckpoint []byte
+	// Block at which the key generation started. It is zero for checkpoints
+	// persisted before the start block was stored.
+	StartBlock uint64
+}
+
+// DKGCheckpoints persists checkpoints of distributed key generations in
+// progress so they can be resumed after the client restarts.
+type DKGCheckpoints struct {
+	handle persistence.Handle
+}
+
+// NewDKGCheckpoints returns DKG checkpoints stored with the given persistence
+// handle. Checkpoints contain secret values so the handle should encrypt the
+// data.
+func NewDKGCheckpoints(persistence persistence.Handle) *DKGCheckpoints {
+	return &DKGCheckpoints{
+		handle: persistence,
+	}
+}
+
+// Save persists the given checkpoint replacing the previous checkpoint
+// of the same member.
+func (dc *DKGCheckpoints) Save(checkpoint *DKGCheckpoint) error {
+	checkpointBytes, err := checkpoint.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling of the checkpoint failed: [%v]", err)
+	}
+
+	return dc.handle.Save(
+		checkpointBytes,
+		dkgCheckpointDirectory(checkpoint.Seed, checkpoint.Index),
+		"/"+dkgCheckpointFileName,
+	)
+}
+
+// Archive moves the checkpoint of the given member to the archive. It should
+// be called once the distributed key generation is over.
+func (dc *DKGCheckpoints) Archive(seed *big.Int, index uint8) error {
+	return dc.handle.Archive(dkgCheckpointDirectory(seed, index))
+}
+
+// LoadExisting returns all checkpoints currently stored. Checkpoints which
+// could not be read are logged and skipped.
+func (dc *DKGCheckpoints) LoadExisting() []*DKGCheckpoint {
+	checkpoints := make([]*DKGCheckpoint, 0)
+
+	descriptorsChannel, errorsChannel := dc.handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels for the same
+	// reason as when loading existing groups; channels are not buffered and
+	// we do not know in what order information is written to them.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChannel {
+			if !isDKGCheckpoint(descriptor) {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read DKG checkpoint from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			checkpoint := &DKGCheckpoint{}
+			if err := checkpoint.Unmarshal(content); err != nil {
+				logger.Errorf(
+					"could not unmarshal DKG checkpoint from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			checkpoints = append(checkpoints, checkpoint)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChannel {
+			logger.Errorf("could not load DKG checkpoint from disk: [%v]", err)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	return checkpoints
+}
+
+// Marshal converts DKGCheckpoint to a byte array.
+func (c *DKGCheckpoint) Marshal() ([]byte, error) {
+	selectedStakers := make([][]byte, 0, len(c.SelectedStakers))
+	for _, staker := range c.SelectedStakers {
+		selectedStakers = append(selectedStakers, staker)
+	}
+
+	return (&pb.DKGCheckpoint{
+		Seed:               c.Seed.Bytes(),
+		Index:              uint32(c.Index),
+		SelectedStakers:    selectedStakers,
+		ProtocolCheckpoint: c.ProtocolCheckpoint,
+		StartBlock:         c.StartBlock,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to DKGCheckpoint.
+func (c *DKGCheckpoint) Unmarshal(bytes []byte) error {
+	pbCheckpoint := pb.DKGCheckpoint{}
+	if err := pbCheckpoint.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	if pbCheckpoint.Index > 255 {
+		return fmt.Errorf("invalid member index [%v]", pbCheckpoint.Index)
+	}
+
+	selectedStakers := make(
+		[]relaychain.StakerAddress,
+		0,
+		len(pbCheckpoint.SelectedStakers),
+	)
+	for _, staker := range pbCheckpoint.SelectedStakers {
+		selectedStakers = append(selectedStakers, staker)
+	}
+
+	c.Seed = new(big.Int).SetBytes(pbCheckpoint.Seed)
+	c.Index = uint8(pbCheckpoint.Index)
+	c.SelectedStakers = selectedStakers
+	c.ProtocolCheckpoint = pbCheckpoint.ProtocolCheckpoint
+	c.StartBlock = pbCheckpoint.StartBlock
+
+	return nil
+}
+
+func dkgCheckpointDirectory(seed *big.Int, index uint8) string {
+	return fmt.Sprintf("%v%v_%v", dkgCheckpointDirectoryPrefix, seed.Text(16), index)
+}
+
+func isDKGCheckpoint(descriptor persistence.DataDescriptor) bool {
+	return strings.HasPrefix(descriptor.Directory(), dkgCheckpointDirectoryPrefix) &&
+		descriptor.Name() == dkgCheckpointFileName
+}
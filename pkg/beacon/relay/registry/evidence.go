@@ -0,0 +1,214 @@
+package registry
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	dkgEvidenceDirectoryPrefix = "dkg_evidence_"
+	dkgEvidenceFileName        = "evidence"
+)
+
+// DKGEvidence represents the evidence log of the distributed key generation
+// executed by one of the client's members.
+type DKGEvidence struct {
+	// Seed of the group selection which selected the member to the group.
+	Seed *big.Int
+	// Index of the member in the selected stakers list. Starts with 0.
+	Index uint8
+	// Time when the evidence log was stored.
+	StoredAt time.Time
+	// Evidence log of the key generation protocol.
+	Evidence []byte
+}
+
+// DKGEvidenceLog persists evidence logs of completed distributed key
+// generations so that misbehaviour of group members can be proven once the
+// group is formed or fails. Evidence logs are kept for the retention period
+// and then moved to the archive.
+type DKGEvidenceLog struct {
+	handle    persistence.Handle
+	retention time.Duration
+
+	// Guards the storage so that evidence logs being saved are not read
+	// at the same time when expired evidence logs are looked up.
+	mutex sync.Mutex
+}
+
+// NewDKGEvidenceLog returns evidence logs stored with the given persistence
+// handle and kept for the given retention period. Evidence logs contain
+// private keys revealed by accusers, so the handle should encrypt the data.
+func NewDKGEvidenceLog(
+	persistence persistence.Handle,
+	retention time.Duration,
+) *DKGEvidenceLog {
+	return &DKGEvidenceLog{
+		handle:    persistence,
+		retention: retention,
+	}
+}
+
+// Save persists the given evidence log replacing the previous evidence log
+// of the same member.
+func (del *DKGEvidenceLog) Save(evidence *DKGEvidence) error {
+	evidenceBytes, err := evidence.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling of the evidence failed: [%v]", err)
+	}
+
+	del.mutex.Lock()
+	defer del.mutex.Unlock()
+
+	return del.handle.Save(
+		evidenceBytes,
+		dkgEvidenceDirectory(evidence.Seed, evidence.Index),
+		"/"+dkgEvidenceFileName,
+	)
+}
+
+// LoadExisting returns all evidence logs currently stored, including the ones
+// whose retention period is over but which have not been archived yet.
+// Evidence logs which could not be read are logged and skipped.
+func (del *DKGEvidenceLog) LoadExisting() []*DKGEvidence {
+	del.mutex.Lock()
+	defer del.mutex.Unlock()
+
+	return del.loadExisting()
+}
+
+// ArchiveExpired moves all evidence logs stored before the retention period
+// preceding the given time to the archive.
+func (del *DKGEvidenceLog) ArchiveExpired(now time.Time) {
+	del.mutex.Lock()
+	defer del.mutex.Unlock()
+
+	for _, evidence := range del.loadExisting() {
+		if now.Sub(evidence.StoredAt) <= del.retention {
+			continue
+		}
+
+		err := del.handle.Archive(
+			dkgEvidenceDirectory(evidence.Seed, evidence.Index),
+		)
+		if err != nil {
+			logger.Errorf(
+				"could not archive expired DKG evidence for member index "+
+					"[%v] and seed [0x%x]: [%v]",
+				evidence.Index,
+				evidence.Seed,
+				err,
+			)
+			continue
+		}
+
+		logger.Infof(
+			"archived DKG evidence for member index [%v] and seed [0x%x] "+
+				"stored at [%v]",
+			evidence.Index,
+			evidence.Seed,
+			evidence.StoredAt,
+		)
+	}
+}
+
+func (del *DKGEvidenceLog) loadExisting() []*DKGEvidence {
+	evidences := make([]*DKGEvidence, 0)
+
+	descriptorsChannel, errorsChannel := del.handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels for the same
+	// reason as when loading existing groups; channels are not buffered and
+	// we do not know in what order information is written to them.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChannel {
+			if !isDKGEvidence(descriptor) {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read DKG evidence from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			evidence := &DKGEvidence{}
+			if err := evidence.Unmarshal(content); err != nil {
+				logger.Errorf(
+					"could not unmarshal DKG evidence from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			evidences = append(evidences, evidence)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChannel {
+			logger.Errorf("could not load DKG evidence from disk: [%v]", err)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	return evidences
+}
+
+// Marshal converts DKGEvidence to a byte array.
+func (e *DKGEvidence) Marshal() ([]byte, error) {
+	return (&pb.DKGEvidence{
+		Seed:     e.Seed.Bytes(),
+		Index:    uint32(e.Index),
+		StoredAt: e.StoredAt.Unix(),
+		Evidence: e.Evidence,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to DKGEvidence.
+func (e *DKGEvidence) Unmarshal(bytes []byte) error {
+	pbEvidence := pb.DKGEvidence{}
+	if err := pbEvidence.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	if pbEvidence.Index > 255 {
+		return fmt.Errorf("invalid member index [%v]", pbEvidence.Index)
+	}
+
+	e.Seed = new(big.Int).SetBytes(pbEvidence.Seed)
+	e.Index = uint8(pbEvidence.Index)
+	e.StoredAt = time.Unix(pbEvidence.StoredAt, 0)
+	e.Evidence = pbEvidence.Evidence
+
+	return nil
+}
+
+func dkgEvidenceDirectory(seed *big.Int, index uint8) string {
+	return fmt.Sprintf("%v%v_%v", dkgEvidenceDirectoryPrefix, seed.Text(16), index)
+}
+
+func isDKGEvidence(descriptor persistence.DataDescriptor) bool {
+	return strings.HasPrefix(descriptor.Directory(), dkgEvidenceDirectoryPrefix) &&
+		descriptor.Name() == dkgEvidenceFileName
+}
@@ -94,8 +94,10 @@ func (m *ThresholdSigner) GetGroupPublicKeyShares() map[uint32][]byte {
 }
 
 type Membership struct {
-	Signer  []byte `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
-	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	Signer     []byte           `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	Channel    string           `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	Version    uint32           `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Parameters *GroupParameters `protobuf:"bytes,4,opt,name=parameters,proto3" json:"parameters,omitempty"`
 }
 
 func (m *Membership) Reset()      { *m = Membership{} }
@@ -144,37 +146,348 @@ func (m *Membership) GetChannel() string {
 	return ""
 }
 
+func (m *Membership) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Membership) GetParameters() *GroupParameters {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+type GroupParameters struct {
+	GroupSize         uint32   `protobuf:"varint,1,opt,name=groupSize,proto3" json:"groupSize,omitempty"`
+	HonestThreshold   uint32   `protobuf:"varint,2,opt,name=honestThreshold,proto3" json:"honestThreshold,omitempty"`
+	DkgSeed           []byte   `protobuf:"bytes,3,opt,name=dkgSeed,proto3" json:"dkgSeed,omitempty"`
+	RegistrationBlock uint64   `protobuf:"varint,4,opt,name=registrationBlock,proto3" json:"registrationBlock,omitempty"`
+	Members           [][]byte `protobuf:"bytes,5,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (m *GroupParameters) Reset()      { *m = GroupParameters{} }
+func (*GroupParameters) ProtoMessage() {}
+func (*GroupParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{2}
+}
+func (m *GroupParameters) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GroupParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GroupParameters.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GroupParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupParameters.Merge(m, src)
+}
+func (m *GroupParameters) XXX_Size() int {
+	return m.Size()
+}
+func (m *GroupParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupParameters proto.InternalMessageInfo
+
+func (m *GroupParameters) GetGroupSize() uint32 {
+	if m != nil {
+		return m.GroupSize
+	}
+	return 0
+}
+
+func (m *GroupParameters) GetHonestThreshold() uint32 {
+	if m != nil {
+		return m.HonestThreshold
+	}
+	return 0
+}
+
+func (m *GroupParameters) GetDkgSeed() []byte {
+	if m != nil {
+		return m.DkgSeed
+	}
+	return nil
+}
+
+func (m *GroupParameters) GetRegistrationBlock() uint64 {
+	if m != nil {
+		return m.RegistrationBlock
+	}
+	return 0
+}
+
+func (m *GroupParameters) GetMembers() [][]byte {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type DKGCheckpoint struct {
+	Seed               []byte   `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	Index              uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	SelectedStakers    [][]byte `protobuf:"bytes,3,rep,name=selectedStakers,proto3" json:"selectedStakers,omitempty"`
+	ProtocolCheckpoint []byte   `protobuf:"bytes,4,opt,name=protocolCheckpoint,proto3" json:"protocolCheckpoint,omitempty"`
+	StartBlock         uint64   `protobuf:"varint,5,opt,name=startBlock,proto3" json:"startBlock,omitempty"`
+}
+
+func (m *DKGCheckpoint) Reset()      { *m = DKGCheckpoint{} }
+func (*DKGCheckpoint) ProtoMessage() {}
+func (*DKGCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{3}
+}
+func (m *DKGCheckpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DKGCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DKGCheckpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DKGCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DKGCheckpoint.Merge(m, src)
+}
+func (m *DKGCheckpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *DKGCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_DKGCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DKGCheckpoint proto.InternalMessageInfo
+
+func (m *DKGCheckpoint) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *DKGCheckpoint) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *DKGCheckpoint) GetSelectedStakers() [][]byte {
+	if m != nil {
+		return m.SelectedStakers
+	}
+	return nil
+}
+
+func (m *DKGCheckpoint) GetProtocolCheckpoint() []byte {
+	if m != nil {
+		return m.ProtocolCheckpoint
+	}
+	return nil
+}
+
+func (m *DKGCheckpoint) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+type DKGEvidence struct {
+	Seed     []byte `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	Index    uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	StoredAt int64  `protobuf:"varint,3,opt,name=storedAt,proto3" json:"storedAt,omitempty"`
+	Evidence []byte `protobuf:"bytes,4,opt,name=evidence,proto3" json:"evidence,omitempty"`
+}
+
+func (m *DKGEvidence) Reset()      { *m = DKGEvidence{} }
+func (*DKGEvidence) ProtoMessage() {}
+func (*DKGEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{4}
+}
+func (m *DKGEvidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DKGEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DKGEvidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DKGEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DKGEvidence.Merge(m, src)
+}
+func (m *DKGEvidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *DKGEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DKGEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DKGEvidence proto.InternalMessageInfo
+
+func (m *DKGEvidence) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *DKGEvidence) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *DKGEvidence) GetStoredAt() int64 {
+	if m != nil {
+		return m.StoredAt
+	}
+	return 0
+}
+
+func (m *DKGEvidence) GetEvidence() []byte {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+type QuarantinedMembership struct {
+	Membership    []byte `protobuf:"bytes,1,opt,name=membership,proto3" json:"membership,omitempty"`
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	QuarantinedAt int64  `protobuf:"varint,3,opt,name=quarantinedAt,proto3" json:"quarantinedAt,omitempty"`
+}
+
+func (m *QuarantinedMembership) Reset()      { *m = QuarantinedMembership{} }
+func (*QuarantinedMembership) ProtoMessage() {}
+func (*QuarantinedMembership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{5}
+}
+func (m *QuarantinedMembership) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuarantinedMembership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuarantinedMembership.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuarantinedMembership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantinedMembership.Merge(m, src)
+}
+func (m *QuarantinedMembership) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuarantinedMembership) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantinedMembership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantinedMembership proto.InternalMessageInfo
+
+func (m *QuarantinedMembership) GetMembership() []byte {
+	if m != nil {
+		return m.Membership
+	}
+	return nil
+}
+
+func (m *QuarantinedMembership) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *QuarantinedMembership) GetQuarantinedAt() int64 {
+	if m != nil {
+		return m.QuarantinedAt
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ThresholdSigner)(nil), "registry.ThresholdSigner")
 	proto.RegisterMapType((map[uint32][]byte)(nil), "registry.ThresholdSigner.GroupPublicKeySharesEntry")
 	proto.RegisterType((*Membership)(nil), "registry.Membership")
+	proto.RegisterType((*GroupParameters)(nil), "registry.GroupParameters")
+	proto.RegisterType((*DKGCheckpoint)(nil), "registry.DKGCheckpoint")
+	proto.RegisterType((*DKGEvidence)(nil), "registry.DKGEvidence")
+	proto.RegisterType((*QuarantinedMembership)(nil), "registry.QuarantinedMembership")
 }
 
 func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
 
 var fileDescriptor_8447775385e7eb85 = []byte{
-	// 328 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x31, 0x4b, 0xc3, 0x40,
-	0x1c, 0xc5, 0x73, 0xa9, 0x56, 0x7b, 0xad, 0x5a, 0x8e, 0x22, 0xd1, 0xe1, 0x4f, 0xe8, 0x20, 0x99,
-	0x22, 0xb4, 0x4b, 0x71, 0x70, 0x10, 0xa4, 0x48, 0x11, 0x24, 0x75, 0x72, 0x4b, 0xda, 0x3f, 0x49,
-	0x30, 0x4d, 0xc2, 0x5d, 0x5a, 0xcc, 0xe6, 0x47, 0xf0, 0x63, 0xf8, 0x35, 0xdc, 0x1c, 0x3b, 0x76,
-	0xb4, 0xd7, 0xc5, 0xb1, 0x1f, 0x41, 0xbc, 0x36, 0x20, 0x25, 0x6e, 0xf7, 0xde, 0xf1, 0x7b, 0xbc,
-	0x77, 0x47, 0x9b, 0xa9, 0x77, 0x39, 0x41, 0x21, 0x5c, 0x1f, 0xed, 0x94, 0x27, 0x59, 0xc2, 0x0e,
-	0x39, 0xfa, 0xa1, 0xc8, 0x78, 0xde, 0xfe, 0xd0, 0xe9, 0xc9, 0x63, 0xc0, 0x51, 0x04, 0x49, 0x34,
-	0x1e, 0x86, 0x7e, 0x8c, 0x9c, 0x99, 0xb4, 0x3e, 0xc1, 0x89, 0x87, 0xfc, 0x2e, 0x1e, 0xe3, 0x8b,
-	0x41, 0x4c, 0x62, 0x1d, 0x39, 0x7f, 0x2d, 0x76, 0x41, 0x8f, 0x7d, 0x9e, 0x4c, 0xd3, 0x87, 0xa9,
-	0x17, 0x85, 0xa3, 0x01, 0xe6, 0x86, 0x6e, 0x12, 0xab, 0xe1, 0xec, 0xb8, 0xac, 0x43, 0x5b, 0x1b,
-	0x87, 0x87, 0x33, 0x37, 0xc3, 0x01, 0xe6, 0xc3, 0xc0, 0xe5, 0x68, 0x54, 0x4c, 0x62, 0xd5, 0x9c,
-	0xd2, 0x3b, 0xe6, 0x17, 0x4c, 0x91, 0xa2, 0x6c, 0x61, 0xec, 0x99, 0x15, 0xab, 0xde, 0xe9, 0xda,
-	0x45, 0x75, 0x7b, 0xa7, 0xb6, 0xdd, 0x2f, 0xa1, 0x6e, 0xe3, 0x8c, 0xe7, 0x4e, 0x69, 0xe0, 0x79,
-	0x9f, 0x9e, 0xfd, 0x8b, 0xb0, 0x26, 0xad, 0x3c, 0x63, 0xbe, 0xdd, 0xfe, 0x7b, 0x64, 0x2d, 0xba,
-	0x3f, 0x73, 0xa3, 0x29, 0x6e, 0xa7, 0x6e, 0xc4, 0x95, 0xde, 0x23, 0xed, 0x6b, 0x4a, 0xef, 0xd5,
-	0xe3, 0x88, 0x20, 0x4c, 0xd9, 0x29, 0xad, 0x0a, 0x55, 0x48, 0xc1, 0x0d, 0x67, 0xab, 0x98, 0x41,
-	0x0f, 0x46, 0x81, 0x1b, 0xc7, 0x18, 0xa9, 0x84, 0x9a, 0x53, 0xc8, 0x9b, 0xde, 0x7c, 0x09, 0xda,
-	0x62, 0x09, 0xda, 0x7a, 0x09, 0xe4, 0x55, 0x02, 0x79, 0x97, 0x40, 0x3e, 0x25, 0x90, 0xb9, 0x04,
-	0xf2, 0x25, 0x81, 0x7c, 0x4b, 0xd0, 0xd6, 0x12, 0xc8, 0xdb, 0x0a, 0xb4, 0xf9, 0x0a, 0xb4, 0xc5,
-	0x0a, 0xb4, 0x27, 0x3d, 0xf5, 0xbc, 0xaa, 0xfa, 0xce, 0xee, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xd0, 0xd8, 0x54, 0xe3, 0xe2, 0x01, 0x00, 0x00,
+	// 601 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x31, 0x6f, 0x13, 0x4d,
+	0x10, 0xf5, 0xda, 0x49, 0xbe, 0x64, 0x6c, 0x7f, 0x09, 0xab, 0x80, 0x9c, 0x08, 0xad, 0x2c, 0x0b,
+	0x21, 0x17, 0xc8, 0x48, 0x49, 0x13, 0xe8, 0x08, 0x89, 0x2c, 0x64, 0x21, 0xc1, 0x9a, 0x8a, 0x6e,
+	0x7d, 0x37, 0xf2, 0x9d, 0x7c, 0xde, 0x3d, 0x76, 0xd7, 0x16, 0xa6, 0xe2, 0x27, 0x20, 0xf1, 0x27,
+	0xe8, 0x29, 0xa9, 0xe8, 0x28, 0x53, 0xa6, 0x24, 0x97, 0x86, 0x32, 0x3f, 0x01, 0xdd, 0xde, 0xf9,
+	0xec, 0x18, 0x53, 0xd0, 0xed, 0x7b, 0xb3, 0xf3, 0xf6, 0xcd, 0xbb, 0x39, 0xd8, 0x8b, 0x07, 0x8f,
+	0xc7, 0x68, 0x8c, 0x18, 0x62, 0x27, 0xd6, 0xca, 0x2a, 0xba, 0xad, 0x71, 0x18, 0x1a, 0xab, 0x67,
+	0xad, 0xef, 0x65, 0xd8, 0x7d, 0x13, 0x68, 0x34, 0x81, 0x8a, 0xfc, 0x7e, 0x38, 0x94, 0xa8, 0x69,
+	0x13, 0xaa, 0x63, 0x1c, 0x0f, 0x50, 0xbf, 0x90, 0x3e, 0xbe, 0x6f, 0x90, 0x26, 0x69, 0xd7, 0xf9,
+	0x32, 0x45, 0x1f, 0xc2, 0xff, 0x43, 0xad, 0x26, 0xf1, 0xab, 0xc9, 0x20, 0x0a, 0xbd, 0x1e, 0xce,
+	0x1a, 0xe5, 0x26, 0x69, 0xd7, 0xf8, 0x0a, 0x4b, 0x8f, 0x60, 0x3f, 0x63, 0x74, 0x38, 0x15, 0x16,
+	0x7b, 0x38, 0xeb, 0x07, 0x42, 0x63, 0xa3, 0xd2, 0x24, 0xed, 0x1d, 0xbe, 0xb6, 0x46, 0x87, 0xb0,
+	0x7f, 0x5b, 0xc5, 0xd1, 0xa6, 0xb1, 0xd1, 0xac, 0xb4, 0xab, 0x47, 0xc7, 0x9d, 0xb9, 0xf5, 0xce,
+	0x8a, 0xed, 0x4e, 0x77, 0x4d, 0xd7, 0xb9, 0xb4, 0x7a, 0xc6, 0xd7, 0x0a, 0x1e, 0x76, 0xe1, 0xe0,
+	0xaf, 0x2d, 0x74, 0x0f, 0x2a, 0x23, 0x9c, 0xe5, 0xb3, 0xa7, 0x47, 0xba, 0x0f, 0x9b, 0x53, 0x11,
+	0x4d, 0x30, 0x1f, 0x35, 0x03, 0x4f, 0xcb, 0x27, 0xa4, 0xf5, 0x99, 0x00, 0xbc, 0x74, 0xe9, 0x98,
+	0x20, 0x8c, 0xe9, 0x3d, 0xd8, 0x32, 0xce, 0x91, 0xeb, 0xae, 0xf1, 0x1c, 0xd1, 0x06, 0xfc, 0xe7,
+	0x05, 0x42, 0x4a, 0x8c, 0x9c, 0xc4, 0x0e, 0x9f, 0xc3, 0xb4, 0x32, 0x45, 0x6d, 0x42, 0x25, 0x5d,
+	0x32, 0x75, 0x3e, 0x87, 0xf4, 0x09, 0x40, 0x2c, 0xb4, 0x18, 0xa3, 0x45, 0x9d, 0x46, 0x40, 0xda,
+	0xd5, 0xa3, 0x83, 0x45, 0x04, 0x99, 0xff, 0xe2, 0x02, 0x5f, 0xba, 0xdc, 0xfa, 0x46, 0x60, 0x77,
+	0xa5, 0x4e, 0xef, 0xc3, 0x8e, 0x8b, 0xa2, 0x1f, 0x7e, 0xc0, 0x7c, 0xb6, 0x05, 0x41, 0xdb, 0xb0,
+	0x1b, 0x28, 0x89, 0xc6, 0x16, 0xc9, 0x3a, 0xa3, 0x75, 0xbe, 0x4a, 0xa7, 0x86, 0xfd, 0xd1, 0xb0,
+	0x8f, 0xe8, 0x3b, 0xc3, 0x35, 0x3e, 0x87, 0xf4, 0x11, 0xdc, 0xc9, 0xdd, 0x09, 0x1b, 0x2a, 0x79,
+	0x1a, 0x29, 0x6f, 0xe4, 0x7c, 0x6f, 0xf0, 0x3f, 0x0b, 0xa9, 0x4e, 0xb6, 0x56, 0xa6, 0xb1, 0xd9,
+	0xac, 0xa4, 0x3a, 0x39, 0x6c, 0x7d, 0x25, 0x50, 0x3f, 0xeb, 0x75, 0x9f, 0x07, 0xe8, 0x8d, 0x62,
+	0x15, 0x4a, 0x4b, 0x29, 0x6c, 0x98, 0xf4, 0xc1, 0x2c, 0x54, 0x77, 0x4e, 0xbf, 0x49, 0xe8, 0x76,
+	0x34, 0xf3, 0x99, 0x81, 0x74, 0x0e, 0x83, 0x11, 0x7a, 0x16, 0xfd, 0xbe, 0x15, 0xa3, 0x54, 0xbd,
+	0xe2, 0xd4, 0x57, 0x69, 0xda, 0x01, 0xea, 0x7e, 0x08, 0x4f, 0x45, 0x8b, 0x97, 0x9c, 0xdd, 0x1a,
+	0x5f, 0x53, 0xa1, 0x0c, 0xc0, 0x58, 0xa1, 0x6d, 0x36, 0xd6, 0xa6, 0x1b, 0x6b, 0x89, 0x69, 0x29,
+	0xa8, 0x9e, 0xf5, 0xba, 0xe7, 0xd3, 0xd0, 0x47, 0xe9, 0xe1, 0x3f, 0x58, 0x3e, 0x84, 0x6d, 0x63,
+	0x95, 0x46, 0xff, 0x99, 0x75, 0x89, 0x56, 0x78, 0x81, 0xd3, 0x1a, 0xe6, 0x8a, 0xb9, 0xb5, 0x02,
+	0xb7, 0x26, 0x70, 0xf7, 0xf5, 0x44, 0x68, 0x21, 0x6d, 0x28, 0xd1, 0x5f, 0x5a, 0x42, 0x06, 0x30,
+	0x2e, 0x50, 0x6e, 0x00, 0xc6, 0xb7, 0x96, 0x54, 0xa3, 0x30, 0x4a, 0xe6, 0xbb, 0x98, 0x23, 0xfa,
+	0x00, 0xea, 0xef, 0x16, 0x82, 0x85, 0x9b, 0xdb, 0xe4, 0xe9, 0xc9, 0xc5, 0x15, 0x2b, 0x5d, 0x5e,
+	0xb1, 0xd2, 0xcd, 0x15, 0x23, 0x1f, 0x13, 0x46, 0xbe, 0x24, 0x8c, 0xfc, 0x48, 0x18, 0xb9, 0x48,
+	0x18, 0xf9, 0x99, 0x30, 0xf2, 0x2b, 0x61, 0xa5, 0x9b, 0x84, 0x91, 0x4f, 0xd7, 0xac, 0x74, 0x71,
+	0xcd, 0x4a, 0x97, 0xd7, 0xac, 0xf4, 0xb6, 0x1c, 0x0f, 0x06, 0x5b, 0x2e, 0xd5, 0xe3, 0xdf, 0x03,
+	0x00, 0x29, 0x66, 0xbe, 0x8b, 0x94, 0x04, 0x00, 0x00,
 }
 
 func (this *ThresholdSigner) Equal(that interface{}) bool {
@@ -240,65 +553,273 @@ func (this *Membership) Equal(that interface{}) bool {
 	if this.Channel != that1.Channel {
 		return false
 	}
+	if this.Version != that1.Version {
+		return false
+	}
+	if !this.Parameters.Equal(that1.Parameters) {
+		return false
+	}
 	return true
 }
-func (this *ThresholdSigner) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *GroupParameters) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&pb.ThresholdSigner{")
-	s = append(s, "MemberIndex: "+fmt.Sprintf("%#v", this.MemberIndex)+",\n")
-	s = append(s, "GroupPublicKey: "+fmt.Sprintf("%#v", this.GroupPublicKey)+",\n")
-	s = append(s, "GroupPrivateKeyShare: "+fmt.Sprintf("%#v", this.GroupPrivateKeyShare)+",\n")
-	keysForGroupPublicKeyShares := make([]uint32, 0, len(this.GroupPublicKeyShares))
-	for k, _ := range this.GroupPublicKeyShares {
-		keysForGroupPublicKeyShares = append(keysForGroupPublicKeyShares, k)
+
+	that1, ok := that.(*GroupParameters)
+	if !ok {
+		that2, ok := that.(GroupParameters)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	github_com_gogo_protobuf_sortkeys.Uint32s(keysForGroupPublicKeyShares)
-	mapStringForGroupPublicKeyShares := "map[uint32][]byte{"
-	for _, k := range keysForGroupPublicKeyShares {
-		mapStringForGroupPublicKeyShares += fmt.Sprintf("%#v: %#v,", k, this.GroupPublicKeyShares[k])
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	mapStringForGroupPublicKeyShares += "}"
-	if this.GroupPublicKeyShares != nil {
-		s = append(s, "GroupPublicKeyShares: "+mapStringForGroupPublicKeyShares+",\n")
+	if this.GroupSize != that1.GroupSize {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *Membership) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.HonestThreshold != that1.HonestThreshold {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.Membership{")
-	s = append(s, "Signer: "+fmt.Sprintf("%#v", this.Signer)+",\n")
-	s = append(s, "Channel: "+fmt.Sprintf("%#v", this.Channel)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func valueToGoStringMessage(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if !bytes.Equal(this.DkgSeed, that1.DkgSeed) {
+		return false
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-func (m *ThresholdSigner) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.RegistrationBlock != that1.RegistrationBlock {
+		return false
 	}
-	return dAtA[:n], nil
+	if len(this.Members) != len(that1.Members) {
+		return false
+	}
+	for i := range this.Members {
+		if !bytes.Equal(this.Members[i], that1.Members[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *DKGCheckpoint) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ThresholdSigner) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*DKGCheckpoint)
+	if !ok {
+		that2, ok := that.(DKGCheckpoint)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Seed, that1.Seed) {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if len(this.SelectedStakers) != len(that1.SelectedStakers) {
+		return false
+	}
+	for i := range this.SelectedStakers {
+		if !bytes.Equal(this.SelectedStakers[i], that1.SelectedStakers[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.ProtocolCheckpoint, that1.ProtocolCheckpoint) {
+		return false
+	}
+	if this.StartBlock != that1.StartBlock {
+		return false
+	}
+	return true
+}
+func (this *DKGEvidence) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*DKGEvidence)
+	if !ok {
+		that2, ok := that.(DKGEvidence)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Seed, that1.Seed) {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if this.StoredAt != that1.StoredAt {
+		return false
+	}
+	if !bytes.Equal(this.Evidence, that1.Evidence) {
+		return false
+	}
+	return true
+}
+func (this *QuarantinedMembership) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*QuarantinedMembership)
+	if !ok {
+		that2, ok := that.(QuarantinedMembership)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Membership, that1.Membership) {
+		return false
+	}
+	if this.Reason != that1.Reason {
+		return false
+	}
+	if this.QuarantinedAt != that1.QuarantinedAt {
+		return false
+	}
+	return true
+}
+func (this *ThresholdSigner) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.ThresholdSigner{")
+	s = append(s, "MemberIndex: "+fmt.Sprintf("%#v", this.MemberIndex)+",\n")
+	s = append(s, "GroupPublicKey: "+fmt.Sprintf("%#v", this.GroupPublicKey)+",\n")
+	s = append(s, "GroupPrivateKeyShare: "+fmt.Sprintf("%#v", this.GroupPrivateKeyShare)+",\n")
+	keysForGroupPublicKeyShares := make([]uint32, 0, len(this.GroupPublicKeyShares))
+	for k, _ := range this.GroupPublicKeyShares {
+		keysForGroupPublicKeyShares = append(keysForGroupPublicKeyShares, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForGroupPublicKeyShares)
+	mapStringForGroupPublicKeyShares := "map[uint32][]byte{"
+	for _, k := range keysForGroupPublicKeyShares {
+		mapStringForGroupPublicKeyShares += fmt.Sprintf("%#v: %#v,", k, this.GroupPublicKeyShares[k])
+	}
+	mapStringForGroupPublicKeyShares += "}"
+	if this.GroupPublicKeyShares != nil {
+		s = append(s, "GroupPublicKeyShares: "+mapStringForGroupPublicKeyShares+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Membership) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.Membership{")
+	s = append(s, "Signer: "+fmt.Sprintf("%#v", this.Signer)+",\n")
+	s = append(s, "Channel: "+fmt.Sprintf("%#v", this.Channel)+",\n")
+	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	if this.Parameters != nil {
+		s = append(s, "Parameters: "+fmt.Sprintf("%#v", this.Parameters)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GroupParameters) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.GroupParameters{")
+	s = append(s, "GroupSize: "+fmt.Sprintf("%#v", this.GroupSize)+",\n")
+	s = append(s, "HonestThreshold: "+fmt.Sprintf("%#v", this.HonestThreshold)+",\n")
+	s = append(s, "DkgSeed: "+fmt.Sprintf("%#v", this.DkgSeed)+",\n")
+	s = append(s, "RegistrationBlock: "+fmt.Sprintf("%#v", this.RegistrationBlock)+",\n")
+	s = append(s, "Members: "+fmt.Sprintf("%#v", this.Members)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DKGCheckpoint) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.DKGCheckpoint{")
+	s = append(s, "Seed: "+fmt.Sprintf("%#v", this.Seed)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "SelectedStakers: "+fmt.Sprintf("%#v", this.SelectedStakers)+",\n")
+	s = append(s, "ProtocolCheckpoint: "+fmt.Sprintf("%#v", this.ProtocolCheckpoint)+",\n")
+	s = append(s, "StartBlock: "+fmt.Sprintf("%#v", this.StartBlock)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DKGEvidence) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.DKGEvidence{")
+	s = append(s, "Seed: "+fmt.Sprintf("%#v", this.Seed)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "StoredAt: "+fmt.Sprintf("%#v", this.StoredAt)+",\n")
+	s = append(s, "Evidence: "+fmt.Sprintf("%#v", this.Evidence)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *QuarantinedMembership) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.QuarantinedMembership{")
+	s = append(s, "Membership: "+fmt.Sprintf("%#v", this.Membership)+",\n")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "QuarantinedAt: "+fmt.Sprintf("%#v", this.QuarantinedAt)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringMessage(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *ThresholdSigner) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ThresholdSigner) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
 func (m *ThresholdSigner) MarshalToSizedBuffer(dAtA []byte) (int, error) {
@@ -367,6 +888,23 @@ func (m *Membership) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Parameters != nil {
+		{
+			size, err := m.Parameters.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintMessage(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Version != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.Channel) > 0 {
 		i -= len(m.Channel)
 		copy(dAtA[i:], m.Channel)
@@ -384,115 +922,1269 @@ func (m *Membership) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
-	offset -= sovMessage(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *GroupParameters) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ThresholdSigner) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *GroupParameters) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GroupParameters) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Members[iNdEx])
+			copy(dAtA[i:], m.Members[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.Members[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.RegistrationBlock != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.RegistrationBlock))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.DkgSeed) > 0 {
+		i -= len(m.DkgSeed)
+		copy(dAtA[i:], m.DkgSeed)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.DkgSeed)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.HonestThreshold != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.HonestThreshold))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupSize != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.GroupSize))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DKGCheckpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DKGCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DKGCheckpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.MemberIndex != 0 {
-		n += 1 + sovMessage(uint64(m.MemberIndex))
+	if m.StartBlock != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StartBlock))
+		i--
+		dAtA[i] = 0x28
 	}
-	l = len(m.GroupPublicKey)
-	if l > 0 {
-		n += 1 + l + sovMessage(uint64(l))
+	if len(m.ProtocolCheckpoint) > 0 {
+		i -= len(m.ProtocolCheckpoint)
+		copy(dAtA[i:], m.ProtocolCheckpoint)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.ProtocolCheckpoint)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.GroupPrivateKeyShare)
-	if l > 0 {
-		n += 1 + l + sovMessage(uint64(l))
+	if len(m.SelectedStakers) > 0 {
+		for iNdEx := len(m.SelectedStakers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SelectedStakers[iNdEx])
+			copy(dAtA[i:], m.SelectedStakers[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.SelectedStakers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	if len(m.GroupPublicKeyShares) > 0 {
-		for k, v := range m.GroupPublicKeyShares {
-			_ = k
-			_ = v
-			l = 0
-			if len(v) > 0 {
-				l = 1 + len(v) + sovMessage(uint64(len(v)))
+	if m.Index != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Seed) > 0 {
+		i -= len(m.Seed)
+		copy(dAtA[i:], m.Seed)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Seed)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DKGEvidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DKGEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DKGEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Evidence) > 0 {
+		i -= len(m.Evidence)
+		copy(dAtA[i:], m.Evidence)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Evidence)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.StoredAt != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StoredAt))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Index != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Seed) > 0 {
+		i -= len(m.Seed)
+		copy(dAtA[i:], m.Seed)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Seed)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QuarantinedMembership) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuarantinedMembership) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuarantinedMembership) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.QuarantinedAt != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.QuarantinedAt))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Membership) > 0 {
+		i -= len(m.Membership)
+		copy(dAtA[i:], m.Membership)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Membership)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
+	offset -= sovMessage(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ThresholdSigner) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberIndex != 0 {
+		n += 1 + sovMessage(uint64(m.MemberIndex))
+	}
+	l = len(m.GroupPublicKey)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.GroupPrivateKeyShare)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if len(m.GroupPublicKeyShares) > 0 {
+		for k, v := range m.GroupPublicKeyShares {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovMessage(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovMessage(uint64(k)) + l
+			n += mapEntrySize + 1 + sovMessage(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *Membership) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Signer)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovMessage(uint64(m.Version))
+	}
+	if m.Parameters != nil {
+		l = m.Parameters.Size()
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *GroupParameters) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupSize != 0 {
+		n += 1 + sovMessage(uint64(m.GroupSize))
+	}
+	if m.HonestThreshold != 0 {
+		n += 1 + sovMessage(uint64(m.HonestThreshold))
+	}
+	l = len(m.DkgSeed)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.RegistrationBlock != 0 {
+		n += 1 + sovMessage(uint64(m.RegistrationBlock))
+	}
+	if len(m.Members) > 0 {
+		for _, b := range m.Members {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DKGCheckpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Seed)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovMessage(uint64(m.Index))
+	}
+	if len(m.SelectedStakers) > 0 {
+		for _, b := range m.SelectedStakers {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	l = len(m.ProtocolCheckpoint)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.StartBlock != 0 {
+		n += 1 + sovMessage(uint64(m.StartBlock))
+	}
+	return n
+}
+
+func (m *DKGEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Seed)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovMessage(uint64(m.Index))
+	}
+	if m.StoredAt != 0 {
+		n += 1 + sovMessage(uint64(m.StoredAt))
+	}
+	l = len(m.Evidence)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *QuarantinedMembership) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Membership)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.QuarantinedAt != 0 {
+		n += 1 + sovMessage(uint64(m.QuarantinedAt))
+	}
+	return n
+}
+
+func sovMessage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozMessage(x uint64) (n int) {
+	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ThresholdSigner) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForGroupPublicKeyShares := make([]uint32, 0, len(this.GroupPublicKeyShares))
+	for k, _ := range this.GroupPublicKeyShares {
+		keysForGroupPublicKeyShares = append(keysForGroupPublicKeyShares, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForGroupPublicKeyShares)
+	mapStringForGroupPublicKeyShares := "map[uint32][]byte{"
+	for _, k := range keysForGroupPublicKeyShares {
+		mapStringForGroupPublicKeyShares += fmt.Sprintf("%v: %v,", k, this.GroupPublicKeyShares[k])
+	}
+	mapStringForGroupPublicKeyShares += "}"
+	s := strings.Join([]string{`&ThresholdSigner{`,
+		`MemberIndex:` + fmt.Sprintf("%v", this.MemberIndex) + `,`,
+		`GroupPublicKey:` + fmt.Sprintf("%v", this.GroupPublicKey) + `,`,
+		`GroupPrivateKeyShare:` + fmt.Sprintf("%v", this.GroupPrivateKeyShare) + `,`,
+		`GroupPublicKeyShares:` + mapStringForGroupPublicKeyShares + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Membership) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Membership{`,
+		`Signer:` + fmt.Sprintf("%v", this.Signer) + `,`,
+		`Channel:` + fmt.Sprintf("%v", this.Channel) + `,`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`Parameters:` + strings.Replace(this.Parameters.String(), "GroupParameters", "GroupParameters", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GroupParameters) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GroupParameters{`,
+		`GroupSize:` + fmt.Sprintf("%v", this.GroupSize) + `,`,
+		`HonestThreshold:` + fmt.Sprintf("%v", this.HonestThreshold) + `,`,
+		`DkgSeed:` + fmt.Sprintf("%v", this.DkgSeed) + `,`,
+		`RegistrationBlock:` + fmt.Sprintf("%v", this.RegistrationBlock) + `,`,
+		`Members:` + fmt.Sprintf("%v", this.Members) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DKGCheckpoint) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DKGCheckpoint{`,
+		`Seed:` + fmt.Sprintf("%v", this.Seed) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`SelectedStakers:` + fmt.Sprintf("%v", this.SelectedStakers) + `,`,
+		`ProtocolCheckpoint:` + fmt.Sprintf("%v", this.ProtocolCheckpoint) + `,`,
+		`StartBlock:` + fmt.Sprintf("%v", this.StartBlock) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DKGEvidence) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DKGEvidence{`,
+		`Seed:` + fmt.Sprintf("%v", this.Seed) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`StoredAt:` + fmt.Sprintf("%v", this.StoredAt) + `,`,
+		`Evidence:` + fmt.Sprintf("%v", this.Evidence) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QuarantinedMembership) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&QuarantinedMembership{`,
+		`Membership:` + fmt.Sprintf("%v", this.Membership) + `,`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`QuarantinedAt:` + fmt.Sprintf("%v", this.QuarantinedAt) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringMessage(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ThresholdSigner) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThresholdSigner: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThresholdSigner: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberIndex", wireType)
+			}
+			m.MemberIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupPublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupPublicKey = append(m.GroupPublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.GroupPublicKey == nil {
+				m.GroupPublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupPrivateKeyShare", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupPrivateKeyShare = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupPublicKeyShares", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GroupPublicKeyShares == nil {
+				m.GroupPublicKeyShares = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMessage
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMessage
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMessage
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthMessage
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthMessage
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipMessage(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthMessage
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.GroupPublicKeyShares[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Membership) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Membership: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Membership: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signer = append(m.Signer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signer == nil {
+				m.Signer = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Parameters == nil {
+				m.Parameters = &GroupParameters{}
+			}
+			if err := m.Parameters.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GroupParameters) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GroupParameters: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GroupParameters: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupSize", wireType)
+			}
+			m.GroupSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HonestThreshold", wireType)
+			}
+			m.HonestThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HonestThreshold |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DkgSeed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DkgSeed = append(m.DkgSeed[:0], dAtA[iNdEx:postIndex]...)
+			if m.DkgSeed == nil {
+				m.DkgSeed = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegistrationBlock", wireType)
+			}
+			m.RegistrationBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegistrationBlock |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, make([]byte, postIndex-iNdEx))
+			copy(m.Members[len(m.Members)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DKGCheckpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DKGCheckpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DKGCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Seed = append(m.Seed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seed == nil {
+				m.Seed = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelectedStakers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SelectedStakers = append(m.SelectedStakers, make([]byte, postIndex-iNdEx))
+			copy(m.SelectedStakers[len(m.SelectedStakers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolCheckpoint", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProtocolCheckpoint = append(m.ProtocolCheckpoint[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProtocolCheckpoint == nil {
+				m.ProtocolCheckpoint = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartBlock", wireType)
+			}
+			m.StartBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartBlock |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			mapEntrySize := 1 + sovMessage(uint64(k)) + l
-			n += mapEntrySize + 1 + sovMessage(uint64(mapEntrySize))
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
-
-func (m *Membership) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Signer)
-	if l > 0 {
-		n += 1 + l + sovMessage(uint64(l))
-	}
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovMessage(uint64(l))
-	}
-	return n
-}
 
-func sovMessage(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozMessage(x uint64) (n int) {
-	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *ThresholdSigner) String() string {
-	if this == nil {
-		return "nil"
-	}
-	keysForGroupPublicKeyShares := make([]uint32, 0, len(this.GroupPublicKeyShares))
-	for k, _ := range this.GroupPublicKeyShares {
-		keysForGroupPublicKeyShares = append(keysForGroupPublicKeyShares, k)
-	}
-	github_com_gogo_protobuf_sortkeys.Uint32s(keysForGroupPublicKeyShares)
-	mapStringForGroupPublicKeyShares := "map[uint32][]byte{"
-	for _, k := range keysForGroupPublicKeyShares {
-		mapStringForGroupPublicKeyShares += fmt.Sprintf("%v: %v,", k, this.GroupPublicKeyShares[k])
-	}
-	mapStringForGroupPublicKeyShares += "}"
-	s := strings.Join([]string{`&ThresholdSigner{`,
-		`MemberIndex:` + fmt.Sprintf("%v", this.MemberIndex) + `,`,
-		`GroupPublicKey:` + fmt.Sprintf("%v", this.GroupPublicKey) + `,`,
-		`GroupPrivateKeyShare:` + fmt.Sprintf("%v", this.GroupPrivateKeyShare) + `,`,
-		`GroupPublicKeyShares:` + mapStringForGroupPublicKeyShares + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Membership) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&Membership{`,
-		`Signer:` + fmt.Sprintf("%v", this.Signer) + `,`,
-		`Channel:` + fmt.Sprintf("%v", this.Channel) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringMessage(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *ThresholdSigner) Unmarshal(dAtA []byte) error {
+func (m *DKGEvidence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -515,34 +2207,15 @@ func (m *ThresholdSigner) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ThresholdSigner: wiretype end group for non-group")
+			return fmt.Errorf("proto: DKGEvidence: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ThresholdSigner: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DKGEvidence: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemberIndex", wireType)
-			}
-			m.MemberIndex = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMessage
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.MemberIndex |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupPublicKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Seed", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -569,16 +2242,16 @@ func (m *ThresholdSigner) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GroupPublicKey = append(m.GroupPublicKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.GroupPublicKey == nil {
-				m.GroupPublicKey = []byte{}
+			m.Seed = append(m.Seed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seed == nil {
+				m.Seed = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupPrivateKeyShare", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var stringLen uint64
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMessage
@@ -588,29 +2261,35 @@ func (m *ThresholdSigner) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Index |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthMessage
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthMessage
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoredAt", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.StoredAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoredAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.GroupPrivateKeyShare = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupPublicKeyShares", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMessage
@@ -620,105 +2299,25 @@ func (m *ThresholdSigner) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthMessage
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMessage
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.GroupPublicKeyShares == nil {
-				m.GroupPublicKeyShares = make(map[uint32][]byte)
-			}
-			var mapkey uint32
-			mapvalue := []byte{}
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowMessage
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowMessage
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapkey |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else if fieldNum == 2 {
-					var mapbyteLen uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowMessage
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapbyteLen |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intMapbyteLen := int(mapbyteLen)
-					if intMapbyteLen < 0 {
-						return ErrInvalidLengthMessage
-					}
-					postbytesIndex := iNdEx + intMapbyteLen
-					if postbytesIndex < 0 {
-						return ErrInvalidLengthMessage
-					}
-					if postbytesIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = make([]byte, mapbyteLen)
-					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
-					iNdEx = postbytesIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipMessage(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthMessage
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			m.Evidence = append(m.Evidence[:0], dAtA[iNdEx:postIndex]...)
+			if m.Evidence == nil {
+				m.Evidence = []byte{}
 			}
-			m.GroupPublicKeyShares[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -744,7 +2343,7 @@ func (m *ThresholdSigner) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Membership) Unmarshal(dAtA []byte) error {
+func (m *QuarantinedMembership) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -767,15 +2366,15 @@ func (m *Membership) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Membership: wiretype end group for non-group")
+			return fmt.Errorf("proto: QuarantinedMembership: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Membership: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QuarantinedMembership: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Membership", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -802,14 +2401,14 @@ func (m *Membership) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Signer = append(m.Signer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Signer == nil {
-				m.Signer = []byte{}
+			m.Membership = append(m.Membership[:0], dAtA[iNdEx:postIndex]...)
+			if m.Membership == nil {
+				m.Membership = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -837,8 +2436,27 @@ func (m *Membership) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Channel = string(dAtA[iNdEx:postIndex])
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuarantinedAt", wireType)
+			}
+			m.QuarantinedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QuarantinedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
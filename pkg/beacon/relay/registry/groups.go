@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"sync"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
@@ -20,6 +21,9 @@ type Groups struct {
 	// key is group public key in uncompressed form
 	myGroups map[string][]*Membership
 
+	// memberships which failed the integrity check; never used for signing
+	quarantined []*QuarantinedMembership
+
 	relayChain relaychain.GroupRegistrationInterface
 
 	storage storage
@@ -29,6 +33,36 @@ type Groups struct {
 type Membership struct {
 	Signer      *dkg.ThresholdSigner
 	ChannelName string
+	// Parameters of the group as of its creation. They are nil for
+	// memberships persisted in the legacy format until they are migrated
+	// with MigrateMemberships.
+	Parameters *GroupParameters
+}
+
+// GroupParameters are parameters of a group as of its creation. Groups keep
+// their parameters for their whole life, even if the chain configuration for
+// new groups changes.
+type GroupParameters struct {
+	// GroupSize is the number of members in the group.
+	GroupSize int
+	// HonestThreshold is the minimum number of active group members needed
+	// to produce a group signature.
+	HonestThreshold int
+	// DKGSeed is the seed of the distributed key generation which created
+	// the group, nil if not known.
+	DKGSeed *big.Int
+	// RegistrationBlock is the block at which the group was registered
+	// on-chain, zero if not known.
+	RegistrationBlock uint64
+	// Members are addresses of all stakers selected to the group, ordered by
+	// their member index.
+	Members []relaychain.StakerAddress
+}
+
+// DishonestThreshold is the maximum number of misbehaving group members for
+// which it is still possible to produce a group signature.
+func (gp *GroupParameters) DishonestThreshold() int {
+	return gp.GroupSize - gp.HonestThreshold
 }
 
 // NewGroupRegistry returns an empty GroupRegistry.
@@ -49,7 +83,12 @@ func NewGroupRegistry(
 func (g *Groups) RegisterGroup(
 	signer *dkg.ThresholdSigner,
 	channelName string,
+	parameters *GroupParameters,
 ) error {
+	if parameters == nil {
+		return fmt.Errorf("group parameters are required")
+	}
+
 	g.mutex.Lock()
 	defer g.mutex.Unlock()
 
@@ -58,6 +97,7 @@ func (g *Groups) RegisterGroup(
 	membership := &Membership{
 		Signer:      signer,
 		ChannelName: channelName,
+		Parameters:  parameters,
 	}
 
 	err := g.storage.save(membership)
@@ -70,6 +110,88 @@ func (g *Groups) RegisterGroup(
 	return nil
 }
 
+// RefreshMembership replaces the signer of the membership held in the group
+// of the given signer by the member with the same index with the given
+// signer, which holds refreshed shares of the group private key committed by
+// the group. The pending refresh stored with SavePendingRefresh is first
+// marked as committed, so that it is applied when the client starts even if
+// the client stops in the middle of replacing the membership. Then
+// the previous membership is stored as a snapshot and the refreshed one
+// replaces it in the storage. The registry is updated even if persisting
+// fails, as other members no longer accept signatures calculated with
+// previous shares. Memberships obtained from the registry before the refresh
+// keep the previous signer.
+func (g *Groups) RefreshMembership(signer *dkg.ThresholdSigner) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	membership, err := g.findMembership(signer)
+	if err != nil {
+		return err
+	}
+
+	refreshedMembership := &Membership{
+		Signer:      signer,
+		ChannelName: membership.ChannelName,
+		Parameters:  membership.Parameters,
+	}
+
+	groupPublicKey := groupKeyToString(signer.GroupPublicKeyBytes())
+	refreshedMemberships := make([]*Membership, 0)
+	for _, current := range g.myGroups[groupPublicKey] {
+		if current == membership {
+			current = refreshedMembership
+		}
+		refreshedMemberships = append(refreshedMemberships, current)
+	}
+	g.myGroups[groupPublicKey] = refreshedMemberships
+
+	err = g.storage.commitPendingRefresh(refreshedMembership)
+	if err != nil {
+		logger.Warningf(
+			"could not mark pending share refresh of member [%v] "+
+				"in group [0x%v] as committed: [%v]",
+			signer.MemberID(),
+			groupPublicKey,
+			err,
+		)
+	}
+
+	err = g.storage.snapshot(membership)
+	if err != nil {
+		return fmt.Errorf(
+			"could not snapshot previous membership: [%v]",
+			err,
+		)
+	}
+
+	err = g.storage.replace(refreshedMembership)
+	if err != nil {
+		return fmt.Errorf(
+			"could not persist refreshed membership to the storage: [%v]",
+			err,
+		)
+	}
+
+	// If the pending refresh could not be archived, it is applied once again
+	// when the client starts.
+	err = g.storage.archivePendingRefresh(
+		signer.GroupPublicKeyBytesCompressed(),
+		signer.MemberID(),
+	)
+	if err != nil {
+		logger.Warningf(
+			"could not archive pending share refresh of member [%v] "+
+				"in group [0x%v]: [%v]",
+			signer.MemberID(),
+			groupPublicKey,
+			err,
+		)
+	}
+
+	return nil
+}
+
 // GetGroup gets a group by a groupPublicKey
 func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 	g.mutex.Lock()
@@ -78,6 +200,21 @@ func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 	return g.myGroups[groupKeyToString(groupPublicKey)]
 }
 
+// GetGroups returns all groups the client is a member of, keyed by the group
+// public key in uncompressed, hex-encoded form. The returned map is a copy and
+// can be safely read while the registry is being updated.
+func (g *Groups) GetGroups() map[string][]*Membership {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	groups := make(map[string][]*Membership, len(g.myGroups))
+	for groupPublicKey, memberships := range g.myGroups {
+		groups[groupPublicKey] = append([]*Membership{}, memberships...)
+	}
+
+	return groups
+}
+
 // UnregisterStaleGroups lookup for groups that have been marked as stale
 // on-chain. A stale group is a group that has expired and a certain time passed
 // after the group expiration. This guarantees the group will not be selected to
@@ -144,9 +281,17 @@ func (g *Groups) UnregisterStaleGroups(latestGroupPublicKey []byte) {
 }
 
 // LoadExistingGroups iterates over all stored memberships on disk and loads them
-// into memory
+// into memory. Quarantined memberships are loaded separately and are never
+// added to the registry. Share refreshes left pending when the client stopped
+// are applied if the group committed them and discarded otherwise.
 func (g *Groups) LoadExistingGroups() {
 	g.myGroups = make(map[string][]*Membership)
+	g.quarantined = g.storage.readQuarantined()
+
+	isQuarantined := make(map[string]bool)
+	for _, quarantinedMembership := range g.quarantined {
+		isQuarantined[membershipKey(quarantinedMembership.Membership)] = true
+	}
 
 	membershipsChannel, errorsChannel := g.storage.readAll()
 
@@ -161,6 +306,16 @@ func (g *Groups) LoadExistingGroups() {
 
 	go func() {
 		for membership := range membershipsChannel {
+			if isQuarantined[membershipKey(membership)] {
+				logger.Warningf(
+					"skipping quarantined membership of member [%v] "+
+						"in group [0x%v]",
+					membership.Signer.MemberID(),
+					groupKeyToString(membership.Signer.GroupPublicKeyBytes()),
+				)
+				continue
+			}
+
 			groupPublicKey := groupKeyToString(
 				membership.Signer.GroupPublicKeyBytes(),
 			)
@@ -186,9 +341,20 @@ func (g *Groups) LoadExistingGroups() {
 
 	wg.Wait()
 
+	g.resolvePendingRefreshes()
+
 	g.printMemberships()
 }
 
+// membershipKey identifies the membership by its group and member index.
+func membershipKey(membership *Membership) string {
+	return fmt.Sprintf(
+		"%v_%v",
+		groupKeyToString(membership.Signer.GroupPublicKeyBytes()),
+		membership.Signer.MemberID(),
+	)
+}
+
 func (g *Groups) printMemberships() {
 	for group, memberships := range g.myGroups {
 		logger.Infof("group [0x%v] loaded with [%v] members", group, len(memberships))
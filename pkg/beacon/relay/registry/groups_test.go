@@ -3,9 +3,12 @@ package registry
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-common/pkg/persistence"
@@ -14,6 +17,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/persistence/layout"
 	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
@@ -49,6 +53,38 @@ var (
 		big.NewInt(2),
 		groupPublicKeyShares,
 	)
+
+	groupParameters = &GroupParameters{
+		GroupSize:         3,
+		HonestThreshold:   2,
+		DKGSeed:           big.NewInt(18313131145),
+		RegistrationBlock: 12,
+		Members: []chain.StakerAddress{
+			[]byte{0x01},
+			[]byte{0x02},
+			[]byte{0x03},
+		},
+	}
+
+	dkgCheckpoint = &DKGCheckpoint{
+		Seed:               big.NewInt(18313131145),
+		Index:              1,
+		SelectedStakers:    []chain.StakerAddress{[]byte{0x01}, []byte{0x02}},
+		ProtocolCheckpoint: []byte{0x03},
+	}
+
+	dkgEvidence = &DKGEvidence{
+		Seed:     big.NewInt(18313131145),
+		Index:    1,
+		StoredAt: time.Unix(1600000000, 0),
+		Evidence: []byte{0x04},
+	}
+	expiredDKGEvidence = &DKGEvidence{
+		Seed:     big.NewInt(18313131146),
+		Index:    2,
+		StoredAt: time.Unix(1500000000, 0),
+		Evidence: []byte{0x05},
+	}
 )
 
 func TestRegisterGroup(t *testing.T) {
@@ -56,7 +92,7 @@ func TestRegisterGroup(t *testing.T) {
 
 	gr := NewGroupRegistry(chain, persistenceMock)
 
-	gr.RegisterGroup(signer1, channelName1)
+	gr.RegisterGroup(signer1, channelName1, groupParameters)
 
 	actual := gr.GetGroup(signer1.GroupPublicKeyBytes())
 
@@ -75,6 +111,273 @@ func TestRegisterGroup(t *testing.T) {
 	}
 }
 
+func TestRefreshMembership(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	gr := NewGroupRegistry(chain, &persistenceHandleMock{})
+
+	gr.RegisterGroup(signer1, channelName1, groupParameters)
+
+	previousMemberships := gr.GetGroup(signer1.GroupPublicKeyBytes())
+
+	refreshedSigner := dkg.NewThresholdSigner(
+		signer1.MemberID(),
+		new(bn256.G2).ScalarBaseMult(big.NewInt(10)),
+		big.NewInt(11),
+		groupPublicKeyShares,
+	)
+
+	err := gr.RefreshMembership(refreshedSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := gr.GetGroup(signer1.GroupPublicKeyBytes())
+	if len(actual) != 1 {
+		t.Fatalf(
+			"Unexpected number of group memberships \nExpected: [%+v]\nActual:   [%+v]",
+			1,
+			len(actual),
+		)
+	}
+
+	if actual[0].Signer != refreshedSigner {
+		t.Errorf("membership signer has not been refreshed")
+	}
+
+	if actual[0].ChannelName != channelName1 {
+		t.Errorf(
+			"Unexpected channel name \nExpected: [%+v]\nActual:   [%+v]",
+			channelName1,
+			actual[0].ChannelName,
+		)
+	}
+
+	if actual[0].Parameters != groupParameters {
+		t.Errorf("group parameters have not been retained")
+	}
+
+	if previousMemberships[0].Signer != signer1 {
+		t.Errorf("previously obtained membership has been modified")
+	}
+}
+
+func TestRefreshNotRegisteredMembership(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	gr := NewGroupRegistry(chain, persistenceMock)
+
+	gr.RegisterGroup(signer1, channelName1, groupParameters)
+
+	err := gr.RefreshMembership(signer3)
+	if err == nil {
+		t.Fatal("expected an error for not registered membership")
+	}
+}
+
+func TestLoadRefreshedMembership(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	refreshedSigner := dkg.NewThresholdSigner(
+		signer1.MemberID(),
+		new(bn256.G2).ScalarBaseMult(big.NewInt(10)),
+		big.NewInt(11),
+		groupPublicKeyShares,
+	)
+
+	membershipFile := hex.EncodeToString(
+		signer1.GroupPublicKeyBytesCompressed(),
+	) + "/membership_1"
+	refreshedMembershipFile := membershipFile + "_refreshed"
+
+	var tests = map[string]struct {
+		// corrupt simulates a crash in the middle of writing files
+		corrupt        func(files map[string][]byte, previous []byte)
+		expectedSigner *dkg.ThresholdSigner
+	}{
+		"refresh completed": {
+			corrupt:        func(files map[string][]byte, previous []byte) {},
+			expectedSigner: refreshedSigner,
+		},
+		"crash when overwriting the previous membership": {
+			corrupt: func(files map[string][]byte, previous []byte) {
+				files[membershipFile] = files[membershipFile][:10]
+			},
+			expectedSigner: refreshedSigner,
+		},
+		"crash when writing the refreshed membership": {
+			corrupt: func(files map[string][]byte, previous []byte) {
+				files[membershipFile] = previous
+				files[refreshedMembershipFile] = files[refreshedMembershipFile][:10]
+			},
+			expectedSigner: signer1,
+		},
+		"crash when writing group parameters of the refreshed membership": {
+			corrupt: func(files map[string][]byte, previous []byte) {
+				refreshedMembershipBytes, _ := (&Membership{
+					Signer:      refreshedSigner,
+					ChannelName: channelName1,
+				}).Marshal()
+
+				files[membershipFile] = previous
+				files[refreshedMembershipFile] = refreshedMembershipBytes
+			},
+			expectedSigner: signer1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			persistence := &memoryPersistenceHandle{
+				files: make(map[string][]byte),
+			}
+
+			gr := NewGroupRegistry(chain, persistence)
+
+			err := gr.RegisterGroup(signer1, channelName1, groupParameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			previous := persistence.files[membershipFile]
+
+			err = gr.RefreshMembership(refreshedSigner)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.corrupt(persistence.files, previous)
+
+			loaded := NewGroupRegistry(chain, persistence)
+			loaded.LoadExistingGroups()
+
+			memberships := loaded.GetGroup(signer1.GroupPublicKeyBytes())
+			if len(memberships) != 1 {
+				t.Fatalf(
+					"Unexpected number of group memberships \nExpected: [%+v]\nActual:   [%+v]",
+					1,
+					len(memberships),
+				)
+			}
+
+			expectedSigner, _ := test.expectedSigner.Marshal()
+			actualSigner, _ := memberships[0].Signer.Marshal()
+			if !bytes.Equal(expectedSigner, actualSigner) {
+				t.Errorf("unexpected signer loaded")
+			}
+
+			if !reflect.DeepEqual(groupParameters, memberships[0].Parameters) {
+				t.Errorf(
+					"Unexpected group parameters \nExpected: [%+v]\nActual:   [%+v]",
+					groupParameters,
+					memberships[0].Parameters,
+				)
+			}
+		})
+	}
+}
+
+func TestLoadPendingRefresh(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	refreshedSigner := dkg.NewThresholdSigner(
+		signer1.MemberID(),
+		new(bn256.G2).ScalarBaseMult(big.NewInt(10)),
+		big.NewInt(11),
+		groupPublicKeyShares,
+	)
+
+	var tests = map[string]struct {
+		// stop simulates the client stopping after the refreshed shares
+		// have been persisted as a pending refresh
+		stop           func(gr *Groups) error
+		expectedSigner *dkg.ThresholdSigner
+	}{
+		"stopped before the commit block": {
+			stop:           func(gr *Groups) error { return nil },
+			expectedSigner: signer1,
+		},
+		"stopped after the group committed the refresh": {
+			stop: func(gr *Groups) error {
+				return gr.storage.commitPendingRefresh(&Membership{
+					Signer: refreshedSigner,
+				})
+			},
+			expectedSigner: refreshedSigner,
+		},
+		"stopped after the group did not commit the refresh": {
+			stop: func(gr *Groups) error {
+				return gr.DiscardPendingRefresh(refreshedSigner)
+			},
+			expectedSigner: signer1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			persistence := &memoryPersistenceHandle{
+				files: make(map[string][]byte),
+			}
+
+			gr := NewGroupRegistry(chain, persistence)
+
+			err := gr.RegisterGroup(signer1, channelName1, groupParameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = gr.SavePendingRefresh(refreshedSigner)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			registered := gr.GetGroup(signer1.GroupPublicKeyBytes())
+			if registered[0].Signer != signer1 {
+				t.Fatalf("pending refresh has been applied before the commit")
+			}
+
+			err = test.stop(gr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 2; i++ {
+				loaded := NewGroupRegistry(chain, persistence)
+				loaded.LoadExistingGroups()
+
+				memberships := loaded.GetGroup(signer1.GroupPublicKeyBytes())
+				if len(memberships) != 1 {
+					t.Fatalf(
+						"Unexpected number of group memberships \nExpected: [%+v]\nActual:   [%+v]",
+						1,
+						len(memberships),
+					)
+				}
+
+				expectedSigner, _ := test.expectedSigner.Marshal()
+				actualSigner, _ := memberships[0].Signer.Marshal()
+				if !bytes.Equal(expectedSigner, actualSigner) {
+					t.Errorf("unexpected signer loaded on start [%v]", i)
+				}
+
+				if !reflect.DeepEqual(groupParameters, memberships[0].Parameters) {
+					t.Errorf(
+						"Unexpected group parameters \nExpected: [%+v]\nActual:   [%+v]",
+						groupParameters,
+						memberships[0].Parameters,
+					)
+				}
+			}
+
+			for path := range persistence.files {
+				if strings.HasPrefix(path, pendingRefreshDirectoryPrefix) {
+					t.Errorf("pending refresh [%v] has not been resolved", path)
+				}
+			}
+		})
+	}
+}
+
 func TestLoadGroup(t *testing.T) {
 	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
 	gr := NewGroupRegistry(chain, persistenceMock)
@@ -114,6 +417,323 @@ func TestLoadGroup(t *testing.T) {
 	if !reflect.DeepEqual(expectedMembership2, actualMembership2) {
 		t.Errorf("\nexpected: %v\nactual:   %v", expectedMembership2, actualMembership2)
 	}
+
+	// membership of signer4 is quarantined and should not be loaded
+	if len(gr.GetGroup(signer2.GroupPublicKeyBytes())) != 1 {
+		t.Errorf(
+			"unexpected number of memberships of the second group\n"+
+				"expected: [%v]\nactual:   [%v]",
+			1,
+			len(gr.GetGroup(signer2.GroupPublicKeyBytes())),
+		)
+	}
+
+	quarantined := gr.QuarantinedMemberships()
+	if len(quarantined) != 1 {
+		t.Fatalf(
+			"unexpected number of quarantined memberships\n"+
+				"expected: [%v]\nactual:   [%v]",
+			1,
+			len(quarantined),
+		)
+	}
+	if quarantined[0].Membership.Signer.MemberID() != signer4.MemberID() ||
+		quarantined[0].Reason != "group is not registered on-chain" {
+		t.Errorf("unexpected quarantined membership: [%+v]", quarantined[0])
+	}
+}
+
+func TestMigrateMemberships(t *testing.T) {
+	// shares of the migrated group are evaluated from a polynomial of degree
+	// one, so its honest threshold is two
+	migratedSigner := newPolynomialSigner(100, 1, 4)
+	// key shares of the group do not allow to recover its honest threshold
+	unknownThresholdSigner := signer1
+	// the group is not registered on-chain
+	notRegisteredSigner := newPolynomialSigner(200, 1, 4)
+
+	persistence := &memoryPersistenceHandle{
+		files: make(map[string][]byte),
+	}
+
+	storage := newStorage(persistence)
+	for i, signer := range []*dkg.ThresholdSigner{
+		migratedSigner,
+		unknownThresholdSigner,
+		notRegisteredSigner,
+	} {
+		err := storage.save(&Membership{
+			Signer:      signer,
+			ChannelName: fmt.Sprintf("channel_%v", i),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gr := NewGroupRegistry(&mockGroupRegistrationInterface{}, persistence)
+	gr.LoadExistingGroups()
+
+	// the group has been created when the group size and honest threshold
+	// were different than they are in the current chain configuration
+	members := []chain.StakerAddress{
+		[]byte{0x01}, []byte{0x02}, []byte{0x03}, []byte{0x04},
+	}
+
+	relayChain := &mockMigrationChain{
+		config: &chain.Config{GroupSize: 3, HonestThreshold: 3},
+		groupMembers: map[string][]chain.StakerAddress{
+			groupKeyToString(migratedSigner.GroupPublicKeyBytes()):         members,
+			groupKeyToString(unknownThresholdSigner.GroupPublicKeyBytes()): members,
+		},
+		dkgResultSubmissions: []*event.DKGResultSubmission{
+			{GroupPublicKey: migratedSigner.GroupPublicKeyBytes(), BlockNumber: 20},
+		},
+		groupSelections: []*event.GroupSelectionStart{
+			{NewEntry: big.NewInt(5), BlockNumber: 5},
+			{NewEntry: big.NewInt(15), BlockNumber: 15},
+			{NewEntry: big.NewInt(25), BlockNumber: 25},
+		},
+	}
+
+	gr.MigrateMemberships(relayChain, 10)
+
+	expectedMigratedParameters := &GroupParameters{
+		GroupSize:         4,
+		HonestThreshold:   2,
+		DKGSeed:           big.NewInt(15),
+		RegistrationBlock: 20,
+		Members:           members,
+	}
+	// parameters of groups which could not be migrated are taken from
+	// the current chain configuration
+	expectedNotMigratedParameters := &GroupParameters{
+		GroupSize:       3,
+		HonestThreshold: 3,
+	}
+
+	assertParameters := func(
+		gr *Groups,
+		signer *dkg.ThresholdSigner,
+		expectedParameters *GroupParameters,
+	) {
+		memberships := gr.GetGroup(signer.GroupPublicKeyBytes())
+		if len(memberships) != 1 {
+			t.Fatalf(
+				"unexpected number of memberships\nexpected: [%v]\nactual:   [%v]",
+				1,
+				len(memberships),
+			)
+		}
+
+		if !reflect.DeepEqual(expectedParameters, memberships[0].Parameters) {
+			t.Errorf(
+				"unexpected parameters of group [0x%x]\nexpected: %v\nactual:   %v",
+				signer.GroupPublicKeyBytes(),
+				expectedParameters,
+				memberships[0].Parameters,
+			)
+		}
+	}
+
+	assertParameters(gr, migratedSigner, expectedMigratedParameters)
+	assertParameters(gr, unknownThresholdSigner, expectedNotMigratedParameters)
+	assertParameters(gr, notRegisteredSigner, expectedNotMigratedParameters)
+
+	// only parameters of the migrated group are persisted
+	loaded := NewGroupRegistry(&mockGroupRegistrationInterface{}, persistence)
+	loaded.LoadExistingGroups()
+
+	assertParameters(loaded, migratedSigner, expectedMigratedParameters)
+	assertParameters(loaded, unknownThresholdSigner, nil)
+	assertParameters(loaded, notRegisteredSigner, nil)
+}
+
+func TestVerifyMemberships(t *testing.T) {
+	operator := chain.StakerAddress([]byte{0x01})
+	otherStaker := chain.StakerAddress([]byte{0x02})
+
+	// valid signers of members 1 and 2 of the group
+	validSigner1 := newPolynomialSigner(100, 1, 3)
+	validSigner2 := newPolynomialSigner(100, 2, 3)
+	// signer of a group in which member 2 is another staker
+	otherMemberSigner := newPolynomialSigner(200, 2, 3)
+	// signer of a group not registered on-chain
+	notRegisteredSigner := newPolynomialSigner(300, 1, 3)
+	// signer of a group for which the chain could not be reached
+	unreachableSigner := newPolynomialSigner(400, 1, 3)
+	// signer with a private key share not matching its public key share
+	corruptedSigner := dkg.NewThresholdSigner(
+		group.MemberIndex(3),
+		new(bn256.G2).ScalarBaseMult(big.NewInt(100)),
+		big.NewInt(1),
+		validSigner1.GroupPublicKeyShares(),
+	)
+
+	relayChain := &mockVerificationChain{
+		registeredGroups: map[string]bool{
+			groupKeyToString(validSigner1.GroupPublicKeyBytes()):        true,
+			groupKeyToString(otherMemberSigner.GroupPublicKeyBytes()):   true,
+			groupKeyToString(notRegisteredSigner.GroupPublicKeyBytes()): false,
+		},
+		members: []chain.StakerAddress{operator, operator, operator},
+		otherMembers: map[string][]chain.StakerAddress{
+			groupKeyToString(otherMemberSigner.GroupPublicKeyBytes()): {
+				operator, otherStaker, operator,
+			},
+		},
+	}
+
+	persistenceMock := &persistenceHandleMock{}
+	gr := NewGroupRegistry(&mockGroupRegistrationInterface{}, persistenceMock)
+
+	for _, signer := range []*dkg.ThresholdSigner{
+		validSigner1,
+		validSigner2,
+		corruptedSigner,
+		otherMemberSigner,
+		notRegisteredSigner,
+		unreachableSigner,
+	} {
+		err := gr.RegisterGroup(signer, channelName1, groupParameters)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gr.VerifyMemberships(relayChain, operator)
+
+	var expectedGroups = map[*dkg.ThresholdSigner][]group.MemberIndex{
+		validSigner1:        {1, 2},
+		otherMemberSigner:   {},
+		notRegisteredSigner: {},
+		unreachableSigner:   {1},
+	}
+	for signer, expectedMembers := range expectedGroups {
+		actualMembers := make([]group.MemberIndex, 0)
+		for _, membership := range gr.GetGroup(signer.GroupPublicKeyBytes()) {
+			actualMembers = append(actualMembers, membership.Signer.MemberID())
+		}
+
+		if !reflect.DeepEqual(expectedMembers, actualMembers) {
+			t.Errorf(
+				"unexpected members of group [0x%x]\n"+
+					"expected: %v\nactual:   %v",
+				signer.GroupPublicKeyBytesCompressed(),
+				expectedMembers,
+				actualMembers,
+			)
+		}
+	}
+
+	expectedReasons := map[string]bool{
+		"private key share does not match public key share of member [3]": true,
+		"member [2] on-chain is [0x02], not the operator":                 true,
+		"group is not registered on-chain":                                true,
+	}
+	quarantined := gr.QuarantinedMemberships()
+	if len(quarantined) != len(expectedReasons) {
+		t.Fatalf(
+			"unexpected number of quarantined memberships\n"+
+				"expected: [%v]\nactual:   [%v]",
+			len(expectedReasons),
+			len(quarantined),
+		)
+	}
+	for _, quarantinedMembership := range quarantined {
+		if !expectedReasons[quarantinedMembership.Reason] {
+			t.Errorf(
+				"unexpected quarantine reason [%v]",
+				quarantinedMembership.Reason,
+			)
+		}
+	}
+
+	// groups with all memberships quarantined are archived
+	expectedArchivedGroups := map[string]bool{
+		hex.EncodeToString(otherMemberSigner.GroupPublicKeyBytesCompressed()):   true,
+		hex.EncodeToString(notRegisteredSigner.GroupPublicKeyBytesCompressed()): true,
+	}
+	if len(persistenceMock.archivedGroups) != len(expectedArchivedGroups) {
+		t.Fatalf(
+			"unexpected number of archived groups\n"+
+				"expected: [%v]\nactual:   [%v]",
+			len(expectedArchivedGroups),
+			len(persistenceMock.archivedGroups),
+		)
+	}
+	for _, archivedGroup := range persistenceMock.archivedGroups {
+		if !expectedArchivedGroups[archivedGroup] {
+			t.Errorf("unexpected archived group [%v]", archivedGroup)
+		}
+	}
+}
+
+// newPolynomialSigner returns a signer of the given member of a group with
+// the given number of members whose shares are evaluations of the polynomial
+// secret + 7x.
+func newPolynomialSigner(
+	secret int64,
+	memberIndex group.MemberIndex,
+	groupSize int,
+) *dkg.ThresholdSigner {
+	privateKeyShare := func(memberIndex group.MemberIndex) *big.Int {
+		return big.NewInt(secret + 7*int64(memberIndex))
+	}
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	for i := group.MemberIndex(1); int(i) <= groupSize; i++ {
+		if i == memberIndex {
+			continue
+		}
+		groupPublicKeyShares[i] = new(bn256.G2).ScalarBaseMult(
+			privateKeyShare(i),
+		)
+	}
+
+	return dkg.NewThresholdSigner(
+		memberIndex,
+		new(bn256.G2).ScalarBaseMult(big.NewInt(secret)),
+		privateKeyShare(memberIndex),
+		groupPublicKeyShares,
+	)
+}
+
+func TestGetGroups(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	gr := NewGroupRegistry(chain, persistenceMock)
+
+	gr.RegisterGroup(signer1, channelName1, groupParameters)
+	gr.RegisterGroup(signer2, channelName1, groupParameters)
+	gr.RegisterGroup(signer4, channelName1, groupParameters)
+
+	groups := gr.GetGroups()
+
+	if len(groups) != 2 {
+		t.Fatalf(
+			"Unexpected number of groups \nExpected: [%+v]\nActual:   [%+v]",
+			2,
+			len(groups),
+		)
+	}
+
+	group1 := groups[groupKeyToString(signer1.GroupPublicKeyBytes())]
+	if len(group1) != 1 || group1[0].Signer != signer1 {
+		t.Errorf("unexpected memberships of the first group: [%v]", group1)
+	}
+
+	group2 := groups[groupKeyToString(signer2.GroupPublicKeyBytes())]
+	if len(group2) != 2 ||
+		group2[0].Signer != signer2 ||
+		group2[1].Signer != signer4 {
+		t.Errorf("unexpected memberships of the second group: [%v]", group2)
+	}
+
+	delete(groups, groupKeyToString(signer1.GroupPublicKeyBytes()))
+	if len(gr.GetGroup(signer1.GroupPublicKeyBytes())) != 1 {
+		t.Errorf("modifying returned groups should not affect the registry")
+	}
 }
 
 func TestUnregisterStaleGroups(t *testing.T) {
@@ -124,9 +744,9 @@ func TestUnregisterStaleGroups(t *testing.T) {
 
 	gr := NewGroupRegistry(mockChain, persistenceMock)
 
-	gr.RegisterGroup(signer1, channelName1)
-	gr.RegisterGroup(signer2, channelName1)
-	gr.RegisterGroup(signer3, channelName1)
+	gr.RegisterGroup(signer1, channelName1, groupParameters)
+	gr.RegisterGroup(signer2, channelName1, groupParameters)
+	gr.RegisterGroup(signer3, channelName1, groupParameters)
 
 	mockChain.markAsStale(signer2.GroupPublicKeyBytes())
 
@@ -160,9 +780,9 @@ func TestUnregisterStaleGroupsSkipLastGroupCheck(t *testing.T) {
 
 	gr := NewGroupRegistry(mockChain, persistenceMock)
 
-	gr.RegisterGroup(signer1, channelName1)
-	gr.RegisterGroup(signer2, channelName1)
-	gr.RegisterGroup(signer3, channelName1)
+	gr.RegisterGroup(signer1, channelName1, groupParameters)
+	gr.RegisterGroup(signer2, channelName1, groupParameters)
+	gr.RegisterGroup(signer3, channelName1, groupParameters)
 
 	gr.UnregisterStaleGroups(signer3.GroupPublicKeyBytes())
 
@@ -182,6 +802,71 @@ func TestUnregisterStaleGroupsSkipLastGroupCheck(t *testing.T) {
 	}
 }
 
+func TestLoadExistingDKGCheckpoints(t *testing.T) {
+	checkpoints := NewDKGCheckpoints(&persistenceHandleMock{}).LoadExisting()
+
+	if len(checkpoints) != 1 {
+		t.Fatalf(
+			"unexpected number of checkpoints\nexpected: %v\nactual:   %v\n",
+			1,
+			len(checkpoints),
+		)
+	}
+
+	if !reflect.DeepEqual(dkgCheckpoint, checkpoints[0]) {
+		t.Fatalf("unexpected content of loaded DKG checkpoint")
+	}
+}
+
+func TestLoadExistingDKGEvidence(t *testing.T) {
+	evidences := NewDKGEvidenceLog(
+		&persistenceHandleMock{},
+		time.Hour,
+	).LoadExisting()
+
+	expectedEvidences := []*DKGEvidence{dkgEvidence, expiredDKGEvidence}
+	if !reflect.DeepEqual(expectedEvidences, evidences) {
+		t.Fatalf("unexpected content of loaded DKG evidence")
+	}
+}
+
+func TestArchiveExpiredDKGEvidence(t *testing.T) {
+	persistenceMock := &persistenceHandleMock{}
+
+	NewDKGEvidenceLog(
+		persistenceMock,
+		30*24*time.Hour,
+	).ArchiveExpired(dkgEvidence.StoredAt.Add(24 * time.Hour))
+
+	expectedArchived := []string{
+		dkgEvidenceDirectory(expiredDKGEvidence.Seed, expiredDKGEvidence.Index),
+	}
+	if !reflect.DeepEqual(expectedArchived, persistenceMock.archivedGroups) {
+		t.Fatalf(
+			"unexpected archived directories\nexpected: %v\nactual:   %v\n",
+			expectedArchived,
+			persistenceMock.archivedGroups,
+		)
+	}
+}
+
+func TestLoadProcessedBlock(t *testing.T) {
+	blockNumber, ok := NewProcessedBlock(&persistenceHandleMock{}).Load()
+
+	if !ok {
+		t.Fatalf("last processed block not loaded")
+	}
+
+	expectedBlockNumber := uint64(12345)
+	if blockNumber != expectedBlockNumber {
+		t.Fatalf(
+			"unexpected last processed block\nexpected: %v\nactual:   %v\n",
+			expectedBlockNumber,
+			blockNumber,
+		)
+	}
+}
+
 type mockGroupRegistrationInterface struct {
 	groupsToRemove       [][]byte
 	groupsCheckedIfStale map[string]bool
@@ -213,6 +898,85 @@ func (mgri *mockGroupRegistrationInterface) GetGroupMembers(
 	return nil, nil // no-op
 }
 
+type mockMigrationChain struct {
+	chain.Interface
+
+	config               *chain.Config
+	groupMembers         map[string][]chain.StakerAddress
+	dkgResultSubmissions []*event.DKGResultSubmission
+	groupSelections      []*event.GroupSelectionStart
+}
+
+func (mmc *mockMigrationChain) GetConfig() *chain.Config {
+	return mmc.config
+}
+
+func (mmc *mockMigrationChain) GetGroupMembers(
+	groupPublicKey []byte,
+) ([]chain.StakerAddress, error) {
+	members, ok := mmc.groupMembers[groupKeyToString(groupPublicKey)]
+	if !ok {
+		return nil, fmt.Errorf("group does not exist")
+	}
+
+	return members, nil
+}
+
+func (mmc *mockMigrationChain) PastDKGResultSubmittedEvents(
+	fromBlock uint64,
+) ([]*event.DKGResultSubmission, error) {
+	submissions := make([]*event.DKGResultSubmission, 0)
+	for _, submission := range mmc.dkgResultSubmissions {
+		if submission.BlockNumber >= fromBlock {
+			submissions = append(submissions, submission)
+		}
+	}
+
+	return submissions, nil
+}
+
+func (mmc *mockMigrationChain) PastGroupSelectionStartedEvents(
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	selections := make([]*event.GroupSelectionStart, 0)
+	for _, selection := range mmc.groupSelections {
+		if selection.BlockNumber >= fromBlock {
+			selections = append(selections, selection)
+		}
+	}
+
+	return selections, nil
+}
+
+type mockVerificationChain struct {
+	chain.Interface
+
+	registeredGroups map[string]bool
+	members          []chain.StakerAddress
+	otherMembers     map[string][]chain.StakerAddress
+}
+
+func (mvc *mockVerificationChain) IsGroupRegistered(
+	groupPublicKey []byte,
+) (bool, error) {
+	registered, ok := mvc.registeredGroups[groupKeyToString(groupPublicKey)]
+	if !ok {
+		return false, fmt.Errorf("chain could not be reached")
+	}
+
+	return registered, nil
+}
+
+func (mvc *mockVerificationChain) GetGroupMembers(
+	groupPublicKey []byte,
+) ([]chain.StakerAddress, error) {
+	if members, ok := mvc.otherMembers[groupKeyToString(groupPublicKey)]; ok {
+		return members, nil
+	}
+
+	return mvc.members, nil
+}
+
 type persistenceHandleMock struct {
 	archivedGroups []string
 }
@@ -243,12 +1007,66 @@ func (phm *persistenceHandleMock) ReadAll() (<-chan persistence.DataDescriptor,
 		ChannelName: channelName2,
 	}).Marshal()
 
-	outputData := make(chan persistence.DataDescriptor, 3)
+	quarantinedMembershipBytes, _ := (&QuarantinedMembership{
+		Membership: &Membership{
+			Signer:      signer4,
+			ChannelName: channelName2,
+		},
+		Reason:        "group is not registered on-chain",
+		QuarantinedAt: time.Unix(1600000000, 0),
+	}).Marshal()
+
+	checkpointBytes, _ := dkgCheckpoint.Marshal()
+
+	evidenceBytes, _ := dkgEvidence.Marshal()
+	expiredEvidenceBytes, _ := expiredDKGEvidence.Marshal()
+
+	processedBlockBytes := []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}
+
+	outputData := make(chan persistence.DataDescriptor, 10)
 	outputErrors := make(chan error)
 
 	outputData <- &testDataDescriptor{"1", "dir", membershipBytes1}
 	outputData <- &testDataDescriptor{"2", "dir", membershipBytes2}
 	outputData <- &testDataDescriptor{"3", "dir", membershipBytes3}
+	outputData <- &testDataDescriptor{
+		quarantineFileName,
+		quarantineDirectory(
+			signer4.GroupPublicKeyBytesCompressed(),
+			signer4.MemberID(),
+		),
+		quarantinedMembershipBytes,
+	}
+	outputData <- &testDataDescriptor{
+		dkgCheckpointFileName,
+		dkgCheckpointDirectory(dkgCheckpoint.Seed, dkgCheckpoint.Index),
+		checkpointBytes,
+	}
+	outputData <- &testDataDescriptor{
+		dkgEvidenceFileName,
+		dkgEvidenceDirectory(dkgEvidence.Seed, dkgEvidence.Index),
+		evidenceBytes,
+	}
+	outputData <- &testDataDescriptor{
+		dkgEvidenceFileName,
+		dkgEvidenceDirectory(expiredDKGEvidence.Seed, expiredDKGEvidence.Index),
+		expiredEvidenceBytes,
+	}
+	outputData <- &testDataDescriptor{
+		processedBlockFileName,
+		processedBlockDirectory,
+		processedBlockBytes,
+	}
+	outputData <- &testDataDescriptor{
+		"0x01_pending",
+		layout.TransactionJournalDirectory,
+		[]byte(`{"hash":"0x01","status":"pending"}`),
+	}
+	outputData <- &testDataDescriptor{
+		"0x02_pending",
+		layout.TransactionJournalGenerationDirectory(1),
+		[]byte(`{"hash":"0x02","status":"pending"}`),
+	}
 
 	close(outputData)
 	close(outputErrors)
@@ -262,6 +1080,50 @@ func (phm *persistenceHandleMock) Archive(directory string) error {
 	return nil
 }
 
+// memoryPersistenceHandle keeps saved files in memory, keyed by directory
+// and file name.
+type memoryPersistenceHandle struct {
+	files map[string][]byte
+}
+
+func (mph *memoryPersistenceHandle) Save(data []byte, directory string, name string) error {
+	mph.files[directory+name] = data
+	return nil
+}
+
+func (mph *memoryPersistenceHandle) Snapshot(data []byte, directory string, name string) error {
+	// noop
+	return nil
+}
+
+func (mph *memoryPersistenceHandle) ReadAll() (<-chan persistence.DataDescriptor, <-chan error) {
+	outputData := make(chan persistence.DataDescriptor, len(mph.files))
+	outputErrors := make(chan error)
+
+	for path, content := range mph.files {
+		separator := strings.LastIndex(path, "/")
+		outputData <- &testDataDescriptor{
+			path[separator+1:],
+			path[:separator],
+			content,
+		}
+	}
+
+	close(outputData)
+	close(outputErrors)
+
+	return outputData, outputErrors
+}
+
+func (mph *memoryPersistenceHandle) Archive(directory string) error {
+	for path := range mph.files {
+		if strings.HasPrefix(path, directory+"/") {
+			delete(mph.files, path)
+		}
+	}
+	return nil
+}
+
 type testDataDescriptor struct {
 	name      string
 	directory string
@@ -2,21 +2,38 @@ package registry
 
 import (
 	"fmt"
+	"math/big"
 
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
 )
 
-// Marshal converts Membership to a byte array.
+// membershipVersion is the current version of the membership format.
+// Memberships in version 0, the legacy format, were persisted without
+// group parameters.
+const membershipVersion = 1
+
+// Marshal converts Membership to a byte array. Membership without group
+// parameters is marshalled in the legacy format.
 func (m *Membership) Marshal() ([]byte, error) {
 	signer, err := m.Signer.Marshal()
 	if err != nil {
 		return nil, err
 	}
 
+	if m.Parameters == nil {
+		return (&pb.Membership{
+			Signer:  signer,
+			Channel: m.ChannelName,
+		}).Marshal()
+	}
+
 	return (&pb.Membership{
-		Signer:  signer,
-		Channel: m.ChannelName,
+		Signer:     signer,
+		Channel:    m.ChannelName,
+		Version:    membershipVersion,
+		Parameters: m.Parameters.toPb(),
 	}).Marshal()
 }
 
@@ -27,6 +44,13 @@ func (m *Membership) Unmarshal(bytes []byte) error {
 		return err
 	}
 
+	if pbMembership.Version > membershipVersion {
+		return fmt.Errorf(
+			"unsupported membership version [%v]",
+			pbMembership.Version,
+		)
+	}
+
 	signer := &dkg.ThresholdSigner{}
 
 	err := signer.Unmarshal(pbMembership.Signer)
@@ -34,8 +58,58 @@ func (m *Membership) Unmarshal(bytes []byte) error {
 		return fmt.Errorf("Unexpected error occured [%v]", err)
 	}
 
+	var parameters *GroupParameters
+	if pbMembership.Version > 0 {
+		if pbMembership.Parameters == nil {
+			return fmt.Errorf("missing group parameters")
+		}
+
+		parameters = groupParametersFromPb(pbMembership.Parameters)
+	}
+
 	m.Signer = signer
 	m.ChannelName = pbMembership.Channel
+	m.Parameters = parameters
 
 	return nil
 }
+
+func (gp *GroupParameters) toPb() *pb.GroupParameters {
+	members := make([][]byte, 0, len(gp.Members))
+	for _, member := range gp.Members {
+		members = append(members, member)
+	}
+
+	var dkgSeed []byte
+	if gp.DKGSeed != nil {
+		dkgSeed = gp.DKGSeed.Bytes()
+	}
+
+	return &pb.GroupParameters{
+		GroupSize:         uint32(gp.GroupSize),
+		HonestThreshold:   uint32(gp.HonestThreshold),
+		DkgSeed:           dkgSeed,
+		RegistrationBlock: gp.RegistrationBlock,
+		Members:           members,
+	}
+}
+
+func groupParametersFromPb(pbParameters *pb.GroupParameters) *GroupParameters {
+	members := make([]relaychain.StakerAddress, 0, len(pbParameters.Members))
+	for _, member := range pbParameters.Members {
+		members = append(members, member)
+	}
+
+	var dkgSeed *big.Int
+	if len(pbParameters.DkgSeed) > 0 {
+		dkgSeed = new(big.Int).SetBytes(pbParameters.DkgSeed)
+	}
+
+	return &GroupParameters{
+		GroupSize:         int(pbParameters.GroupSize),
+		HonestThreshold:   int(pbParameters.HonestThreshold),
+		DKGSeed:           dkgSeed,
+		RegistrationBlock: pbParameters.RegistrationBlock,
+		Members:           members,
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"testing"
 
 	"math/big"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
 	"github.com/keep-network/keep-core/pkg/internal/pbutils"
 )
 
@@ -23,18 +26,111 @@ func TestMembershipRoundtrip(t *testing.T) {
 		},
 	)
 
-	membership := &Membership{
-		Signer:      signer,
-		ChannelName: "channel_test_name",
+	var tests = map[string]struct {
+		parameters *GroupParameters
+	}{
+		"membership with group parameters": {
+			parameters: &GroupParameters{
+				GroupSize:         5,
+				HonestThreshold:   3,
+				DKGSeed:           big.NewInt(18313131145),
+				RegistrationBlock: 12,
+				Members: []relaychain.StakerAddress{
+					[]byte{0x01},
+					[]byte{0x02},
+					[]byte{0x03},
+					[]byte{0x04},
+					[]byte{0x05},
+				},
+			},
+		},
+		"membership with unknown DKG seed and registration block": {
+			parameters: &GroupParameters{
+				GroupSize:       5,
+				HonestThreshold: 3,
+				Members:         []relaychain.StakerAddress{},
+			},
+		},
+		"legacy membership": {
+			parameters: nil,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			membership := &Membership{
+				Signer:      signer,
+				ChannelName: "channel_test_name",
+				Parameters:  test.parameters,
+			}
+
+			unmarshaled := &Membership{}
+
+			err := pbutils.RoundTrip(membership, unmarshaled)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(membership, unmarshaled) {
+				t.Fatalf("unexpected content of unmarshaled membership")
+			}
+		})
+	}
+}
+
+func TestUnmarshalUnsupportedMembershipVersion(t *testing.T) {
+	bytes, err := (&pb.Membership{
+		Channel: "channel_test_name",
+		Version: membershipVersion + 1,
+	}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = (&Membership{}).Unmarshal(bytes)
+	if err == nil {
+		t.Fatal("expected an error for unsupported membership version")
+	}
+}
+
+func TestDKGCheckpointRoundtrip(t *testing.T) {
+	checkpoint := &DKGCheckpoint{
+		Seed:  big.NewInt(18313131145),
+		Index: 2,
+		SelectedStakers: []relaychain.StakerAddress{
+			[]byte{0x01},
+			[]byte{0x02},
+			[]byte{0x03},
+		},
+		ProtocolCheckpoint: []byte{0x04, 0x05},
+		StartBlock:         120,
+	}
+
+	unmarshaled := &DKGCheckpoint{}
+
+	err := pbutils.RoundTrip(checkpoint, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(checkpoint, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled DKG checkpoint")
+	}
+}
+
+func TestDKGEvidenceRoundtrip(t *testing.T) {
+	evidence := &DKGEvidence{
+		Seed:     big.NewInt(18313131145),
+		Index:    2,
+		StoredAt: time.Unix(1600000000, 0),
+		Evidence: []byte{0x04, 0x05},
 	}
 
-	unmarshaled := &Membership{}
+	unmarshaled := &DKGEvidence{}
 
-	err := pbutils.RoundTrip(membership, unmarshaled)
+	err := pbutils.RoundTrip(evidence, unmarshaled)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !reflect.DeepEqual(membership, unmarshaled) {
-		t.Fatalf("unexpected content of unmarshaled membership")
+	if !reflect.DeepEqual(evidence, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled DKG evidence")
 	}
 }
@@ -0,0 +1,247 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+)
+
+// MigrateMemberships migrates memberships loaded from the legacy format,
+// persisted without group parameters, to the current format. Parameters of
+// each legacy group are recovered from the chain: group members are read
+// from the group registered on-chain, the registration block from the DKG
+// result submission of the group and the DKG seed from the last group
+// selection started before it. The group size is the number of members
+// registered on-chain. The honest threshold is recovered from key shares of
+// the stored membership, as the chain configuration may have changed since
+// the group was created. Past events are searched starting from the given
+// block, usually the block at which the operator contract was deployed.
+//
+// If parameters of a group could not be recovered, its memberships are given
+// parameters from the current chain configuration in memory only, so that the
+// group remains operational and the migration is retried on the next start.
+// Such parameters may not match the group.
+func (g *Groups) MigrateMemberships(
+	relayChain relaychain.Interface,
+	fromBlock uint64,
+) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	migration := &membershipMigration{
+		relayChain: relayChain,
+		fromBlock:  fromBlock,
+	}
+
+	for groupPublicKey, memberships := range g.myGroups {
+		if !hasLegacyMembership(memberships) {
+			continue
+		}
+
+		publicKeyBytes, err := groupKeyFromString(groupPublicKey)
+		if err != nil {
+			logger.Errorf(
+				"error occurred while decoding public key into bytes: [%v]",
+				err,
+			)
+			continue
+		}
+
+		recovered := true
+		parameters, err := migration.groupParameters(
+			publicKeyBytes,
+			memberships[0].Signer,
+		)
+		if err != nil {
+			logger.Errorf(
+				"could not recover parameters of group [0x%v]; "+
+					"using current chain configuration until the next "+
+					"start: [%v]",
+				groupPublicKey,
+				err,
+			)
+
+			recovered = false
+			parameters = &GroupParameters{
+				GroupSize:       relayChain.GetConfig().GroupSize,
+				HonestThreshold: relayChain.GetConfig().HonestThreshold,
+			}
+		}
+
+		migratedMemberships := make([]*Membership, len(memberships))
+		for i, membership := range memberships {
+			migratedMemberships[i] = membership
+			if membership.Parameters != nil {
+				continue
+			}
+
+			migratedMembership := &Membership{
+				Signer:      membership.Signer,
+				ChannelName: membership.ChannelName,
+				Parameters:  parameters,
+			}
+
+			if recovered {
+				err := g.storage.save(migratedMembership)
+				if err != nil {
+					// parameters are kept in memory and the membership is
+					// migrated again on the next start
+					logger.Errorf(
+						"could not persist migrated membership of member [%v] "+
+							"in group [0x%v]: [%v]",
+						membership.Signer.MemberID(),
+						groupPublicKey,
+						err,
+					)
+				}
+			}
+
+			migratedMemberships[i] = migratedMembership
+		}
+		g.myGroups[groupPublicKey] = migratedMemberships
+
+		if recovered {
+			logger.Infof(
+				"migrated memberships of group [0x%v] registered at block [%v]",
+				groupPublicKey,
+				parameters.RegistrationBlock,
+			)
+		}
+	}
+}
+
+func hasLegacyMembership(memberships []*Membership) bool {
+	for _, membership := range memberships {
+		if membership.Parameters == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// membershipMigration recovers group parameters from the chain. Past events
+// are fetched once and shared by all migrated groups.
+type membershipMigration struct {
+	relayChain relaychain.Interface
+	fromBlock  uint64
+
+	dkgResultSubmissions []*event.DKGResultSubmission
+	groupSelections      []*event.GroupSelectionStart
+}
+
+func (mm *membershipMigration) groupParameters(
+	groupPublicKey []byte,
+	signer *dkg.ThresholdSigner,
+) (*GroupParameters, error) {
+	honestThreshold, err := signer.HonestThreshold()
+	if err != nil {
+		return nil, fmt.Errorf("could not recover honest threshold: [%v]", err)
+	}
+
+	members, err := mm.relayChain.GetGroupMembers(groupPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not get group members: [%v]", err)
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("group has no members on-chain")
+	}
+
+	registrationBlock, err := mm.registrationBlock(groupPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := &GroupParameters{
+		GroupSize:         len(members),
+		HonestThreshold:   honestThreshold,
+		RegistrationBlock: registrationBlock,
+		Members:           members,
+	}
+
+	if registrationBlock == 0 {
+		logger.Warningf(
+			"could not find registration of group [0x%x]; "+
+				"registration block and DKG seed are unknown",
+			groupPublicKey,
+		)
+		return parameters, nil
+	}
+
+	dkgSeed, err := mm.dkgSeed(registrationBlock)
+	if err != nil {
+		return nil, err
+	}
+	parameters.DKGSeed = dkgSeed
+
+	return parameters, nil
+}
+
+// registrationBlock returns the block at which the result of DKG creating
+// the given group has been accepted on-chain or zero if there is no such
+// result.
+func (mm *membershipMigration) registrationBlock(
+	groupPublicKey []byte,
+) (uint64, error) {
+	if mm.dkgResultSubmissions == nil {
+		submissions, err := mm.relayChain.PastDKGResultSubmittedEvents(
+			mm.fromBlock,
+		)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"could not get past DKG result submissions: [%v]",
+				err,
+			)
+		}
+		mm.dkgResultSubmissions = submissions
+	}
+
+	for _, submission := range mm.dkgResultSubmissions {
+		if bytes.Equal(submission.GroupPublicKey, groupPublicKey) {
+			return submission.BlockNumber, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// dkgSeed returns the new entry of the last group selection started before
+// the given registration block. The group selection determined members of
+// the group and its new entry was used as the seed of DKG.
+func (mm *membershipMigration) dkgSeed(
+	registrationBlock uint64,
+) (*big.Int, error) {
+	if mm.groupSelections == nil {
+		selections, err := mm.relayChain.PastGroupSelectionStartedEvents(
+			mm.fromBlock,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get past group selections: [%v]",
+				err,
+			)
+		}
+		mm.groupSelections = selections
+	}
+
+	var groupSelection *event.GroupSelectionStart
+	for _, selection := range mm.groupSelections {
+		if selection.BlockNumber >= registrationBlock {
+			continue
+		}
+		if groupSelection == nil ||
+			selection.BlockNumber > groupSelection.BlockNumber {
+			groupSelection = selection
+		}
+	}
+
+	if groupSelection == nil {
+		return nil, nil
+	}
+
+	return groupSelection.NewEntry, nil
+}
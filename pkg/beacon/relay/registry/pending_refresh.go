@@ -0,0 +1,182 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	pendingRefreshDirectoryPrefix = "refresh_"
+	pendingRefreshFileName        = "membership"
+	pendingRefreshCommittedName   = "committed"
+)
+
+// pendingRefresh is a membership holding refreshed shares which has been
+// persisted before the group decided whether to commit the share refresh.
+type pendingRefresh struct {
+	membership *Membership
+	// committed is true if the group committed the refresh but the membership
+	// may have not replaced the previous one in the storage yet.
+	committed bool
+}
+
+// SavePendingRefresh persists the given signer holding refreshed shares of
+// the group private key as the pending refresh of the membership held in
+// the group of the given signer by the member with the same index. Neither
+// the registry nor the stored membership change; the pending refresh replaces
+// the membership only once the group commits the refresh and
+// RefreshMembership is called.
+func (g *Groups) SavePendingRefresh(signer *dkg.ThresholdSigner) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	membership, err := g.findMembership(signer)
+	if err != nil {
+		return err
+	}
+
+	return g.storage.savePendingRefresh(&Membership{
+		Signer:      signer,
+		ChannelName: membership.ChannelName,
+		Parameters:  membership.Parameters,
+	})
+}
+
+// DiscardPendingRefresh removes the pending refresh of the membership held
+// by the given signer's member from the storage. It should be called once
+// the group decides not to commit the refresh.
+func (g *Groups) DiscardPendingRefresh(signer *dkg.ThresholdSigner) error {
+	return g.storage.archivePendingRefresh(
+		signer.GroupPublicKeyBytesCompressed(),
+		signer.MemberID(),
+	)
+}
+
+// findMembership returns the membership held in the group of the given
+// signer by the member with the same index. It must be called with the mutex
+// locked.
+func (g *Groups) findMembership(signer *dkg.ThresholdSigner) (*Membership, error) {
+	groupPublicKey := groupKeyToString(signer.GroupPublicKeyBytes())
+
+	for _, membership := range g.myGroups[groupPublicKey] {
+		if membership.Signer.MemberID() == signer.MemberID() {
+			return membership, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"member [%v] is not registered in group [0x%v]",
+		signer.MemberID(),
+		groupPublicKey,
+	)
+}
+
+// resolvePendingRefreshes resolves share refreshes left pending when
+// the client stopped. Refreshes committed by the group replace loaded
+// memberships. Other pending refreshes are discarded and previous shares are
+// kept, as the client can not learn whether the group committed them; if it
+// did, signature shares of the member are rejected by other members until
+// the group is refreshed again. It must be called once stored memberships
+// are loaded into the registry.
+func (g *Groups) resolvePendingRefreshes() {
+	for _, refresh := range g.storage.readPendingRefreshes() {
+		signer := refresh.membership.Signer
+		groupPublicKey := groupKeyToString(signer.GroupPublicKeyBytes())
+
+		memberships := g.myGroups[groupPublicKey]
+		index := -1
+		for i, membership := range memberships {
+			if membership.Signer.MemberID() == signer.MemberID() {
+				index = i
+			}
+		}
+
+		switch {
+		case index < 0:
+			logger.Warningf(
+				"discarding pending share refresh of member [%v] "+
+					"in group [0x%v] not loaded into the registry",
+				signer.MemberID(),
+				groupPublicKey,
+			)
+		case refresh.committed:
+			err := g.storage.replace(refresh.membership)
+
+			refreshedMemberships := append([]*Membership{}, memberships...)
+			refreshedMemberships[index] = refresh.membership
+			g.myGroups[groupPublicKey] = refreshedMemberships
+
+			// The pending refresh is kept if it could not be persisted as
+			// the membership, so that it is applied again on the next start.
+			if err != nil {
+				logger.Errorf(
+					"could not persist committed share refresh of member "+
+						"[%v] in group [0x%v]: [%v]",
+					signer.MemberID(),
+					groupPublicKey,
+					err,
+				)
+				continue
+			}
+
+			logger.Infof(
+				"applied committed share refresh of member [%v] "+
+					"in group [0x%v]",
+				signer.MemberID(),
+				groupPublicKey,
+			)
+		default:
+			logger.Warningf(
+				"discarding share refresh of member [%v] in group [0x%v] "+
+					"which was not committed before the client stopped; "+
+					"keeping previous shares",
+				signer.MemberID(),
+				groupPublicKey,
+			)
+		}
+
+		err := g.storage.archivePendingRefresh(
+			signer.GroupPublicKeyBytesCompressed(),
+			signer.MemberID(),
+		)
+		if err != nil {
+			logger.Errorf(
+				"could not archive pending share refresh of member [%v] "+
+					"in group [0x%v]: [%v]",
+				signer.MemberID(),
+				groupPublicKey,
+				err,
+			)
+		}
+	}
+}
+
+// pendingRefreshDirectory returns the directory of the pending refresh of
+// the membership. The compressed group public key is hashed to keep
+// the directory name within the allowed length.
+func pendingRefreshDirectory(
+	groupPublicKeyCompressed []byte,
+	memberIndex group.MemberIndex,
+) string {
+	groupPublicKeyHash := sha256.Sum256(groupPublicKeyCompressed)
+
+	return fmt.Sprintf(
+		"%v%v_%v",
+		pendingRefreshDirectoryPrefix,
+		hex.EncodeToString(groupPublicKeyHash[:]),
+		memberIndex,
+	)
+}
+
+func isPendingRefresh(descriptor persistence.DataDescriptor) bool {
+	return strings.HasPrefix(descriptor.Directory(), pendingRefreshDirectoryPrefix) &&
+		(descriptor.Name() == pendingRefreshFileName ||
+			descriptor.Name() == pendingRefreshCommittedName)
+}
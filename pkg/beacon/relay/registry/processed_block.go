@@ -0,0 +1,104 @@
+package registry
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	processedBlockDirectory = "events"
+	processedBlockFileName  = "last_processed_block"
+)
+
+// ProcessedBlock persists the number of the last block for which all chain
+// events have been fully processed by the client. It lets the client backfill
+// events emitted while it was offline.
+type ProcessedBlock struct {
+	handle persistence.Handle
+}
+
+// NewProcessedBlock returns the last processed block stored with the given
+// persistence handle.
+func NewProcessedBlock(persistence persistence.Handle) *ProcessedBlock {
+	return &ProcessedBlock{
+		handle: persistence,
+	}
+}
+
+// Save persists the given block number as the last processed block replacing
+// the previously stored one.
+func (pb *ProcessedBlock) Save(blockNumber uint64) error {
+	blockNumberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(blockNumberBytes, blockNumber)
+
+	return pb.handle.Save(
+		blockNumberBytes,
+		processedBlockDirectory,
+		"/"+processedBlockFileName,
+	)
+}
+
+// Load returns the last processed block stored. The second returned value is
+// false if no block has been stored yet or if it could not be read.
+func (pb *ProcessedBlock) Load() (uint64, bool) {
+	var blockNumber uint64
+	found := false
+
+	descriptorsChannel, errorsChannel := pb.handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels for the same
+	// reason as when loading existing groups; channels are not buffered and
+	// we do not know in what order information is written to them.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChannel {
+			if !isProcessedBlock(descriptor) {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read last processed block: [%v]",
+					err,
+				)
+				continue
+			}
+
+			if len(content) != 8 {
+				logger.Errorf(
+					"could not read last processed block: [%v]",
+					fmt.Errorf("unexpected length [%v]", len(content)),
+				)
+				continue
+			}
+
+			blockNumber = binary.BigEndian.Uint64(content)
+			found = true
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChannel {
+			logger.Errorf("could not load last processed block from disk: [%v]", err)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	return blockNumber, found
+}
+
+func isProcessedBlock(descriptor persistence.DataDescriptor) bool {
+	return descriptor.Directory() == processedBlockDirectory &&
+		descriptor.Name() == processedBlockFileName
+}
@@ -0,0 +1,276 @@
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	quarantineDirectoryPrefix = "quarantine_"
+	quarantineFileName        = "membership"
+)
+
+// QuarantinedMembership is a stored membership which failed the integrity
+// check at the client start. Quarantined memberships are kept in the storage
+// for inspection but are never loaded into the registry, so they are not used
+// for signing.
+type QuarantinedMembership struct {
+	// Membership which failed the integrity check.
+	Membership *Membership
+	// Reason why the membership failed the integrity check.
+	Reason string
+	// Time when the membership was quarantined.
+	QuarantinedAt time.Time
+}
+
+// VerifyMemberships checks the integrity of all memberships in the registry.
+// For each membership it checks that the private key share matches the
+// public key share of the member, that the public key shares interpolate to
+// the group public key, that the group is registered on-chain and that the
+// given operator is the group member at the member index of the membership.
+//
+// Memberships failing the check are moved to the quarantine and removed from
+// the registry. If a check could not be completed because of a chain error,
+// the membership is kept in the registry and checked again on the next start.
+func (g *Groups) VerifyMemberships(
+	relayChain relaychain.Interface,
+	operator relaychain.StakerAddress,
+) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for groupPublicKey, memberships := range g.myGroups {
+		publicKeyBytes, err := groupKeyFromString(groupPublicKey)
+		if err != nil {
+			logger.Errorf(
+				"error occurred while decoding public key into bytes: [%v]",
+				err,
+			)
+			continue
+		}
+
+		verification := &membershipVerification{
+			relayChain:     relayChain,
+			operator:       operator,
+			groupPublicKey: publicKeyBytes,
+		}
+
+		verifiedMemberships := make([]*Membership, 0)
+		for _, membership := range memberships {
+			reason, err := verification.verify(membership)
+			if err != nil {
+				logger.Errorf(
+					"could not verify membership of member [%v] "+
+						"in group [0x%v]: [%v]",
+					membership.Signer.MemberID(),
+					groupPublicKey,
+					err,
+				)
+				verifiedMemberships = append(verifiedMemberships, membership)
+				continue
+			}
+
+			if reason == "" {
+				verifiedMemberships = append(verifiedMemberships, membership)
+				continue
+			}
+
+			logger.Errorf(
+				"membership of member [%v] in group [0x%v] failed "+
+					"the integrity check and is quarantined: [%v]",
+				membership.Signer.MemberID(),
+				groupPublicKey,
+				reason,
+			)
+
+			quarantinedMembership := &QuarantinedMembership{
+				Membership:    membership,
+				Reason:        reason,
+				QuarantinedAt: time.Now(),
+			}
+
+			// The membership is not used for signing even if the quarantine
+			// could not be persisted; it is verified again on the next start.
+			if err := g.storage.quarantine(quarantinedMembership); err != nil {
+				logger.Errorf(
+					"could not persist quarantined membership of member [%v] "+
+						"in group [0x%v]: [%v]",
+					membership.Signer.MemberID(),
+					groupPublicKey,
+					err,
+				)
+			}
+
+			g.quarantined = append(g.quarantined, quarantinedMembership)
+		}
+
+		if len(verifiedMemberships) == len(memberships) {
+			continue
+		}
+
+		if len(verifiedMemberships) > 0 {
+			g.myGroups[groupPublicKey] = verifiedMemberships
+			continue
+		}
+
+		delete(g.myGroups, groupPublicKey)
+
+		// Quarantined memberships are skipped when loading the registry,
+		// archiving just keeps the group directory tidy.
+		compressedPublicKey := memberships[0].Signer.GroupPublicKeyBytesCompressed()
+		if err := g.storage.archive(compressedPublicKey); err != nil {
+			logger.Errorf(
+				"failed to archive quarantined group with compressed "+
+					"public key [%s]: [%v]",
+				hex.EncodeToString(compressedPublicKey),
+				err,
+			)
+		}
+	}
+}
+
+// QuarantinedMemberships returns all memberships moved to the quarantine,
+// both the ones stored before and the ones quarantined since the client
+// start.
+func (g *Groups) QuarantinedMemberships() []*QuarantinedMembership {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return append([]*QuarantinedMembership{}, g.quarantined...)
+}
+
+// membershipVerification verifies memberships of a single group. Group
+// registration and members are fetched from the chain once and shared by
+// all memberships in the group.
+type membershipVerification struct {
+	relayChain     relaychain.Interface
+	operator       relaychain.StakerAddress
+	groupPublicKey []byte
+
+	registered *bool
+	members    []relaychain.StakerAddress
+}
+
+// verify returns the reason why the membership failed the integrity check
+// or an empty string if the membership passed it. An error is returned if
+// the check could not be completed.
+func (mv *membershipVerification) verify(
+	membership *Membership,
+) (string, error) {
+	honestThreshold := 0
+	if membership.Parameters != nil {
+		honestThreshold = membership.Parameters.HonestThreshold
+	}
+
+	if err := membership.Signer.VerifyShares(honestThreshold); err != nil {
+		return err.Error(), nil
+	}
+
+	if mv.registered == nil {
+		registered, err := mv.relayChain.IsGroupRegistered(mv.groupPublicKey)
+		if err != nil {
+			return "", fmt.Errorf(
+				"could not check if group is registered: [%v]",
+				err,
+			)
+		}
+		mv.registered = &registered
+	}
+
+	if !*mv.registered {
+		return "group is not registered on-chain", nil
+	}
+
+	if mv.members == nil {
+		members, err := mv.relayChain.GetGroupMembers(mv.groupPublicKey)
+		if err != nil {
+			return "", fmt.Errorf("could not get group members: [%v]", err)
+		}
+		mv.members = members
+	}
+
+	memberIndex := int(membership.Signer.MemberID())
+	if memberIndex < 1 || memberIndex > len(mv.members) {
+		return fmt.Sprintf(
+			"member index [%v] is out of range of [%v] group members on-chain",
+			memberIndex,
+			len(mv.members),
+		), nil
+	}
+
+	if !bytes.Equal(mv.members[memberIndex-1], mv.operator) {
+		return fmt.Sprintf(
+			"member [%v] on-chain is [0x%x], not the operator",
+			memberIndex,
+			[]byte(mv.members[memberIndex-1]),
+		), nil
+	}
+
+	return "", nil
+}
+
+// Marshal converts QuarantinedMembership to a byte array.
+func (qm *QuarantinedMembership) Marshal() ([]byte, error) {
+	membershipBytes, err := qm.Membership.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return (&pb.QuarantinedMembership{
+		Membership:    membershipBytes,
+		Reason:        qm.Reason,
+		QuarantinedAt: qm.QuarantinedAt.Unix(),
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// QuarantinedMembership.
+func (qm *QuarantinedMembership) Unmarshal(bytes []byte) error {
+	pbQuarantinedMembership := pb.QuarantinedMembership{}
+	if err := pbQuarantinedMembership.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	membership := &Membership{}
+	if err := membership.Unmarshal(pbQuarantinedMembership.Membership); err != nil {
+		return fmt.Errorf("could not unmarshal membership: [%v]", err)
+	}
+
+	qm.Membership = membership
+	qm.Reason = pbQuarantinedMembership.Reason
+	qm.QuarantinedAt = time.Unix(pbQuarantinedMembership.QuarantinedAt, 0)
+
+	return nil
+}
+
+// quarantineDirectory returns the directory of the quarantined membership.
+// The compressed group public key is hashed to keep the directory name
+// within the allowed length.
+func quarantineDirectory(
+	groupPublicKeyCompressed []byte,
+	memberIndex group.MemberIndex,
+) string {
+	groupPublicKeyHash := sha256.Sum256(groupPublicKeyCompressed)
+
+	return fmt.Sprintf(
+		"%v%v_%v",
+		quarantineDirectoryPrefix,
+		hex.EncodeToString(groupPublicKeyHash[:]),
+		memberIndex,
+	)
+}
+
+func isQuarantinedMembership(descriptor persistence.DataDescriptor) bool {
+	return strings.HasPrefix(descriptor.Directory(), quarantineDirectoryPrefix) &&
+		descriptor.Name() == quarantineFileName
+}
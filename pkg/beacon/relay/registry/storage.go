@@ -2,17 +2,36 @@ package registry
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/persistence/layout"
 
 	"encoding/hex"
 )
 
+const (
+	membershipFileNamePrefix      = "membership_"
+	refreshedMembershipFileSuffix = "_refreshed"
+)
+
 type storage interface {
 	save(membership *Membership) error
+	replace(membership *Membership) error
+	snapshot(membership *Membership) error
 	readAll() (<-chan *Membership, <-chan error)
 	archive(groupPublicKey []byte) error
+	quarantine(quarantinedMembership *QuarantinedMembership) error
+	readQuarantined() []*QuarantinedMembership
+	savePendingRefresh(membership *Membership) error
+	commitPendingRefresh(membership *Membership) error
+	archivePendingRefresh(
+		groupPublicKeyCompressed []byte,
+		memberIndex group.MemberIndex,
+	) error
+	readPendingRefreshes() []*pendingRefresh
 }
 
 type persistentStorage struct {
@@ -33,13 +52,257 @@ func (ps *persistentStorage) save(membership *Membership) error {
 
 	hexGroupPublicKey := hex.EncodeToString(membership.Signer.GroupPublicKeyBytesCompressed())
 
-	return ps.handle.Save(membershipBytes, hexGroupPublicKey, "/membership_"+fmt.Sprint(membership.Signer.MemberID()))
+	return ps.handle.Save(membershipBytes, hexGroupPublicKey, "/"+membershipFileName(membership))
+}
+
+// replace persists the membership in place of the membership of the same
+// member stored before. Files are truncated when saved, so the membership is
+// first written to a separate file and only then the previous membership file
+// is overwritten. If the client crashes in the middle of any of those writes,
+// one intact copy of the membership is still stored and readAll picks it.
+// Once the separate file has been written, the membership is considered
+// persisted even if overwriting the previous membership file fails.
+func (ps *persistentStorage) replace(membership *Membership) error {
+	membershipBytes, err := membership.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling of the membership failed: [%v]", err)
+	}
+
+	hexGroupPublicKey := hex.EncodeToString(membership.Signer.GroupPublicKeyBytesCompressed())
+
+	err = ps.handle.Save(
+		membershipBytes,
+		hexGroupPublicKey,
+		"/"+membershipFileName(membership)+refreshedMembershipFileSuffix,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = ps.handle.Save(
+		membershipBytes,
+		hexGroupPublicKey,
+		"/"+membershipFileName(membership),
+	)
+	if err != nil {
+		logger.Warningf(
+			"could not overwrite previous membership of member [%v] "+
+				"in group [0x%v]; the refreshed membership is kept "+
+				"in a separate file: [%v]",
+			membership.Signer.MemberID(),
+			hexGroupPublicKey,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (ps *persistentStorage) snapshot(membership *Membership) error {
+	membershipBytes, err := membership.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling of the membership failed: [%v]", err)
+	}
+
+	hexGroupPublicKey := hex.EncodeToString(membership.Signer.GroupPublicKeyBytesCompressed())
+
+	return ps.handle.Snapshot(membershipBytes, hexGroupPublicKey, "/"+membershipFileName(membership))
 }
 
 func (ps *persistentStorage) archive(groupPublicKeyCompressed []byte) error {
 	return ps.handle.Archive(hex.EncodeToString(groupPublicKeyCompressed))
 }
 
+func (ps *persistentStorage) quarantine(
+	quarantinedMembership *QuarantinedMembership,
+) error {
+	quarantinedMembershipBytes, err := quarantinedMembership.Marshal()
+	if err != nil {
+		return fmt.Errorf(
+			"marshalling of the quarantined membership failed: [%v]",
+			err,
+		)
+	}
+
+	signer := quarantinedMembership.Membership.Signer
+
+	return ps.handle.Save(
+		quarantinedMembershipBytes,
+		quarantineDirectory(
+			signer.GroupPublicKeyBytesCompressed(),
+			signer.MemberID(),
+		),
+		"/"+quarantineFileName,
+	)
+}
+
+// readQuarantined returns all quarantined memberships currently stored.
+// Quarantined memberships which could not be read are logged and skipped.
+func (ps *persistentStorage) readQuarantined() []*QuarantinedMembership {
+	quarantinedMemberships := make([]*QuarantinedMembership, 0)
+
+	descriptorsChannel, errorsChannel := ps.handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels for the same
+	// reason as when reading memberships; channels are not buffered and we
+	// do not know in what order information is written to them.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChannel {
+			if !isQuarantinedMembership(descriptor) {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read quarantined membership from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			quarantinedMembership := &QuarantinedMembership{}
+			if err := quarantinedMembership.Unmarshal(content); err != nil {
+				logger.Errorf(
+					"could not unmarshal quarantined membership from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			quarantinedMemberships = append(
+				quarantinedMemberships,
+				quarantinedMembership,
+			)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChannel {
+			logger.Errorf(
+				"could not load quarantined membership from disk: [%v]",
+				err,
+			)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	return quarantinedMemberships
+}
+
+func (ps *persistentStorage) savePendingRefresh(membership *Membership) error {
+	membershipBytes, err := membership.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling of the membership failed: [%v]", err)
+	}
+
+	return ps.handle.Save(
+		membershipBytes,
+		pendingRefreshDirectory(
+			membership.Signer.GroupPublicKeyBytesCompressed(),
+			membership.Signer.MemberID(),
+		),
+		"/"+pendingRefreshFileName,
+	)
+}
+
+// commitPendingRefresh marks the pending refresh of the membership as
+// committed by the group, so that it replaces the membership when the client
+// starts even if the client stops before the membership is replaced.
+func (ps *persistentStorage) commitPendingRefresh(membership *Membership) error {
+	return ps.handle.Save(
+		[]byte{},
+		pendingRefreshDirectory(
+			membership.Signer.GroupPublicKeyBytesCompressed(),
+			membership.Signer.MemberID(),
+		),
+		"/"+pendingRefreshCommittedName,
+	)
+}
+
+func (ps *persistentStorage) archivePendingRefresh(
+	groupPublicKeyCompressed []byte,
+	memberIndex group.MemberIndex,
+) error {
+	return ps.handle.Archive(
+		pendingRefreshDirectory(groupPublicKeyCompressed, memberIndex),
+	)
+}
+
+// readPendingRefreshes returns all pending refreshes currently stored. Pending
+// refreshes which could not be read are logged and skipped; previous
+// memberships are used for them.
+func (ps *persistentStorage) readPendingRefreshes() []*pendingRefresh {
+	memberships := make(map[string]*Membership)
+	committed := make(map[string]bool)
+	var directories []string
+
+	descriptorsChannel, errorsChannel := ps.handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels for the same
+	// reason as when reading memberships; channels are not buffered and we
+	// do not know in what order information is written to them.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChannel {
+			if !isPendingRefresh(descriptor) {
+				continue
+			}
+
+			if descriptor.Name() == pendingRefreshCommittedName {
+				committed[descriptor.Directory()] = true
+				continue
+			}
+
+			membership, err := readMembership(descriptor)
+			if err != nil {
+				logger.Errorf("could not read pending share refresh: [%v]", err)
+				continue
+			}
+
+			memberships[descriptor.Directory()] = membership
+			directories = append(directories, descriptor.Directory())
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChannel {
+			logger.Errorf(
+				"could not load pending share refresh from disk: [%v]",
+				err,
+			)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	pendingRefreshes := make([]*pendingRefresh, 0, len(directories))
+	for _, directory := range directories {
+		pendingRefreshes = append(pendingRefreshes, &pendingRefresh{
+			membership: memberships[directory],
+			committed:  committed[directory],
+		})
+	}
+
+	return pendingRefreshes
+}
+
 func (ps *persistentStorage) readAll() (<-chan *Membership, <-chan error) {
 	outputMemberships := make(chan *Membership)
 	outputErrors := make(chan error)
@@ -76,31 +339,91 @@ func (ps *persistentStorage) readAll() (<-chan *Membership, <-chan error) {
 	// Memberships goroutine reads data from input channel, tries to unmarshal
 	// the data to Membership and write the unmarshalled Membership to the
 	// output memberships channel. In case of an error, goroutine writes that
-	// error to an output errors channel.
+	// error to an output errors channel. Memberships are written only once
+	// all data have been read, because the refreshed copy of a membership,
+	// if intact, takes precedence over the membership file and the order in
+	// which files are read is not known.
 	go func() {
+		storedMemberships := make(map[string]*storedMembership)
+		var keys []string
+
 		for descriptor := range inputData {
-			content, err := descriptor.Content()
-			if err != nil {
-				outputErrors <- fmt.Errorf(
-					"could not unmarshal membership from file [%v] in directory [%v]: [%v]",
+			// DKG checkpoints, DKG evidence logs, the last processed block,
+			// quarantined memberships, pending share refreshes and
+			// the transaction journal share the storage with memberships.
+			if isDKGCheckpoint(descriptor) ||
+				isDKGEvidence(descriptor) ||
+				isProcessedBlock(descriptor) ||
+				isQuarantinedMembership(descriptor) ||
+				isPendingRefresh(descriptor) ||
+				isTransactionJournalRecord(descriptor) {
+				continue
+			}
+
+			isRefreshed := strings.HasSuffix(
+				descriptor.Name(),
+				refreshedMembershipFileSuffix,
+			)
+			key := descriptor.Directory() + "/" + strings.TrimSuffix(
+				descriptor.Name(),
+				refreshedMembershipFileSuffix,
+			)
+
+			stored, ok := storedMemberships[key]
+			if !ok {
+				stored = &storedMembership{}
+				storedMemberships[key] = stored
+				keys = append(keys, key)
+			}
+
+			membership, err := readMembership(descriptor)
+			if err == nil && isRefreshed && membership.Parameters == nil {
+				// Refreshed memberships always have group parameters;
+				// a refreshed copy without them has been cut in the
+				// middle of writing.
+				err = fmt.Errorf(
+					"refreshed membership from file [%v] in directory [%v] "+
+						"has no group parameters",
 					descriptor.Name(),
 					descriptor.Directory(),
-					err,
 				)
+			}
+			if err != nil {
+				stored.errors = append(stored.errors, err)
 				continue
 			}
 
-			membership := &Membership{}
+			if isRefreshed {
+				stored.refreshed = membership
+			} else {
+				stored.membership = membership
+			}
+		}
 
-			err = membership.Unmarshal(content)
-			if err != nil {
-				outputErrors <- fmt.Errorf(
-					"could not unmarshal membership from file [%v] in directory [%v]: [%v]",
-					descriptor.Name(),
-					descriptor.Directory(),
+		for _, key := range keys {
+			stored := storedMemberships[key]
+
+			membership := stored.refreshed
+			if membership == nil {
+				membership = stored.membership
+			}
+
+			if membership == nil {
+				for _, err := range stored.errors {
+					outputErrors <- err
+				}
+				continue
+			}
+
+			for _, err := range stored.errors {
+				logger.Warningf(
+					"could not read one of the copies of membership of "+
+						"member [%v] in group [0x%v]; using the intact "+
+						"one: [%v]",
+					membership.Signer.MemberID(),
+					groupKeyToString(membership.Signer.GroupPublicKeyBytes()),
 					err,
 				)
-				continue
 			}
 
 			outputMemberships <- membership
@@ -111,3 +434,46 @@ func (ps *persistentStorage) readAll() (<-chan *Membership, <-chan error) {
 
 	return outputMemberships, outputErrors
 }
+
+// storedMembership holds copies of the membership of one member read from
+// the storage.
+type storedMembership struct {
+	membership *Membership
+	refreshed  *Membership
+	errors     []error
+}
+
+func readMembership(descriptor persistence.DataDescriptor) (*Membership, error) {
+	content, err := descriptor.Content()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal membership from file [%v] in directory [%v]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	membership := &Membership{}
+
+	err = membership.Unmarshal(content)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal membership from file [%v] in directory [%v]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	return membership, nil
+}
+
+func membershipFileName(membership *Membership) string {
+	return membershipFileNamePrefix + fmt.Sprint(membership.Signer.MemberID())
+}
+
+func isTransactionJournalRecord(descriptor persistence.DataDescriptor) bool {
+	_, ok := layout.TransactionJournalGeneration(descriptor.Directory())
+	return ok
+}
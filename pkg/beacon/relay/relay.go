@@ -1,14 +1,21 @@
 package relay
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 )
@@ -25,13 +32,22 @@ func NewNode(
 	blockCounter chain.BlockCounter,
 	chainConfig *relayChain.Config,
 	groupRegistry *registry.Groups,
+	dkgCheckpoints *registry.DKGCheckpoints,
+	dkgEvidenceLog *registry.DKGEvidenceLog,
 ) Node {
 	return Node{
-		Staker:        staker,
-		netProvider:   netProvider,
-		blockCounter:  blockCounter,
-		chainConfig:   chainConfig,
-		groupRegistry: groupRegistry,
+		Staker:         staker,
+		netProvider:    netProvider,
+		blockCounter:   blockCounter,
+		chainConfig:    chainConfig,
+		groupRegistry:  groupRegistry,
+		dkgCheckpoints: dkgCheckpoints,
+		dkgEvidenceLog: dkgEvidenceLog,
+		dkgTelemetry:   dkg.NewTelemetry(),
+		machines:       state.NewRegistry(),
+		protocols:      &sync.WaitGroup{},
+		signings:       make(map[*SigningStatus]bool),
+		refreshes:      make(map[string]*shareRefresh),
 	}
 }
 
@@ -39,54 +55,301 @@ func NewNode(
 // When a processing group which is supposed to deliver a relay entry does not
 // fulfill its work, then this Node notifies the chain about it. In the case of
 // delivering a relay entry by a processing group, this Node does nothing.
+//
+// To avoid all nodes competing to report the same timeout, each node becomes
+// eligible to report it in its own reporting slot, starting at the relay entry
+// timeout block. Slots are taken by members of active groups in an order
+// derived from the previous entry, so the timeout is reported at the timeout
+// block as long as the staker of the first slot is online. Members of the group
+// selected to deliver the relay entry are punished for the timeout so they
+// never report it and do not claim a part of their own penalty as the reporter
+// reward. Before reporting, the node checks whether the timeout has not been
+// already reported by a node from an earlier slot.
 func (n *Node) MonitorRelayEntry(
 	relayChain relayChain.Interface,
+	previousEntry []byte,
+	groupPublicKey []byte,
 	relayRequestBlockNumber uint64,
 	chainConfig *relayChain.Config,
 ) {
+	if n.isGroupMember(relayChain, groupPublicKey) {
+		logger.Infof(
+			"member of group [0x%x] selected to deliver the relay entry; "+
+				"not monitoring the relay entry timeout",
+			groupPublicKey,
+		)
+		return
+	}
+
 	logger.Infof("monitoring chain for a new relay entry")
 
-	timeoutWaiterChannel, err := n.blockCounter.BlockHeightWaiter(relayRequestBlockNumber + chainConfig.RelayEntryTimeout)
+	reportingSlot := relayEntryTimeoutReportingSlot(
+		previousEntry,
+		n.Staker.Address(),
+		relayEntryTimeoutReporters(relayChain, groupPublicKey),
+	)
+
+	// T_timeout + slot * T_step
+	eligibleBlockHeight := relayRequestBlockNumber +
+		chainConfig.RelayEntryTimeout +
+		reportingSlot*chainConfig.ResultPublicationBlockStep
+
+	eligibleToReportWaiter, err := n.blockCounter.BlockHeightWaiter(
+		eligibleBlockHeight,
+	)
 	if err != nil {
 		logger.Errorf("waiter for a relay entry timeout block failed: [%v]", err)
+		return
 	}
 
-	onEntrySubmittedChannel := make(chan *event.EntrySubmitted)
-
-	subscription := relayChain.OnRelayEntrySubmitted(
+	onEntrySubmittedChannel := make(chan uint64, 1)
+	entrySubmittedSubscription := relayChain.OnRelayEntrySubmitted(
 		func(event *event.EntrySubmitted) {
-			onEntrySubmittedChannel <- event
+			select {
+			case onEntrySubmittedChannel <- event.BlockNumber:
+			default:
+			}
 		},
 	)
+	defer entrySubmittedSubscription.Unsubscribe()
 
-	for {
-		select {
-		case blockNumber := <-timeoutWaiterChannel:
-			subscription.Unsubscribe()
-			close(onEntrySubmittedChannel)
+	onTimeoutReportedChannel := make(chan uint64, 1)
+	timeoutReportedSubscription := relayChain.OnRelayEntryTimeoutReported(
+		func(report *event.RelayEntryTimeoutReport) {
+			if report.BlockNumber < relayRequestBlockNumber {
+				return
+			}
+
+			select {
+			case onTimeoutReportedChannel <- report.BlockNumber:
+			default:
+			}
+		},
+	)
+	defer timeoutReportedSubscription.Unsubscribe()
+
+	select {
+	case blockNumber := <-eligibleToReportWaiter:
+		isHandled, err := isRelayRequestHandled(
+			relayChain,
+			relayRequestBlockNumber,
+		)
+		if err != nil {
+			// If it is not possible to check, the timeout is reported anyway.
+			// At worst, the report is rejected by the chain.
 			logger.Warningf(
-				"relay entry was not submitted on time, reporting timeout at block [%v]",
-				blockNumber,
+				"could not check if relay request from block [%v] "+
+					"is still in progress: [%v]",
+				relayRequestBlockNumber,
+				err,
 			)
-			err = relayChain.ReportRelayEntryTimeout()
-			if err != nil {
-				logger.Errorf("could not report a relay entry timeout: [%v]", err)
-			}
-			return
-		case entry := <-onEntrySubmittedChannel:
+		} else if isHandled {
 			logger.Infof(
-				"relay entry was submitted by the selected group on time at block [%v]",
-				entry.BlockNumber,
+				"relay request from block [%v] is no longer in progress; "+
+					"not reporting relay entry timeout",
+				relayRequestBlockNumber,
 			)
 			return
 		}
+
+		logger.Warningf(
+			"relay entry was not submitted on time, reporting timeout at block [%v]",
+			blockNumber,
+		)
+		err = relayChain.ReportRelayEntryTimeout(relayRequestBlockNumber)
+		if err != nil {
+			logRelayEntryTimeoutReportError(err)
+		}
+	case blockNumber := <-onEntrySubmittedChannel:
+		logger.Infof(
+			"relay entry was submitted by the selected group on time at block [%v]",
+			blockNumber,
+		)
+	case blockNumber := <-onTimeoutReportedChannel:
+		logger.Infof(
+			"relay entry timeout was reported by other node at block [%v]",
+			blockNumber,
+		)
+	}
+}
+
+// logRelayEntryTimeoutReportError logs the error of a relay entry timeout
+// report. A report skipped because the timeout has already been reported by
+// other node is not an error.
+func logRelayEntryTimeoutReportError(err error) {
+	if relayChain.IsAlreadyDone(err) {
+		logger.Infof("relay entry timeout not reported: [%v]", err)
+		return
+	}
+
+	logger.Errorf("could not report a relay entry timeout: [%v]", err)
+}
+
+// isGroupMember checks whether the staker of this node is a member of the
+// group with the given public key. Members are read from the chain. If they
+// could not be read, memberships stored by this node are checked instead.
+func (n *Node) isGroupMember(
+	relayChain relayChain.GroupRegistrationInterface,
+	groupPublicKey []byte,
+) bool {
+	groupMembers, err := relayChain.GetGroupMembers(groupPublicKey)
+	if err != nil {
+		logger.Warningf(
+			"could not get members of group [0x%x]; "+
+				"checking stored memberships: [%v]",
+			groupPublicKey,
+			err,
+		)
+		return n.groupRegistry != nil && n.IsInGroup(groupPublicKey)
+	}
+
+	for _, member := range groupMembers {
+		if bytes.Equal(member, n.Staker.Address()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// relayEntryTimeoutReporters returns addresses of stakers expected to report
+// a relay entry timeout of the group with the given public key, that is
+// members of active groups other than the timed-out group. The chain returns
+// a member for each seat in active groups, so a staker holding several seats
+// is returned only once. If members could not be read, no reporters are
+// returned.
+func relayEntryTimeoutReporters(
+	relayChain relayChain.Interface,
+	groupPublicKey []byte,
+) []relayChain.StakerAddress {
+	activeGroupMembers, err := relayChain.GetActiveGroupMembers()
+	if err != nil {
+		logger.Warningf(
+			"could not get members of active groups; "+
+				"reporting relay entry timeout in the first slot: [%v]",
+			err,
+		)
+		return nil
+	}
+
+	groupMembers, err := relayChain.GetGroupMembers(groupPublicKey)
+	if err != nil {
+		logger.Warningf(
+			"could not get members of group [0x%x]; "+
+				"reporting relay entry timeout in the first slot: [%v]",
+			groupPublicKey,
+			err,
+		)
+		return nil
+	}
+
+	timedOutGroupMembers := make(map[string]bool)
+	for _, member := range groupMembers {
+		timedOutGroupMembers[hex.EncodeToString(member)] = true
+	}
+
+	// The zero capacity makes appends allocate a new array, so that members
+	// returned by the chain are not modified.
+	reporters := activeGroupMembers[:0:0]
+	seenMembers := make(map[string]bool)
+	for _, member := range activeGroupMembers {
+		memberKey := hex.EncodeToString(member)
+		if timedOutGroupMembers[memberKey] || seenMembers[memberKey] {
+			continue
+		}
+
+		seenMembers[memberKey] = true
+		reporters = append(reporters, member)
+	}
+
+	return reporters
+}
+
+// relayEntryTimeoutReportingSlot determines the slot in which the staker with
+// the given address becomes eligible to report a relay entry timeout for the
+// request with the given previous entry. The given reporters must be distinct.
+// They are ordered by the hash of the previous entry and their address and
+// each of them gets the slot of its position, so that timeouts of different
+// requests are reported by different stakers, no two reporters share a slot
+// and the first slot is always taken. Stakers not being reporters become
+// eligible to report together, in the slot following the last reporter.
+func relayEntryTimeoutReportingSlot(
+	previousEntry []byte,
+	stakerAddress []byte,
+	reporters []relayChain.StakerAddress,
+) uint64 {
+	stakerHash := reportingHash(previousEntry, stakerAddress)
+
+	slot := uint64(0)
+	isReporter := false
+	for _, reporter := range reporters {
+		if bytes.Equal(reporter, stakerAddress) {
+			isReporter = true
+			continue
+		}
+
+		// Ties are broken by the address so that the order is the same
+		// for all stakers.
+		comparison := bytes.Compare(
+			reportingHash(previousEntry, reporter),
+			stakerHash,
+		)
+		if comparison < 0 ||
+			(comparison == 0 && bytes.Compare(reporter, stakerAddress) < 0) {
+			slot++
+		}
+	}
+
+	if !isReporter {
+		return uint64(len(reporters))
+	}
+
+	return slot
+}
+
+// reportingHash returns the hash by which the staker with the given address
+// is ordered among relay entry timeout reporters for the request with
+// the given previous entry.
+func reportingHash(previousEntry []byte, stakerAddress []byte) []byte {
+	hash := sha256.New()
+	hash.Write(previousEntry)
+	hash.Write(stakerAddress)
+
+	return hash.Sum(nil)
+}
+
+// isRelayRequestHandled checks on-chain if the relay request started at the
+// given block is no longer in progress, either because the relay entry has been
+// submitted or because the relay entry timeout has been already reported.
+func isRelayRequestHandled(
+	relayChain relayChain.RelayEntryInterface,
+	relayRequestBlockNumber uint64,
+) (bool, error) {
+	isEntryInProgress, err := relayChain.IsEntryInProgress()
+	if err != nil {
+		return false, err
+	}
+
+	if !isEntryInProgress {
+		return true, nil
+	}
+
+	// When a timeout is reported, the chain requests a new entry from another
+	// group straight away, so the request currently in progress is a new one.
+	currentRequestStartBlock, err := relayChain.CurrentRequestStartBlock()
+	if err != nil {
+		return false, err
 	}
+
+	return currentRequestStartBlock.Uint64() != relayRequestBlockNumber, nil
 }
 
 // GenerateRelayEntry is triggered for a new relay request and checks if this
 // client is one of the group members selected to create a new relay entry.
 // If it is, this client enters the threshold signature creation process and,
 // upon successfully completing it, submits the signature as a new relay entry.
+// The signature is created with the honest threshold the group was created
+// with, not with the one currently configured on-chain.
 // Note that this function returns immediately after determining whether the
 // node is or is not a member of the requested group, and signature creation
 // and submission is performed in a background goroutine.
@@ -131,24 +394,53 @@ func (n *Node) GenerateRelayEntry(
 		)
 	}
 
-	for _, member := range memberships {
-		go func(member *registry.Membership) {
-			err = entry.SignAndSubmit(
-				n.blockCounter,
-				channel,
-				relayChain,
-				previousEntry,
-				n.chainConfig.HonestThreshold,
-				member.Signer,
-				startBlockHeight,
-			)
-			if err != nil {
-				logger.Errorf(
-					"error creating threshold signature: [%v]",
-					err,
-				)
-				return
-			}
-		}(member)
+	if !n.startProtocol() {
+		logger.Warningf(
+			"node is stopping; not signing relay entry for group [0x%x]",
+			groupPublicKey,
+		)
+		return
 	}
+
+	// All the members controlled by this node take part in a single signing
+	// session so that shares are broadcast and verified only once.
+	go func() {
+		defer n.protocols.Done()
+
+		memberships, signings := n.startGroupSigning(
+			groupPublicKey,
+			previousEntry,
+			startBlockHeight,
+		)
+		for _, signing := range signings {
+			defer n.completeSigning(signing)
+		}
+
+		if len(memberships) < 1 {
+			return
+		}
+
+		signers := make([]*dkg.ThresholdSigner, len(memberships))
+		for i, member := range memberships {
+			signers[i] = member.Signer
+		}
+
+		err := entry.SignAndSubmit(
+			n.blockCounter,
+			channel,
+			relayChain,
+			membershipValidator,
+			previousEntry,
+			memberships[0].Parameters.HonestThreshold,
+			signers,
+			startBlockHeight,
+		)
+		if err != nil {
+			logger.Errorf(
+				"error creating threshold signature: [%v]",
+				err,
+			)
+			return
+		}
+	}()
 }
@@ -1,16 +1,21 @@
 package relay
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
+	"reflect"
+	"sync"
 	"testing"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/chain"
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
 )
 
 var address = "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
 var relayEntryTimeout = uint64(15)
+var previousEntry = big.NewInt(10).Bytes()
 
 func TestMonitorRelayEntryOnChain_EntrySubmitted(t *testing.T) {
 	chain := chainLocal.Connect(5, 3, big.NewInt(200))
@@ -20,6 +25,7 @@ func TestMonitorRelayEntryOnChain_EntrySubmitted(t *testing.T) {
 	}
 
 	node := &Node{
+		Staker:       newTestStaker(t, chain, address),
 		blockCounter: blockCounter,
 	}
 
@@ -27,13 +33,17 @@ func TestMonitorRelayEntryOnChain_EntrySubmitted(t *testing.T) {
 	chainConfig := &relaychain.Config{
 		RelayEntryTimeout: uint64(relayEntryTimeout),
 	}
-	startBlockHeight, err := blockCounter.CurrentBlock()
+
+	request, err := chain.SimulateRelayEntryRequest(previousEntry, []byte{})
 	if err != nil {
 		t.Fatal(err)
 	}
+	startBlockHeight := request.BlockNumber
 
 	go node.MonitorRelayEntry(
 		relayChain,
+		previousEntry,
+		[]byte{},
 		startBlockHeight,
 		chainConfig,
 	)
@@ -78,6 +88,7 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 	}
 
 	node := &Node{
+		Staker:       newTestStaker(t, chain, address),
 		blockCounter: blockCounter,
 	}
 
@@ -85,13 +96,17 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 	chainConfig := &relaychain.Config{
 		RelayEntryTimeout: uint64(relayEntryTimeout),
 	}
-	startBlockHeight, err := blockCounter.CurrentBlock()
+
+	request, err := chain.SimulateRelayEntryRequest(previousEntry, []byte{})
 	if err != nil {
 		t.Fatal(err)
 	}
+	startBlockHeight := request.BlockNumber
 
 	go node.MonitorRelayEntry(
 		relayChain,
+		previousEntry,
+		[]byte{},
 		startBlockHeight,
 		chainConfig,
 	)
@@ -121,3 +136,354 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 		)
 	}
 }
+
+func TestMonitorRelayEntryOnChain_TimeoutReportedByOtherNode(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainConfig := &relaychain.Config{
+		GroupSize:                  4,
+		RelayEntryTimeout:          relayEntryTimeout,
+		ResultPublicationBlockStep: 2,
+	}
+
+	// Both stakers are members of an active group so they report the
+	// timeout in the first two slots.
+	stakers := make(map[uint64]string)
+	var activeGroupMembers []relaychain.StakerAddress
+	for i := 0; i < 2; i++ {
+		stakerAddress := fmt.Sprintf("0x%040x", i+1)
+		activeGroupMembers = append(
+			activeGroupMembers,
+			newTestStaker(t, chain, stakerAddress).Address(),
+		)
+	}
+	for i, member := range activeGroupMembers {
+		slot := relayEntryTimeoutReportingSlot(
+			previousEntry,
+			member,
+			activeGroupMembers,
+		)
+		stakers[slot] = fmt.Sprintf("0x%040x", i+1)
+	}
+	if len(stakers) != 2 {
+		t.Fatalf("stakers should be eligible in different slots")
+	}
+
+	relayChain := &reportsCountingChain{
+		Interface:          chain.ThresholdRelay(),
+		activeGroupMembers: activeGroupMembers,
+	}
+
+	request, err := chain.SimulateRelayEntryRequest(previousEntry, []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, stakerAddress := range stakers {
+		node := &Node{
+			Staker:       newTestStaker(t, chain, stakerAddress),
+			blockCounter: blockCounter,
+		}
+
+		go node.MonitorRelayEntry(
+			relayChain,
+			previousEntry,
+			request.GroupPublicKey,
+			request.BlockNumber,
+			chainConfig,
+		)
+	}
+
+	timeoutBlock := request.BlockNumber + relayEntryTimeout
+	blockCounter.WaitForBlockHeight(
+		timeoutBlock + 2*chainConfig.ResultPublicationBlockStep,
+	)
+
+	if relayChain.reportsCount() != 1 {
+		t.Fatalf(
+			"unexpected number of timeout report attempts\n"+
+				"expected: [%v]\nactual:   [%v]",
+			1,
+			relayChain.reportsCount(),
+		)
+	}
+
+	timeoutsReport := chain.GetRelayEntryTimeoutReports()
+	if len(timeoutsReport) != 1 || timeoutsReport[0] != timeoutBlock {
+		t.Fatalf(
+			"unexpected timeout reports\nexpected: [%v]\nactual:   %v",
+			timeoutBlock,
+			timeoutsReport,
+		)
+	}
+}
+
+func TestMonitorRelayEntryOnChain_TimedOutGroupMember(t *testing.T) {
+	localChain := chainLocal.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainConfig := &relaychain.Config{
+		GroupSize:         4,
+		RelayEntryTimeout: relayEntryTimeout,
+	}
+
+	// Find a staker which would be eligible to report the timeout in the
+	// first slot if it was not a member of the timed-out group.
+	var activeGroupMembers []relaychain.StakerAddress
+	for i := 0; i < 4; i++ {
+		activeGroupMembers = append(
+			activeGroupMembers,
+			newTestStaker(t, localChain, fmt.Sprintf("0x%040x", i+1)).Address(),
+		)
+	}
+
+	var memberStaker chain.Staker
+	for i, member := range activeGroupMembers {
+		slot := relayEntryTimeoutReportingSlot(
+			previousEntry,
+			member,
+			activeGroupMembers,
+		)
+		if slot == 0 {
+			memberStaker = newTestStaker(t, localChain, fmt.Sprintf("0x%040x", i+1))
+		}
+	}
+
+	relayChain := &reportsCountingChain{
+		Interface: localChain.ThresholdRelay(),
+		groupMembers: []relaychain.StakerAddress{
+			[]byte{0x01},
+			memberStaker.Address(),
+			[]byte{0x03},
+			[]byte{0x04},
+		},
+		activeGroupMembers: activeGroupMembers,
+	}
+
+	request, err := localChain.SimulateRelayEntryRequest(previousEntry, []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := &Node{
+		Staker:       memberStaker,
+		blockCounter: blockCounter,
+	}
+
+	monitoringDone := make(chan struct{})
+	go func() {
+		node.MonitorRelayEntry(
+			relayChain,
+			previousEntry,
+			request.GroupPublicKey,
+			request.BlockNumber,
+			chainConfig,
+		)
+		close(monitoringDone)
+	}()
+
+	blockCounter.WaitForBlockHeight(request.BlockNumber + relayEntryTimeout + 1)
+
+	select {
+	case <-monitoringDone:
+	default:
+		t.Fatal("member of the timed-out group should not monitor the timeout")
+	}
+
+	if relayChain.reportsCount() != 0 {
+		t.Fatalf(
+			"unexpected number of timeout report attempts\n"+
+				"expected: [%v]\nactual:   [%v]",
+			0,
+			relayChain.reportsCount(),
+		)
+	}
+}
+
+func TestRelayEntryTimeoutReportingSlot(t *testing.T) {
+	reporters := make([]relaychain.StakerAddress, 1024)
+	for i := range reporters {
+		reporters[i] = big.NewInt(int64(i + 1)).Bytes()
+	}
+
+	slots := make(map[uint64]bool)
+	for _, reporter := range reporters {
+		slot := relayEntryTimeoutReportingSlot(previousEntry, reporter, reporters)
+		if slot >= uint64(len(reporters)) {
+			t.Fatalf(
+				"slot [%v] out of range [%v]",
+				slot,
+				len(reporters),
+			)
+		}
+
+		if slots[slot] {
+			t.Fatalf("slot [%v] taken by more than one reporter", slot)
+		}
+		slots[slot] = true
+	}
+
+	if !slots[0] {
+		t.Errorf("first slot should be taken")
+	}
+
+	nonReporterSlot := relayEntryTimeoutReportingSlot(
+		previousEntry,
+		big.NewInt(2048).Bytes(),
+		reporters,
+	)
+	if nonReporterSlot != uint64(len(reporters)) {
+		t.Errorf(
+			"unexpected slot of non-reporter\nexpected: [%v]\nactual:   [%v]",
+			len(reporters),
+			nonReporterSlot,
+		)
+	}
+}
+
+func TestRelayEntryTimeoutReportingSlotOfMultiSeatStakers(t *testing.T) {
+	// The chain returns a member for each seat in active groups. Stakers 0x01
+	// and 0x02 hold several seats and staker 0x04 is also a member of
+	// the timed-out group.
+	relayChain := &reportsCountingChain{
+		groupMembers: []relaychain.StakerAddress{
+			[]byte{0x04}, []byte{0x05},
+		},
+		activeGroupMembers: []relaychain.StakerAddress{
+			[]byte{0x01}, []byte{0x02}, []byte{0x01}, []byte{0x03},
+			[]byte{0x02}, []byte{0x04}, []byte{0x01}, []byte{0x04},
+		},
+	}
+
+	reporters := relayEntryTimeoutReporters(relayChain, []byte{0xff})
+
+	expectedReporters := []relaychain.StakerAddress{
+		[]byte{0x01}, []byte{0x02}, []byte{0x03},
+	}
+	if !reflect.DeepEqual(expectedReporters, reporters) {
+		t.Fatalf(
+			"unexpected reporters\nexpected: %v\nactual:   %v",
+			expectedReporters,
+			reporters,
+		)
+	}
+
+	if len(relayChain.activeGroupMembers) != 8 ||
+		!bytes.Equal(relayChain.activeGroupMembers[1], []byte{0x02}) {
+		t.Errorf("members returned by the chain should not be modified")
+	}
+
+	slots := make(map[uint64]bool)
+	for _, reporter := range reporters {
+		slots[relayEntryTimeoutReportingSlot(
+			previousEntry,
+			reporter,
+			reporters,
+		)] = true
+	}
+
+	expectedSlots := map[uint64]bool{0: true, 1: true, 2: true}
+	if !reflect.DeepEqual(expectedSlots, slots) {
+		t.Errorf(
+			"unexpected reporting slots\nexpected: %v\nactual:   %v",
+			expectedSlots,
+			slots,
+		)
+	}
+}
+
+func TestRelayEntryTimeoutReportingSlotDependsOnPreviousEntry(t *testing.T) {
+	reporters := make([]relaychain.StakerAddress, 16)
+	for i := range reporters {
+		reporters[i] = big.NewInt(int64(i + 1)).Bytes()
+	}
+
+	firstReporter := func(previousEntry []byte) string {
+		for _, reporter := range reporters {
+			if relayEntryTimeoutReportingSlot(
+				previousEntry,
+				reporter,
+				reporters,
+			) == 0 {
+				return fmt.Sprintf("%x", reporter)
+			}
+		}
+		t.Fatal("first slot should be taken")
+		return ""
+	}
+
+	firstReporters := make(map[string]bool)
+	for i := 0; i < 16; i++ {
+		firstReporters[firstReporter(big.NewInt(int64(i)).Bytes())] = true
+	}
+
+	if len(firstReporters) < 2 {
+		t.Errorf("timeouts of different requests should be reported by different stakers")
+	}
+}
+
+func newTestStaker(
+	t *testing.T,
+	localChain chainLocal.Chain,
+	address string,
+) chain.Staker {
+	stakeMonitor, err := localChain.StakeMonitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	staker, err := stakeMonitor.StakerFor(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return staker
+}
+
+// reportsCountingChain counts relay entry timeout report attempts.
+type reportsCountingChain struct {
+	relaychain.Interface
+
+	groupMembers       []relaychain.StakerAddress
+	activeGroupMembers []relaychain.StakerAddress
+
+	mutex   sync.Mutex
+	reports int
+}
+
+func (rcc *reportsCountingChain) GetGroupMembers(
+	groupPublicKey []byte,
+) ([]relaychain.StakerAddress, error) {
+	return rcc.groupMembers, nil
+}
+
+func (rcc *reportsCountingChain) GetActiveGroupMembers() (
+	[]relaychain.StakerAddress,
+	error,
+) {
+	return rcc.activeGroupMembers, nil
+}
+
+func (rcc *reportsCountingChain) ReportRelayEntryTimeout(
+	relayRequestBlockNumber uint64,
+) error {
+	rcc.mutex.Lock()
+	rcc.reports++
+	rcc.mutex.Unlock()
+
+	return rcc.Interface.ReportRelayEntryTimeout(relayRequestBlockNumber)
+}
+
+func (rcc *reportsCountingChain) reportsCount() int {
+	rcc.mutex.Lock()
+	defer rcc.mutex.Unlock()
+
+	return rcc.reports
+}
@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
@@ -22,6 +23,48 @@ type Machine struct {
 	channel      net.BroadcastChannel
 	blockCounter chain.BlockCounter
 	initialState State // first state from which execution starts
+
+	checkpointer Checkpointer
+	observer     Observer
+	registry     *Registry
+}
+
+// Checkpointer persists the progress of the state machine execution so that
+// it can be resumed with Machine.Resume after the client restarts.
+type Checkpointer interface {
+	// Checkpoint is called each time the state machine enters a new state,
+	// before the state is initiated, and once again after the state has been
+	// successfully initiated. lastStateEndBlockHeight is the block at which
+	// the previous state ended and the current state started.
+	Checkpoint(
+		currentState State,
+		lastStateEndBlockHeight uint64,
+		initiated bool,
+	) error
+}
+
+// Observer is notified each time the state machine completes a state. It lets
+// to collect telemetry of the state machine execution.
+type Observer interface {
+	// StateCompleted is called once the state is over, before the state
+	// machine moves on to the next state.
+	StateCompleted(report *StateReport)
+}
+
+// StateReport describes the execution of a single completed state.
+type StateReport struct {
+	// State is the completed state.
+	State State
+	// StartBlock is the block at which the state machine entered the state.
+	StartBlock uint64
+	// EndBlock is the block at which the state was over.
+	EndBlock uint64
+	// StartTime is the time at which the state machine entered the state.
+	StartTime time.Time
+	// EndTime is the time at which the state was over.
+	EndTime time.Time
+	// ReceivedMessages is the number of messages the state received.
+	ReceivedMessages int
 }
 
 // NewMachine returns a new state machine. It requires a broadcast channel and
@@ -38,9 +81,47 @@ func NewMachine(
 	}
 }
 
+// SetCheckpointer makes the state machine report its progress to the provided
+// checkpointer. Checkpoint failures are logged and do not interrupt the
+// execution.
+func (m *Machine) SetCheckpointer(checkpointer Checkpointer) {
+	m.checkpointer = checkpointer
+}
+
+// SetObserver makes the state machine report each completed state to the
+// provided observer.
+func (m *Machine) SetObserver(observer Observer) {
+	m.observer = observer
+}
+
+// SetRegistry makes the state machine report the state it is currently in to
+// the provided registry for as long as it is executed.
+func (m *Machine) SetRegistry(registry *Registry) {
+	m.registry = registry
+}
+
 // Execute state machine starting with initial state up to finalization. It
 // requires the broadcast channel to be pre-initialized.
 func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
+	return m.execute(startBlockHeight, false)
+}
+
+// Resume state machine execution from a previously checkpointed initial state
+// up to finalization. lastStateEndBlockHeight is the block at which the
+// initial state has been originally entered. If the initial state has already
+// been initiated before the checkpoint was taken, it is not initiated again.
+// It requires the broadcast channel to be pre-initialized.
+func (m *Machine) Resume(
+	lastStateEndBlockHeight uint64,
+	initiated bool,
+) (State, uint64, error) {
+	return m.execute(lastStateEndBlockHeight, initiated)
+}
+
+func (m *Machine) execute(
+	startBlockHeight uint64,
+	initiated bool,
+) (State, uint64, error) {
 	recvChan := make(chan net.Message, receiveBuffer)
 	handler := func(msg net.Message) {
 		recvChan <- msg
@@ -50,6 +131,9 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	m.channel.Recv(ctx, handler)
 
+	m.reportStatus(currentState, startBlockHeight)
+	defer m.clearStatus()
+
 	logger.Infof(
 		"[member:%v,channel:%s] waiting for block %v to start execution",
 		currentState.MemberIndex(),
@@ -63,12 +147,13 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 
 	lastStateEndBlockHeight := startBlockHeight
 
-	blockWaiter, err := stateTransition(
+	report := m.newStateReport(currentState, lastStateEndBlockHeight)
+
+	blockWaiter, err := m.stateTransition(
 		ctx,
 		currentState,
 		lastStateEndBlockHeight,
-		m.blockCounter,
-		m.channel.Name()[:5],
+		initiated,
 	)
 	if err != nil {
 		cancelCtx()
@@ -78,6 +163,8 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 	for {
 		select {
 		case msg := <-recvChan:
+			report.ReceivedMessages++
+
 			err := currentState.Receive(msg)
 			if err != nil {
 				logger.Errorf(
@@ -91,6 +178,8 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 
 		case lastStateEndBlockHeight := <-blockWaiter:
 			cancelCtx()
+			m.completeState(report, lastStateEndBlockHeight)
+
 			nextState := currentState.Next()
 			if nextState == nil {
 				logger.Infof(
@@ -104,15 +193,15 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 			}
 
 			currentState = nextState
+			report = m.newStateReport(currentState, lastStateEndBlockHeight)
 			ctx, cancelCtx = context.WithCancel(context.Background())
 			m.channel.Recv(ctx, handler)
 
-			blockWaiter, err = stateTransition(
+			blockWaiter, err = m.stateTransition(
 				ctx,
 				currentState,
 				lastStateEndBlockHeight,
-				m.blockCounter,
-				m.channel.Name()[:5],
+				false,
 			)
 			if err != nil {
 				cancelCtx()
@@ -124,13 +213,17 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 	}
 }
 
-func stateTransition(
+func (m *Machine) stateTransition(
 	ctx context.Context,
 	currentState State,
 	lastStateEndBlockHeight uint64,
-	blockCounter chain.BlockCounter,
-	channelName string,
+	initiated bool,
 ) (<-chan uint64, error) {
+	blockCounter := m.blockCounter
+	channelName := m.channel.Name()[:5]
+
+	m.reportStatus(currentState, lastStateEndBlockHeight)
+
 	logger.Infof(
 		"[member:%v,channel:%s,state:%T] transitioning to a new state at block: [%v]",
 		currentState.MemberIndex(),
@@ -154,9 +247,15 @@ func stateTransition(
 		)
 	}
 
-	err = currentState.Initiate(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initiate new state [%v]", err)
+	if !initiated {
+		m.checkpoint(currentState, lastStateEndBlockHeight, false)
+
+		err = currentState.Initiate(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initiate new state [%v]", err)
+		}
+
+		m.checkpoint(currentState, lastStateEndBlockHeight, true)
 	}
 
 	blockWaiter, err := blockCounter.BlockHeightWaiter(
@@ -179,3 +278,50 @@ func stateTransition(
 
 	return blockWaiter, nil
 }
+
+func (m *Machine) newStateReport(
+	currentState State,
+	lastStateEndBlockHeight uint64,
+) *StateReport {
+	return &StateReport{
+		State:      currentState,
+		StartBlock: lastStateEndBlockHeight,
+		StartTime:  time.Now(),
+	}
+}
+
+func (m *Machine) completeState(report *StateReport, endBlockHeight uint64) {
+	if m.observer == nil {
+		return
+	}
+
+	report.EndBlock = endBlockHeight
+	report.EndTime = time.Now()
+
+	m.observer.StateCompleted(report)
+}
+
+func (m *Machine) checkpoint(
+	currentState State,
+	lastStateEndBlockHeight uint64,
+	initiated bool,
+) {
+	if m.checkpointer == nil {
+		return
+	}
+
+	err := m.checkpointer.Checkpoint(
+		currentState,
+		lastStateEndBlockHeight,
+		initiated,
+	)
+	if err != nil {
+		logger.Warningf(
+			"[member:%v,channel:%s,state:%T] failed to checkpoint state: [%v]",
+			currentState.MemberIndex(),
+			m.channel.Name()[:5],
+			currentState,
+			err,
+		)
+	}
+}
@@ -93,6 +93,249 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestResume(t *testing.T) {
+	testLog = make(map[uint64][]string)
+
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	blockCounter, _ = localChain.BlockCounter()
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("resume_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &TestMessage{}
+	})
+
+	initialState := &testState3{testState2{testState1{
+		memberIndex: group.MemberIndex(1),
+		channel:     channel,
+	}}}
+
+	checkpointer := &testCheckpointer{}
+
+	stateMachine := NewMachine(channel, blockCounter, initialState)
+	stateMachine.SetCheckpointer(checkpointer)
+
+	finalState, endBlockHeight, err := stateMachine.Resume(2, true)
+	if err != nil {
+		t.Errorf("unexpected error [%v]", err)
+	}
+
+	if _, ok := finalState.(*testState5); !ok {
+		t.Errorf("state is not final [%v]", finalState)
+	}
+
+	if endBlockHeight != 5 {
+		t.Errorf("unexpected end block [%v]", endBlockHeight)
+	}
+
+	expectedTestLog := map[uint64][]string{
+		3: []string{"1-state.testState4-initiate"},
+		5: []string{"1-state.testState5-initiate"},
+	}
+
+	if !reflect.DeepEqual(expectedTestLog, testLog) {
+		t.Errorf("\nexpected: %v\nactual:   %v\n", expectedTestLog, testLog)
+	}
+
+	expectedCheckpoints := []string{
+		"*state.testState4-3-false",
+		"*state.testState4-3-true",
+		"*state.testState5-5-false",
+		"*state.testState5-5-true",
+	}
+
+	if !reflect.DeepEqual(expectedCheckpoints, checkpointer.checkpoints) {
+		t.Errorf(
+			"\nexpected: %v\nactual:   %v\n",
+			expectedCheckpoints,
+			checkpointer.checkpoints,
+		)
+	}
+}
+
+func TestRunningMachines(t *testing.T) {
+	testLog = make(map[uint64][]string)
+
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	blockCounter, _ = localChain.BlockCounter()
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("status_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &TestMessage{}
+	})
+
+	initialState := &testState3{testState2{testState1{
+		memberIndex: group.MemberIndex(1),
+		channel:     channel,
+	}}}
+
+	// Each operator keeps its own registry; the machine executed by the first
+	// operator must not be reported by the registry of the second one.
+	registry := NewRegistry()
+	otherRegistry := NewRegistry()
+
+	checkpointer := &statusCheckpointer{
+		registries: []*Registry{registry, otherRegistry},
+	}
+
+	stateMachine := NewMachine(channel, blockCounter, initialState)
+	stateMachine.SetCheckpointer(checkpointer)
+	stateMachine.SetRegistry(registry)
+
+	_, _, err = stateMachine.Resume(2, true)
+	if err != nil {
+		t.Errorf("unexpected error [%v]", err)
+	}
+
+	expectedStatuses := [][][]*MachineStatus{
+		{{{"status_test", 1, "*state.testState4", 3}}, {}},
+		{{{"status_test", 1, "*state.testState4", 3}}, {}},
+		{{{"status_test", 1, "*state.testState5", 5}}, {}},
+		{{{"status_test", 1, "*state.testState5", 5}}, {}},
+	}
+
+	if !reflect.DeepEqual(expectedStatuses, checkpointer.statuses) {
+		t.Errorf(
+			"\nexpected: %v\nactual:   %v\n",
+			expectedStatuses,
+			checkpointer.statuses,
+		)
+	}
+
+	if len(registry.RunningMachines()) != 0 {
+		t.Errorf("completed state machine should not be reported as running")
+	}
+}
+
+func TestObserver(t *testing.T) {
+	testLog = make(map[uint64][]string)
+
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	blockCounter, _ = localChain.BlockCounter()
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("observer_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func(blockCounter chain.BlockCounter) {
+		blockCounter.WaitForBlockHeight(1)
+		ctx, cancel := context.WithCancel(context.Background())
+		channel.Send(ctx, &TestMessage{"message_1"})
+		cancel()
+
+		blockCounter.WaitForBlockHeight(7)
+		ctx, cancel = context.WithCancel(context.Background())
+		channel.Send(ctx, &TestMessage{"message_2"})
+		channel.Send(ctx, &TestMessage{"message_3"})
+		cancel()
+	}(blockCounter)
+
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &TestMessage{}
+	})
+
+	initialState := testState1{
+		memberIndex: group.MemberIndex(1),
+		channel:     channel,
+	}
+
+	observer := &testObserver{}
+
+	stateMachine := NewMachine(channel, blockCounter, initialState)
+	stateMachine.SetObserver(observer)
+
+	_, _, err = stateMachine.Execute(1)
+	if err != nil {
+		t.Errorf("unexpected error [%v]", err)
+	}
+
+	expectedReports := []string{
+		"state.testState1-1-3-1",
+		"*state.testState2-3-5-0",
+		"*state.testState3-5-6-0",
+		"*state.testState4-6-8-2",
+		"*state.testState5-8-8-0",
+	}
+
+	if !reflect.DeepEqual(expectedReports, observer.reports) {
+		t.Errorf(
+			"\nexpected: %v\nactual:   %v\n",
+			expectedReports,
+			observer.reports,
+		)
+	}
+}
+
+// statusCheckpointer captures statuses of running state machines reported by
+// each of the registries every time the state machine checkpoints its progress.
+type statusCheckpointer struct {
+	registries []*Registry
+	statuses   [][][]*MachineStatus
+}
+
+func (sc *statusCheckpointer) Checkpoint(
+	currentState State,
+	lastStateEndBlockHeight uint64,
+	initiated bool,
+) error {
+	statuses := make([][]*MachineStatus, len(sc.registries))
+	for i, registry := range sc.registries {
+		statuses[i] = registry.RunningMachines()
+	}
+	sc.statuses = append(sc.statuses, statuses)
+	return nil
+}
+
+type testCheckpointer struct {
+	checkpoints []string
+}
+
+func (tc *testCheckpointer) Checkpoint(
+	currentState State,
+	lastStateEndBlockHeight uint64,
+	initiated bool,
+) error {
+	tc.checkpoints = append(
+		tc.checkpoints,
+		fmt.Sprintf(
+			"%v-%v-%v",
+			reflect.TypeOf(currentState),
+			lastStateEndBlockHeight,
+			initiated,
+		),
+	)
+	return nil
+}
+
+type testObserver struct {
+	reports []string
+}
+
+func (to *testObserver) StateCompleted(report *StateReport) {
+	if report.EndTime.Before(report.StartTime) {
+		panic("state ended before it started")
+	}
+
+	to.reports = append(
+		to.reports,
+		fmt.Sprintf(
+			"%v-%v-%v-%v",
+			reflect.TypeOf(report.State),
+			report.StartBlock,
+			report.EndBlock,
+			report.ReceivedMessages,
+		),
+	)
+}
+
 func addToTestLog(testState State, functionName string) {
 	currentBlock, _ := blockCounter.CurrentBlock()
 	testLog[currentBlock] = append(
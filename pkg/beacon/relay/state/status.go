@@ -0,0 +1,88 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// MachineStatus describes the state a running state machine is currently in.
+type MachineStatus struct {
+	// ChannelName is the name of the broadcast channel the state machine
+	// communicates over.
+	ChannelName string
+	// MemberIndex is the index of the member executing the state machine.
+	MemberIndex group.MemberIndex
+	// State is the type name of the current state.
+	State string
+	// StateStartBlock is the block at which the current state started.
+	StateStartBlock uint64
+}
+
+// Registry tracks statuses of state machines executed by one operator.
+// Each operator keeps its own registry, so that diagnostics of an operator
+// report only protocols executed by that operator, even if several operators
+// are run from the same client process.
+type Registry struct {
+	mutex    sync.Mutex
+	statuses map[*Machine]*MachineStatus
+}
+
+// NewRegistry returns an empty registry of state machine statuses.
+func NewRegistry() *Registry {
+	return &Registry{
+		statuses: make(map[*Machine]*MachineStatus),
+	}
+}
+
+// RunningMachines returns statuses of all state machines reporting to
+// the registry which are currently executed, ordered by the channel name and
+// the member index.
+func (r *Registry) RunningMachines() []*MachineStatus {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	statuses := make([]*MachineStatus, 0, len(r.statuses))
+	for _, status := range r.statuses {
+		statusCopy := *status
+		statuses = append(statuses, &statusCopy)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].ChannelName != statuses[j].ChannelName {
+			return statuses[i].ChannelName < statuses[j].ChannelName
+		}
+		return statuses[i].MemberIndex < statuses[j].MemberIndex
+	})
+
+	return statuses
+}
+
+func (m *Machine) reportStatus(currentState State, stateStartBlock uint64) {
+	if m.registry == nil {
+		return
+	}
+
+	m.registry.mutex.Lock()
+	defer m.registry.mutex.Unlock()
+
+	m.registry.statuses[m] = &MachineStatus{
+		ChannelName:     m.channel.Name(),
+		MemberIndex:     currentState.MemberIndex(),
+		State:           fmt.Sprintf("%T", currentState),
+		StateStartBlock: stateStartBlock,
+	}
+}
+
+func (m *Machine) clearStatus() {
+	if m.registry == nil {
+		return
+	}
+
+	m.registry.mutex.Lock()
+	defer m.registry.mutex.Unlock()
+
+	delete(m.registry.statuses, m)
+}
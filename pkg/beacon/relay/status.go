@@ -0,0 +1,114 @@
+package relay
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+)
+
+// SigningStatus describes a relay entry signing currently executed by the
+// node.
+type SigningStatus struct {
+	// GroupPublicKey is the public key of the group signing the entry.
+	GroupPublicKey []byte
+	// MemberIndex is the index of the group member executing the signing.
+	MemberIndex group.MemberIndex
+	// PreviousEntry is the relay entry being signed.
+	PreviousEntry []byte
+	// StartBlock is the block at which the relay entry has been requested.
+	StartBlock uint64
+}
+
+// RunningSignings returns statuses of all relay entry signings currently
+// executed by the node, ordered by the start block and the member index.
+func (n *Node) RunningSignings() []*SigningStatus {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	signings := make([]*SigningStatus, 0, len(n.signings))
+	for signing := range n.signings {
+		signingCopy := *signing
+		signings = append(signings, &signingCopy)
+	}
+
+	sort.Slice(signings, func(i, j int) bool {
+		if signings[i].StartBlock != signings[j].StartBlock {
+			return signings[i].StartBlock < signings[j].StartBlock
+		}
+		return signings[i].MemberIndex < signings[j].MemberIndex
+	})
+
+	return signings
+}
+
+func (n *Node) startSigning(signing *SigningStatus) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.signings[signing] = true
+}
+
+// startGroupSigning registers signings of the given relay entry by all members
+// of the group with the given public key controlled by this node and returns
+// their memberships along with the registered signings. Shares of the group
+// must not change while signing. Relay entries requested before the commit
+// block of the latest share refresh of the group, or while the refresh
+// protocol is still running, are signed by all members with previous shares
+// no matter whether the refresh gets committed, so memberships held before
+// the refresh are returned without waiting for it. Only if the entry has been
+// requested after the commit block of a refresh which is still completing,
+// startGroupSigning waits for the refresh to complete and returns memberships
+// the refresh left in the registry. No refresh of the group starts until
+// the returned signings complete.
+func (n *Node) startGroupSigning(
+	groupPublicKey []byte,
+	previousEntry []byte,
+	startBlock uint64,
+) ([]*registry.Membership, []*SigningStatus) {
+	key := hex.EncodeToString(groupPublicKey)
+
+	for {
+		n.mutex.Lock()
+		refresh, ok := n.refreshes[key]
+		if !ok || !refresh.appliesTo(startBlock) || refresh.isDone() {
+			break
+		}
+		n.mutex.Unlock()
+
+		logger.Infof(
+			"waiting for share refresh of group [0x%x] committed "+
+				"at block [%v] to complete before signing",
+			groupPublicKey,
+			refresh.commitBlock,
+		)
+		<-refresh.done
+	}
+	defer n.mutex.Unlock()
+
+	memberships := n.groupRegistry.GetGroup(groupPublicKey)
+	if refresh, ok := n.refreshes[key]; ok && !refresh.appliesTo(startBlock) {
+		memberships = refresh.previousMemberships
+	}
+
+	signings := make([]*SigningStatus, len(memberships))
+	for i, membership := range memberships {
+		signings[i] = &SigningStatus{
+			GroupPublicKey: groupPublicKey,
+			MemberIndex:    membership.Signer.MemberID(),
+			PreviousEntry:  previousEntry,
+			StartBlock:     startBlock,
+		}
+		n.signings[signings[i]] = true
+	}
+
+	return memberships, signings
+}
+
+func (n *Node) completeSigning(signing *SigningStatus) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	delete(n.signings, signing)
+}
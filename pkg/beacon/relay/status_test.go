@@ -0,0 +1,134 @@
+package relay
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestRunningSignings(t *testing.T) {
+	node := &Node{signings: make(map[*SigningStatus]bool)}
+
+	signing1 := &SigningStatus{
+		GroupPublicKey: []byte{0x01},
+		MemberIndex:    2,
+		PreviousEntry:  []byte{0x0a},
+		StartBlock:     100,
+	}
+	signing2 := &SigningStatus{
+		GroupPublicKey: []byte{0x01},
+		MemberIndex:    1,
+		PreviousEntry:  []byte{0x0a},
+		StartBlock:     100,
+	}
+	signing3 := &SigningStatus{
+		GroupPublicKey: []byte{0x02},
+		MemberIndex:    1,
+		PreviousEntry:  []byte{0x0b},
+		StartBlock:     90,
+	}
+
+	node.startSigning(signing1)
+	node.startSigning(signing2)
+	node.startSigning(signing3)
+	node.completeSigning(signing3)
+
+	expectedSignings := []*SigningStatus{signing2, signing1}
+	actualSignings := node.RunningSignings()
+
+	if !reflect.DeepEqual(expectedSignings, actualSignings) {
+		t.Errorf(
+			"unexpected running signings\nexpected: %v\nactual:   %v",
+			expectedSignings,
+			actualSignings,
+		)
+	}
+}
+
+func TestRunningMachinesOfTwoOperators(t *testing.T) {
+	localChain := chainLocal.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel, err := netLocal.Connect().BroadcastChannelFor("running_machines")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstNode := NewNode(nil, nil, blockCounter, nil, nil, nil, nil)
+	secondNode := NewNode(nil, nil, blockCounter, nil, nil, nil, nil)
+
+	initialState := &statusReportingState{nodes: []*Node{&firstNode, &secondNode}}
+
+	machine := state.NewMachine(channel, blockCounter, initialState)
+	machine.SetRegistry(firstNode.machines)
+
+	if _, _, err := machine.Execute(1); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedStatuses := [][]*state.MachineStatus{
+		{{
+			ChannelName:     "running_machines",
+			MemberIndex:     1,
+			State:           "*relay.statusReportingState",
+			StateStartBlock: 1,
+		}},
+		{},
+	}
+
+	if !reflect.DeepEqual(expectedStatuses, initialState.statuses) {
+		t.Errorf(
+			"unexpected running machines\nexpected: %v\nactual:   %v",
+			expectedStatuses,
+			initialState.statuses,
+		)
+	}
+
+	if len(firstNode.RunningMachines()) != 0 {
+		t.Errorf("completed state machine should not be reported as running")
+	}
+}
+
+// statusReportingState captures running machines reported by each of the
+// nodes when it is initiated.
+type statusReportingState struct {
+	nodes    []*Node
+	statuses [][]*state.MachineStatus
+}
+
+func (srs *statusReportingState) DelayBlocks() uint64 {
+	return state.SilentStateDelayBlocks
+}
+
+func (srs *statusReportingState) ActiveBlocks() uint64 {
+	return state.SilentStateActiveBlocks
+}
+
+func (srs *statusReportingState) Initiate(ctx context.Context) error {
+	for _, node := range srs.nodes {
+		srs.statuses = append(srs.statuses, node.RunningMachines())
+	}
+	return nil
+}
+
+func (srs *statusReportingState) Receive(msg net.Message) error {
+	return nil
+}
+
+func (srs *statusReportingState) Next() state.State {
+	return nil
+}
+
+func (srs *statusReportingState) MemberIndex() group.MemberIndex {
+	return 1
+}
@@ -0,0 +1,109 @@
+package beacon
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+)
+
+// GroupStatus describes a group the operator is a member of.
+type GroupStatus struct {
+	// GroupPublicKey is the group public key in uncompressed form.
+	GroupPublicKey []byte
+	// MemberIndices are indices of all members of the group controlled by
+	// the operator.
+	MemberIndices []group.MemberIndex
+	// IsStale is true if the group has been marked as stale on-chain.
+	IsStale bool
+	// StaleCheckError is set if it could not be determined whether the group
+	// is stale.
+	StaleCheckError error
+}
+
+// Groups returns statuses of all groups the operator is a member of, ordered
+// by the group public key.
+func (b *Beacon) Groups() []*GroupStatus {
+	groups := b.groupRegistry.GetGroups()
+
+	statuses := make([]*GroupStatus, 0, len(groups))
+	for groupPublicKey, memberships := range groups {
+		groupPublicKeyBytes, err := hex.DecodeString(groupPublicKey)
+		if err != nil {
+			logger.Errorf(
+				"could not decode public key of group [%v]: [%v]",
+				groupPublicKey,
+				err,
+			)
+			continue
+		}
+
+		memberIndices := make([]group.MemberIndex, len(memberships))
+		for i, membership := range memberships {
+			memberIndices[i] = membership.Signer.MemberID()
+		}
+
+		isStale, err := b.relayChain.IsStaleGroup(groupPublicKeyBytes)
+
+		statuses = append(statuses, &GroupStatus{
+			GroupPublicKey:  groupPublicKeyBytes,
+			MemberIndices:   memberIndices,
+			IsStale:         isStale,
+			StaleCheckError: err,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return hex.EncodeToString(statuses[i].GroupPublicKey) <
+			hex.EncodeToString(statuses[j].GroupPublicKey)
+	})
+
+	return statuses
+}
+
+// QuarantinedMemberships returns memberships of the operator which failed
+// the integrity check and are not used for signing.
+func (b *Beacon) QuarantinedMemberships() []*registry.QuarantinedMembership {
+	return b.groupRegistry.QuarantinedMemberships()
+}
+
+// PendingGroupSelections returns seeds, in hex form, of group selections
+// currently executed by the operator.
+func (b *Beacon) PendingGroupSelections() []string {
+	return b.pendingGroupSelections.Entries()
+}
+
+// PendingRelayRequests returns previous entries, in hex form, of relay
+// requests currently handled by the operator.
+func (b *Beacon) PendingRelayRequests() []string {
+	return b.pendingRelayRequests.Entries()
+}
+
+// RunningDKGs returns statuses of state machines of all key generation,
+// result publication and share refresh protocols currently executed by
+// the operator.
+func (b *Beacon) RunningDKGs() []*state.MachineStatus {
+	return b.node.RunningMachines()
+}
+
+// RunningSignings returns statuses of all relay entry signings currently
+// executed by the operator.
+func (b *Beacon) RunningSignings() []*relay.SigningStatus {
+	return b.node.RunningSignings()
+}
+
+// DKGTelemetry returns statistics of distributed key generations executed by
+// the operator.
+func (b *Beacon) DKGTelemetry() dkg.TelemetryStats {
+	return b.node.DKGTelemetry()
+}
+
+// ChainConfig returns the relay configuration read from the chain.
+func (b *Beacon) ChainConfig() *relaychain.Config {
+	return b.chainConfig
+}
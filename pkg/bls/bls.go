@@ -1,6 +1,7 @@
 package bls
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"math/big"
@@ -75,6 +76,68 @@ func VerifyG1(publicKey *bn256.G2, message *bn256.G1, signature *bn256.G1) bool
 	return bn256.PairingCheck(a, b)
 }
 
+// batchVerificationScalarBits is the bit length of random scalars used to
+// combine signatures and public keys in batch verification. An invalid batch
+// passes the check with a probability of at most 2^-128.
+const batchVerificationScalarBits = 128
+
+// VerifyG1Batch checks whether all the provided signatures are correct for
+// the provided G1 point message and the corresponding public keys, given at
+// the same positions. Instead of performing one pairing check per signature,
+// signatures and public keys are combined using random scalars r_i and
+// a single check is performed:
+//
+//	e(r_1*s_1 + ... + r_n*s_n, g2) = e(m, r_1*p_1 + ... + r_n*p_n)
+//
+// The function returns true only if all the signatures are valid. If it
+// returns false, at least one of the signatures is invalid and the caller
+// should verify them one by one to find the invalid ones.
+func VerifyG1Batch(
+	publicKeys []*bn256.G2,
+	message *bn256.G1,
+	signatures []*bn256.G1,
+) (bool, error) {
+	if len(publicKeys) != len(signatures) {
+		return false, fmt.Errorf(
+			"number of public keys [%v] does not match number of signatures [%v]",
+			len(publicKeys),
+			len(signatures),
+		)
+	}
+
+	if len(signatures) == 0 {
+		return true, nil
+	}
+
+	if len(signatures) == 1 {
+		return VerifyG1(publicKeys[0], message, signatures[0]), nil
+	}
+
+	scalarsLimit := new(big.Int).Lsh(big.NewInt(1), batchVerificationScalarBits)
+
+	combinedSignature := new(bn256.G1)
+	combinedPublicKey := new(bn256.G2)
+	for i := range signatures {
+		scalar, err := rand.Int(rand.Reader, scalarsLimit)
+		if err != nil {
+			return false, fmt.Errorf("could not generate random scalar: [%v]", err)
+		}
+		// Zero scalar would exclude the signature from the check.
+		scalar.Add(scalar, big.NewInt(1))
+
+		combinedSignature.Add(
+			combinedSignature,
+			new(bn256.G1).ScalarMult(signatures[i], scalar),
+		)
+		combinedPublicKey.Add(
+			combinedPublicKey,
+			new(bn256.G2).ScalarMult(publicKeys[i], scalar),
+		)
+	}
+
+	return VerifyG1(combinedPublicKey, message, combinedSignature), nil
+}
+
 // RecoverSignature reconstructs the full BLS signature from a threshold number of
 // signature shares using Lagrange interpolation.
 func RecoverSignature(shares []*SignatureShare, threshold int) (*bn256.G1, error) {
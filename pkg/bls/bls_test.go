@@ -184,3 +184,131 @@ func TestThresholdBLS(t *testing.T) {
 	}
 
 }
+
+func TestVerifyG1Batch(t *testing.T) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(1328472189))
+
+	publicKeys, signatures := signWithRandomKeys(t, message, 10)
+
+	_, invalidSignature, err := bn256.RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		publicKeys     []*bn256.G2
+		signatures     []*bn256.G1
+		expectedResult bool
+		expectedError  bool
+	}{
+		"all signatures valid": {
+			publicKeys:     publicKeys,
+			signatures:     signatures,
+			expectedResult: true,
+		},
+		"single valid signature": {
+			publicKeys:     publicKeys[0:1],
+			signatures:     signatures[0:1],
+			expectedResult: true,
+		},
+		"no signatures": {
+			publicKeys:     []*bn256.G2{},
+			signatures:     []*bn256.G1{},
+			expectedResult: true,
+		},
+		"one signature invalid": {
+			publicKeys: publicKeys,
+			signatures: append(
+				append([]*bn256.G1{}, signatures[0:9]...),
+				invalidSignature,
+			),
+			expectedResult: false,
+		},
+		"signatures swapped": {
+			publicKeys: publicKeys[0:2],
+			signatures: []*bn256.G1{
+				signatures[1],
+				signatures[0],
+			},
+			expectedResult: false,
+		},
+		"signatures not matching public keys": {
+			publicKeys:    publicKeys[0:2],
+			signatures:    signatures[0:3],
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			result, err := VerifyG1Batch(test.publicKeys, message, test.signatures)
+
+			if test.expectedError != (err != nil) {
+				t.Fatalf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if result != test.expectedResult {
+				t.Errorf(
+					"unexpected result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResult,
+					result,
+				)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyG1_64Shares(b *testing.B) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(1328472189))
+	publicKeys, signatures := signWithRandomKeys(b, message, 64)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range signatures {
+			if !VerifyG1(publicKeys[i], message, signatures[i]) {
+				b.Fatal("invalid signature")
+			}
+		}
+	}
+}
+
+func BenchmarkVerifyG1Batch_64Shares(b *testing.B) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(1328472189))
+	publicKeys, signatures := signWithRandomKeys(b, message, 64)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		valid, err := VerifyG1Batch(publicKeys, message, signatures)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if !valid {
+			b.Fatal("invalid batch")
+		}
+	}
+}
+
+func signWithRandomKeys(
+	tb testing.TB,
+	message *bn256.G1,
+	count int,
+) ([]*bn256.G2, []*bn256.G1) {
+	publicKeys := make([]*bn256.G2, count)
+	signatures := make([]*bn256.G1, count)
+
+	for i := 0; i < count; i++ {
+		secretKey, publicKey, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		publicKeys[i] = publicKey
+		signatures[i] = SignG1(secretKey, message)
+	}
+
+	return publicKeys, signatures
+}
@@ -0,0 +1,222 @@
+// Package confirmation implements confirmation-depth aware delivery of chain
+// events. Events are held until the required number of blocks is mined on top
+// of the block in which they were emitted and are then looked up on-chain
+// again to ensure they have not been removed by a chain reorganization.
+package confirmation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+var logger = log.Logger("keep-confirmation")
+
+// maxLookupAttempts is the maximum number of attempts to look up an event
+// on-chain, one per block, before the event is dropped.
+const maxLookupAttempts = 5
+
+// LookupFn looks for the awaited event in the given range of blocks, both
+// inclusive. It returns the number of the block in which the event has been
+// found. The second returned value is false if the event is not on-chain
+// in the given range of blocks.
+type LookupFn func(fromBlock, toBlock uint64) (uint64, bool, error)
+
+// Waiter holds chain events until they get the required number of
+// confirmations.
+type Waiter struct {
+	blockCounter  chain.BlockCounter
+	confirmations uint64
+
+	// pending counts events awaiting confirmation by the block in which
+	// they have been received.
+	pendingMutex sync.Mutex
+	pending      map[uint64]int
+}
+
+// NewWaiter creates a new Waiter holding events until the given number of
+// blocks is mined on top of the block in which the event was emitted. When
+// the number of confirmations is zero, events are delivered immediately.
+func NewWaiter(
+	blockCounter chain.BlockCounter,
+	confirmations uint64,
+) *Waiter {
+	return &Waiter{
+		blockCounter:  blockCounter,
+		confirmations: confirmations,
+		pending:       make(map[uint64]int),
+	}
+}
+
+// Deliver is a non-blocking version of Wait. When the number of
+// confirmations is zero, onConfirmed is called synchronously so that the order
+// in which events are delivered is preserved.
+func (w *Waiter) Deliver(
+	ctx context.Context,
+	blockNumber uint64,
+	lookup LookupFn,
+	onConfirmed func(blockNumber uint64),
+) {
+	if w.confirmations == 0 {
+		onConfirmed(blockNumber)
+		return
+	}
+
+	// The event is marked as pending before this function returns so that
+	// there is no moment in which the received event is not accounted for.
+	w.markPending(blockNumber)
+
+	go func() {
+		defer w.unmarkPending(blockNumber)
+		w.wait(ctx, blockNumber, lookup, onConfirmed)
+	}()
+}
+
+// Wait holds the event emitted at the given block until it gets the required
+// number of confirmations and then calls onConfirmed with the number of the
+// block in which the event is confirmed. Before confirming, the event is
+// looked up on-chain. If it is no longer there because its block got reorged
+// out, the event is dropped. If the event has been mined again in a later
+// block, Wait holds it until that block gets the required confirmations.
+// When the provided context is done, the event is dropped. Wait blocks until
+// the event is either confirmed or dropped. Until then, the block in which the
+// event has been received is reported by EarliestPendingBlock.
+func (w *Waiter) Wait(
+	ctx context.Context,
+	blockNumber uint64,
+	lookup LookupFn,
+	onConfirmed func(blockNumber uint64),
+) {
+	if w.confirmations == 0 {
+		onConfirmed(blockNumber)
+		return
+	}
+
+	w.markPending(blockNumber)
+	defer w.unmarkPending(blockNumber)
+
+	w.wait(ctx, blockNumber, lookup, onConfirmed)
+}
+
+func (w *Waiter) wait(
+	ctx context.Context,
+	blockNumber uint64,
+	lookup LookupFn,
+	onConfirmed func(blockNumber uint64),
+) {
+	lookupAttempt := 1
+	confirmationBlock := blockNumber + w.confirmations
+
+	for {
+		waiter, err := w.blockCounter.BlockHeightWaiter(confirmationBlock)
+		if err != nil {
+			logger.Errorf(
+				"could not wait for confirmation of event from block [%v]: [%v]",
+				blockNumber,
+				err,
+			)
+			return
+		}
+
+		var currentBlock uint64
+		select {
+		case currentBlock = <-waiter:
+		case <-ctx.Done():
+			return
+		}
+
+		foundBlockNumber, found, err := lookup(blockNumber, currentBlock)
+		if err != nil {
+			if lookupAttempt == maxLookupAttempts {
+				logger.Errorf(
+					"could not look up event from block [%v]: [%v]; "+
+						"dropping the event after [%v] attempts",
+					blockNumber,
+					err,
+					maxLookupAttempts,
+				)
+				return
+			}
+
+			logger.Warningf(
+				"could not look up event from block [%v]: [%v]; "+
+					"will retry at the next block",
+				blockNumber,
+				err,
+			)
+
+			lookupAttempt++
+			confirmationBlock = currentBlock + 1
+			continue
+		}
+
+		if !found {
+			logger.Warningf(
+				"event from block [%v] is no longer on-chain; "+
+					"dropping the event",
+				blockNumber,
+			)
+			return
+		}
+
+		if foundBlockNumber != blockNumber {
+			logger.Infof(
+				"event from block [%v] has been reorged to block [%v]; "+
+					"waiting for its confirmation",
+				blockNumber,
+				foundBlockNumber,
+			)
+
+			blockNumber = foundBlockNumber
+			confirmationBlock = blockNumber + w.confirmations
+			continue
+		}
+
+		onConfirmed(blockNumber)
+		return
+	}
+}
+
+// Confirmations returns the number of blocks which need to be mined on top of
+// the block in which an event was emitted before the event is delivered.
+func (w *Waiter) Confirmations() uint64 {
+	return w.confirmations
+}
+
+// EarliestPendingBlock returns the earliest block in which an event still
+// awaiting confirmation has been received. An event stops being pending once
+// its onConfirmed callback returns or once it is dropped. The second returned
+// value is false if no event is pending.
+func (w *Waiter) EarliestPendingBlock() (uint64, bool) {
+	w.pendingMutex.Lock()
+	defer w.pendingMutex.Unlock()
+
+	earliestBlock, found := uint64(0), false
+	for blockNumber := range w.pending {
+		if !found || blockNumber < earliestBlock {
+			earliestBlock, found = blockNumber, true
+		}
+	}
+
+	return earliestBlock, found
+}
+
+func (w *Waiter) markPending(blockNumber uint64) {
+	w.pendingMutex.Lock()
+	defer w.pendingMutex.Unlock()
+
+	w.pending[blockNumber]++
+}
+
+func (w *Waiter) unmarkPending(blockNumber uint64) {
+	w.pendingMutex.Lock()
+	defer w.pendingMutex.Unlock()
+
+	w.pending[blockNumber]--
+	if w.pending[blockNumber] <= 0 {
+		delete(w.pending, blockNumber)
+	}
+}
@@ -0,0 +1,246 @@
+package confirmation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWait(t *testing.T) {
+	eventBlock := uint64(100)
+	confirmations := uint64(6)
+
+	var tests = map[string]struct {
+		lookup func(attempt int, fromBlock, toBlock uint64) (uint64, bool, error)
+		// expectedConfirmedBlock is zero if the event should be dropped.
+		expectedConfirmedBlock uint64
+		expectedLookups        []string
+	}{
+		"event still on-chain": {
+			lookup: func(attempt int, fromBlock, toBlock uint64) (uint64, bool, error) {
+				return eventBlock, true, nil
+			},
+			expectedConfirmedBlock: eventBlock,
+			expectedLookups:        []string{"100-106"},
+		},
+		"event reorged out": {
+			lookup: func(attempt int, fromBlock, toBlock uint64) (uint64, bool, error) {
+				return 0, false, nil
+			},
+			expectedLookups: []string{"100-106"},
+		},
+		"event mined again in a later block": {
+			lookup: func(attempt int, fromBlock, toBlock uint64) (uint64, bool, error) {
+				return 103, true, nil
+			},
+			expectedConfirmedBlock: 103,
+			expectedLookups:        []string{"100-106", "103-109"},
+		},
+		"lookup failed once": {
+			lookup: func(attempt int, fromBlock, toBlock uint64) (uint64, bool, error) {
+				if attempt == 1 {
+					return 0, false, fmt.Errorf("connection lost")
+				}
+				return eventBlock, true, nil
+			},
+			expectedConfirmedBlock: eventBlock,
+			expectedLookups:        []string{"100-106", "100-107"},
+		},
+		"lookup failed all the time": {
+			lookup: func(attempt int, fromBlock, toBlock uint64) (uint64, bool, error) {
+				return 0, false, fmt.Errorf("connection lost")
+			},
+			expectedLookups: []string{
+				"100-106", "100-107", "100-108", "100-109", "100-110",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			waiter := NewWaiter(&instantBlockCounter{}, confirmations)
+
+			lookups := make([]string, 0)
+			lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+				lookups = append(lookups, fmt.Sprintf("%v-%v", fromBlock, toBlock))
+				return test.lookup(len(lookups), fromBlock, toBlock)
+			}
+
+			confirmedBlock := uint64(0)
+			waiter.Wait(
+				context.Background(),
+				eventBlock,
+				lookup,
+				func(blockNumber uint64) {
+					confirmedBlock = blockNumber
+				},
+			)
+
+			if confirmedBlock != test.expectedConfirmedBlock {
+				t.Errorf(
+					"unexpected confirmed block\nexpected: [%v]\nactual:   [%v]",
+					test.expectedConfirmedBlock,
+					confirmedBlock,
+				)
+			}
+
+			if !reflect.DeepEqual(test.expectedLookups, lookups) {
+				t.Errorf(
+					"unexpected lookups\nexpected: [%v]\nactual:   [%v]",
+					test.expectedLookups,
+					lookups,
+				)
+			}
+		})
+	}
+}
+
+func TestWaitWithNoConfirmations(t *testing.T) {
+	waiter := NewWaiter(&instantBlockCounter{}, 0)
+
+	lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+		t.Fatal("unexpected lookup")
+		return 0, false, nil
+	}
+
+	confirmedBlock := uint64(0)
+	waiter.Deliver(context.Background(), 100, lookup, func(blockNumber uint64) {
+		confirmedBlock = blockNumber
+	})
+
+	if confirmedBlock != 100 {
+		t.Errorf(
+			"unexpected confirmed block\nexpected: [%v]\nactual:   [%v]",
+			100,
+			confirmedBlock,
+		)
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	waiter := NewWaiter(&neverBlockCounter{}, 6)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+		t.Fatal("unexpected lookup")
+		return 0, false, nil
+	}
+
+	waiter.Wait(ctx, 100, lookup, func(blockNumber uint64) {
+		t.Fatal("event should not be confirmed")
+	})
+}
+
+func TestEarliestPendingBlock(t *testing.T) {
+	waiter := NewWaiter(&neverBlockCounter{}, 6)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+		t.Fatal("unexpected lookup")
+		return 0, false, nil
+	}
+	onConfirmed := func(blockNumber uint64) {
+		t.Fatal("event should not be confirmed")
+	}
+
+	if _, pending := waiter.EarliestPendingBlock(); pending {
+		t.Fatal("no event should be pending")
+	}
+
+	waiter.Deliver(ctx, 100, lookup, onConfirmed)
+	waiter.Deliver(ctx, 90, lookup, onConfirmed)
+
+	earliestBlock, pending := waiter.EarliestPendingBlock()
+	if !pending || earliestBlock != 90 {
+		t.Errorf(
+			"unexpected earliest pending block\n"+
+				"expected: [%v, %v]\nactual:   [%v, %v]",
+			90,
+			true,
+			earliestBlock,
+			pending,
+		)
+	}
+
+	// Dropped events are no longer pending.
+	cancelCtx()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, pending := waiter.EarliestPendingBlock(); !pending {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("dropped events should not be pending")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEarliestPendingBlockUntilConfirmed(t *testing.T) {
+	waiter := NewWaiter(&instantBlockCounter{}, 6)
+
+	lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+		return fromBlock, true, nil
+	}
+
+	waiter.Wait(context.Background(), 100, lookup, func(blockNumber uint64) {
+		earliestBlock, pending := waiter.EarliestPendingBlock()
+		if !pending || earliestBlock != 100 {
+			t.Errorf(
+				"event should be pending until confirmed\n"+
+					"expected: [%v, %v]\nactual:   [%v, %v]",
+				100,
+				true,
+				earliestBlock,
+				pending,
+			)
+		}
+	})
+
+	if _, pending := waiter.EarliestPendingBlock(); pending {
+		t.Error("confirmed event should not be pending")
+	}
+}
+
+// instantBlockCounter pretends every awaited block height is reached
+// immediately.
+type instantBlockCounter struct{}
+
+func (ibc *instantBlockCounter) WaitForBlockHeight(blockNumber uint64) error {
+	return nil
+}
+
+func (ibc *instantBlockCounter) BlockHeightWaiter(
+	blockNumber uint64,
+) (<-chan uint64, error) {
+	waiter := make(chan uint64, 1)
+	waiter <- blockNumber
+	return waiter, nil
+}
+
+func (ibc *instantBlockCounter) CurrentBlock() (uint64, error) {
+	panic("not implemented")
+}
+
+func (ibc *instantBlockCounter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	panic("not implemented")
+}
+
+// neverBlockCounter never reaches any awaited block height.
+type neverBlockCounter struct {
+	instantBlockCounter
+}
+
+func (nbc *neverBlockCounter) BlockHeightWaiter(
+	blockNumber uint64,
+) (<-chan uint64, error) {
+	return make(chan uint64), nil
+}
@@ -1,21 +1,27 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"strings"
 	"sync"
 	"time"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/blockcounter"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/confirmation"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 	"github.com/keep-network/keep-core/pkg/chain/gen/contract"
 )
 
@@ -34,17 +40,38 @@ var (
 	DefaultMaxGasPrice = big.NewInt(500000000000) // 500 Gwei
 )
 
+// TicketSubmissionGasLimit is the gas limit of a group selection ticket
+// submission transaction.
+const TicketSubmissionGasLimit = 250000
+
 type ethereumChain struct {
 	config                           ethereum.Config
 	client                           ethutil.EthereumClient
-	clientRPC                        *rpc.Client
-	clientWS                         *rpc.Client
 	keepRandomBeaconOperatorContract *contract.KeepRandomBeaconOperator
 	stakingContract                  *contract.TokenStaking
 	accountKey                       *keystore.Key
 	blockCounter                     *blockcounter.EthereumBlockCounter
 	chainConfig                      *relaychain.Config
 
+	// keepRandomBeaconOperatorAddress, keepRandomBeaconOperatorABI and
+	// keepRandomBeaconOperatorFilterer are used to subscribe to events of
+	// the operator contract and to parse them.
+	keepRandomBeaconOperatorAddress  common.Address
+	keepRandomBeaconOperatorABI      ethereumabi.ABI
+	keepRandomBeaconOperatorFilterer *abi.KeepRandomBeaconOperatorFilterer
+
+	// eventWaiter holds relay chain events until they get the configured
+	// number of confirmations.
+	eventWaiter *confirmation.Waiter
+
+	// subscriptionMonitor collects statistics of event subscriptions.
+	subscriptionMonitor *subscriptionMonitor
+
+	// simulationBackend simulates operator contract transactions at the
+	// block at which they become eligible before they are submitted. If nil,
+	// transactions are submitted without simulation.
+	simulationBackend simulationBackend
+
 	// transactionMutex allows interested parties to forcibly serialize
 	// transaction submission.
 	//
@@ -67,7 +94,7 @@ type ethereumUtilityChain struct {
 }
 
 func connect(config ethereum.Config) (*ethereumChain, error) {
-	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+	client, _, _, err := ethutil.ConnectClients(config.URL, config.URLRPC)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"error connecting to Ethereum server: %s [%v]",
@@ -76,52 +103,78 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 		)
 	}
 
-	return connectWithClient(config, client, clientWS, clientRPC)
+	return connectWithClient(config, client)
 }
 
 func connectWithClient(
 	config ethereum.Config,
 	client *ethclient.Client,
-	clientWS *rpc.Client,
-	clientRPC *rpc.Client,
 ) (*ethereumChain, error) {
-	pv := &ethereumChain{
-		config:           config,
-		client:           addClientWrappers(config, client),
-		clientRPC:        clientRPC,
-		clientWS:         clientWS,
-		transactionMutex: &sync.Mutex{},
-	}
+	wrappedClient := addClientWrappers(config, client)
 
-	blockCounter, err := blockcounter.CreateBlockCounter(pv.client)
+	blockCounter, err := blockcounter.CreateBlockCounter(wrappedClient)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to create Ethereum blockcounter: [%v]",
 			err,
 		)
 	}
-	pv.blockCounter = blockCounter
 
-	if pv.accountKey == nil {
-		key, err := ethutil.DecryptKeyFile(
+	ec, err := connectOperator(config, wrappedClient, blockCounter)
+	if err != nil {
+		return nil, err
+	}
+
+	ec.simulationBackend = client
+
+	return ec, nil
+}
+
+// connectOperator creates a handle to the chain for the operator account set
+// in the config. The handle uses the given, possibly shared, client
+// connections and block counter, but has its own nonce manager and
+// transaction mutex so that transactions of different operators do not
+// interfere with each other.
+func connectOperator(
+	config ethereum.Config,
+	client ethutil.EthereumClient,
+	blockCounter *blockcounter.EthereumBlockCounter,
+) (*ethereumChain, error) {
+	key, err := ethutil.DecryptKeyFile(
+		config.Account.KeyFile,
+		config.Account.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read KeyFile: %s: [%v]",
 			config.Account.KeyFile,
-			config.Account.KeyFilePassword,
+			err,
 		)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to read KeyFile: %s: [%v]",
-				config.Account.KeyFile,
-				err,
-			)
-		}
-		pv.accountKey = key
 	}
 
-	checkInterval := DefaultMiningCheckInterval
-	maxGasPrice := DefaultMaxGasPrice
-	if config.MiningCheckInterval != 0 {
-		checkInterval = time.Duration(config.MiningCheckInterval) * time.Second
+	return connectAccount(config, key, client, blockCounter)
+}
+
+// connectAccount creates a handle to the chain signing transactions with
+// the given account key.
+func connectAccount(
+	config ethereum.Config,
+	accountKey *keystore.Key,
+	client ethutil.EthereumClient,
+	blockCounter *blockcounter.EthereumBlockCounter,
+) (*ethereumChain, error) {
+	pv := &ethereumChain{
+		config:              config,
+		client:              client,
+		accountKey:          accountKey,
+		blockCounter:        blockCounter,
+		eventWaiter:         confirmation.NewWaiter(blockCounter, 0),
+		subscriptionMonitor: newSubscriptionMonitor(),
+		transactionMutex:    &sync.Mutex{},
 	}
+
+	checkInterval := miningCheckInterval(config)
+	maxGasPrice := DefaultMaxGasPrice
 	if config.MaxGasPrice != nil {
 		maxGasPrice = config.MaxGasPrice.Int
 	}
@@ -155,6 +208,24 @@ func connectWithClient(
 	}
 	pv.keepRandomBeaconOperatorContract = keepRandomBeaconOperatorContract
 
+	keepRandomBeaconOperatorABI, err := ethereumabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing KeepRandomBeaconOperator ABI: [%v]", err)
+	}
+	pv.keepRandomBeaconOperatorABI = keepRandomBeaconOperatorABI
+
+	keepRandomBeaconOperatorFilterer, err := abi.NewKeepRandomBeaconOperatorFilterer(
+		*address,
+		pv.client,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error attaching to KeepRandomBeaconOperator events: [%v]", err)
+	}
+	pv.keepRandomBeaconOperatorAddress = *address
+	pv.keepRandomBeaconOperatorFilterer = keepRandomBeaconOperatorFilterer
+
 	address, err = addressForContract(config, "TokenStaking")
 	if err != nil {
 		return nil, fmt.Errorf("error resolving TokenStaking contract: [%v]", err)
@@ -184,6 +255,16 @@ func connectWithClient(
 	return pv, nil
 }
 
+// miningCheckInterval returns the interval in which the transaction mining
+// status is checked, as set in the config or the default one.
+func miningCheckInterval(config ethereum.Config) time.Duration {
+	if config.MiningCheckInterval != 0 {
+		return time.Duration(config.MiningCheckInterval) * time.Second
+	}
+
+	return DefaultMiningCheckInterval
+}
+
 func addClientWrappers(
 	config ethereum.Config,
 	backend ethutil.EthereumClient,
@@ -217,7 +298,7 @@ func addClientWrappers(
 // the configuration will need to reference a websocket, "ws://", or local IPC
 // connection.
 func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
-	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+	client, _, _, err := ethutil.ConnectClients(config.URL, config.URLRPC)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"error connecting to Ethereum server: %s [%v]",
@@ -226,7 +307,7 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 		)
 	}
 
-	base, err := connectWithClient(config, client, clientWS, clientRPC)
+	base, err := connectWithClient(config, client)
 	if err != nil {
 		return nil, err
 	}
@@ -239,11 +320,8 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 		)
 	}
 
-	checkInterval := DefaultMiningCheckInterval
+	checkInterval := miningCheckInterval(config)
 	maxGasPrice := DefaultMaxGasPrice
-	if config.MiningCheckInterval != 0 {
-		checkInterval = time.Duration(config.MiningCheckInterval) * time.Second
-	}
 	if config.MaxGasPrice != nil {
 		maxGasPrice = config.MaxGasPrice.Int
 	}
@@ -284,8 +362,246 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 // standard handle to the chain interface. Note: for other things to work
 // correctly the configuration will need to reference a websocket, "ws://", or
 // local IPC connection.
-func Connect(config ethereum.Config) (chain.Handle, error) {
-	return connect(config)
+//
+// Relay chain events are delivered to subscribers only after the given number
+// of blocks is mined on top of the block in which they were emitted. Events
+// removed from the chain by a reorganization in the meantime are dropped.
+func Connect(
+	config ethereum.Config,
+	eventConfirmations uint64,
+) (chain.Handle, error) {
+	ec, err := connect(config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("using [%v] event confirmations", eventConfirmations)
+	ec.eventWaiter = confirmation.NewWaiter(ec.blockCounter, eventConfirmations)
+
+	return ec, nil
+}
+
+// ConnectOperators makes a single network connection to the Ethereum network
+// and returns standard handles to the chain interface, one for each of the
+// given operator accounts, in the same order. All handles share the client
+// connection, but each of them signs and submits transactions with its own
+// account key and nonce manager.
+//
+// The connection uses the endpoint set in the config along with endpoints
+// of the failover config. Requests, transactions and event subscriptions are
+// moved to another endpoint when the one in use becomes unhealthy.
+//
+// Relay chain events are delivered to subscribers only after the given number
+// of blocks is mined on top of the block in which they were emitted. Event
+// subscription statistics are collected for all handles together.
+//
+// If transaction journals are given, one for each of the operator accounts,
+// they are reconciled with the chain before the handles are created and all
+// transactions submitted through each handle are recorded in the journal of
+// its operator. Journals are monitored for status changes of pending
+// transactions until the given context is done.
+func ConnectOperators(
+	ctx context.Context,
+	config ethereum.Config,
+	eventConfirmations uint64,
+	accounts []ethereum.Account,
+	failover FailoverConfig,
+	journals []*TransactionJournal,
+) ([]chain.Handle, error) {
+	if journals != nil && len(journals) != len(accounts) {
+		return nil, fmt.Errorf(
+			"[%v] transaction journals given for [%v] operator accounts",
+			len(journals),
+			len(accounts),
+		)
+	}
+
+	failoverClient, err := connectFailoverClient(config, failover)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to Ethereum server: %s [%v]",
+			config.URL,
+			err,
+		)
+	}
+
+	for _, journal := range journals {
+		if err := journal.Reconcile(ctx, failoverClient); err != nil {
+			return nil, fmt.Errorf(
+				"could not reconcile transaction journal: [%v]",
+				err,
+			)
+		}
+	}
+
+	wrappedClient := addClientWrappers(config, failoverClient)
+
+	blockCounter, err := blockcounter.CreateBlockCounter(wrappedClient)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to create Ethereum blockcounter: [%v]",
+			err,
+		)
+	}
+
+	logger.Infof("using [%v] event confirmations", eventConfirmations)
+	eventWaiter := confirmation.NewWaiter(blockCounter, eventConfirmations)
+
+	subscriptionMonitor := newSubscriptionMonitor()
+
+	handles := make([]chain.Handle, len(accounts))
+	for i, account := range accounts {
+		operatorConfig := config
+		operatorConfig.Account = account
+
+		// Transactions are recorded in the journal of the operator before
+		// they are submitted. The mining waiter of the operator fetches
+		// receipts through the journaling client as well, so the status of
+		// watched transactions is recorded as soon as they are mined.
+		var client ethutil.EthereumClient = wrappedClient
+		if journals != nil {
+			client, err = newJournalingClient(wrappedClient, journals[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ec, err := connectOperator(operatorConfig, client, blockCounter)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not connect operator with key file [%v]: [%v]",
+				account.KeyFile,
+				err,
+			)
+		}
+
+		ec.eventWaiter = eventWaiter
+		ec.subscriptionMonitor = subscriptionMonitor
+		ec.simulationBackend = failoverClient
+		handles[i] = ec
+
+		if journals != nil {
+			go journals[i].MonitorPending(
+				ctx,
+				wrappedClient,
+				miningCheckInterval(config),
+			)
+		}
+	}
+
+	return handles, nil
+}
+
+// ConnectObserver makes the network connection to the Ethereum network and
+// returns a read-only handle to the chain interface, for clients following
+// the chain without an operator account. No account key is read; the handle
+// uses a throwaway key generated on connection and refuses to submit any
+// transaction.
+//
+// Just like for ConnectOperators, the connection uses the endpoint set in
+// the config along with endpoints of the failover config, and relay chain
+// events are delivered to subscribers only after the given number of blocks
+// is mined on top of the block in which they were emitted.
+func ConnectObserver(
+	config ethereum.Config,
+	eventConfirmations uint64,
+	failover FailoverConfig,
+) (chain.Handle, error) {
+	failoverClient, err := connectFailoverClient(config, failover)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to Ethereum server: %s [%v]",
+			config.URL,
+			err,
+		)
+	}
+
+	wrappedClient := addClientWrappers(config, failoverClient)
+
+	blockCounter, err := blockcounter.CreateBlockCounter(wrappedClient)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to create Ethereum blockcounter: [%v]",
+			err,
+		)
+	}
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate observer key: [%v]", err)
+	}
+
+	ec, err := connectAccount(
+		config,
+		&keystore.Key{
+			Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+			PrivateKey: privateKey,
+		},
+		&readOnlyClient{wrappedClient},
+		blockCounter,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("using [%v] event confirmations", eventConfirmations)
+	ec.eventWaiter = confirmation.NewWaiter(blockCounter, eventConfirmations)
+	ec.simulationBackend = failoverClient
+
+	return ec, nil
+}
+
+// readOnlyClient refuses to submit transactions through the wrapped client.
+type readOnlyClient struct {
+	ethutil.EthereumClient
+}
+
+func (roc *readOnlyClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	return fmt.Errorf(
+		"transaction [%v] not submitted; the chain handle is read-only",
+		transaction.Hash().Hex(),
+	)
+}
+
+// connectFailoverClient connects to the endpoint set in the config and the
+// endpoints of the failover config, and starts monitoring their health.
+// The RPC URL set in the config is not used by the failover connection.
+func connectFailoverClient(
+	config ethereum.Config,
+	failover FailoverConfig,
+) (*failoverClient, error) {
+	if config.URLRPC != "" {
+		logger.Warningf(
+			"URLRPC [%v] is deprecated and ignored by the client node; "+
+				"configure additional Ethereum endpoints instead",
+			config.URLRPC,
+		)
+	}
+
+	endpoints := []*endpoint{newEndpoint(config.URL, 0)}
+	for _, failoverEndpoint := range failover.Endpoints {
+		endpoints = append(
+			endpoints,
+			newEndpoint(failoverEndpoint.URL, failoverEndpoint.Priority),
+		)
+	}
+
+	client, err := newFailoverClient(failover, endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(
+		"using [%v] Ethereum endpoints checked every [%v]",
+		len(endpoints),
+		client.config.HealthCheckInterval,
+	)
+	go client.monitorHealth()
+
+	return client, nil
 }
 
 func addressForContract(config ethereum.Config, contractName string) (*common.Address, error) {
@@ -1,6 +1,7 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -78,3 +79,19 @@ func TestResolveContractByName(t *testing.T) {
 		})
 	}
 }
+
+func TestReadOnlyClientRefusesTransactions(t *testing.T) {
+	backend := newJournalBackend()
+	client := &readOnlyClient{backend}
+
+	transaction := signJournalTransaction(t, newJournalKey(t), 0, 10, nil)
+
+	err := client.SendTransaction(context.Background(), transaction)
+	if err == nil {
+		t.Fatal("expected transaction to be refused")
+	}
+
+	if len(backend.sent) != 0 {
+		t.Errorf("transaction should not reach the Ethereum node")
+	}
+}
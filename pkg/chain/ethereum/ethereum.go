@@ -1,6 +1,7 @@
 package ethereum
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
@@ -8,12 +9,16 @@ import (
 
 	"github.com/ipfs/go-log"
 
+	"github.com/ethereum/go-ethereum"
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/confirmation"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/subscription"
@@ -21,6 +26,10 @@ import (
 
 var logger = log.Logger("keep-chain-ethereum")
 
+// transactionLookupTimeout is the maximum time of reading a single mined
+// transaction from the chain.
+const transactionLookupTimeout = 10 * time.Second
+
 // ThresholdRelay converts from ethereumChain to beacon.ChainInterface.
 func (ec *ethereumChain) ThresholdRelay() relayChain.Interface {
 	return ec
@@ -49,6 +58,20 @@ func (ec *ethereumChain) HasMinimumStake(address common.Address) (bool, error) {
 	return ec.keepRandomBeaconOperatorContract.HasMinimumStake(address)
 }
 
+// EarliestUnconfirmedEventBlock returns the earliest block in which a relay
+// chain event has been received but is still awaiting confirmations. False is
+// returned if no event is awaiting confirmations.
+func (ec *ethereumChain) EarliestUnconfirmedEventBlock() (uint64, bool) {
+	return ec.eventWaiter.EarliestPendingBlock()
+}
+
+// EventConfirmations returns the number of blocks which need to be mined on
+// top of the block in which a relay chain event was emitted before the event
+// is delivered to the handlers.
+func (ec *ethereumChain) EventConfirmations() uint64 {
+	return ec.eventWaiter.Confirmations()
+}
+
 func (ec *ethereumChain) SubmitTicket(ticket *relayChain.Ticket) *async.EventGroupTicketSubmissionPromise {
 	submittedTicketPromise := &async.EventGroupTicketSubmissionPromise{}
 
@@ -65,10 +88,21 @@ func (ec *ethereumChain) SubmitTicket(ticket *relayChain.Ticket) *async.EventGro
 
 	ticketBytes := ec.packTicket(ticket)
 
+	// Tickets can be submitted straight away.
+	if err := ec.simulateOperatorTransaction(
+		0,
+		nil,
+		"submitTicket",
+		ticketBytes,
+	); err != nil {
+		failPromise(err)
+		return submittedTicketPromise
+	}
+
 	_, err := ec.keepRandomBeaconOperatorContract.SubmitTicket(
 		ticketBytes,
 		ethutil.TransactionOptions{
-			GasLimit: 250000,
+			GasLimit: TicketSubmissionGasLimit,
 		},
 	)
 	if err != nil {
@@ -92,6 +126,53 @@ func (ec *ethereumChain) packTicket(ticket *relayChain.Ticket) [32]uint8 {
 	return ticketFixedArray
 }
 
+func (ec *ethereumChain) EstimateTicketSubmissionCost(
+	ticket *relayChain.Ticket,
+) (*big.Int, error) {
+	input, err := ec.keepRandomBeaconOperatorABI.Pack(
+		"submitTicket",
+		ec.packTicket(ticket),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not pack parameters of submitTicket call: [%v]",
+			err,
+		)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		transactionLookupTimeout,
+	)
+	defer cancelCtx()
+
+	gas, err := ec.client.EstimateGas(ctx, ethereum.CallMsg{
+		From: ec.accountKey.Address,
+		To:   &ec.keepRandomBeaconOperatorAddress,
+		Data: input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not estimate gas: [%v]", err)
+	}
+
+	gasPrice, err := ec.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get gas price: [%v]", err)
+	}
+
+	// The gas price of the submission never exceeds the maximum gas price,
+	// even if the transaction has to be resubmitted with a higher one.
+	maxGasPrice := DefaultMaxGasPrice
+	if ec.config.MaxGasPrice != nil {
+		maxGasPrice = ec.config.MaxGasPrice.Int
+	}
+	if gasPrice.Cmp(maxGasPrice) > 0 {
+		gasPrice = maxGasPrice
+	}
+
+	return new(big.Int).Mul(new(big.Int).SetUint64(gas), gasPrice), nil
+}
+
 func (ec *ethereumChain) GetSubmittedTickets() ([]uint64, error) {
 	return ec.keepRandomBeaconOperatorContract.SubmittedTickets()
 }
@@ -157,6 +238,17 @@ func (ec *ethereumChain) SubmitRelayEntry(
 		}
 	}
 
+	// The relay entry can be submitted straight away.
+	if err := ec.simulateOperatorTransaction(
+		0,
+		nil,
+		"relayEntry",
+		entry,
+	); err != nil {
+		failPromise(err)
+		return relayEntryPromise
+	}
+
 	generatedEntry := make(chan *event.EntrySubmitted)
 
 	subscription := ec.OnRelayEntrySubmitted(
@@ -215,81 +307,315 @@ func (ec *ethereumChain) SubmitRelayEntry(
 func (ec *ethereumChain) OnRelayEntrySubmitted(
 	handle func(entry *event.EntrySubmitted),
 ) subscription.EventSubscription {
-	onEvent := func(blockNumber uint64) {
-		handle(&event.EntrySubmitted{
-			BlockNumber: blockNumber,
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	onEvent := func(transactionHash common.Hash, blockNumber uint64) {
+		// Relay entry submitted event carries no data so the submission is
+		// identified by the entry value passed to the transaction which
+		// emitted the event. If the value could not be read, only the same
+		// transaction confirms the submission.
+		entry, err := ec.submittedRelayEntry(transactionHash)
+		if err != nil {
+			logger.Warningf(
+				"could not read relay entry submitted in transaction [%v]; "+
+					"confirming by transaction hash only: [%v]",
+				transactionHash.Hex(),
+				err,
+			)
+		}
+
+		lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+			events, err := ec.keepRandomBeaconOperatorContract.PastRelayEntrySubmittedEvents(
+				fromBlock,
+				&toBlock,
+			)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, submittedEvent := range events {
+				if submittedEvent.Raw.TxHash == transactionHash {
+					return submittedEvent.Raw.BlockNumber, true, nil
+				}
+
+				if entry == nil {
+					continue
+				}
+
+				submittedEntry, err := ec.submittedRelayEntry(
+					submittedEvent.Raw.TxHash,
+				)
+				if err != nil {
+					return 0, false, err
+				}
+
+				if bytes.Equal(submittedEntry, entry) {
+					return submittedEvent.Raw.BlockNumber, true, nil
+				}
+			}
+
+			return 0, false, nil
+		}
+
+		ec.eventWaiter.Deliver(ctx, blockNumber, lookup, func(blockNumber uint64) {
+			handle(&event.EntrySubmitted{
+				BlockNumber: blockNumber,
+			})
 		})
 	}
 
-	subscription := ec.keepRandomBeaconOperatorContract.RelayEntrySubmitted(
-		nil,
-	).OnEvent(onEvent)
+	subscription := ec.subscribeOperatorEvent(
+		"RelayEntrySubmitted",
+		func(log types.Log) error {
+			operatorEvent, err := ec.keepRandomBeaconOperatorFilterer.ParseRelayEntrySubmitted(log)
+			if err != nil {
+				return err
+			}
+
+			onEvent(operatorEvent.Raw.TxHash, operatorEvent.Raw.BlockNumber)
+			return nil
+		},
+	)
+
+	return withCancel(subscription, cancelCtx)
+}
 
-	return subscription
+// submittedRelayEntry returns the relay entry passed to the operator contract
+// in the transaction with the given hash.
+func (ec *ethereumChain) submittedRelayEntry(
+	transactionHash common.Hash,
+) ([]byte, error) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		transactionLookupTimeout,
+	)
+	defer cancelCtx()
+
+	transaction, _, err := ec.client.TransactionByHash(ctx, transactionHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not get transaction: [%v]", err)
+	}
+
+	return relayEntryFromInput(ec.keepRandomBeaconOperatorABI, transaction.Data())
+}
+
+// relayEntryFromInput decodes the relay entry from the input data of
+// a transaction calling the relayEntry method of the operator contract.
+func relayEntryFromInput(
+	operatorABI ethereumabi.ABI,
+	input []byte,
+) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, fmt.Errorf("input too short: [%v] bytes", len(input))
+	}
+
+	method, err := operatorABI.MethodById(input[:4])
+	if err != nil {
+		return nil, err
+	}
+
+	if method.Name != "relayEntry" {
+		return nil, fmt.Errorf("unexpected method: [%v]", method.Name)
+	}
+
+	values, err := method.Inputs.UnpackValues(input[4:])
+	if err != nil {
+		return nil, fmt.Errorf("could not unpack input: [%v]", err)
+	}
+
+	if len(values) != 1 {
+		return nil, fmt.Errorf("unexpected number of inputs: [%v]", len(values))
+	}
+
+	entry, ok := values[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected input type: [%T]", values[0])
+	}
+
+	return entry, nil
 }
 
 func (ec *ethereumChain) OnRelayEntryRequested(
 	handle func(request *event.Request),
 ) subscription.EventSubscription {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
 	onEvent := func(
 		previousEntry []byte,
 		groupPublicKey []byte,
 		blockNumber uint64,
 	) {
-		handle(&event.Request{
-			PreviousEntry:  previousEntry,
-			GroupPublicKey: groupPublicKey,
-			BlockNumber:    blockNumber,
+		lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+			events, err := ec.keepRandomBeaconOperatorContract.PastRelayEntryRequestedEvents(
+				fromBlock,
+				&toBlock,
+			)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, requestEvent := range events {
+				if bytes.Equal(requestEvent.PreviousEntry, previousEntry) &&
+					bytes.Equal(requestEvent.GroupPublicKey, groupPublicKey) {
+					return requestEvent.Raw.BlockNumber, true, nil
+				}
+			}
+
+			return 0, false, nil
+		}
+
+		ec.eventWaiter.Deliver(ctx, blockNumber, lookup, func(blockNumber uint64) {
+			handle(&event.Request{
+				PreviousEntry:  previousEntry,
+				GroupPublicKey: groupPublicKey,
+				BlockNumber:    blockNumber,
+			})
 		})
 	}
 
-	subscription := ec.keepRandomBeaconOperatorContract.RelayEntryRequested(
+	subscription := ec.subscribeOperatorEvent(
+		"RelayEntryRequested",
+		func(log types.Log) error {
+			operatorEvent, err := ec.keepRandomBeaconOperatorFilterer.ParseRelayEntryRequested(log)
+			if err != nil {
+				return err
+			}
+
+			onEvent(
+				operatorEvent.PreviousEntry,
+				operatorEvent.GroupPublicKey,
+				operatorEvent.Raw.BlockNumber,
+			)
+			return nil
+		},
+	)
+
+	return withCancel(subscription, cancelCtx)
+}
+
+func (ec *ethereumChain) PastRelayEntryRequestedEvents(
+	fromBlock uint64,
+) ([]*event.Request, error) {
+	events, err := ec.keepRandomBeaconOperatorContract.PastRelayEntryRequestedEvents(
+		fromBlock,
 		nil,
-	).OnEvent(onEvent)
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]*event.Request, 0, len(events))
+	for _, requestEvent := range events {
+		requests = append(requests, &event.Request{
+			PreviousEntry:  requestEvent.PreviousEntry,
+			GroupPublicKey: requestEvent.GroupPublicKey,
+			BlockNumber:    requestEvent.Raw.BlockNumber,
+		})
+	}
 
-	return subscription
+	return requests, nil
 }
 
 func (ec *ethereumChain) OnGroupSelectionStarted(
 	handle func(groupSelectionStart *event.GroupSelectionStart),
 ) subscription.EventSubscription {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
 	onEvent := func(
 		newEntry *big.Int,
 		blockNumber uint64,
 	) {
-		handle(&event.GroupSelectionStart{
-			NewEntry:    newEntry,
-			BlockNumber: blockNumber,
+		lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+			events, err := ec.keepRandomBeaconOperatorContract.PastGroupSelectionStartedEvents(
+				fromBlock,
+				&toBlock,
+			)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, groupSelectionEvent := range events {
+				if groupSelectionEvent.NewEntry.Cmp(newEntry) == 0 {
+					return groupSelectionEvent.Raw.BlockNumber, true, nil
+				}
+			}
+
+			return 0, false, nil
+		}
+
+		ec.eventWaiter.Deliver(ctx, blockNumber, lookup, func(blockNumber uint64) {
+			handle(&event.GroupSelectionStart{
+				NewEntry:    newEntry,
+				BlockNumber: blockNumber,
+			})
 		})
 	}
 
-	subscription := ec.keepRandomBeaconOperatorContract.GroupSelectionStarted(
+	subscription := ec.subscribeOperatorEvent(
+		"GroupSelectionStarted",
+		func(log types.Log) error {
+			operatorEvent, err := ec.keepRandomBeaconOperatorFilterer.ParseGroupSelectionStarted(log)
+			if err != nil {
+				return err
+			}
+
+			onEvent(operatorEvent.NewEntry, operatorEvent.Raw.BlockNumber)
+			return nil
+		},
+	)
+
+	return withCancel(subscription, cancelCtx)
+}
+
+func (ec *ethereumChain) PastGroupSelectionStartedEvents(
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	events, err := ec.keepRandomBeaconOperatorContract.PastGroupSelectionStartedEvents(
+		fromBlock,
 		nil,
-	).OnEvent(onEvent)
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	groupSelectionStarts := make([]*event.GroupSelectionStart, 0, len(events))
+	for _, groupSelectionEvent := range events {
+		groupSelectionStarts = append(groupSelectionStarts, &event.GroupSelectionStart{
+			NewEntry:    groupSelectionEvent.NewEntry,
+			BlockNumber: groupSelectionEvent.Raw.BlockNumber,
+		})
+	}
 
-	return subscription
+	return groupSelectionStarts, nil
 }
 
 func (ec *ethereumChain) OnGroupRegistered(
 	handle func(groupRegistration *event.GroupRegistration),
 ) subscription.EventSubscription {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
 	onEvent := func(
 		memberIndex *big.Int,
 		groupPublicKey []byte,
 		misbehaved []byte,
 		blockNumber uint64,
 	) {
-		handle(&event.GroupRegistration{
-			GroupPublicKey: groupPublicKey,
-			BlockNumber:    blockNumber,
-		})
+		ec.eventWaiter.Deliver(
+			ctx,
+			blockNumber,
+			ec.dkgResultSubmittedLookup(groupPublicKey),
+			func(blockNumber uint64) {
+				handle(&event.GroupRegistration{
+					GroupPublicKey: groupPublicKey,
+					BlockNumber:    blockNumber,
+				})
+			},
+		)
 	}
 
-	subscription := ec.keepRandomBeaconOperatorContract.DkgResultSubmittedEvent(
-		nil,
-	).OnEvent(onEvent)
+	subscription := ec.subscribeDKGResultSubmitted(onEvent)
 
-	return subscription
+	return withCancel(subscription, cancelCtx)
 }
 
 func (ec *ethereumChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
@@ -322,28 +648,177 @@ func (ec *ethereumChain) GetGroupMembers(groupPublicKey []byte) (
 func (ec *ethereumChain) OnDKGResultSubmitted(
 	handler func(dkgResultPublication *event.DKGResultSubmission),
 ) subscription.EventSubscription {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
 	onEvent := func(
 		memberIndex *big.Int,
 		groupPublicKey []byte,
 		misbehaved []byte,
 		blockNumber uint64,
 	) {
-		handler(&event.DKGResultSubmission{
-			MemberIndex:    uint32(memberIndex.Uint64()),
-			GroupPublicKey: groupPublicKey,
-			Misbehaved:     misbehaved,
-			BlockNumber:    blockNumber,
-		})
+		ec.eventWaiter.Deliver(
+			ctx,
+			blockNumber,
+			ec.dkgResultSubmittedLookup(groupPublicKey),
+			func(blockNumber uint64) {
+				handler(&event.DKGResultSubmission{
+					MemberIndex:    uint32(memberIndex.Uint64()),
+					GroupPublicKey: groupPublicKey,
+					Misbehaved:     misbehaved,
+					BlockNumber:    blockNumber,
+				})
+			},
+		)
 	}
 
-	subscription := ec.keepRandomBeaconOperatorContract.DkgResultSubmittedEvent(
+	subscription := ec.subscribeDKGResultSubmitted(onEvent)
+
+	return withCancel(subscription, cancelCtx)
+}
+
+func (ec *ethereumChain) OnUnconfirmedDKGResultSubmitted(
+	handler func(dkgResultPublication *event.DKGResultSubmission),
+) subscription.EventSubscription {
+	return ec.subscribeDKGResultSubmitted(
+		func(
+			memberIndex *big.Int,
+			groupPublicKey []byte,
+			misbehaved []byte,
+			blockNumber uint64,
+		) {
+			handler(&event.DKGResultSubmission{
+				MemberIndex:    uint32(memberIndex.Uint64()),
+				GroupPublicKey: groupPublicKey,
+				Misbehaved:     misbehaved,
+				BlockNumber:    blockNumber,
+			})
+		},
+	)
+}
+
+// dkgResultSubmittedLookup returns a function looking up the DKG result
+// submission of the group with the given public key. Only one result can be
+// submitted for the given group public key.
+func (ec *ethereumChain) dkgResultSubmittedLookup(
+	groupPublicKey []byte,
+) confirmation.LookupFn {
+	return func(fromBlock, toBlock uint64) (uint64, bool, error) {
+		events, err := ec.keepRandomBeaconOperatorContract.PastDkgResultSubmittedEventEvents(
+			fromBlock,
+			&toBlock,
+		)
+		if err != nil {
+			return 0, false, err
+		}
+
+		for _, submissionEvent := range events {
+			if bytes.Equal(submissionEvent.GroupPubKey, groupPublicKey) {
+				return submissionEvent.Raw.BlockNumber, true, nil
+			}
+		}
+
+		return 0, false, nil
+	}
+}
+
+// subscribeDKGResultSubmitted subscribes to DKG result submission events of
+// the operator contract.
+func (ec *ethereumChain) subscribeDKGResultSubmitted(
+	onEvent func(
+		memberIndex *big.Int,
+		groupPublicKey []byte,
+		misbehaved []byte,
+		blockNumber uint64,
+	),
+) subscription.EventSubscription {
+	return ec.subscribeOperatorEvent(
+		"DkgResultSubmittedEvent",
+		func(log types.Log) error {
+			operatorEvent, err := ec.keepRandomBeaconOperatorFilterer.ParseDkgResultSubmittedEvent(log)
+			if err != nil {
+				return err
+			}
+
+			onEvent(
+				operatorEvent.MemberIndex,
+				operatorEvent.GroupPubKey,
+				operatorEvent.Misbehaved,
+				operatorEvent.Raw.BlockNumber,
+			)
+			return nil
+		},
+	)
+}
+
+// withCancel returns a subscription which, on unsubscribe, unsubscribes from
+// the given subscription and cancels the context of events which are still
+// awaiting confirmation so that they are never delivered.
+func withCancel(
+	eventSubscription subscription.EventSubscription,
+	cancelCtx context.CancelFunc,
+) subscription.EventSubscription {
+	return subscription.NewEventSubscription(func() {
+		eventSubscription.Unsubscribe()
+		cancelCtx()
+	})
+}
+
+func (ec *ethereumChain) PastDKGResultSubmittedEvents(
+	fromBlock uint64,
+) ([]*event.DKGResultSubmission, error) {
+	events, err := ec.keepRandomBeaconOperatorContract.PastDkgResultSubmittedEventEvents(
+		fromBlock,
 		nil,
-	).OnEvent(onEvent)
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions := make([]*event.DKGResultSubmission, 0, len(events))
+	for _, submissionEvent := range events {
+		submissions = append(submissions, &event.DKGResultSubmission{
+			MemberIndex:    uint32(submissionEvent.MemberIndex.Uint64()),
+			GroupPublicKey: submissionEvent.GroupPubKey,
+			Misbehaved:     submissionEvent.Misbehaved,
+			BlockNumber:    submissionEvent.Raw.BlockNumber,
+		})
+	}
 
-	return subscription
+	return submissions, nil
 }
 
-func (ec *ethereumChain) ReportRelayEntryTimeout() error {
+func (ec *ethereumChain) ReportRelayEntryTimeout(
+	relayRequestBlockNumber uint64,
+) error {
+	// The timeout can be reported once the relay entry timeout has passed
+	// since the relay request. If the request has been already handled by
+	// the time, the simulation reverts.
+	timeoutBlock := relayRequestBlockNumber + ec.chainConfig.RelayEntryTimeout + 1
+
+	// The timeout has already been reported if the monitored relay request
+	// is no longer the current one.
+	timeoutReported := func() (bool, error) {
+		isEntryInProgress, err := ec.IsEntryInProgress()
+		if err != nil || !isEntryInProgress {
+			return !isEntryInProgress, err
+		}
+
+		currentRequestStartBlock, err := ec.CurrentRequestStartBlock()
+		if err != nil {
+			return false, err
+		}
+
+		return currentRequestStartBlock.Uint64() != relayRequestBlockNumber, nil
+	}
+
+	if err := ec.simulateOperatorTransaction(
+		timeoutBlock,
+		timeoutReported,
+		"reportRelayEntryTimeout",
+	); err != nil {
+		return err
+	}
+
 	_, err := ec.keepRandomBeaconOperatorContract.ReportRelayEntryTimeout()
 	if err != nil {
 		return err
@@ -352,6 +827,58 @@ func (ec *ethereumChain) ReportRelayEntryTimeout() error {
 	return nil
 }
 
+func (ec *ethereumChain) OnRelayEntryTimeoutReported(
+	handle func(report *event.RelayEntryTimeoutReport),
+) subscription.EventSubscription {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	onEvent := func(groupIndex *big.Int, log types.Log) {
+		// Timeouts of the same group may be reported multiple times so
+		// the report is identified by the log which announced it.
+		lookup := func(fromBlock, toBlock uint64) (uint64, bool, error) {
+			events, err := ec.keepRandomBeaconOperatorContract.PastRelayEntryTimeoutReportedEvents(
+				fromBlock,
+				&toBlock,
+				[]*big.Int{groupIndex},
+			)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, reportedEvent := range events {
+				if reportedEvent.Raw.BlockHash == log.BlockHash &&
+					reportedEvent.Raw.TxHash == log.TxHash {
+					return reportedEvent.Raw.BlockNumber, true, nil
+				}
+			}
+
+			return 0, false, nil
+		}
+
+		ec.eventWaiter.Deliver(ctx, log.BlockNumber, lookup, func(blockNumber uint64) {
+			handle(&event.RelayEntryTimeoutReport{
+				GroupIndex:  groupIndex,
+				BlockNumber: blockNumber,
+			})
+		})
+	}
+
+	subscription := ec.subscribeOperatorEvent(
+		"RelayEntryTimeoutReported",
+		func(log types.Log) error {
+			operatorEvent, err := ec.keepRandomBeaconOperatorFilterer.ParseRelayEntryTimeoutReported(log)
+			if err != nil {
+				return err
+			}
+
+			onEvent(operatorEvent.GroupIndex, operatorEvent.Raw)
+			return nil
+		},
+	)
+
+	return withCancel(subscription, cancelCtx)
+}
+
 func (ec *ethereumChain) IsEntryInProgress() (bool, error) {
 	return ec.keepRandomBeaconOperatorContract.IsEntryInProgress()
 }
@@ -373,6 +900,47 @@ func (ec *ethereumChain) CurrentRequestGroupPublicKey() ([]byte, error) {
 	return ec.keepRandomBeaconOperatorContract.GetGroupPublicKey(currentRequestGroupIndex)
 }
 
+func (ec *ethereumChain) GetActiveGroupMembers() (
+	[]relayChain.StakerAddress,
+	error,
+) {
+	firstActiveGroupIndex, err := ec.keepRandomBeaconOperatorContract.GetFirstActiveGroupIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	numberOfCreatedGroups, err := ec.keepRandomBeaconOperatorContract.GetNumberOfCreatedGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	seenMembers := make(map[string]bool)
+	activeGroupMembers := make([]relayChain.StakerAddress, 0)
+
+	for groupIndex := firstActiveGroupIndex.Uint64(); groupIndex < numberOfCreatedGroups.Uint64(); groupIndex++ {
+		groupPublicKey, err := ec.keepRandomBeaconOperatorContract.GetGroupPublicKey(
+			new(big.Int).SetUint64(groupIndex),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		groupMembers, err := ec.GetGroupMembers(groupPublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range groupMembers {
+			if !seenMembers[string(member)] {
+				seenMembers[string(member)] = true
+				activeGroupMembers = append(activeGroupMembers, member)
+			}
+		}
+	}
+
+	return activeGroupMembers, nil
+}
+
 func (ec *ethereumChain) SubmitDKGResult(
 	participantIndex relayChain.GroupMemberIndex,
 	result *relayChain.DKGResult,
@@ -433,6 +1001,40 @@ func (ec *ethereumChain) SubmitDKGResult(
 		return resultPublicationPromise
 	}
 
+	// The result is submitted once the member is eligible to submit it
+	// according to the block counter, which may be ahead of the Ethereum
+	// node used for the simulation.
+	eligibleBlock, err := ec.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Warningf(
+			"could not determine the DKG result submission block: [%v]",
+			err,
+		)
+		eligibleBlock = 0
+	}
+
+	// The result has already been submitted by other member if the group
+	// it creates has been registered.
+	resultSubmitted := func() (bool, error) {
+		return ec.IsGroupRegistered(result.GroupPublicKey)
+	}
+
+	if err := ec.simulateOperatorTransaction(
+		eligibleBlock,
+		resultSubmitted,
+		"submitDkgResult",
+		big.NewInt(int64(participantIndex)),
+		result.GroupPublicKey,
+		result.Misbehaved,
+		signaturesOnChainFormat,
+		membersIndicesOnChainFormat,
+	); err != nil {
+		subscription.Unsubscribe()
+		close(publishedResult)
+		failPromise(err)
+		return resultPublicationPromise
+	}
+
 	if _, err = ec.keepRandomBeaconOperatorContract.SubmitDkgResult(
 		big.NewInt(int64(participantIndex)),
 		result.GroupPublicKey,
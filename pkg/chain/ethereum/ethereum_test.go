@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 )
 
 // TestCalculateDKGResultHash validates if calculated DKG result hash matches
@@ -234,3 +237,65 @@ func TestPackTicket(t *testing.T) {
 		})
 	}
 }
+
+func TestRelayEntryFromInput(t *testing.T) {
+	operatorABI, err := ethereumabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := []byte{0x01, 0x02, 0x03}
+
+	relayEntryInput, err := operatorABI.Pack("relayEntry", entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeoutReportInput, err := operatorABI.Pack("reportRelayEntryTimeout")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		input         []byte
+		expectedEntry []byte
+		expectedError bool
+	}{
+		"relay entry submission": {
+			input:         relayEntryInput,
+			expectedEntry: entry,
+		},
+		"other method call": {
+			input:         timeoutReportInput,
+			expectedError: true,
+		},
+		"input too short": {
+			input:         []byte{0x01},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actualEntry, err := relayEntryFromInput(operatorABI, test.input)
+
+			if test.expectedError != (err != nil) {
+				t.Fatalf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if !bytes.Equal(test.expectedEntry, actualEntry) {
+				t.Errorf(
+					"unexpected entry\nexpected: [%x]\nactual:   [%x]",
+					test.expectedEntry,
+					actualEntry,
+				)
+			}
+		})
+	}
+}
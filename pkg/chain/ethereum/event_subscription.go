@@ -0,0 +1,371 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// eventDeduplicationBlocks is the number of blocks below the last delivered
+// block for which delivered events are remembered, so that they are not
+// delivered again when the same blocks are read once more.
+const eventDeduplicationBlocks = 100
+
+// SubscriptionStats describes the health of contract event subscriptions.
+type SubscriptionStats struct {
+	// Subscriptions is the number of active event subscriptions.
+	Subscriptions uint64
+	// Unhealthy is the number of active event subscriptions which are not
+	// established with the chain at the moment.
+	Unhealthy uint64
+	// Failures is the number of failed attempts to establish a subscription
+	// and of failures of established subscriptions.
+	Failures uint64
+	// Resubscriptions is the number of times a subscription has been
+	// established again after a failure.
+	Resubscriptions uint64
+	// DeliveredEvents is the number of events delivered to subscribers.
+	DeliveredEvents uint64
+	// ReplayedEvents is the number of delivered events which were read from
+	// the chain after establishing a subscription instead of being received
+	// through the subscription.
+	ReplayedEvents uint64
+	// DuplicateEvents is the number of events received again after they have
+	// been delivered and skipped.
+	DuplicateEvents uint64
+	// LastDeliveredBlock is the highest block of a delivered event.
+	LastDeliveredBlock uint64
+}
+
+// subscriptionMonitor collects statistics of event subscriptions.
+type subscriptionMonitor struct {
+	mutex sync.Mutex
+	stats SubscriptionStats
+}
+
+func newSubscriptionMonitor() *subscriptionMonitor {
+	return &subscriptionMonitor{}
+}
+
+// added records a new subscription. New subscriptions are unhealthy until
+// they are established.
+func (sm *subscriptionMonitor) added() {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.stats.Subscriptions++
+	sm.stats.Unhealthy++
+}
+
+func (sm *subscriptionMonitor) removed(established bool) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.stats.Subscriptions--
+	if !established {
+		sm.stats.Unhealthy--
+	}
+}
+
+func (sm *subscriptionMonitor) established(resubscription bool) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.stats.Unhealthy--
+	if resubscription {
+		sm.stats.Resubscriptions++
+	}
+}
+
+func (sm *subscriptionMonitor) failed(established bool) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.stats.Failures++
+	if established {
+		sm.stats.Unhealthy++
+	}
+}
+
+func (sm *subscriptionMonitor) delivered(blockNumber uint64, replayed bool) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.stats.DeliveredEvents++
+	if replayed {
+		sm.stats.ReplayedEvents++
+	}
+	if blockNumber > sm.stats.LastDeliveredBlock {
+		sm.stats.LastDeliveredBlock = blockNumber
+	}
+}
+
+func (sm *subscriptionMonitor) duplicate() {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.stats.DuplicateEvents++
+}
+
+func (sm *subscriptionMonitor) Stats() SubscriptionStats {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	return sm.stats
+}
+
+// EventSubscriptionStats returns statistics of contract event subscriptions
+// of all chain handles sharing the connection.
+func (ec *ethereumChain) EventSubscriptionStats() SubscriptionStats {
+	return ec.subscriptionMonitor.Stats()
+}
+
+// eventID identifies an event emitted on the chain.
+type eventID struct {
+	txHash   common.Hash
+	logIndex uint
+}
+
+// eventSubscription delivers logs matching the query to the handler. It keeps
+// track of the block of the last delivered log. Each time the subscription is
+// established, logs emitted since that block are read from the chain and
+// delivered before any new log, so logs emitted while the subscription was
+// not established are not missed. Logs are delivered at most once.
+type eventSubscription struct {
+	name         string
+	query        ethereum.FilterQuery
+	client       ethereum.LogFilterer
+	currentBlock func() (uint64, error)
+	handle       func(log types.Log) error
+	monitor      *subscriptionMonitor
+
+	// started is true once the block at which the subscription has been
+	// started is known
+	started bool
+	// lastBlock is the block of the last delivered log or the block at which
+	// the subscription has been started
+	lastBlock uint64
+	// delivered holds blocks of logs delivered from the recent blocks
+	delivered map[eventID]uint64
+}
+
+// subscribeOperatorEvent subscribes to the event of the operator contract
+// with the given name. The handler is called with each log of the event and
+// returns an error if the log could not be handled.
+func (ec *ethereumChain) subscribeOperatorEvent(
+	name string,
+	handle func(log types.Log) error,
+) subscription.EventSubscription {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	es := &eventSubscription{
+		name: name,
+		query: ethereum.FilterQuery{
+			Addresses: []common.Address{ec.keepRandomBeaconOperatorAddress},
+			Topics: [][]common.Hash{
+				{ec.keepRandomBeaconOperatorABI.Events[name].ID()},
+			},
+		},
+		client:       ec.client,
+		currentBlock: ec.blockCounter.CurrentBlock,
+		handle:       handle,
+		monitor:      ec.subscriptionMonitor,
+		delivered:    make(map[eventID]uint64),
+	}
+
+	es.monitor.added()
+	go es.run(ctx)
+
+	return subscription.NewEventSubscription(cancelCtx)
+}
+
+// run keeps the subscription established until the context is done.
+func (es *eventSubscription) run(ctx context.Context) {
+	established := false
+	defer func() {
+		es.monitor.removed(established)
+	}()
+
+	backoff := resubscriptionBackoffMin
+	resubscription := false
+
+	for {
+		logs := make(chan types.Log, 16)
+		logsSubscription, err := es.subscribe(ctx, logs)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			es.monitor.failed(false)
+			logger.Warningf(
+				"could not subscribe to event [%v]; retrying in [%v]: [%v]",
+				es.name,
+				backoff,
+				err,
+			)
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+
+			backoff *= 2
+			if backoff > resubscriptionBackoffMax {
+				backoff = resubscriptionBackoffMax
+			}
+			continue
+		}
+
+		backoff = resubscriptionBackoffMin
+		established = true
+		es.monitor.established(resubscription)
+		if resubscription {
+			logger.Infof("subscription to event [%v] re-established", es.name)
+		}
+		resubscription = true
+
+		err = es.receive(ctx, logsSubscription, logs)
+		logsSubscription.Unsubscribe()
+		if err == nil {
+			return
+		}
+
+		established = false
+		es.monitor.failed(true)
+		logger.Warningf(
+			"subscription to event [%v] failed; resubscribing: [%v]",
+			es.name,
+			err,
+		)
+	}
+}
+
+// subscribe establishes the subscription and delivers logs emitted since the
+// last block. New logs are passed to the given channel.
+func (es *eventSubscription) subscribe(
+	ctx context.Context,
+	logs chan<- types.Log,
+) (ethereum.Subscription, error) {
+	if !es.started {
+		currentBlock, err := es.currentBlock()
+		if err != nil {
+			return nil, fmt.Errorf("could not get current block: [%v]", err)
+		}
+
+		es.lastBlock = currentBlock
+		es.started = true
+	}
+
+	logsSubscription, err := es.client.SubscribeFilterLogs(ctx, es.query, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := es.replay(ctx); err != nil {
+		logsSubscription.Unsubscribe()
+		return nil, err
+	}
+
+	return logsSubscription, nil
+}
+
+// replay reads logs emitted since the last block and delivers them.
+func (es *eventSubscription) replay(ctx context.Context) error {
+	query := es.query
+	query.FromBlock = new(big.Int).SetUint64(es.lastBlock)
+
+	pastLogs, err := es.client.FilterLogs(ctx, query)
+	if err != nil {
+		return fmt.Errorf(
+			"could not read past events since block [%v]: [%v]",
+			es.lastBlock,
+			err,
+		)
+	}
+
+	for _, log := range pastLogs {
+		// no log is delivered once the subscription is unsubscribed
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		es.deliver(log, true)
+	}
+
+	return nil
+}
+
+// receive delivers logs received through the subscription until the
+// subscription fails or the context is done. It returns the error of the
+// subscription or nil if the context is done.
+func (es *eventSubscription) receive(
+	ctx context.Context,
+	logsSubscription ethereum.Subscription,
+	logs <-chan types.Log,
+) error {
+	for {
+		select {
+		case log := <-logs:
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			es.deliver(log, false)
+		case err := <-logsSubscription.Err():
+			if err == nil {
+				err = fmt.Errorf("subscription closed")
+			}
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// deliver passes the log to the handler unless it has already been
+// delivered.
+func (es *eventSubscription) deliver(log types.Log, replayed bool) {
+	// Logs removed by a chain reorganization are not delivered; events
+	// are looked up again before they are confirmed anyway.
+	if log.Removed {
+		return
+	}
+
+	id := eventID{log.TxHash, log.Index}
+	if _, ok := es.delivered[id]; ok {
+		es.monitor.duplicate()
+		return
+	}
+
+	if err := es.handle(log); err != nil {
+		logger.Errorf(
+			"could not handle event [%v] from transaction [%v]: [%v]",
+			es.name,
+			log.TxHash.Hex(),
+			err,
+		)
+		return
+	}
+
+	es.delivered[id] = log.BlockNumber
+	es.monitor.delivered(log.BlockNumber, replayed)
+
+	if log.BlockNumber > es.lastBlock {
+		es.lastBlock = log.BlockNumber
+
+		for id, blockNumber := range es.delivered {
+			if blockNumber+eventDeduplicationBlocks < es.lastBlock {
+				delete(es.delivered, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestEventSubscriptionReplaysGap(t *testing.T) {
+	backend := newFakeBackend(10)
+	monitor := newSubscriptionMonitor()
+
+	delivered := make(chan types.Log, 10)
+	subscription := &eventSubscription{
+		name:   "TestEvent",
+		query:  ethereum.FilterQuery{},
+		client: backend,
+		currentBlock: func() (uint64, error) {
+			return 10, nil
+		},
+		handle: func(log types.Log) error {
+			delivered <- log
+			return nil
+		},
+		monitor:   monitor,
+		delivered: make(map[eventID]uint64),
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	monitor.added()
+	go subscription.run(ctx)
+
+	waitForStats(t, monitor, func(stats SubscriptionStats) bool {
+		return stats.Unhealthy == 0
+	})
+
+	log11 := eventLog(11, 0)
+	backend.emitLog(log11)
+
+	// The subscription fails and can not be established again until the
+	// backend is back. Logs emitted in the meantime are replayed.
+	waitForStats(t, monitor, func(stats SubscriptionStats) bool {
+		return stats.DeliveredEvents == 1
+	})
+	backend.setDown(true)
+	backend.failSubscriptions()
+
+	log12 := eventLog(12, 0)
+	log13 := eventLog(13, 1)
+	backend.addLog(log12)
+	backend.addLog(log13)
+
+	waitForStats(t, monitor, func(stats SubscriptionStats) bool {
+		return stats.Failures == 2
+	})
+	backend.setDown(false)
+	waitForStats(t, monitor, func(stats SubscriptionStats) bool {
+		return stats.Resubscriptions == 1 && stats.Unhealthy == 0
+	})
+
+	removedLog14 := eventLog(14, 0)
+	removedLog14.Removed = true
+	backend.emitLog(removedLog14)
+
+	log15 := eventLog(15, 0)
+	backend.emitLog(log15)
+
+	expectedLogs := []types.Log{log11, log12, log13, log15}
+	actualLogs := make([]types.Log, 0)
+	for range expectedLogs {
+		select {
+		case log := <-delivered:
+			actualLogs = append(actualLogs, log)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for logs: [%v]", actualLogs)
+		}
+	}
+	if !reflect.DeepEqual(expectedLogs, actualLogs) {
+		t.Errorf(
+			"unexpected logs\nexpected: [%v]\nactual:   [%v]",
+			expectedLogs,
+			actualLogs,
+		)
+	}
+
+	select {
+	case log := <-delivered:
+		t.Errorf("unexpected log delivered: [%v]", log)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	expectedStats := SubscriptionStats{
+		Subscriptions:      1,
+		Unhealthy:          0,
+		Failures:           2, // subscription failure and failed attempt
+		Resubscriptions:    1,
+		DeliveredEvents:    4,
+		ReplayedEvents:     2,
+		DuplicateEvents:    1, // log 11 read again with the gap
+		LastDeliveredBlock: 15,
+	}
+	if stats := monitor.Stats(); !reflect.DeepEqual(expectedStats, stats) {
+		t.Errorf(
+			"unexpected stats\nexpected: [%+v]\nactual:   [%+v]",
+			expectedStats,
+			stats,
+		)
+	}
+
+	cancelCtx()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for monitor.Stats().Subscriptions != 0 || backend.activeSubscriptions() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for unsubscribe")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEventSubscriptionDeliversOnce(t *testing.T) {
+	subscription := &eventSubscription{
+		name:      "TestEvent",
+		monitor:   newSubscriptionMonitor(),
+		delivered: make(map[eventID]uint64),
+		lastBlock: 10,
+	}
+
+	delivered := make([]types.Log, 0)
+	subscription.handle = func(log types.Log) error {
+		delivered = append(delivered, log)
+		return nil
+	}
+
+	log10 := eventLog(10, 0)
+	log11 := eventLog(11, 0)
+	log11Second := eventLog(11, 1)
+	log200 := eventLog(200, 0)
+
+	subscription.deliver(log10, false)
+	subscription.deliver(log11, false)
+	subscription.deliver(log10, true)
+	subscription.deliver(log11Second, true)
+	subscription.deliver(log11, true)
+	subscription.deliver(log200, false)
+
+	expectedLogs := []types.Log{log10, log11, log11Second, log200}
+	if !reflect.DeepEqual(expectedLogs, delivered) {
+		t.Errorf(
+			"unexpected logs\nexpected: [%v]\nactual:   [%v]",
+			expectedLogs,
+			delivered,
+		)
+	}
+
+	// logs far behind the last delivered block are no longer remembered
+	if len(subscription.delivered) != 1 {
+		t.Errorf(
+			"unexpected number of remembered logs\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(subscription.delivered),
+		)
+	}
+}
+
+func waitForStats(
+	t *testing.T,
+	monitor *subscriptionMonitor,
+	condition func(stats SubscriptionStats) bool,
+) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition(monitor.Stats()) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for stats: [%+v]", monitor.Stats())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func eventLog(blockNumber uint64, index uint) types.Log {
+	return types.Log{
+		BlockNumber: blockNumber,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber * 100)),
+		Index:       index,
+	}
+}
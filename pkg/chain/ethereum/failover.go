@@ -0,0 +1,691 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+var (
+	// DefaultHealthCheckInterval is the default interval in which health of
+	// Ethereum endpoints is checked.
+	DefaultHealthCheckInterval = 10 * time.Second
+
+	// DefaultMaxHeadLag is the default maximum number of blocks the head of
+	// a healthy endpoint can be behind the highest head seen among all
+	// endpoints.
+	DefaultMaxHeadLag = uint64(3)
+
+	// DefaultMaxErrorRate is the default maximum rate of failed requests to
+	// a healthy endpoint.
+	DefaultMaxErrorRate = 0.5
+
+	// DefaultMaxLatency is the default maximum latency of a health check
+	// request to a healthy endpoint.
+	DefaultMaxLatency = 5 * time.Second
+)
+
+// errorRateWindow is the number of the most recent requests to an endpoint
+// its error rate is calculated from.
+const errorRateWindow = 20
+
+// Endpoint is an Ethereum node endpoint the client can connect to.
+type Endpoint struct {
+	// URL is the WebSocket or IPC URL of the node, e.g.
+	// "ws://192.168.0.157:8546".
+	URL string
+
+	// Priority of the endpoint. Healthy endpoints with lower priority
+	// values are preferred. The endpoint set in the Ethereum configuration
+	// has priority 0.
+	Priority int
+}
+
+// FailoverConfig configures additional Ethereum endpoints the client fails
+// over to and health checks of all the endpoints. Zero values are replaced
+// with defaults.
+type FailoverConfig struct {
+	// Endpoints are used alongside the endpoint set in the Ethereum
+	// configuration.
+	Endpoints []Endpoint
+
+	// HealthCheckInterval is the interval in which health of all endpoints
+	// is checked.
+	HealthCheckInterval time.Duration
+
+	// MaxHeadLag is the maximum number of blocks the head of a healthy
+	// endpoint can be behind the highest head seen among all endpoints.
+	MaxHeadLag uint64
+
+	// MaxErrorRate is the maximum rate of failed requests to a healthy
+	// endpoint, between 0 and 1.
+	MaxErrorRate float64
+
+	// MaxLatency is the maximum latency of a health check request to
+	// a healthy endpoint.
+	MaxLatency time.Duration
+}
+
+func (fc FailoverConfig) withDefaults() FailoverConfig {
+	if fc.HealthCheckInterval == 0 {
+		fc.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+	if fc.MaxHeadLag == 0 {
+		fc.MaxHeadLag = DefaultMaxHeadLag
+	}
+	if fc.MaxErrorRate == 0 {
+		fc.MaxErrorRate = DefaultMaxErrorRate
+	}
+	if fc.MaxLatency == 0 {
+		fc.MaxLatency = DefaultMaxLatency
+	}
+	return fc
+}
+
+// endpoint is a single Ethereum endpoint used by the failover client along
+// with its health statistics.
+type endpoint struct {
+	url      string
+	priority int
+	connect  func() (ethutil.EthereumClient, error)
+
+	mutex    sync.Mutex
+	client   ethutil.EthereumClient
+	checked  bool
+	head     uint64
+	latency  time.Duration
+	outcomes [errorRateWindow]bool // true for failed requests
+	requests int
+}
+
+func newEndpoint(url string, priority int) *endpoint {
+	return &endpoint{
+		url:      url,
+		priority: priority,
+		connect: func() (ethutil.EthereumClient, error) {
+			return ethclient.Dial(url)
+		},
+	}
+}
+
+// connectedClient returns the client of the endpoint or nil if the endpoint
+// is not connected.
+func (e *endpoint) connectedClient() ethutil.EthereumClient {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.client
+}
+
+// ensureConnected connects the endpoint if it is not connected yet.
+func (e *endpoint) ensureConnected() (ethutil.EthereumClient, error) {
+	if client := e.connectedClient(); client != nil {
+		return client, nil
+	}
+
+	client, err := e.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.client == nil {
+		e.client = client
+	}
+	return e.client, nil
+}
+
+func (e *endpoint) recordOutcome(failed bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.outcomes[e.requests%errorRateWindow] = failed
+	e.requests++
+}
+
+func (e *endpoint) recordHead(head uint64, latency time.Duration) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.checked = true
+	e.head = head
+	e.latency = latency
+}
+
+func (e *endpoint) lastHead() uint64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.head
+}
+
+// errorRate returns the rate of failed requests among the most recent
+// requests to the endpoint.
+func (e *endpoint) errorRate() float64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	count := e.requests
+	if count > errorRateWindow {
+		count = errorRateWindow
+	}
+	if count == 0 {
+		return 0
+	}
+
+	failures := 0
+	for i := 0; i < count; i++ {
+		if e.outcomes[i] {
+			failures++
+		}
+	}
+
+	return float64(failures) / float64(count)
+}
+
+// isHealthy returns true if the endpoint is connected, has been checked and
+// is within all the limits of the config. The best head is the highest head
+// seen among all endpoints.
+func (e *endpoint) isHealthy(config FailoverConfig, bestHead uint64) bool {
+	errorRate := e.errorRate()
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.client != nil &&
+		e.checked &&
+		e.head+config.MaxHeadLag >= bestHead &&
+		e.latency <= config.MaxLatency &&
+		errorRate <= config.MaxErrorRate
+}
+
+// failoverClient is an Ethereum client using the healthiest of several
+// endpoints. Requests are sent to the active endpoint and, if the endpoint
+// could not be reached, to the other endpoints in the order of preference.
+// Subscriptions are moved to the active endpoint when it changes.
+type failoverClient struct {
+	config FailoverConfig
+	// endpoints in the order of priority
+	endpoints []*endpoint
+
+	mutex  sync.RWMutex
+	active *endpoint
+	// switched is closed and replaced when the active endpoint changes
+	switched chan struct{}
+}
+
+// newFailoverClient connects all given endpoints and selects the active one
+// after checking their health. It fails only if none of the endpoints could
+// be connected; endpoints which could not be connected are retried on every
+// health check.
+func newFailoverClient(
+	config FailoverConfig,
+	endpoints []*endpoint,
+) (*failoverClient, error) {
+	// endpoints with the same priority are kept in the configured order
+	sortedEndpoints := append([]*endpoint{}, endpoints...)
+	sort.SliceStable(sortedEndpoints, func(i, j int) bool {
+		return sortedEndpoints[i].priority < sortedEndpoints[j].priority
+	})
+
+	fc := &failoverClient{
+		config:    config.withDefaults(),
+		endpoints: sortedEndpoints,
+		switched:  make(chan struct{}),
+	}
+
+	fc.checkHealth()
+
+	if fc.activeEndpoint() == nil {
+		return nil, fmt.Errorf("could not connect to any Ethereum endpoint")
+	}
+
+	return fc, nil
+}
+
+// monitorHealth checks health of all endpoints in the configured interval.
+// It should be run in a separate goroutine.
+func (fc *failoverClient) monitorHealth() {
+	ticker := time.NewTicker(fc.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		fc.checkHealth()
+	}
+}
+
+// checkHealth reads the head of every endpoint, connecting the endpoints
+// which are not connected yet, and selects the active endpoint.
+func (fc *failoverClient) checkHealth() {
+	var wg sync.WaitGroup
+	wg.Add(len(fc.endpoints))
+
+	for _, e := range fc.endpoints {
+		go func(e *endpoint) {
+			defer wg.Done()
+
+			client, err := e.ensureConnected()
+			if err != nil {
+				logger.Warningf(
+					"could not connect to Ethereum endpoint [%v]: [%v]",
+					e.url,
+					err,
+				)
+				e.recordOutcome(true)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(
+				context.Background(),
+				fc.config.MaxLatency,
+			)
+			defer cancel()
+
+			start := time.Now()
+			header, err := client.HeaderByNumber(ctx, nil)
+			if err != nil {
+				logger.Warningf(
+					"health check of Ethereum endpoint [%v] failed: [%v]",
+					e.url,
+					err,
+				)
+				e.recordOutcome(true)
+				return
+			}
+
+			e.recordOutcome(false)
+			e.recordHead(header.Number.Uint64(), time.Since(start))
+		}(e)
+	}
+
+	wg.Wait()
+
+	fc.selectActive()
+}
+
+// selectActive makes the healthy endpoint with the lowest priority value
+// active. If there is no healthy endpoint, the active endpoint is kept as
+// long as it is connected.
+func (fc *failoverClient) selectActive() {
+	bestHead := uint64(0)
+	for _, e := range fc.endpoints {
+		if head := e.lastHead(); head > bestHead {
+			bestHead = head
+		}
+	}
+
+	var selected *endpoint
+	for _, e := range fc.endpoints {
+		if e.isHealthy(fc.config, bestHead) {
+			selected = e
+			break
+		}
+	}
+
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	if selected == nil {
+		if fc.active != nil && fc.active.connectedClient() != nil {
+			return
+		}
+		for _, e := range fc.endpoints {
+			if e.connectedClient() != nil {
+				selected = e
+				break
+			}
+		}
+	}
+
+	if selected == nil || selected == fc.active {
+		return
+	}
+
+	if fc.active == nil {
+		logger.Infof("using Ethereum endpoint [%v]", selected.url)
+	} else {
+		logger.Warningf(
+			"switching from Ethereum endpoint [%v] to [%v]",
+			fc.active.url,
+			selected.url,
+		)
+	}
+
+	fc.active = selected
+	close(fc.switched)
+	fc.switched = make(chan struct{})
+}
+
+func (fc *failoverClient) activeEndpoint() *endpoint {
+	fc.mutex.RLock()
+	defer fc.mutex.RUnlock()
+
+	return fc.active
+}
+
+// switchNotification returns a channel closed when the active endpoint
+// changes.
+func (fc *failoverClient) switchNotification() <-chan struct{} {
+	fc.mutex.RLock()
+	defer fc.mutex.RUnlock()
+
+	return fc.switched
+}
+
+// candidates returns connected endpoints in the order they should be tried:
+// the active endpoint first, then healthy endpoints and then all other
+// endpoints, by their priority.
+func (fc *failoverClient) candidates() []*endpoint {
+	active := fc.activeEndpoint()
+
+	bestHead := uint64(0)
+	for _, e := range fc.endpoints {
+		if head := e.lastHead(); head > bestHead {
+			bestHead = head
+		}
+	}
+
+	candidates := make([]*endpoint, 0, len(fc.endpoints))
+	if active != nil && active.connectedClient() != nil {
+		candidates = append(candidates, active)
+	}
+
+	unhealthy := make([]*endpoint, 0)
+	for _, e := range fc.endpoints {
+		if e == active || e.connectedClient() == nil {
+			continue
+		}
+		if e.isHealthy(fc.config, bestHead) {
+			candidates = append(candidates, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+
+	return append(candidates, unhealthy...)
+}
+
+// do executes the call with clients of the candidate endpoints until the
+// call completes without an endpoint error. If the active endpoint failed,
+// the active endpoint is selected again.
+func (fc *failoverClient) do(call func(ethutil.EthereumClient) error) error {
+	err := fmt.Errorf("no Ethereum endpoint is connected")
+
+	failed := false
+	for _, e := range fc.candidates() {
+		err = call(e.connectedClient())
+		if !isEndpointError(err) {
+			e.recordOutcome(false)
+			break
+		}
+
+		logger.Warningf(
+			"request to Ethereum endpoint [%v] failed: [%v]",
+			e.url,
+			err,
+		)
+		e.recordOutcome(true)
+		failed = true
+	}
+
+	if failed {
+		fc.selectActive()
+	}
+
+	return err
+}
+
+// isEndpointError returns true if the error means the endpoint could not
+// handle the request and the request should be sent to another endpoint.
+// Errors returned by the node itself, like reverts or unknown transactions,
+// and errors of the caller's context are not endpoint errors.
+func isEndpointError(err error) bool {
+	if err == nil ||
+		err == ethereum.NotFound ||
+		err == context.Canceled ||
+		err == context.DeadlineExceeded {
+		return false
+	}
+
+	if _, ok := err.(rpc.Error); ok {
+		return false
+	}
+
+	return true
+}
+
+func (fc *failoverClient) CodeAt(
+	ctx context.Context,
+	contract common.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	var code []byte
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		code, err = client.CodeAt(ctx, contract, blockNumber)
+		return
+	})
+	return code, err
+}
+
+func (fc *failoverClient) CallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	var result []byte
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		result, err = client.CallContract(ctx, call, blockNumber)
+		return
+	})
+	return result, err
+}
+
+// PendingCallContract calls the contract against the pending block. Endpoint
+// clients not able to do it call the contract against the latest block.
+func (fc *failoverClient) PendingCallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+) ([]byte, error) {
+	var result []byte
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		if pendingCaller, ok := client.(pendingContractCaller); ok {
+			result, err = pendingCaller.PendingCallContract(ctx, call)
+		} else {
+			result, err = client.CallContract(ctx, call, nil)
+		}
+		return
+	})
+	return result, err
+}
+
+func (fc *failoverClient) PendingCodeAt(
+	ctx context.Context,
+	account common.Address,
+) ([]byte, error) {
+	var code []byte
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		code, err = client.PendingCodeAt(ctx, account)
+		return
+	})
+	return code, err
+}
+
+func (fc *failoverClient) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	var nonce uint64
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return
+	})
+	return nonce, err
+}
+
+func (fc *failoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var gasPrice *big.Int
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		gasPrice, err = client.SuggestGasPrice(ctx)
+		return
+	})
+	return gasPrice, err
+}
+
+func (fc *failoverClient) EstimateGas(
+	ctx context.Context,
+	call ethereum.CallMsg,
+) (uint64, error) {
+	var gas uint64
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		gas, err = client.EstimateGas(ctx, call)
+		return
+	})
+	return gas, err
+}
+
+// SendTransaction sends the transaction to the active endpoint. If the
+// endpoint could not be reached, the same signed transaction is sent to the
+// next endpoint, so the transaction is never submitted twice.
+func (fc *failoverClient) SendTransaction(
+	ctx context.Context,
+	tx *types.Transaction,
+) error {
+	return fc.do(func(client ethutil.EthereumClient) error {
+		return client.SendTransaction(ctx, tx)
+	})
+}
+
+func (fc *failoverClient) FilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+) ([]types.Log, error) {
+	var logs []types.Log
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		logs, err = client.FilterLogs(ctx, query)
+		return
+	})
+	return logs, err
+}
+
+func (fc *failoverClient) BlockByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Block, error) {
+	var block *types.Block
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		block, err = client.BlockByHash(ctx, hash)
+		return
+	})
+	return block, err
+}
+
+func (fc *failoverClient) BlockByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Block, error) {
+	var block *types.Block
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		block, err = client.BlockByNumber(ctx, number)
+		return
+	})
+	return block, err
+}
+
+func (fc *failoverClient) HeaderByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Header, error) {
+	var header *types.Header
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		header, err = client.HeaderByHash(ctx, hash)
+		return
+	})
+	return header, err
+}
+
+func (fc *failoverClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	var header *types.Header
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		header, err = client.HeaderByNumber(ctx, number)
+		return
+	})
+	return header, err
+}
+
+func (fc *failoverClient) TransactionCount(
+	ctx context.Context,
+	blockHash common.Hash,
+) (uint, error) {
+	var count uint
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		count, err = client.TransactionCount(ctx, blockHash)
+		return
+	})
+	return count, err
+}
+
+func (fc *failoverClient) TransactionInBlock(
+	ctx context.Context,
+	blockHash common.Hash,
+	index uint,
+) (*types.Transaction, error) {
+	var transaction *types.Transaction
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		transaction, err = client.TransactionInBlock(ctx, blockHash, index)
+		return
+	})
+	return transaction, err
+}
+
+func (fc *failoverClient) TransactionByHash(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Transaction, bool, error) {
+	var transaction *types.Transaction
+	var isPending bool
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		transaction, isPending, err = client.TransactionByHash(ctx, txHash)
+		return
+	})
+	return transaction, isPending, err
+}
+
+func (fc *failoverClient) TransactionReceipt(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		return
+	})
+	return receipt, err
+}
+
+func (fc *failoverClient) BalanceAt(
+	ctx context.Context,
+	account common.Address,
+	blockNumber *big.Int,
+) (*big.Int, error) {
+	var balance *big.Int
+	err := fc.do(func(client ethutil.EthereumClient) (err error) {
+		balance, err = client.BalanceAt(ctx, account, blockNumber)
+		return
+	})
+	return balance, err
+}
@@ -0,0 +1,440 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// subscriptionRequestTimeout is the timeout of a request establishing
+	// a subscription with an endpoint.
+	subscriptionRequestTimeout = 10 * time.Second
+
+	// resubscriptionBackoffMin and resubscriptionBackoffMax are the bounds
+	// of the exponential backoff between attempts to establish a subscription
+	// with any endpoint.
+	resubscriptionBackoffMin = 1 * time.Second
+	resubscriptionBackoffMax = 30 * time.Second
+)
+
+// subscribeFn establishes a subscription with the given endpoint. The
+// channel is closed when the failover subscription is unsubscribed.
+type subscribeFn func(
+	ctx context.Context,
+	e *endpoint,
+	unsubscribed <-chan struct{},
+) (ethereum.Subscription, error)
+
+// failoverSubscription is a subscription kept established with the active
+// endpoint of the failover client. When the subscription with an endpoint
+// fails or another endpoint becomes active, the subscription is established
+// again with the active endpoint. The subscription never fails on its own,
+// so its error channel is only closed when it is unsubscribed.
+type failoverSubscription struct {
+	client    *failoverClient
+	subscribe subscribeFn
+
+	unsubscribeOnce sync.Once
+	unsubscribed    chan struct{}
+	done            chan struct{}
+	err             chan error
+}
+
+// newFailoverSubscription establishes the subscription with the first
+// candidate endpoint accepting it and keeps it established in the background.
+// The context is used only to establish the first subscription.
+func newFailoverSubscription(
+	ctx context.Context,
+	client *failoverClient,
+	subscribe subscribeFn,
+) (*failoverSubscription, error) {
+	fs := &failoverSubscription{
+		client:       client,
+		subscribe:    subscribe,
+		unsubscribed: make(chan struct{}),
+		done:         make(chan struct{}),
+		err:          make(chan error),
+	}
+
+	e, subscription, switched, err := fs.trySubscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	go fs.run(e, subscription, switched)
+
+	return fs, nil
+}
+
+// trySubscribe tries to establish the subscription with candidate endpoints
+// of the failover client, one by one. Along with the subscription, it returns
+// the channel notifying about the active endpoint switch obtained before
+// subscribing, so that no switch happening meanwhile is missed.
+func (fs *failoverSubscription) trySubscribe(ctx context.Context) (
+	*endpoint,
+	ethereum.Subscription,
+	<-chan struct{},
+	error,
+) {
+	err := fmt.Errorf("no Ethereum endpoint is connected")
+	switched := fs.client.switchNotification()
+
+	for _, e := range fs.client.candidates() {
+		var subscription ethereum.Subscription
+		subscription, err = fs.subscribeWithTimeout(ctx, e)
+		if err == nil {
+			e.recordOutcome(false)
+			return e, subscription, switched, nil
+		}
+
+		logger.Warningf(
+			"could not subscribe with Ethereum endpoint [%v]: [%v]",
+			e.url,
+			err,
+		)
+		if isEndpointError(err) {
+			e.recordOutcome(true)
+		}
+	}
+
+	return nil, nil, nil, err
+}
+
+func (fs *failoverSubscription) run(
+	e *endpoint,
+	subscription ethereum.Subscription,
+	switched <-chan struct{},
+) {
+	defer close(fs.done)
+	defer close(fs.err)
+
+	for {
+		select {
+		case err := <-subscription.Err():
+			logger.Warningf(
+				"subscription with Ethereum endpoint [%v] failed: [%v]",
+				e.url,
+				err,
+			)
+			e.recordOutcome(true)
+			fs.client.selectActive()
+		case <-switched:
+			switched = fs.client.switchNotification()
+			if fs.client.activeEndpoint() == e {
+				continue
+			}
+		case <-fs.unsubscribed:
+			subscription.Unsubscribe()
+			return
+		}
+
+		subscription.Unsubscribe()
+
+		var ok bool
+		e, subscription, switched, ok = fs.resubscribe()
+		if !ok {
+			return
+		}
+
+		logger.Infof("subscription moved to Ethereum endpoint [%v]", e.url)
+	}
+}
+
+// resubscribe tries to establish the subscription with any endpoint, with
+// a backoff between attempts, until it succeeds or the subscription is
+// unsubscribed.
+func (fs *failoverSubscription) resubscribe() (
+	*endpoint,
+	ethereum.Subscription,
+	<-chan struct{},
+	bool,
+) {
+	backoff := resubscriptionBackoffMin
+
+	for {
+		select {
+		case <-fs.unsubscribed:
+			return nil, nil, nil, false
+		default:
+		}
+
+		e, subscription, switched, err := fs.trySubscribe(context.Background())
+		if err == nil {
+			return e, subscription, switched, true
+		}
+
+		logger.Errorf(
+			"could not subscribe with any Ethereum endpoint; "+
+				"retrying in [%v]: [%v]",
+			backoff,
+			err,
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-fs.unsubscribed:
+			return nil, nil, nil, false
+		}
+
+		backoff *= 2
+		if backoff > resubscriptionBackoffMax {
+			backoff = resubscriptionBackoffMax
+		}
+	}
+}
+
+func (fs *failoverSubscription) subscribeWithTimeout(
+	ctx context.Context,
+	e *endpoint,
+) (ethereum.Subscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, subscriptionRequestTimeout)
+	defer cancel()
+
+	return fs.subscribe(ctx, e, fs.unsubscribed)
+}
+
+func (fs *failoverSubscription) Unsubscribe() {
+	fs.unsubscribeOnce.Do(func() {
+		close(fs.unsubscribed)
+	})
+	<-fs.done
+}
+
+func (fs *failoverSubscription) Err() <-chan error {
+	return fs.err
+}
+
+// SubscribeNewHead subscribes to new heads of the active endpoint. Heads
+// emitted while the subscription is moved to another endpoint are not
+// delivered.
+func (fc *failoverClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (ethereum.Subscription, error) {
+	return newFailoverSubscription(
+		ctx,
+		fc,
+		func(
+			ctx context.Context,
+			e *endpoint,
+			unsubscribed <-chan struct{},
+		) (ethereum.Subscription, error) {
+			return e.connectedClient().SubscribeNewHead(ctx, ch)
+		},
+	)
+}
+
+// SubscribeFilterLogs subscribes to logs matching the query emitted on the
+// chain seen by the active endpoint. When the subscription is moved to
+// another endpoint, logs emitted since the block of the last delivered log
+// are read from the new endpoint and delivered before any new log, so no log
+// is missed. Logs already delivered are not delivered again.
+func (fc *failoverClient) SubscribeFilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	ls := &logsSubscription{
+		query:     query,
+		sink:      ch,
+		delivered: make(map[logID]bool),
+	}
+
+	subscribed := false
+	return newFailoverSubscription(
+		ctx,
+		fc,
+		func(
+			ctx context.Context,
+			e *endpoint,
+			unsubscribed <-chan struct{},
+		) (ethereum.Subscription, error) {
+			client := e.connectedClient()
+
+			// Logs are received in a separate channel and passed to the
+			// subscriber only once the logs emitted while moving between
+			// endpoints are delivered.
+			logs := make(chan types.Log, cap(ch))
+			subscription, err := client.SubscribeFilterLogs(ctx, query, logs)
+			if err != nil {
+				return nil, err
+			}
+
+			if !subscribed {
+				subscribed = true
+				ls.start(e.lastHead())
+			} else if err := ls.backfill(ctx, client, unsubscribed); err != nil {
+				subscription.Unsubscribe()
+				return nil, err
+			}
+
+			return ls.forward(subscription, logs, unsubscribed), nil
+		},
+	)
+}
+
+// logID identifies a log in the chain.
+type logID struct {
+	blockHash common.Hash
+	index     uint
+	removed   bool
+}
+
+// logsSubscription delivers logs received from subscriptions with all the
+// endpoints and logs read when moving between endpoints to the subscriber,
+// skipping the logs already delivered.
+type logsSubscription struct {
+	query ethereum.FilterQuery
+	sink  chan<- types.Log
+
+	mutex sync.Mutex
+	// lastBlock is the block of the last delivered log or the head of the
+	// chain when the subscription was established; logs are read from it
+	// when moving to another endpoint
+	lastBlock uint64
+	// delivered holds logs delivered from the last block
+	delivered map[logID]bool
+}
+
+func (ls *logsSubscription) start(head uint64) {
+	ls.mutex.Lock()
+	defer ls.mutex.Unlock()
+
+	ls.lastBlock = head
+	if ls.query.FromBlock != nil && ls.query.FromBlock.Uint64() > head {
+		ls.lastBlock = ls.query.FromBlock.Uint64()
+	}
+}
+
+// backfill reads logs emitted since the last block from the given client and
+// delivers them to the subscriber.
+func (ls *logsSubscription) backfill(
+	ctx context.Context,
+	client logFilterer,
+	unsubscribed <-chan struct{},
+) error {
+	ls.mutex.Lock()
+	fromBlock := ls.lastBlock
+	ls.mutex.Unlock()
+
+	query := ls.query
+	query.FromBlock = new(big.Int).SetUint64(fromBlock)
+	if query.ToBlock != nil && query.ToBlock.Uint64() < fromBlock {
+		return nil
+	}
+
+	logs, err := client.FilterLogs(ctx, query)
+	if err != nil {
+		return fmt.Errorf(
+			"could not read logs since block [%v]: [%v]",
+			fromBlock,
+			err,
+		)
+	}
+
+	if len(logs) > 0 {
+		logger.Infof(
+			"read [%v] logs since block [%v] after moving subscription",
+			len(logs),
+			fromBlock,
+		)
+	}
+
+	for _, log := range logs {
+		if !ls.deliver(log, unsubscribed) {
+			return fmt.Errorf("subscription has been unsubscribed")
+		}
+	}
+
+	return nil
+}
+
+// forward delivers logs received by the given subscription to the subscriber
+// until the returned subscription is unsubscribed.
+func (ls *logsSubscription) forward(
+	subscription ethereum.Subscription,
+	logs <-chan types.Log,
+	unsubscribed <-chan struct{},
+) ethereum.Subscription {
+	fs := &forwardingSubscription{
+		Subscription: subscription,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	go func() {
+		defer close(fs.done)
+
+		for {
+			select {
+			case log := <-logs:
+				if !ls.deliver(log, fs.stop) {
+					return
+				}
+			case <-fs.stop:
+				return
+			case <-unsubscribed:
+				return
+			}
+		}
+	}()
+
+	return fs
+}
+
+// deliver passes the log to the subscriber unless it has already been
+// delivered. It returns false if the log could not be delivered before the
+// given channel was closed.
+func (ls *logsSubscription) deliver(
+	log types.Log,
+	stop <-chan struct{},
+) bool {
+	ls.mutex.Lock()
+	defer ls.mutex.Unlock()
+
+	id := logID{log.BlockHash, log.Index, log.Removed}
+	if ls.delivered[id] {
+		return true
+	}
+
+	select {
+	case ls.sink <- log:
+	case <-stop:
+		return false
+	}
+
+	if log.BlockNumber > ls.lastBlock {
+		ls.lastBlock = log.BlockNumber
+		// logs from blocks before the last block are never read again
+		ls.delivered = make(map[logID]bool)
+	}
+	ls.delivered[id] = true
+
+	return true
+}
+
+// forwardingSubscription is a subscription with an endpoint whose logs are
+// forwarded to the subscriber. Unsubscribing waits until forwarding stops.
+type forwardingSubscription struct {
+	ethereum.Subscription
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func (fs *forwardingSubscription) Unsubscribe() {
+	fs.Subscription.Unsubscribe()
+	close(fs.stop)
+	<-fs.done
+}
+
+type logFilterer interface {
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
@@ -0,0 +1,563 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+func TestFailoverClientFailsOverRequests(t *testing.T) {
+	primary := newFakeBackend(10)
+	secondary := newFakeBackend(10)
+	secondary.nonce = 7
+
+	client := newTestFailoverClient(t, primary, secondary)
+
+	primary.setDown(true)
+
+	// The primary endpoint is switched once its error rate exceeds the
+	// limit; all requests are served by the secondary endpoint meanwhile.
+	for i := 0; i < 3; i++ {
+		nonce, err := client.PendingNonceAt(
+			context.Background(),
+			common.Address{},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nonce != 7 {
+			t.Errorf(
+				"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+				7,
+				nonce,
+			)
+		}
+	}
+
+	if client.activeEndpoint().url != "secondary" {
+		t.Errorf(
+			"unexpected active endpoint\nexpected: [%v]\nactual:   [%v]",
+			"secondary",
+			client.activeEndpoint().url,
+		)
+	}
+
+	err := client.SendTransaction(context.Background(), types.NewTransaction(
+		1, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if primary.sentTransactions() != 0 || secondary.sentTransactions() != 1 {
+		t.Errorf(
+			"unexpected transactions sent\nprimary:   [%v]\nsecondary: [%v]",
+			primary.sentTransactions(),
+			secondary.sentTransactions(),
+		)
+	}
+}
+
+func TestFailoverClientDoesNotFailOverNodeErrors(t *testing.T) {
+	primary := newFakeBackend(10)
+	secondary := newFakeBackend(10)
+
+	client := newTestFailoverClient(t, primary, secondary)
+
+	_, err := client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	if err != errExecutionReverted {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			errExecutionReverted,
+			err,
+		)
+	}
+
+	if primary.contractCalls() != 1 || secondary.contractCalls() != 0 {
+		t.Errorf(
+			"unexpected contract calls\nprimary:   [%v]\nsecondary: [%v]",
+			primary.contractCalls(),
+			secondary.contractCalls(),
+		)
+	}
+}
+
+func TestFailoverClientSwitchesOnHeadLag(t *testing.T) {
+	primary := newFakeBackend(10)
+	secondary := newFakeBackend(10)
+
+	client := newTestFailoverClient(t, primary, secondary)
+
+	var tests = []struct {
+		primaryHead            uint64
+		secondaryHead          uint64
+		expectedActiveEndpoint string
+	}{
+		{10, 13, "primary"},   // lag within the limit
+		{10, 14, "secondary"}, // primary stalled
+		{14, 15, "primary"},   // primary caught up
+	}
+
+	for _, test := range tests {
+		primary.setHead(test.primaryHead)
+		secondary.setHead(test.secondaryHead)
+
+		client.checkHealth()
+
+		if client.activeEndpoint().url != test.expectedActiveEndpoint {
+			t.Errorf(
+				"unexpected active endpoint for heads [%v] and [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				test.primaryHead,
+				test.secondaryHead,
+				test.expectedActiveEndpoint,
+				client.activeEndpoint().url,
+			)
+		}
+	}
+}
+
+func TestFailoverClientMovesSubscriptions(t *testing.T) {
+	var tests = map[string]struct {
+		breakPrimary func(primary *fakeBackend, client *failoverClient)
+	}{
+		"primary stalled": {
+			breakPrimary: func(primary *fakeBackend, client *failoverClient) {
+				// the primary head stays behind the secondary one
+				client.checkHealth()
+			},
+		},
+		"primary subscription dropped": {
+			breakPrimary: func(primary *fakeBackend, client *failoverClient) {
+				primary.setDown(true)
+				primary.failSubscriptions()
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			primary := newFakeBackend(10)
+			secondary := newFakeBackend(10)
+
+			client := newTestFailoverClient(t, primary, secondary)
+
+			logs := make(chan types.Log, 10)
+			logsSubscription, err := client.SubscribeFilterLogs(
+				context.Background(),
+				ethereum.FilterQuery{},
+				logs,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer logsSubscription.Unsubscribe()
+
+			headers := make(chan *types.Header, 10)
+			headersSubscription, err := client.SubscribeNewHead(
+				context.Background(),
+				headers,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer headersSubscription.Unsubscribe()
+
+			log10 := testLog(10, 0)
+			primary.emitLog(log10)
+			secondary.addLog(log10)
+
+			// logs emitted while the primary is falling behind
+			log12 := testLog(12, 0)
+			log15 := testLog(15, 1)
+			secondary.addLog(log12)
+			secondary.addLog(log15)
+			secondary.setHead(15)
+
+			test.breakPrimary(primary, client)
+
+			waitForSubscriptions(t, secondary, 2)
+
+			log16 := testLog(16, 0)
+			secondary.emitLog(log16)
+			secondary.emitHeader(16)
+
+			expectedLogs := []types.Log{log10, log12, log15, log16}
+			actualLogs := make([]types.Log, 0)
+			for range expectedLogs {
+				select {
+				case log := <-logs:
+					actualLogs = append(actualLogs, log)
+				case <-time.After(5 * time.Second):
+					t.Fatalf("timed out waiting for logs: [%v]", actualLogs)
+				}
+			}
+			if !reflect.DeepEqual(expectedLogs, actualLogs) {
+				t.Errorf(
+					"unexpected logs\nexpected: [%v]\nactual:   [%v]",
+					expectedLogs,
+					actualLogs,
+				)
+			}
+
+			select {
+			case log := <-logs:
+				t.Errorf("unexpected log delivered: [%v]", log)
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			select {
+			case header := <-headers:
+				if header.Number.Uint64() != 16 {
+					t.Errorf(
+						"unexpected header\nexpected: [%v]\nactual:   [%v]",
+						16,
+						header.Number,
+					)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for header")
+			}
+		})
+	}
+}
+
+func newTestFailoverClient(
+	t *testing.T,
+	primary *fakeBackend,
+	secondary *fakeBackend,
+) *failoverClient {
+	endpoints := []*endpoint{
+		primary.endpoint("primary", 0),
+		secondary.endpoint("secondary", 1),
+	}
+
+	client, err := newFailoverClient(FailoverConfig{}, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.activeEndpoint().url != "primary" {
+		t.Fatalf(
+			"unexpected active endpoint\nexpected: [%v]\nactual:   [%v]",
+			"primary",
+			client.activeEndpoint().url,
+		)
+	}
+
+	return client
+}
+
+func waitForSubscriptions(
+	t *testing.T,
+	backend *fakeBackend,
+	count int,
+) {
+	deadline := time.Now().Add(5 * time.Second)
+	for backend.activeSubscriptions() < count {
+		if time.Now().After(deadline) {
+			t.Fatalf(
+				"timed out waiting for [%v] subscriptions; has [%v]",
+				count,
+				backend.activeSubscriptions(),
+			)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func testLog(blockNumber uint64, index uint) types.Log {
+	return types.Log{
+		BlockNumber: blockNumber,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		TxHash:      common.BigToHash(big.NewInt(int64(index) + 1)),
+		Index:       index,
+	}
+}
+
+var (
+	errConnectionRefused = fmt.Errorf("connection refused")
+	errExecutionReverted = &fakeNodeError{"execution reverted"}
+)
+
+type fakeNodeError struct {
+	message string
+}
+
+func (fne *fakeNodeError) Error() string {
+	return fne.message
+}
+
+func (fne *fakeNodeError) ErrorCode() int {
+	return 3
+}
+
+// fakeBackend is an Ethereum node answering only the requests used by the
+// tests. All other requests panic.
+type fakeBackend struct {
+	ethutil.EthereumClient
+
+	mutex             sync.Mutex
+	down              bool
+	head              uint64
+	nonce             uint64
+	logs              []types.Log
+	logSinks          map[*fakeSubscription]chan<- types.Log
+	headerSinks       map[*fakeSubscription]chan<- *types.Header
+	transactions      int
+	contractCallCount int
+}
+
+func newFakeBackend(head uint64) *fakeBackend {
+	return &fakeBackend{
+		head:        head,
+		logSinks:    make(map[*fakeSubscription]chan<- types.Log),
+		headerSinks: make(map[*fakeSubscription]chan<- *types.Header),
+	}
+}
+
+func (fb *fakeBackend) endpoint(url string, priority int) *endpoint {
+	return &endpoint{
+		url:      url,
+		priority: priority,
+		connect: func() (ethutil.EthereumClient, error) {
+			if fb.isDown() {
+				return nil, errConnectionRefused
+			}
+			return fb, nil
+		},
+	}
+}
+
+func (fb *fakeBackend) isDown() bool {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	return fb.down
+}
+
+func (fb *fakeBackend) setDown(down bool) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	fb.down = down
+}
+
+func (fb *fakeBackend) setHead(head uint64) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	fb.head = head
+}
+
+func (fb *fakeBackend) sentTransactions() int {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	return fb.transactions
+}
+
+func (fb *fakeBackend) contractCalls() int {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	return fb.contractCallCount
+}
+
+func (fb *fakeBackend) activeSubscriptions() int {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	return len(fb.logSinks) + len(fb.headerSinks)
+}
+
+// addLog adds the log to the chain without notifying subscribers.
+func (fb *fakeBackend) addLog(log types.Log) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	fb.logs = append(fb.logs, log)
+}
+
+// emitLog adds the log to the chain and notifies subscribers.
+func (fb *fakeBackend) emitLog(log types.Log) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	fb.logs = append(fb.logs, log)
+	for _, sink := range fb.logSinks {
+		sink <- log
+	}
+}
+
+func (fb *fakeBackend) emitHeader(number uint64) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	fb.head = number
+	for _, sink := range fb.headerSinks {
+		sink <- &types.Header{Number: new(big.Int).SetUint64(number)}
+	}
+}
+
+func (fb *fakeBackend) failSubscriptions() {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	for subscription := range fb.logSinks {
+		subscription.err <- errConnectionRefused
+	}
+	for subscription := range fb.headerSinks {
+		subscription.err <- errConnectionRefused
+	}
+}
+
+func (fb *fakeBackend) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	if fb.down {
+		return nil, errConnectionRefused
+	}
+
+	return &types.Header{Number: new(big.Int).SetUint64(fb.head)}, nil
+}
+
+func (fb *fakeBackend) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	if fb.down {
+		return 0, errConnectionRefused
+	}
+
+	return fb.nonce, nil
+}
+
+func (fb *fakeBackend) CallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	if fb.down {
+		return nil, errConnectionRefused
+	}
+
+	fb.contractCallCount++
+	return nil, errExecutionReverted
+}
+
+func (fb *fakeBackend) SendTransaction(
+	ctx context.Context,
+	tx *types.Transaction,
+) error {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	if fb.down {
+		return errConnectionRefused
+	}
+
+	fb.transactions++
+	return nil
+}
+
+func (fb *fakeBackend) FilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+) ([]types.Log, error) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	if fb.down {
+		return nil, errConnectionRefused
+	}
+
+	logs := make([]types.Log, 0)
+	for _, log := range fb.logs {
+		if query.FromBlock != nil && log.BlockNumber < query.FromBlock.Uint64() {
+			continue
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
+
+func (fb *fakeBackend) SubscribeFilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	if fb.down {
+		return nil, errConnectionRefused
+	}
+
+	subscription := fb.newSubscription()
+	fb.logSinks[subscription] = ch
+
+	return subscription, nil
+}
+
+func (fb *fakeBackend) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (ethereum.Subscription, error) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+
+	if fb.down {
+		return nil, errConnectionRefused
+	}
+
+	subscription := fb.newSubscription()
+	fb.headerSinks[subscription] = ch
+
+	return subscription, nil
+}
+
+func (fb *fakeBackend) newSubscription() *fakeSubscription {
+	subscription := &fakeSubscription{err: make(chan error, 1)}
+	subscription.unsubscribe = func() {
+		fb.mutex.Lock()
+		defer fb.mutex.Unlock()
+
+		delete(fb.logSinks, subscription)
+		delete(fb.headerSinks, subscription)
+	}
+
+	return subscription
+}
+
+type fakeSubscription struct {
+	err         chan error
+	unsubscribe func()
+}
+
+func (fs *fakeSubscription) Unsubscribe() {
+	fs.unsubscribe()
+}
+
+func (fs *fakeSubscription) Err() <-chan error {
+	return fs.err
+}
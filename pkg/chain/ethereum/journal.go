@@ -0,0 +1,799 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+	"github.com/keep-network/keep-core/pkg/persistence/layout"
+)
+
+// transactionJournalRetention is the time for which transactions which are no
+// longer pending are kept in the transaction journal before they are moved to
+// the archive.
+const transactionJournalRetention = 7 * 24 * time.Hour
+
+// transactionJournalArchiveInterval determines how often transactions whose
+// retention time is over are moved to the archive.
+const transactionJournalArchiveInterval = time.Hour
+
+// TransactionPurpose describes why a transaction has been submitted.
+type TransactionPurpose string
+
+const (
+	// PurposeTicket is a group selection ticket submission.
+	PurposeTicket TransactionPurpose = "ticket"
+	// PurposeDKGResult is a DKG result submission.
+	PurposeDKGResult TransactionPurpose = "dkg_result"
+	// PurposeRelayEntry is a relay entry submission.
+	PurposeRelayEntry TransactionPurpose = "relay_entry"
+	// PurposeTimeoutReport is a relay entry timeout report.
+	PurposeTimeoutReport TransactionPurpose = "timeout_report"
+	// PurposeOther is any other transaction.
+	PurposeOther TransactionPurpose = "other"
+)
+
+// TransactionStatus is the status of a journaled transaction.
+type TransactionStatus string
+
+const (
+	// StatusPending is the status of a transaction submitted to the chain
+	// and not mined yet.
+	StatusPending TransactionStatus = "pending"
+	// StatusMined is the status of a transaction mined successfully.
+	StatusMined TransactionStatus = "mined"
+	// StatusReverted is the status of a transaction mined and reverted.
+	StatusReverted TransactionStatus = "reverted"
+	// StatusReplaced is the status of a transaction whose nonce has been used
+	// by another transaction, usually a resubmission with a higher gas price.
+	StatusReplaced TransactionStatus = "replaced"
+	// StatusRejected is the status of a transaction rejected by the Ethereum
+	// node on submission.
+	StatusRejected TransactionStatus = "rejected"
+)
+
+// JournalEntry is a transaction recorded in the transaction journal.
+type JournalEntry struct {
+	Hash        common.Hash        `json:"hash"`
+	From        common.Address     `json:"from"`
+	To          *common.Address    `json:"to,omitempty"`
+	Nonce       uint64             `json:"nonce"`
+	Purpose     TransactionPurpose `json:"purpose"`
+	GasLimit    uint64             `json:"gasLimit"`
+	GasPrice    *big.Int           `json:"gasPrice"`
+	Status      TransactionStatus  `json:"status"`
+	SubmittedAt time.Time          `json:"submittedAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	// Transaction is the signed transaction, kept so that it can be
+	// submitted again if the Ethereum node lost it.
+	Transaction hexutil.Bytes `json:"transaction"`
+}
+
+// TransactionJournal records every transaction submitted by the operator
+// along with its status in the operator's persistence storage. Each change
+// is saved as a separate record before the transaction is submitted, so that
+// the journal survives a crash and a record cut in the middle of writing
+// does not damage records saved before.
+//
+// Records are saved in the directory of the current generation of the journal.
+// Transactions which are no longer pending are kept in the journal for
+// the retention time. Once it is over for any of them, records of the rest
+// are saved in the directory of a new generation and directories of previous
+// generations are moved to the archive.
+type TransactionJournal struct {
+	mutex   sync.Mutex
+	handle  persistence.Handle
+	entries []*JournalEntry
+	byHash  map[common.Hash]*JournalEntry
+
+	// generation is the generation of the journal whose directory records
+	// are saved in.
+	generation uint64
+	// previousGenerations are generations of the journal whose directories
+	// have not been moved to the archive yet.
+	previousGenerations map[uint64]bool
+}
+
+// OpenTransactionJournal opens the transaction journal kept in the given
+// persistence storage.
+func OpenTransactionJournal(
+	handle persistence.Handle,
+) (*TransactionJournal, error) {
+	journal := &TransactionJournal{
+		handle:              handle,
+		byHash:              make(map[common.Hash]*JournalEntry),
+		previousGenerations: make(map[uint64]bool),
+	}
+
+	if err := journal.load(); err != nil {
+		return nil, err
+	}
+
+	return journal, nil
+}
+
+// ReadTransactionJournal reads entries of the transaction journal kept in
+// the given persistence storage in the order of submission. Transactions moved
+// to the archive are not read.
+func ReadTransactionJournal(handle persistence.Handle) ([]*JournalEntry, error) {
+	journal, err := OpenTransactionJournal(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return journal.Entries(), nil
+}
+
+// load reads all records from the persistence storage and continues saving
+// records in the directory of the latest generation read. Of records of the
+// same transaction, the last updated one wins. Records which could not be
+// read are skipped.
+func (tj *TransactionJournal) load() error {
+	descriptorsChannel, errorsChannel := tj.handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels for the same
+	// reason as when loading existing groups; channels are not buffered and
+	// we do not know in what order information is written to them.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	generations := make(map[uint64]bool)
+	go func() {
+		for descriptor := range descriptorsChannel {
+			generation, ok := layout.TransactionJournalGeneration(
+				descriptor.Directory(),
+			)
+			if !ok {
+				continue
+			}
+
+			generations[generation] = true
+			tj.loadRecord(descriptor)
+		}
+
+		wg.Done()
+	}()
+
+	var readErrors []error
+	go func() {
+		for err := range errorsChannel {
+			readErrors = append(readErrors, err)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	if len(readErrors) > 0 {
+		return fmt.Errorf(
+			"could not read transaction journal: [%v]",
+			readErrors[0],
+		)
+	}
+
+	for generation := range generations {
+		if generation > tj.generation {
+			tj.generation = generation
+		}
+	}
+	for generation := range generations {
+		if generation != tj.generation {
+			tj.previousGenerations[generation] = true
+		}
+	}
+
+	sort.SliceStable(tj.entries, func(i, j int) bool {
+		if tj.entries[i].SubmittedAt.Equal(tj.entries[j].SubmittedAt) {
+			return tj.entries[i].Nonce < tj.entries[j].Nonce
+		}
+		return tj.entries[i].SubmittedAt.Before(tj.entries[j].SubmittedAt)
+	})
+
+	return nil
+}
+
+func (tj *TransactionJournal) loadRecord(descriptor persistence.DataDescriptor) {
+	content, err := descriptor.Content()
+	if err != nil {
+		logger.Warningf(
+			"skipping unreadable transaction journal record [%v]: [%v]",
+			descriptor.Name(),
+			err,
+		)
+		return
+	}
+
+	entry := &JournalEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		logger.Warningf(
+			"skipping malformed transaction journal record [%v]: [%v]",
+			descriptor.Name(),
+			err,
+		)
+		return
+	}
+
+	existing, ok := tj.byHash[entry.Hash]
+	if !ok {
+		tj.entries = append(tj.entries, entry)
+		tj.byHash[entry.Hash] = entry
+		return
+	}
+
+	if entry.UpdatedAt.After(existing.UpdatedAt) ||
+		(entry.UpdatedAt.Equal(existing.UpdatedAt) &&
+			existing.Status == StatusPending) {
+		*existing = *entry
+	}
+}
+
+// Entries returns copies of all journal entries in the order of submission.
+func (tj *TransactionJournal) Entries() []*JournalEntry {
+	tj.mutex.Lock()
+	defer tj.mutex.Unlock()
+
+	entries := make([]*JournalEntry, len(tj.entries))
+	for i, entry := range tj.entries {
+		entryCopy := *entry
+		entries[i] = &entryCopy
+	}
+
+	return entries
+}
+
+// recordSubmission records the transaction about to be submitted as pending.
+func (tj *TransactionJournal) recordSubmission(
+	transaction *types.Transaction,
+	purpose TransactionPurpose,
+) error {
+	from, err := transactionSender(transaction)
+	if err != nil {
+		return fmt.Errorf("could not resolve transaction sender: [%v]", err)
+	}
+
+	encodedTransaction, err := rlp.EncodeToBytes(transaction)
+	if err != nil {
+		return fmt.Errorf("could not encode transaction: [%v]", err)
+	}
+
+	now := time.Now()
+
+	tj.mutex.Lock()
+	defer tj.mutex.Unlock()
+
+	if _, ok := tj.byHash[transaction.Hash()]; ok {
+		// the same transaction is submitted again
+		return nil
+	}
+
+	entry := &JournalEntry{
+		Hash:        transaction.Hash(),
+		From:        from,
+		To:          transaction.To(),
+		Nonce:       transaction.Nonce(),
+		Purpose:     purpose,
+		GasLimit:    transaction.Gas(),
+		GasPrice:    transaction.GasPrice(),
+		Status:      StatusPending,
+		SubmittedAt: now,
+		UpdatedAt:   now,
+		Transaction: encodedTransaction,
+	}
+
+	if err := tj.append(entry); err != nil {
+		return err
+	}
+
+	tj.entries = append(tj.entries, entry)
+	tj.byHash[entry.Hash] = entry
+
+	return nil
+}
+
+// updateStatus records the new status of the journaled transaction. When
+// the transaction is mined, other pending transactions of the same account
+// with the same nonce are recorded as replaced. Transactions not present in
+// the journal are ignored.
+func (tj *TransactionJournal) updateStatus(
+	hash common.Hash,
+	status TransactionStatus,
+) error {
+	tj.mutex.Lock()
+	defer tj.mutex.Unlock()
+
+	entry, ok := tj.byHash[hash]
+	if !ok {
+		return nil
+	}
+
+	if err := tj.setStatus(entry, status); err != nil {
+		return err
+	}
+
+	if status != StatusMined && status != StatusReverted {
+		return nil
+	}
+
+	for _, other := range tj.entries {
+		if other.Hash != entry.Hash &&
+			other.From == entry.From &&
+			other.Nonce == entry.Nonce &&
+			other.Status == StatusPending {
+			if err := tj.setStatus(other, StatusReplaced); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (tj *TransactionJournal) setStatus(
+	entry *JournalEntry,
+	status TransactionStatus,
+) error {
+	if entry.Status == status {
+		return nil
+	}
+
+	updated := *entry
+	updated.Status = status
+	updated.UpdatedAt = time.Now()
+
+	if err := tj.append(&updated); err != nil {
+		return err
+	}
+
+	*entry = updated
+
+	return nil
+}
+
+// append saves the record in the persistence storage. Each status of the
+// transaction is saved in a separate file so that the records saved before
+// are not overwritten.
+func (tj *TransactionJournal) append(entry *JournalEntry) error {
+	record, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not marshal journal record: [%v]", err)
+	}
+
+	err = tj.handle.Save(
+		record,
+		layout.TransactionJournalGenerationDirectory(tj.generation),
+		fmt.Sprintf("/%v_%v", entry.Hash.Hex(), entry.Status),
+	)
+	if err != nil {
+		return fmt.Errorf("could not save journal record: [%v]", err)
+	}
+
+	return nil
+}
+
+// ArchiveConfirmed moves transactions which are no longer pending and whose
+// status has not changed for the retention time preceding the given time out
+// of the journal. Records of the rest of transactions are saved in
+// the directory of a new generation of the journal and only then directories
+// of previous generations are moved to the archive, so no record is lost if
+// the client crashes in the meantime.
+func (tj *TransactionJournal) ArchiveConfirmed(now time.Time) error {
+	tj.mutex.Lock()
+	defer tj.mutex.Unlock()
+
+	retained := make([]*JournalEntry, 0, len(tj.entries))
+	for _, entry := range tj.entries {
+		if entry.Status == StatusPending ||
+			now.Sub(entry.UpdatedAt) <= transactionJournalRetention {
+			retained = append(retained, entry)
+		}
+	}
+
+	archivedCount := len(tj.entries) - len(retained)
+	if archivedCount == 0 && len(tj.previousGenerations) == 0 {
+		return nil
+	}
+
+	if archivedCount > 0 {
+		tj.generation++
+		for _, entry := range retained {
+			if err := tj.append(entry); err != nil {
+				// Records already saved in the new generation directory are
+				// saved there again in the next attempt.
+				tj.generation--
+				return fmt.Errorf(
+					"could not save records of the new journal "+
+						"generation: [%v]",
+					err,
+				)
+			}
+		}
+
+		tj.previousGenerations[tj.generation-1] = true
+
+		tj.entries = retained
+		tj.byHash = make(map[common.Hash]*JournalEntry, len(retained))
+		for _, entry := range retained {
+			tj.byHash[entry.Hash] = entry
+		}
+
+		logger.Infof(
+			"moving [%v] confirmed transactions out of the journal",
+			archivedCount,
+		)
+	}
+
+	for generation := range tj.previousGenerations {
+		err := tj.handle.Archive(
+			layout.TransactionJournalGenerationDirectory(generation),
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not archive generation [%v] of the journal: [%v]",
+				generation,
+				err,
+			)
+		}
+
+		delete(tj.previousGenerations, generation)
+	}
+
+	return nil
+}
+
+// journalReconciler is the part of the Ethereum client used to reconcile
+// the journal with the chain.
+type journalReconciler interface {
+	ethereum.TransactionReader
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// Reconcile updates the status of pending transactions in the journal with
+// their state on the chain. Transactions which are mined are recorded as
+// mined or reverted. Transactions whose nonce has been used by another
+// transaction are recorded as replaced. Transactions which are unknown to
+// the Ethereum node and whose nonce has not been used yet are submitted again
+// so that later transactions of the account are not blocked by a nonce gap
+// and their nonces are not reused. Of transactions with the same nonce, only
+// the latest submission is submitted again.
+func (tj *TransactionJournal) Reconcile(
+	ctx context.Context,
+	client journalReconciler,
+) error {
+	pending := make([]*JournalEntry, 0)
+	for _, entry := range tj.Entries() {
+		if entry.Status == StatusPending {
+			pending = append(pending, entry)
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	logger.Infof(
+		"reconciling [%v] pending transactions from the journal",
+		len(pending),
+	)
+
+	unknown := make([]*JournalEntry, 0)
+	for _, entry := range pending {
+		receipt, err := client.TransactionReceipt(ctx, entry.Hash)
+		if err == nil && receipt != nil {
+			if err := tj.updateStatus(entry.Hash, receiptStatus(receipt)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil && err != ethereum.NotFound {
+			return fmt.Errorf(
+				"could not get receipt of transaction [%v]: [%v]",
+				entry.Hash.Hex(),
+				err,
+			)
+		}
+
+		_, _, err = client.TransactionByHash(ctx, entry.Hash)
+		if err == nil {
+			// still waiting to be mined
+			continue
+		}
+		if err != ethereum.NotFound {
+			return fmt.Errorf(
+				"could not get transaction [%v]: [%v]",
+				entry.Hash.Hex(),
+				err,
+			)
+		}
+
+		unknown = append(unknown, entry)
+	}
+
+	// The latest submission of each nonce is submitted again in the order of
+	// nonces so that none of them is rejected because of a nonce gap.
+	latest := make(map[common.Address]map[uint64]*JournalEntry)
+	for _, entry := range unknown {
+		if _, ok := latest[entry.From]; !ok {
+			latest[entry.From] = make(map[uint64]*JournalEntry)
+		}
+		if tj.isPending(entry.Hash) {
+			latest[entry.From][entry.Nonce] = entry
+		}
+	}
+
+	for account, entries := range latest {
+		pendingNonce, err := client.PendingNonceAt(ctx, account)
+		if err != nil {
+			return fmt.Errorf(
+				"could not get pending nonce of account [%v]: [%v]",
+				account.Hex(),
+				err,
+			)
+		}
+
+		nonces := make([]uint64, 0, len(entries))
+		for nonce := range entries {
+			nonces = append(nonces, nonce)
+		}
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+
+		for _, nonce := range nonces {
+			entry := entries[nonce]
+
+			if nonce < pendingNonce {
+				if err := tj.updateStatus(entry.Hash, StatusReplaced); err != nil {
+					return err
+				}
+				continue
+			}
+
+			transaction := &types.Transaction{}
+			if err := rlp.DecodeBytes(entry.Transaction, transaction); err != nil {
+				return fmt.Errorf(
+					"could not decode transaction [%v]: [%v]",
+					entry.Hash.Hex(),
+					err,
+				)
+			}
+
+			logger.Warningf(
+				"transaction [%v] with nonce [%v] is unknown to the "+
+					"Ethereum node; submitting it again",
+				entry.Hash.Hex(),
+				entry.Nonce,
+			)
+
+			if err := client.SendTransaction(ctx, transaction); err != nil {
+				logger.Errorf(
+					"could not submit transaction [%v] again: [%v]",
+					entry.Hash.Hex(),
+					err,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MonitorPending periodically updates the status of pending transactions
+// in the journal with their receipts until the context is done. Receipts
+// are fetched by the mining waiter only while it watches the transaction;
+// it stops watching a transaction submitted with the maximum gas price or
+// one it could not resubmit, and the status of such a transaction would not
+// be updated otherwise. Transactions whose retention time is over are
+// periodically moved out of the journal as well.
+func (tj *TransactionJournal) MonitorPending(
+	ctx context.Context,
+	client ethereum.TransactionReader,
+	checkInterval time.Duration,
+) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	archiveTicker := time.NewTicker(transactionJournalArchiveInterval)
+	defer archiveTicker.Stop()
+
+	tj.archiveConfirmed()
+
+	for {
+		select {
+		case <-ticker.C:
+			tj.updatePending(ctx, client)
+		case <-archiveTicker.C:
+			tj.archiveConfirmed()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (tj *TransactionJournal) archiveConfirmed() {
+	if err := tj.ArchiveConfirmed(time.Now()); err != nil {
+		logger.Errorf(
+			"could not move confirmed transactions out of the journal: [%v]",
+			err,
+		)
+	}
+}
+
+func (tj *TransactionJournal) updatePending(
+	ctx context.Context,
+	client ethereum.TransactionReader,
+) {
+	for _, entry := range tj.Entries() {
+		if entry.Status != StatusPending {
+			continue
+		}
+
+		receipt, err := client.TransactionReceipt(ctx, entry.Hash)
+		if err != nil || receipt == nil {
+			// not mined yet or the Ethereum node is not available;
+			// checked again in the next interval
+			continue
+		}
+
+		if err := tj.updateStatus(entry.Hash, receiptStatus(receipt)); err != nil {
+			logger.Errorf(
+				"could not record status of transaction [%v]: [%v]",
+				entry.Hash.Hex(),
+				err,
+			)
+		}
+	}
+}
+
+func (tj *TransactionJournal) isPending(hash common.Hash) bool {
+	tj.mutex.Lock()
+	defer tj.mutex.Unlock()
+
+	entry, ok := tj.byHash[hash]
+	return ok && entry.Status == StatusPending
+}
+
+func receiptStatus(receipt *types.Receipt) TransactionStatus {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return StatusMined
+	}
+
+	return StatusReverted
+}
+
+func transactionSender(transaction *types.Transaction) (common.Address, error) {
+	if transaction.Protected() {
+		return types.Sender(
+			types.NewEIP155Signer(transaction.ChainId()),
+			transaction,
+		)
+	}
+
+	return types.Sender(types.HomesteadSigner{}, transaction)
+}
+
+// journalingClient records transactions submitted through the client in the
+// transaction journal and updates their status when their receipts are
+// fetched, e.g. by the mining waiter.
+type journalingClient struct {
+	ethutil.EthereumClient
+
+	journal  *TransactionJournal
+	purposes map[string]TransactionPurpose
+}
+
+// newJournalingClient wraps the client so that transactions are recorded in
+// the journal. Purposes of transactions are resolved from the called methods
+// of the operator contract.
+func newJournalingClient(
+	client ethutil.EthereumClient,
+	journal *TransactionJournal,
+) (*journalingClient, error) {
+	operatorABI, err := ethereumabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing KeepRandomBeaconOperator ABI: [%v]", err)
+	}
+
+	methodPurposes := map[string]TransactionPurpose{
+		"submitTicket":            PurposeTicket,
+		"submitDkgResult":         PurposeDKGResult,
+		"relayEntry":              PurposeRelayEntry,
+		"reportRelayEntryTimeout": PurposeTimeoutReport,
+	}
+
+	purposes := make(map[string]TransactionPurpose)
+	for name, purpose := range methodPurposes {
+		method, ok := operatorABI.Methods[name]
+		if !ok {
+			return nil, fmt.Errorf(
+				"method [%v] not found in KeepRandomBeaconOperator ABI",
+				name,
+			)
+		}
+		purposes[string(method.ID())] = purpose
+	}
+
+	return &journalingClient{
+		EthereumClient: client,
+		journal:        journal,
+		purposes:       purposes,
+	}, nil
+}
+
+func (jc *journalingClient) purpose(transaction *types.Transaction) TransactionPurpose {
+	data := transaction.Data()
+	if len(data) < 4 {
+		return PurposeOther
+	}
+
+	if purpose, ok := jc.purposes[string(data[:4])]; ok {
+		return purpose
+	}
+
+	return PurposeOther
+}
+
+// SendTransaction records the transaction in the journal before submitting
+// it. If the transaction could not be recorded, it is not submitted.
+func (jc *journalingClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	err := jc.journal.recordSubmission(transaction, jc.purpose(transaction))
+	if err != nil {
+		return fmt.Errorf("could not record transaction in journal: [%v]", err)
+	}
+
+	err = jc.EthereumClient.SendTransaction(ctx, transaction)
+	if _, ok := err.(rpc.Error); ok {
+		if journalErr := jc.journal.updateStatus(
+			transaction.Hash(),
+			StatusRejected,
+		); journalErr != nil {
+			logger.Errorf(
+				"could not record rejection of transaction [%v]: [%v]",
+				transaction.Hash().Hex(),
+				journalErr,
+			)
+		}
+	}
+
+	return err
+}
+
+func (jc *journalingClient) TransactionReceipt(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Receipt, error) {
+	receipt, err := jc.EthereumClient.TransactionReceipt(ctx, hash)
+	if err == nil && receipt != nil {
+		if journalErr := jc.journal.updateStatus(
+			hash,
+			receiptStatus(receipt),
+		); journalErr != nil {
+			logger.Errorf(
+				"could not record status of transaction [%v]: [%v]",
+				hash.Hex(),
+				journalErr,
+			)
+		}
+	}
+
+	return receipt, err
+}
@@ -0,0 +1,563 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+	"github.com/keep-network/keep-core/pkg/persistence/layout"
+)
+
+func TestTransactionJournalRecordsTransactions(t *testing.T) {
+	dataDir := newJournalDataDir(t)
+	defer os.RemoveAll(dataDir)
+
+	handle := newJournalPersistence(t, dataDir)
+	key := newJournalKey(t)
+	backend := newJournalBackend()
+
+	journal, err := OpenTransactionJournal(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := newJournalingClient(backend, journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticket := signJournalTransaction(t, key, 0, 10, operatorMethodID(t, "submitTicket"))
+	ticketResubmission := signJournalTransaction(t, key, 0, 12, operatorMethodID(t, "submitTicket"))
+	relayEntry := signJournalTransaction(t, key, 1, 10, operatorMethodID(t, "relayEntry"))
+	transfer := signJournalTransaction(t, key, 2, 10, nil)
+
+	for _, transaction := range []*types.Transaction{
+		ticket,
+		ticketResubmission,
+		relayEntry,
+		transfer,
+	} {
+		if err := client.SendTransaction(context.Background(), transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backend.mine(ticketResubmission, types.ReceiptStatusSuccessful)
+	backend.mine(relayEntry, types.ReceiptStatusFailed)
+
+	for _, transaction := range []*types.Transaction{ticketResubmission, relayEntry} {
+		if _, err := client.TransactionReceipt(
+			context.Background(),
+			transaction.Hash(),
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A record torn by a crash does not affect records saved before.
+	writeJournalRecord(
+		t,
+		dataDir,
+		fmt.Sprintf("%v_%v", relayEntry.Hash().Hex(), StatusMined),
+		[]byte(`{"hash":"0x12`),
+	)
+
+	journal, err = OpenTransactionJournal(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := journal.updateStatus(transfer.Hash(), StatusMined); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadTransactionJournal(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type entrySummary struct {
+		hash     common.Hash
+		from     common.Address
+		nonce    uint64
+		purpose  TransactionPurpose
+		gasPrice int64
+		status   TransactionStatus
+	}
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	expectedEntries := []entrySummary{
+		{ticket.Hash(), from, 0, PurposeTicket, 10, StatusReplaced},
+		{ticketResubmission.Hash(), from, 0, PurposeTicket, 12, StatusMined},
+		{relayEntry.Hash(), from, 1, PurposeRelayEntry, 10, StatusReverted},
+		{transfer.Hash(), from, 2, PurposeOther, 10, StatusMined},
+	}
+
+	actualEntries := make([]entrySummary, len(entries))
+	for i, entry := range entries {
+		actualEntries[i] = entrySummary{
+			entry.Hash,
+			entry.From,
+			entry.Nonce,
+			entry.Purpose,
+			entry.GasPrice.Int64(),
+			entry.Status,
+		}
+	}
+
+	if !reflect.DeepEqual(expectedEntries, actualEntries) {
+		t.Errorf(
+			"unexpected entries\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEntries,
+			actualEntries,
+		)
+	}
+}
+
+func TestTransactionJournalReconcile(t *testing.T) {
+	dataDir := newJournalDataDir(t)
+	defer os.RemoveAll(dataDir)
+
+	key := newJournalKey(t)
+	backend := newJournalBackend()
+
+	journal, err := OpenTransactionJournal(newJournalPersistence(t, dataDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replaced := signJournalTransaction(t, key, 3, 10, nil)
+	mined := signJournalTransaction(t, key, 4, 10, nil)
+	reverted := signJournalTransaction(t, key, 5, 10, nil)
+	known := signJournalTransaction(t, key, 6, 10, nil)
+	lost := signJournalTransaction(t, key, 7, 10, nil)
+	lostResubmission := signJournalTransaction(t, key, 7, 12, nil)
+	lostNext := signJournalTransaction(t, key, 8, 10, nil)
+
+	for _, transaction := range []*types.Transaction{
+		replaced,
+		mined,
+		reverted,
+		known,
+		lost,
+		lostResubmission,
+		lostNext,
+	} {
+		if err := journal.recordSubmission(transaction, PurposeOther); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backend.mine(mined, types.ReceiptStatusSuccessful)
+	backend.mine(reverted, types.ReceiptStatusFailed)
+	backend.addPending(known)
+	backend.pendingNonce = 7
+
+	if err := journal.Reconcile(context.Background(), backend); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedStatuses := map[common.Hash]TransactionStatus{
+		replaced.Hash():         StatusReplaced,
+		mined.Hash():            StatusMined,
+		reverted.Hash():         StatusReverted,
+		known.Hash():            StatusPending,
+		lost.Hash():             StatusPending,
+		lostResubmission.Hash(): StatusPending,
+		lostNext.Hash():         StatusPending,
+	}
+
+	actualStatuses := make(map[common.Hash]TransactionStatus)
+	for _, entry := range journal.Entries() {
+		actualStatuses[entry.Hash] = entry.Status
+	}
+
+	if !reflect.DeepEqual(expectedStatuses, actualStatuses) {
+		t.Errorf(
+			"unexpected statuses\nexpected: [%v]\nactual:   [%v]",
+			expectedStatuses,
+			actualStatuses,
+		)
+	}
+
+	// Only the latest submission of each lost nonce is submitted again, in
+	// the order of nonces.
+	expectedSent := []common.Hash{lostResubmission.Hash(), lostNext.Hash()}
+	if !reflect.DeepEqual(expectedSent, backend.sent) {
+		t.Errorf(
+			"unexpected transactions submitted again\nexpected: [%v]\nactual:   [%v]",
+			expectedSent,
+			backend.sent,
+		)
+	}
+}
+
+func TestTransactionJournalUpdatesStatusOfWatchedTransactions(t *testing.T) {
+	dataDir := newJournalDataDir(t)
+	defer os.RemoveAll(dataDir)
+
+	key := newJournalKey(t)
+	backend := newJournalBackend()
+
+	journal, err := OpenTransactionJournal(newJournalPersistence(t, dataDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := newJournalingClient(backend, journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waited := signJournalTransaction(t, key, 0, 10, nil)
+	unwatched := signJournalTransaction(t, key, 1, 10, nil)
+	notMined := signJournalTransaction(t, key, 2, 10, nil)
+
+	for _, transaction := range []*types.Transaction{
+		waited,
+		unwatched,
+		notMined,
+	} {
+		if err := client.SendTransaction(context.Background(), transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backend.mine(waited, types.ReceiptStatusSuccessful)
+	backend.mine(unwatched, types.ReceiptStatusFailed)
+
+	// The mining waiter fetches receipts through the journaling client.
+	miningWaiter := ethutil.NewMiningWaiter(client, time.Second, big.NewInt(20))
+	if _, err := miningWaiter.WaitMined(time.Second, waited); err != nil {
+		t.Fatal(err)
+	}
+
+	if status := journalStatus(journal, unwatched.Hash()); status != StatusPending {
+		t.Fatalf(
+			"unexpected status of transaction not watched yet\n"+
+				"expected: [%v]\nactual:   [%v]",
+			StatusPending,
+			status,
+		)
+	}
+
+	// Transactions the mining waiter does not watch are updated by the
+	// journal monitor.
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancelCtx()
+	journal.MonitorPending(ctx, backend, 10*time.Millisecond)
+
+	expectedStatuses := map[common.Hash]TransactionStatus{
+		waited.Hash():    StatusMined,
+		unwatched.Hash(): StatusReverted,
+		notMined.Hash():  StatusPending,
+	}
+
+	for hash, expectedStatus := range expectedStatuses {
+		if status := journalStatus(journal, hash); status != expectedStatus {
+			t.Errorf(
+				"unexpected status of transaction [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				hash.Hex(),
+				expectedStatus,
+				status,
+			)
+		}
+	}
+}
+
+func TestTransactionJournalArchiveConfirmed(t *testing.T) {
+	dataDir := newJournalDataDir(t)
+	defer os.RemoveAll(dataDir)
+
+	handle := newJournalPersistence(t, dataDir)
+	key := newJournalKey(t)
+
+	journal, err := OpenTransactionJournal(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mined := signJournalTransaction(t, key, 0, 10, nil)
+	pending := signJournalTransaction(t, key, 1, 10, nil)
+	for _, transaction := range []*types.Transaction{mined, pending} {
+		if err := journal.recordSubmission(transaction, PurposeOther); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := journal.updateStatus(mined.Hash(), StatusMined); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing is archived before the retention time is over.
+	err = journal.ArchiveConfirmed(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJournalEntries(t, handle, mined.Hash(), pending.Hash())
+
+	err = journal.ArchiveConfirmed(
+		time.Now().Add(transactionJournalRetention + time.Minute),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJournalEntries(t, handle, pending.Hash())
+
+	// Records are saved in the directory of the new journal generation.
+	if err := journal.updateStatus(pending.Hash(), StatusMined); err != nil {
+		t.Fatal(err)
+	}
+
+	journal, err = OpenTransactionJournal(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := journalStatus(journal, pending.Hash()); status != StatusMined {
+		t.Errorf(
+			"unexpected status\nexpected: [%v]\nactual:   [%v]",
+			StatusMined,
+			status,
+		)
+	}
+
+	archived, err := ioutil.ReadDir(filepath.Join(
+		dataDir,
+		"archive",
+		layout.TransactionJournalDirectory,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 3 {
+		t.Errorf(
+			"unexpected number of archived records\nexpected: [%v]\nactual:   [%v]",
+			3,
+			len(archived),
+		)
+	}
+}
+
+func assertJournalEntries(
+	t *testing.T,
+	handle persistence.Handle,
+	expectedHashes ...common.Hash,
+) {
+	entries, err := ReadTransactionJournal(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actualHashes := make([]common.Hash, len(entries))
+	for i, entry := range entries {
+		actualHashes[i] = entry.Hash
+	}
+
+	if !reflect.DeepEqual(expectedHashes, actualHashes) {
+		t.Errorf(
+			"unexpected journal entries\nexpected: [%v]\nactual:   [%v]",
+			expectedHashes,
+			actualHashes,
+		)
+	}
+}
+
+func journalStatus(
+	journal *TransactionJournal,
+	hash common.Hash,
+) TransactionStatus {
+	for _, entry := range journal.Entries() {
+		if entry.Hash == hash {
+			return entry.Status
+		}
+	}
+
+	return ""
+}
+
+func newJournalDataDir(t *testing.T) string {
+	dataDir, err := ioutil.TempDir("", "journal-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dataDir
+}
+
+func newJournalPersistence(t *testing.T, dataDir string) persistence.Handle {
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return persistence.NewEncryptedPersistence(handle, "password")
+}
+
+func newJournalKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func signJournalTransaction(
+	t *testing.T,
+	key *ecdsa.PrivateKey,
+	nonce uint64,
+	gasPrice int64,
+	data []byte,
+) *types.Transaction {
+	transaction, err := types.SignTx(
+		types.NewTransaction(
+			nonce,
+			common.HexToAddress("0x1"),
+			big.NewInt(0),
+			250000,
+			big.NewInt(gasPrice),
+			data,
+		),
+		types.NewEIP155Signer(big.NewInt(1101)),
+		key,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction
+}
+
+func operatorMethodID(t *testing.T, name string) []byte {
+	operatorABI, err := ethereumabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return operatorABI.Methods[name].ID()
+}
+
+func writeJournalRecord(
+	t *testing.T,
+	dataDir string,
+	name string,
+	content []byte,
+) {
+	err := ioutil.WriteFile(
+		filepath.Join(dataDir, "current", layout.TransactionJournalDirectory, name),
+		content,
+		0600,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// journalBackend is an Ethereum node answering only the requests used by
+// the transaction journal. All other requests panic.
+type journalBackend struct {
+	ethutil.EthereumClient
+
+	mutex        sync.Mutex
+	receipts     map[common.Hash]*types.Receipt
+	pending      map[common.Hash]*types.Transaction
+	pendingNonce uint64
+	sent         []common.Hash
+}
+
+func newJournalBackend() *journalBackend {
+	return &journalBackend{
+		receipts: make(map[common.Hash]*types.Receipt),
+		pending:  make(map[common.Hash]*types.Transaction),
+	}
+}
+
+func (jb *journalBackend) mine(transaction *types.Transaction, status uint64) {
+	jb.mutex.Lock()
+	defer jb.mutex.Unlock()
+
+	jb.receipts[transaction.Hash()] = &types.Receipt{
+		Status: status,
+		TxHash: transaction.Hash(),
+	}
+}
+
+func (jb *journalBackend) addPending(transaction *types.Transaction) {
+	jb.mutex.Lock()
+	defer jb.mutex.Unlock()
+
+	jb.pending[transaction.Hash()] = transaction
+}
+
+func (jb *journalBackend) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	jb.mutex.Lock()
+	defer jb.mutex.Unlock()
+
+	jb.sent = append(jb.sent, transaction.Hash())
+	return nil
+}
+
+func (jb *journalBackend) TransactionReceipt(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Receipt, error) {
+	jb.mutex.Lock()
+	defer jb.mutex.Unlock()
+
+	receipt, ok := jb.receipts[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+
+	return receipt, nil
+}
+
+func (jb *journalBackend) TransactionByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Transaction, bool, error) {
+	jb.mutex.Lock()
+	defer jb.mutex.Unlock()
+
+	transaction, ok := jb.pending[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+
+	return transaction, true, nil
+}
+
+func (jb *journalBackend) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	jb.mutex.Lock()
+	defer jb.mutex.Unlock()
+
+	return jb.pendingNonce, nil
+}
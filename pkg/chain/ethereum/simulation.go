@@ -0,0 +1,325 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+)
+
+// simulationTimeout is the maximum time a transaction simulation can take,
+// including the time spent waiting for the eligible block.
+const simulationTimeout = 30 * time.Second
+
+// simulationBlockCheckInterval is the interval in which the latest block is
+// checked while waiting for the eligible block to simulate a transaction at.
+const simulationBlockCheckInterval = time.Second
+
+// revertReasonSelector is the selector of the Error(string) function the
+// contract revert reason is encoded with.
+var revertReasonSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+// revertReasonArguments describe the ABI encoding of the revert reason
+// following the selector.
+var revertReasonArguments ethereumabi.Arguments
+
+// revertMessagePrefixes are prefixes of error messages with which Ethereum
+// nodes report reverted calls. The prefix is followed by the revert reason,
+// if any.
+var revertMessagePrefixes = []string{
+	"execution reverted",
+	"VM Exception while processing transaction: revert",
+}
+
+// alreadyDoneReasons lists, for operator contract methods, the revert
+// reasons meaning the work the transaction was supposed to do has already
+// been done by someone else.
+var alreadyDoneReasons = map[string][]string{
+	// The same ticket has already been submitted.
+	"submitTicket": {"Duplicate ticket"},
+	// Another group member has already submitted the relay entry.
+	"relayEntry": {"Entry was submitted"},
+}
+
+// possiblyDoneReasons lists, for operator contract methods, the revert
+// reasons which are reported if the work the transaction was supposed to do
+// has already been done by someone else, but also if the transaction failed
+// for other reasons. Transactions reverting with these reasons are considered
+// already done only if the chain state confirms it.
+var possiblyDoneReasons = map[string][]string{
+	// Another group member has already submitted the result so the group
+	// selection has finished and its tickets were cleaned up, or the group
+	// selection failed.
+	"submitDkgResult": {"Not enough tickets submitted"},
+	// Another node has already reported the timeout of the monitored relay
+	// request, or the request is still pending at the simulated block.
+	"reportRelayEntryTimeout": {"Entry did not time out"},
+}
+
+// alreadyDoneCheck checks on-chain whether the work a transaction was supposed
+// to do has already been done by someone else.
+type alreadyDoneCheck func() (bool, error)
+
+func init() {
+	stringType, err := ethereumabi.NewType("string", "", nil)
+	if err != nil {
+		panic(fmt.Sprintf("could not create revert reason type: [%v]", err))
+	}
+
+	revertReasonArguments = ethereumabi.Arguments{{Type: stringType}}
+}
+
+// pendingContractCaller calls contracts against the pending block.
+type pendingContractCaller interface {
+	PendingCallContract(
+		ctx context.Context,
+		call ethereum.CallMsg,
+	) ([]byte, error)
+}
+
+// simulationBackend is the part of the Ethereum client used to simulate
+// transactions.
+type simulationBackend interface {
+	pendingContractCaller
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// simulateOperatorTransaction calls the given operator contract method at
+// the block at which the transaction submitted by the operator becomes
+// eligible to be executed, but without submitting it. The call is made
+// against the pending block once it is not before the eligible block, so
+// that the contract sees the same block number and state as the submitted
+// transaction would. If the eligible block is zero, the transaction is
+// eligible straight away.
+//
+// If the call reverts, the transaction is certain to revert as well, so
+// a relayChain.TransactionSkippedError with the revert reason is returned
+// and the transaction should not be submitted. If the simulation could not
+// be run, nil is returned and the transaction should be submitted anyway.
+// The given check, if any, confirms the transaction has already been done
+// when the call reverts with one of possiblyDoneReasons of the method.
+func (ec *ethereumChain) simulateOperatorTransaction(
+	eligibleBlock uint64,
+	checkAlreadyDone alreadyDoneCheck,
+	method string,
+	parameters ...interface{},
+) error {
+	if ec.simulationBackend == nil {
+		logger.Warningf(
+			"transaction simulation is not available; "+
+				"submitting [%v] transaction without simulation",
+			method,
+		)
+		return nil
+	}
+
+	input, err := ec.keepRandomBeaconOperatorABI.Pack(method, parameters...)
+	if err != nil {
+		return fmt.Errorf(
+			"could not pack parameters of [%v] call: [%v]",
+			method,
+			err,
+		)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		simulationTimeout,
+	)
+	defer cancelCtx()
+
+	if err := ec.waitForPendingBlock(ctx, eligibleBlock); err != nil {
+		logger.Warningf(
+			"could not simulate [%v] transaction at eligible block [%v]; "+
+				"submitting without simulation: [%v]",
+			method,
+			eligibleBlock,
+			err,
+		)
+		return nil
+	}
+
+	output, err := ec.simulationBackend.PendingCallContract(
+		ctx,
+		ethereum.CallMsg{
+			From: ec.accountKey.Address,
+			To:   &ec.keepRandomBeaconOperatorAddress,
+			Data: input,
+		},
+	)
+
+	reason, reverted := revertReason(output, err)
+	if !reverted {
+		if err != nil {
+			logger.Warningf(
+				"could not simulate [%v] transaction; "+
+					"submitting without simulation: [%v]",
+				method,
+				err,
+			)
+		}
+
+		return nil
+	}
+
+	skippedErr := &relayChain.TransactionSkippedError{
+		Method:      method,
+		Reason:      reason,
+		AlreadyDone: isAlreadyDone(method, reason, checkAlreadyDone),
+	}
+
+	if skippedErr.AlreadyDone {
+		logger.Infof(
+			"skipping [%v] transaction; already done by someone else: [%v]",
+			method,
+			reason,
+		)
+	} else {
+		logger.Errorf(
+			"skipping [%v] transaction; simulation reverted: [%v]",
+			method,
+			reason,
+		)
+	}
+
+	return skippedErr
+}
+
+// waitForPendingBlock waits until the pending block of the simulation
+// backend is not before the given block, that is until the block preceding
+// it is mined. The Ethereum node used for the simulation may lag behind the
+// block counter of the client.
+func (ec *ethereumChain) waitForPendingBlock(
+	ctx context.Context,
+	block uint64,
+) error {
+	if block == 0 {
+		return nil
+	}
+
+	for {
+		header, err := ec.simulationBackend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("could not get the latest block: [%v]", err)
+		}
+
+		if header.Number.Uint64()+1 >= block {
+			return nil
+		}
+
+		select {
+		case <-time.After(simulationBlockCheckInterval):
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"block [%v] has not been mined: [%v]",
+				block-1,
+				ctx.Err(),
+			)
+		}
+	}
+}
+
+// revertReason determines if the call with the given output and error
+// reverted and returns the revert reason.
+func revertReason(output []byte, err error) (string, bool) {
+	if err == nil {
+		// Some nodes do not report reverted calls as errors but return the
+		// encoded revert reason as the call output.
+		return decodeRevertReason(output)
+	}
+
+	// Some nodes return the encoded revert reason along with the error.
+	if dataErr, ok := err.(interface{ ErrorData() interface{} }); ok {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, ok := decodeRevertReason(common.FromHex(data)); ok {
+				return reason, true
+			}
+		}
+	}
+
+	// Errors not returned by the node do not tell anything about the call.
+	if _, ok := err.(rpc.Error); !ok {
+		return "", false
+	}
+
+	message := err.Error()
+	for _, prefix := range revertMessagePrefixes {
+		if strings.HasPrefix(message, prefix) {
+			return strings.TrimLeft(strings.TrimPrefix(message, prefix), ": "), true
+		}
+	}
+
+	return "", false
+}
+
+// decodeRevertReason decodes the revert reason from the given return data
+// of a reverted call.
+func decodeRevertReason(data []byte) (string, bool) {
+	if len(data) < len(revertReasonSelector) ||
+		!bytes.Equal(data[:len(revertReasonSelector)], revertReasonSelector) {
+		return "", false
+	}
+
+	values, err := revertReasonArguments.UnpackValues(
+		data[len(revertReasonSelector):],
+	)
+	if err != nil || len(values) != 1 {
+		return "", false
+	}
+
+	reason, ok := values[0].(string)
+	return reason, ok
+}
+
+// isAlreadyDone determines if the revert of the given method with the given
+// reason means the transaction has already been done by someone else.
+// Reverts with one of possiblyDoneReasons are confirmed with the given check;
+// if there is no check or it fails, the transaction is not considered done.
+func isAlreadyDone(
+	method string,
+	reason string,
+	checkAlreadyDone alreadyDoneCheck,
+) bool {
+	if containsReason(alreadyDoneReasons[method], reason) {
+		return true
+	}
+
+	if !containsReason(possiblyDoneReasons[method], reason) ||
+		checkAlreadyDone == nil {
+		return false
+	}
+
+	alreadyDone, err := checkAlreadyDone()
+	if err != nil {
+		logger.Warningf(
+			"could not check if [%v] transaction reverted with [%v] "+
+				"has already been done: [%v]",
+			method,
+			reason,
+			err,
+		)
+		return false
+	}
+
+	return alreadyDone
+}
+
+func containsReason(reasons []string, reason string) bool {
+	for _, candidate := range reasons {
+		if candidate == reason {
+			return true
+		}
+	}
+
+	return false
+}
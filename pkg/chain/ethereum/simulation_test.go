@@ -0,0 +1,284 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestRevertReason(t *testing.T) {
+	encodedReason := encodeRevertReason(t, "Entry was submitted")
+
+	var tests = map[string]struct {
+		output           []byte
+		err              error
+		expectedReason   string
+		expectedReverted bool
+	}{
+		"successful call": {
+			output:           []byte{0x01},
+			expectedReverted: false,
+		},
+		"successful call without output": {
+			output:           []byte{},
+			expectedReverted: false,
+		},
+		"revert reason returned as output": {
+			output:           encodedReason,
+			expectedReason:   "Entry was submitted",
+			expectedReverted: true,
+		},
+		"revert reason returned as error data": {
+			err: &testRPCDataError{
+				testRPCError{3, "execution reverted"},
+				hexutil.Encode(encodedReason),
+			},
+			expectedReason:   "Entry was submitted",
+			expectedReverted: true,
+		},
+		"revert reason in error message": {
+			err: &testRPCError{
+				3,
+				"execution reverted: Entry was submitted",
+			},
+			expectedReason:   "Entry was submitted",
+			expectedReverted: true,
+		},
+		"revert reason in ganache error message": {
+			err: &testRPCError{
+				-32000,
+				"VM Exception while processing transaction: revert Duplicate ticket",
+			},
+			expectedReason:   "Duplicate ticket",
+			expectedReverted: true,
+		},
+		"revert without reason": {
+			err:              &testRPCError{-32000, "execution reverted"},
+			expectedReason:   "",
+			expectedReverted: true,
+		},
+		"other node error": {
+			err:              &testRPCError{-32000, "out of gas"},
+			expectedReverted: false,
+		},
+		"connection error": {
+			err:              errConnectionRefused,
+			expectedReverted: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			reason, reverted := revertReason(test.output, test.err)
+
+			if test.expectedReverted != reverted {
+				t.Errorf(
+					"unexpected reverted\nexpected: [%v]\nactual:   [%v]",
+					test.expectedReverted,
+					reverted,
+				)
+			}
+
+			if test.expectedReason != reason {
+				t.Errorf(
+					"unexpected reason\nexpected: [%v]\nactual:   [%v]",
+					test.expectedReason,
+					reason,
+				)
+			}
+		})
+	}
+}
+
+func TestIsAlreadyDone(t *testing.T) {
+	confirmed := func() (bool, error) { return true, nil }
+	notConfirmed := func() (bool, error) { return false, nil }
+	checkFailed := func() (bool, error) { return false, fmt.Errorf("boom") }
+
+	var tests = map[string]struct {
+		method           string
+		reason           string
+		checkAlreadyDone alreadyDoneCheck
+		expected         bool
+	}{
+		"relay entry submitted by other member": {
+			method:   "relayEntry",
+			reason:   "Entry was submitted",
+			expected: true,
+		},
+		"relay entry timed out": {
+			method:   "relayEntry",
+			reason:   "Entry timed out",
+			expected: false,
+		},
+		"reason of other method": {
+			method:   "submitTicket",
+			reason:   "Entry was submitted",
+			expected: false,
+		},
+		"relay entry timeout already reported": {
+			method:           "reportRelayEntryTimeout",
+			reason:           "Entry did not time out",
+			checkAlreadyDone: confirmed,
+			expected:         true,
+		},
+		"relay entry still pending": {
+			method:           "reportRelayEntryTimeout",
+			reason:           "Entry did not time out",
+			checkAlreadyDone: notConfirmed,
+			expected:         false,
+		},
+		"DKG result already submitted": {
+			method:           "submitDkgResult",
+			reason:           "Not enough tickets submitted",
+			checkAlreadyDone: confirmed,
+			expected:         true,
+		},
+		"group selection failed": {
+			method:           "submitDkgResult",
+			reason:           "Not enough tickets submitted",
+			checkAlreadyDone: notConfirmed,
+			expected:         false,
+		},
+		"DKG result submission not checked": {
+			method:           "submitDkgResult",
+			reason:           "Not enough tickets submitted",
+			checkAlreadyDone: checkFailed,
+			expected:         false,
+		},
+		"no check for possibly done reason": {
+			method:   "submitDkgResult",
+			reason:   "Not enough tickets submitted",
+			expected: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actual := isAlreadyDone(
+				test.method,
+				test.reason,
+				test.checkAlreadyDone,
+			)
+			if test.expected != actual {
+				t.Errorf(
+					"unexpected result\nexpected: [%v]\nactual:   [%v]",
+					test.expected,
+					actual,
+				)
+			}
+		})
+	}
+}
+
+func TestWaitForPendingBlock(t *testing.T) {
+	var tests = map[string]struct {
+		latestBlock    uint64
+		eligibleBlock  uint64
+		expectedChecks int
+	}{
+		"eligible straight away": {
+			latestBlock:    10,
+			eligibleBlock:  0,
+			expectedChecks: 0,
+		},
+		"eligible block already mined": {
+			latestBlock:    10,
+			eligibleBlock:  8,
+			expectedChecks: 1,
+		},
+		"eligible block is the pending block": {
+			latestBlock:    10,
+			eligibleBlock:  11,
+			expectedChecks: 1,
+		},
+		"eligible block not reached yet": {
+			latestBlock:    10,
+			eligibleBlock:  13,
+			expectedChecks: 3,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			backend := &testSimulationBackend{latestBlock: test.latestBlock}
+			ec := &ethereumChain{simulationBackend: backend}
+
+			err := ec.waitForPendingBlock(context.Background(), test.eligibleBlock)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedChecks != backend.checks {
+				t.Errorf(
+					"unexpected number of block checks\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedChecks,
+					backend.checks,
+				)
+			}
+		})
+	}
+}
+
+func encodeRevertReason(t *testing.T, reason string) []byte {
+	encoded, err := revertReasonArguments.Pack(reason)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return append(append([]byte{}, revertReasonSelector...), encoded...)
+}
+
+type testRPCError struct {
+	code    int
+	message string
+}
+
+func (tre *testRPCError) Error() string {
+	return tre.message
+}
+
+func (tre *testRPCError) ErrorCode() int {
+	return tre.code
+}
+
+type testRPCDataError struct {
+	testRPCError
+	data interface{}
+}
+
+func (trde *testRPCDataError) ErrorData() interface{} {
+	return trde.data
+}
+
+// testSimulationBackend mines a new block every time the latest block is
+// checked.
+type testSimulationBackend struct {
+	latestBlock uint64
+	checks      int
+}
+
+func (tsb *testSimulationBackend) PendingCallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+) ([]byte, error) {
+	return nil, nil
+}
+
+func (tsb *testSimulationBackend) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	header := &types.Header{Number: new(big.Int).SetUint64(tsb.latestBlock)}
+
+	tsb.checks++
+	tsb.latestBlock++
+
+	return header, nil
+}
@@ -2,6 +2,7 @@ package local
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"encoding/binary"
@@ -19,6 +20,7 @@ import (
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/confirmation"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/subscription"
@@ -47,6 +49,30 @@ type Chain interface {
 	// GetRelayEntryTimeoutReports returns an array of blocks which denote at what
 	// block a relay entry timeout occured.
 	GetRelayEntryTimeoutReports() []uint64
+
+	// SimulateRelayEntryRequest simulates a new relay request for a signature
+	// of the given previous entry, to be produced by the group with the given
+	// public key. The request stays in progress until a relay entry is
+	// submitted or a relay entry timeout is reported.
+	SimulateRelayEntryRequest(
+		previousEntry []byte,
+		groupPublicKey []byte,
+	) (*event.Request, error)
+
+	// SetEventConfirmations sets the number of blocks which need to be mined
+	// on top of the block in which an event was emitted before the event is
+	// delivered to subscribers.
+	SetEventConfirmations(confirmations uint64)
+
+	// SetTicketSubmissionCost sets the amount reported as the estimated cost
+	// of a single ticket submission. It is zero by default.
+	SetTicketSubmissionCost(cost *big.Int)
+
+	// SimulateReorg simulates a chain reorganization removing all events
+	// emitted at the given block or later. If remineBlock is not zero, the
+	// removed events are mined again at that block. Otherwise, they are
+	// removed from the chain for good.
+	SimulateReorg(fromBlock uint64, remineBlock uint64)
 }
 
 type localGroup struct {
@@ -54,6 +80,11 @@ type localGroup struct {
 	registrationBlockHeight uint64
 }
 
+type relayEntrySubmission struct {
+	entry       []byte
+	blockNumber uint64
+}
+
 type localChain struct {
 	relayConfig *relaychain.Config
 
@@ -64,18 +95,33 @@ type localChain struct {
 	lastSubmittedRelayEntry          []byte
 
 	handlerMutex                  sync.Mutex
-	relayEntryHandlers            map[int]func(entry *event.EntrySubmitted)
+	relayEntryHandlers            map[int]func(submission *relayEntrySubmission)
 	relayRequestHandlers          map[int]func(request *event.Request)
+	relayEntryTimeoutHandlers     map[int]func(report *event.RelayEntryTimeoutReport)
 	groupSelectionStartedHandlers map[int]func(groupSelectionStart *event.GroupSelectionStart)
 	groupRegisteredHandlers       map[int]func(groupRegistration *event.GroupRegistration)
 	resultSubmissionHandlers      map[int]func(submission *event.DKGResultSubmission)
 
+	// Local chain never emits group selection start events so only relay
+	// requests, relay entry and DKG result submissions are recorded.
+	eventsMutex           sync.Mutex
+	relayRequests         []*event.Request
+	relayEntrySubmissions []*relayEntrySubmission
+	dkgResultSubmissions  []*event.DKGResultSubmission
+
+	// currentRequest is the relay request in progress or nil if no relay
+	// entry is in progress.
+	currentRequest *event.Request
+
+	eventWaiter *confirmation.Waiter
+
 	simulatedHeight uint64
 	stakeMonitor    chain.StakeMonitor
 	blockCounter    chain.BlockCounter
 
-	tickets      []*relaychain.Ticket
-	ticketsMutex sync.Mutex
+	tickets              []*relaychain.Ticket
+	ticketsMutex         sync.Mutex
+	ticketSubmissionCost *big.Int
 
 	relayEntryTimeoutReportsMutex sync.Mutex
 	relayEntryTimeoutReports      []uint64
@@ -115,6 +161,19 @@ func (c *localChain) SubmitTicket(ticket *relaychain.Ticket) *async.EventGroupTi
 	c.ticketsMutex.Lock()
 	defer c.ticketsMutex.Unlock()
 
+	for _, submittedTicket := range c.tickets {
+		if submittedTicket.Value == ticket.Value {
+			err := promise.Fail(
+				skipTransaction("submitTicket", "Duplicate ticket", true),
+			)
+			if err != nil {
+				logger.Errorf("failed to fail promise: [%v]", err)
+			}
+
+			return promise
+		}
+	}
+
 	c.tickets = append(c.tickets, ticket)
 	sort.SliceStable(c.tickets, func(i, j int) bool {
 		// Ticket value bytes are interpreted as a big-endian unsigned integers.
@@ -135,6 +194,15 @@ func (c *localChain) SubmitTicket(ticket *relaychain.Ticket) *async.EventGroupTi
 	return promise
 }
 
+func (c *localChain) EstimateTicketSubmissionCost(
+	ticket *relaychain.Ticket,
+) (*big.Int, error) {
+	c.ticketsMutex.Lock()
+	defer c.ticketsMutex.Unlock()
+
+	return c.ticketSubmissionCost, nil
+}
+
 func (c *localChain) GetSubmittedTickets() ([]uint64, error) {
 	tickets := make([]uint64, len(c.tickets))
 
@@ -169,38 +237,65 @@ func (c *localChain) GetSelectedParticipants() ([]relaychain.StakerAddress, erro
 	return selectedParticipants, nil
 }
 
+// SubmitRelayEntry submits the relay entry to the chain. Just like the
+// on-chain contract, it rejects the entry if relay requests are simulated and
+// there is no request in progress or the request in progress timed out.
+// Without simulated relay requests, all entries are accepted.
 func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmittedPromise {
-	c.ticketsMutex.Lock()
-	c.tickets = make([]*relaychain.Ticket, 0)
-	c.ticketsMutex.Unlock()
-
 	relayEntryPromise := &async.EventEntrySubmittedPromise{}
 
-	currentBlock, err := c.blockCounter.CurrentBlock()
-	if err != nil {
-		failErr := relayEntryPromise.Fail(
-			fmt.Errorf("cannot read current block: [%v]", err),
-		)
+	failPromise := func(err error) {
+		failErr := relayEntryPromise.Fail(err)
 		if failErr != nil {
 			logger.Errorf("failed to fail promise: [%v]", failErr)
 		}
+	}
 
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		failPromise(fmt.Errorf("cannot read current block: [%v]", err))
 		return relayEntryPromise
 	}
 
-	entry := &event.EntrySubmitted{
-		BlockNumber: currentBlock,
+	submission := &relayEntrySubmission{
+		entry:       newEntry,
+		blockNumber: currentBlock,
 	}
 
+	c.eventsMutex.Lock()
+	if len(c.relayRequests) > 0 && c.currentRequest == nil {
+		c.eventsMutex.Unlock()
+		failPromise(skipTransaction("relayEntry", "Entry was submitted", true))
+		return relayEntryPromise
+	}
+	if c.currentRequest != nil &&
+		currentBlock >= c.currentRequest.BlockNumber+c.relayConfig.RelayEntryTimeout {
+		c.eventsMutex.Unlock()
+		failPromise(skipTransaction("relayEntry", "Entry timed out", false))
+		return relayEntryPromise
+	}
+	c.relayEntrySubmissions = append(c.relayEntrySubmissions, submission)
+	c.currentRequest = nil
+	c.eventsMutex.Unlock()
+
+	c.ticketsMutex.Lock()
+	c.tickets = make([]*relaychain.Ticket, 0)
+	c.ticketsMutex.Unlock()
+
 	c.handlerMutex.Lock()
 	for _, handler := range c.relayEntryHandlers {
-		go func(handler func(entry *event.EntrySubmitted), entry *event.EntrySubmitted) {
-			handler(entry)
-		}(handler, entry)
+		go func(
+			handler func(submission *relayEntrySubmission),
+			submission *relayEntrySubmission,
+		) {
+			handler(submission)
+		}(handler, submission)
 	}
 	c.handlerMutex.Unlock()
 
-	err = relayEntryPromise.Fulfill(entry)
+	err = relayEntryPromise.Fulfill(&event.EntrySubmitted{
+		BlockNumber: currentBlock,
+	})
 	if err != nil {
 		logger.Errorf("failed to fulfill promise: [%v]", err)
 	}
@@ -216,17 +311,50 @@ func (c *localChain) OnRelayEntrySubmitted(
 	c.handlerMutex.Lock()
 	defer c.handlerMutex.Unlock()
 
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
 	handlerID := generateHandlerID()
-	c.relayEntryHandlers[handlerID] = handler
+	c.relayEntryHandlers[handlerID] = func(submission *relayEntrySubmission) {
+		c.eventWaiter.Deliver(
+			ctx,
+			submission.blockNumber,
+			c.relayEntrySubmittedLookup(submission.entry),
+			func(blockNumber uint64) {
+				handler(&event.EntrySubmitted{
+					BlockNumber: blockNumber,
+				})
+			},
+		)
+	}
 
 	return subscription.NewEventSubscription(func() {
 		c.handlerMutex.Lock()
 		defer c.handlerMutex.Unlock()
 
 		delete(c.relayEntryHandlers, handlerID)
+		cancelCtx()
 	})
 }
 
+func (c *localChain) relayEntrySubmittedLookup(
+	entry []byte,
+) confirmation.LookupFn {
+	return func(fromBlock, toBlock uint64) (uint64, bool, error) {
+		c.eventsMutex.Lock()
+		defer c.eventsMutex.Unlock()
+
+		for _, submission := range c.relayEntrySubmissions {
+			if submission.blockNumber >= fromBlock &&
+				submission.blockNumber <= toBlock &&
+				bytes.Equal(submission.entry, entry) {
+				return submission.blockNumber, true, nil
+			}
+		}
+
+		return 0, false, nil
+	}
+}
+
 func (c *localChain) GetLastRelayEntry() []byte {
 	return c.lastSubmittedRelayEntry
 }
@@ -248,6 +376,53 @@ func (c *localChain) OnRelayEntryRequested(
 	})
 }
 
+func (c *localChain) PastRelayEntryRequestedEvents(
+	fromBlock uint64,
+) ([]*event.Request, error) {
+	c.eventsMutex.Lock()
+	defer c.eventsMutex.Unlock()
+
+	requests := make([]*event.Request, 0)
+	for _, request := range c.relayRequests {
+		if request.BlockNumber >= fromBlock {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests, nil
+}
+
+func (c *localChain) SimulateRelayEntryRequest(
+	previousEntry []byte,
+	groupPublicKey []byte,
+) (*event.Request, error) {
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read current block: [%v]", err)
+	}
+
+	request := &event.Request{
+		PreviousEntry:  previousEntry,
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    currentBlock,
+	}
+
+	c.eventsMutex.Lock()
+	c.relayRequests = append(c.relayRequests, request)
+	c.currentRequest = request
+	c.eventsMutex.Unlock()
+
+	c.handlerMutex.Lock()
+	for _, handler := range c.relayRequestHandlers {
+		go func(handler func(request *event.Request), request *event.Request) {
+			handler(request)
+		}(handler, request)
+	}
+	c.handlerMutex.Unlock()
+
+	return request, nil
+}
+
 func (c *localChain) OnGroupSelectionStarted(
 	handler func(entry *event.GroupSelectionStart),
 ) subscription.EventSubscription {
@@ -265,21 +440,44 @@ func (c *localChain) OnGroupSelectionStarted(
 	})
 }
 
+func (c *localChain) PastGroupSelectionStartedEvents(
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	return []*event.GroupSelectionStart{}, nil
+}
+
 func (c *localChain) OnGroupRegistered(
 	handler func(groupRegistration *event.GroupRegistration),
 ) subscription.EventSubscription {
 	c.handlerMutex.Lock()
 	defer c.handlerMutex.Unlock()
 
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
 	handlerID := generateHandlerID()
 
-	c.groupRegisteredHandlers[handlerID] = handler
+	c.groupRegisteredHandlers[handlerID] = func(
+		groupRegistration *event.GroupRegistration,
+	) {
+		c.eventWaiter.Deliver(
+			ctx,
+			groupRegistration.BlockNumber,
+			c.dkgResultSubmittedLookup(groupRegistration.GroupPublicKey),
+			func(blockNumber uint64) {
+				handler(&event.GroupRegistration{
+					GroupPublicKey: groupRegistration.GroupPublicKey,
+					BlockNumber:    blockNumber,
+				})
+			},
+		)
+	}
 
 	return subscription.NewEventSubscription(func() {
 		c.handlerMutex.Lock()
 		defer c.handlerMutex.Unlock()
 
 		delete(c.groupRegisteredHandlers, handlerID)
+		cancelCtx()
 	})
 }
 
@@ -328,16 +526,20 @@ func ConnectWithKey(
 			ResultPublicationBlockStep: resultPublicationBlockStep,
 			RelayEntryTimeout:          resultPublicationBlockStep * uint64(groupSize),
 		},
-		relayEntryHandlers:       make(map[int]func(request *event.EntrySubmitted)),
-		relayRequestHandlers:     make(map[int]func(request *event.Request)),
-		groupRegisteredHandlers:  make(map[int]func(groupRegistration *event.GroupRegistration)),
-		resultSubmissionHandlers: make(map[int]func(submission *event.DKGResultSubmission)),
-		blockCounter:             bc,
-		stakeMonitor:             NewStakeMonitor(minimumStake),
-		tickets:                  make([]*relaychain.Ticket, 0),
-		groups:                   []localGroup{group},
-		operatorKey:              operatorKey,
-		minimumStake:             minimumStake,
+		relayEntryHandlers:            make(map[int]func(submission *relayEntrySubmission)),
+		relayRequestHandlers:          make(map[int]func(request *event.Request)),
+		relayEntryTimeoutHandlers:     make(map[int]func(report *event.RelayEntryTimeoutReport)),
+		groupSelectionStartedHandlers: make(map[int]func(groupSelectionStart *event.GroupSelectionStart)),
+		groupRegisteredHandlers:       make(map[int]func(groupRegistration *event.GroupRegistration)),
+		resultSubmissionHandlers:      make(map[int]func(submission *event.DKGResultSubmission)),
+		eventWaiter:                   confirmation.NewWaiter(bc, 0),
+		blockCounter:                  bc,
+		stakeMonitor:                  NewStakeMonitor(minimumStake),
+		tickets:                       make([]*relaychain.Ticket, 0),
+		ticketSubmissionCost:          big.NewInt(0),
+		groups:                        []localGroup{group},
+		operatorKey:                   operatorKey,
+		minimumStake:                  minimumStake,
 	}
 }
 
@@ -412,6 +614,17 @@ func (c *localChain) SubmitDKGResult(
 		return dkgResultPublicationPromise
 	}
 
+	if isRegistered, _ := c.IsGroupRegistered(resultToPublish.GroupPublicKey); isRegistered {
+		err := dkgResultPublicationPromise.Fail(
+			skipTransaction("submitDkgResult", "Group already registered", true),
+		)
+		if err != nil {
+			logger.Errorf("failed to fail promise: [%v]", err)
+		}
+
+		return dkgResultPublicationPromise
+	}
+
 	currentBlock, err := c.blockCounter.CurrentBlock()
 	if err != nil {
 		failErr := dkgResultPublicationPromise.Fail(
@@ -444,6 +657,13 @@ func (c *localChain) SubmitDKGResult(
 		BlockNumber:    currentBlock,
 	}
 
+	c.eventsMutex.Lock()
+	c.dkgResultSubmissions = append(
+		c.dkgResultSubmissions,
+		dkgResultPublicationEvent,
+	)
+	c.eventsMutex.Unlock()
+
 	c.handlerMutex.Lock()
 	for _, handler := range c.resultSubmissionHandlers {
 		go func(handler func(*event.DKGResultSubmission), dkgResultPublication *event.DKGResultSubmission) {
@@ -472,6 +692,42 @@ func (c *localChain) OnDKGResultSubmitted(
 	c.handlerMutex.Lock()
 	defer c.handlerMutex.Unlock()
 
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	handlerID := generateHandlerID()
+	c.resultSubmissionHandlers[handlerID] = func(
+		submission *event.DKGResultSubmission,
+	) {
+		c.eventWaiter.Deliver(
+			ctx,
+			submission.BlockNumber,
+			c.dkgResultSubmittedLookup(submission.GroupPublicKey),
+			func(blockNumber uint64) {
+				handler(&event.DKGResultSubmission{
+					MemberIndex:    submission.MemberIndex,
+					GroupPublicKey: submission.GroupPublicKey,
+					Misbehaved:     submission.Misbehaved,
+					BlockNumber:    blockNumber,
+				})
+			},
+		)
+	}
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(c.resultSubmissionHandlers, handlerID)
+		cancelCtx()
+	})
+}
+
+func (c *localChain) OnUnconfirmedDKGResultSubmitted(
+	handler func(dkgResultPublication *event.DKGResultSubmission),
+) subscription.EventSubscription {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
 	handlerID := generateHandlerID()
 	c.resultSubmissionHandlers[handlerID] = handler
 
@@ -483,6 +739,93 @@ func (c *localChain) OnDKGResultSubmitted(
 	})
 }
 
+func (c *localChain) dkgResultSubmittedLookup(
+	groupPublicKey []byte,
+) confirmation.LookupFn {
+	return func(fromBlock, toBlock uint64) (uint64, bool, error) {
+		c.eventsMutex.Lock()
+		defer c.eventsMutex.Unlock()
+
+		for _, submission := range c.dkgResultSubmissions {
+			if submission.BlockNumber >= fromBlock &&
+				submission.BlockNumber <= toBlock &&
+				bytes.Equal(submission.GroupPublicKey, groupPublicKey) {
+				return submission.BlockNumber, true, nil
+			}
+		}
+
+		return 0, false, nil
+	}
+}
+
+func (c *localChain) SetEventConfirmations(confirmations uint64) {
+	c.eventWaiter = confirmation.NewWaiter(c.blockCounter, confirmations)
+}
+
+func (c *localChain) SetTicketSubmissionCost(cost *big.Int) {
+	c.ticketsMutex.Lock()
+	defer c.ticketsMutex.Unlock()
+
+	c.ticketSubmissionCost = cost
+}
+
+func (c *localChain) SimulateReorg(fromBlock uint64, remineBlock uint64) {
+	c.eventsMutex.Lock()
+	defer c.eventsMutex.Unlock()
+
+	relayEntrySubmissions := make([]*relayEntrySubmission, 0)
+	for _, submission := range c.relayEntrySubmissions {
+		if submission.blockNumber >= fromBlock {
+			if remineBlock == 0 {
+				continue
+			}
+
+			submission = &relayEntrySubmission{
+				entry:       submission.entry,
+				blockNumber: remineBlock,
+			}
+		}
+
+		relayEntrySubmissions = append(relayEntrySubmissions, submission)
+	}
+	c.relayEntrySubmissions = relayEntrySubmissions
+
+	dkgResultSubmissions := make([]*event.DKGResultSubmission, 0)
+	for _, submission := range c.dkgResultSubmissions {
+		if submission.BlockNumber >= fromBlock {
+			if remineBlock == 0 {
+				continue
+			}
+
+			submission = &event.DKGResultSubmission{
+				MemberIndex:    submission.MemberIndex,
+				GroupPublicKey: submission.GroupPublicKey,
+				Misbehaved:     submission.Misbehaved,
+				BlockNumber:    remineBlock,
+			}
+		}
+
+		dkgResultSubmissions = append(dkgResultSubmissions, submission)
+	}
+	c.dkgResultSubmissions = dkgResultSubmissions
+}
+
+func (c *localChain) PastDKGResultSubmittedEvents(
+	fromBlock uint64,
+) ([]*event.DKGResultSubmission, error) {
+	c.eventsMutex.Lock()
+	defer c.eventsMutex.Unlock()
+
+	submissions := make([]*event.DKGResultSubmission, 0)
+	for _, submission := range c.dkgResultSubmissions {
+		if submission.BlockNumber >= fromBlock {
+			submissions = append(submissions, submission)
+		}
+	}
+
+	return submissions, nil
+}
+
 func (c *localChain) GetLastDKGResult() (
 	*relaychain.DKGResult,
 	map[relaychain.GroupMemberIndex][]byte,
@@ -490,7 +833,14 @@ func (c *localChain) GetLastDKGResult() (
 	return c.lastSubmittedDKGResult, c.lastSubmittedDKGResultSignatures
 }
 
-func (c *localChain) ReportRelayEntryTimeout() error {
+// ReportRelayEntryTimeout reports that the relay entry currently in progress
+// timed out. Just like the on-chain contract, it rejects the report if there is
+// no entry in progress or the entry did not time out yet, so that only
+// the first report of the timeout is accepted. The report is rejected as well
+// if the relay entry in progress is not the one requested at the given block.
+func (c *localChain) ReportRelayEntryTimeout(
+	relayRequestBlockNumber uint64,
+) error {
 	c.relayEntryTimeoutReportsMutex.Lock()
 	defer c.relayEntryTimeoutReportsMutex.Unlock()
 
@@ -499,24 +849,133 @@ func (c *localChain) ReportRelayEntryTimeout() error {
 		return err
 	}
 
+	c.eventsMutex.Lock()
+	currentRequest := c.currentRequest
+	if currentRequest == nil {
+		// If there were relay requests, the last one has been already
+		// handled with a relay entry or a timeout report.
+		alreadyDone := len(c.relayRequests) > 0
+		c.eventsMutex.Unlock()
+		return skipTransaction(
+			"reportRelayEntryTimeout",
+			"no relay entry in progress",
+			alreadyDone,
+		)
+	}
+	if currentRequest.BlockNumber != relayRequestBlockNumber {
+		c.eventsMutex.Unlock()
+		return skipTransaction(
+			"reportRelayEntryTimeout",
+			"relay request has already been handled",
+			true,
+		)
+	}
+	if currentBlock < currentRequest.BlockNumber+c.relayConfig.RelayEntryTimeout {
+		c.eventsMutex.Unlock()
+		return skipTransaction(
+			"reportRelayEntryTimeout",
+			"relay entry did not time out",
+			false,
+		)
+	}
+	c.currentRequest = nil
+	c.eventsMutex.Unlock()
+
 	c.relayEntryTimeoutReports = append(c.relayEntryTimeoutReports, currentBlock)
+
+	report := &event.RelayEntryTimeoutReport{
+		GroupIndex:  big.NewInt(int64(c.groupIndex(currentRequest.GroupPublicKey))),
+		BlockNumber: currentBlock,
+	}
+
+	c.handlerMutex.Lock()
+	for _, handler := range c.relayEntryTimeoutHandlers {
+		go func(
+			handler func(report *event.RelayEntryTimeoutReport),
+			report *event.RelayEntryTimeoutReport,
+		) {
+			handler(report)
+		}(handler, report)
+	}
+	c.handlerMutex.Unlock()
+
 	return nil
 }
 
+func (c *localChain) OnRelayEntryTimeoutReported(
+	handler func(report *event.RelayEntryTimeoutReport),
+) subscription.EventSubscription {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	handlerID := generateHandlerID()
+	c.relayEntryTimeoutHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(c.relayEntryTimeoutHandlers, handlerID)
+	})
+}
+
+// groupIndex returns the index of the group with the given public key or -1
+// if there is no such group.
+func (c *localChain) groupIndex(groupPublicKey []byte) int {
+	for i, group := range c.groups {
+		if bytes.Equal(group.groupPublicKey, groupPublicKey) {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func (c *localChain) IsEntryInProgress() (bool, error) {
-	panic("not implemented")
+	c.eventsMutex.Lock()
+	defer c.eventsMutex.Unlock()
+
+	return c.currentRequest != nil, nil
 }
 
 func (c *localChain) CurrentRequestStartBlock() (*big.Int, error) {
-	panic("not implemented")
+	c.eventsMutex.Lock()
+	defer c.eventsMutex.Unlock()
+
+	if c.currentRequest == nil {
+		return big.NewInt(0), nil
+	}
+
+	return new(big.Int).SetUint64(c.currentRequest.BlockNumber), nil
 }
 
 func (c *localChain) CurrentRequestPreviousEntry() ([]byte, error) {
-	panic("not implemented")
+	c.eventsMutex.Lock()
+	defer c.eventsMutex.Unlock()
+
+	if c.currentRequest == nil {
+		return []byte{}, nil
+	}
+
+	return c.currentRequest.PreviousEntry, nil
 }
 
 func (c *localChain) CurrentRequestGroupPublicKey() ([]byte, error) {
-	panic("not implemented")
+	c.eventsMutex.Lock()
+	defer c.eventsMutex.Unlock()
+
+	if c.currentRequest == nil {
+		return []byte{}, nil
+	}
+
+	return c.currentRequest.GroupPublicKey, nil
+}
+
+func (c *localChain) GetActiveGroupMembers() (
+	[]relaychain.StakerAddress,
+	error,
+) {
+	return nil, nil // no-op
 }
 
 func (c *localChain) GetRelayEntryTimeoutReports() []uint64 {
@@ -527,6 +986,14 @@ func (c *localChain) MinimumStake() (*big.Int, error) {
 	return c.minimumStake, nil
 }
 
+func (c *localChain) EarliestUnconfirmedEventBlock() (uint64, bool) {
+	return c.eventWaiter.EarliestPendingBlock()
+}
+
+func (c *localChain) EventConfirmations() uint64 {
+	return c.eventWaiter.Confirmations()
+}
+
 // CalculateDKGResultHash calculates a 256-bit hash of the DKG result.
 func (c *localChain) CalculateDKGResultHash(
 	dkgResult *relaychain.DKGResult,
@@ -134,6 +134,47 @@ func TestLocalSubmitRelayEntry(t *testing.T) {
 
 }
 
+func TestLocalSubmitRelayEntrySkipsHandledRequest(t *testing.T) {
+	chain := Connect(10, 4, big.NewInt(200))
+	chainHandle := chain.ThresholdRelay()
+
+	_, err := chain.SimulateRelayEntryRequest(big.NewInt(10).Bytes(), []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submissionErrors := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		chainHandle.SubmitRelayEntry(big.NewInt(19).Bytes()).OnComplete(
+			func(entry *event.EntrySubmitted, err error) {
+				submissionErrors <- err
+			},
+		)
+	}
+
+	// Promise callbacks are not called in any particular order.
+	acceptedCount, alreadyDoneCount := 0, 0
+	for i := 0; i < 2; i++ {
+		err := <-submissionErrors
+		if err == nil {
+			acceptedCount++
+		} else if relaychain.IsAlreadyDone(err) {
+			alreadyDoneCount++
+		} else {
+			t.Fatal(err)
+		}
+	}
+
+	if acceptedCount != 1 || alreadyDoneCount != 1 {
+		t.Errorf(
+			"unexpected submissions\nexpected: [1 accepted, 1 already done]\n"+
+				"actual:   [%v accepted, %v already done]",
+			acceptedCount,
+			alreadyDoneCount,
+		)
+	}
+}
+
 func TestLocalOnEntrySubmitted(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
@@ -186,6 +227,90 @@ func TestLocalOnEntrySubmittedUnsubscribed(t *testing.T) {
 	}
 }
 
+func TestLocalReportRelayEntryTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	chain := Connect(2, 2, big.NewInt(200))
+	chainHandle := chain.ThresholdRelay()
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = chainHandle.ReportRelayEntryTimeout(0)
+	if err == nil {
+		t.Fatal("expected an error when there is no relay entry in progress")
+	}
+
+	request, err := chain.SimulateRelayEntryRequest(
+		big.NewInt(10).Bytes(),
+		[]byte{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = chainHandle.ReportRelayEntryTimeout(request.BlockNumber)
+	if err == nil {
+		t.Fatal("expected an error when the relay entry did not time out")
+	}
+
+	reportFired := make(chan *event.RelayEntryTimeoutReport)
+	subscription := chainHandle.OnRelayEntryTimeoutReported(
+		func(report *event.RelayEntryTimeoutReport) {
+			reportFired <- report
+		},
+	)
+	defer subscription.Unsubscribe()
+
+	timeoutBlock := request.BlockNumber + chainHandle.GetConfig().RelayEntryTimeout
+	err = blockCounter.WaitForBlockHeight(timeoutBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = chainHandle.ReportRelayEntryTimeout(request.BlockNumber + 1)
+	if !relaychain.IsAlreadyDone(err) {
+		t.Fatalf(
+			"expected the report of another relay request to be skipped "+
+				"as already done; has: [%v]",
+			err,
+		)
+	}
+
+	err = chainHandle.ReportRelayEntryTimeout(request.BlockNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case report := <-reportFired:
+		if report.BlockNumber < timeoutBlock {
+			t.Errorf(
+				"unexpected report block\nexpected: >= [%v]\nactual:   [%v]",
+				timeoutBlock,
+				report.BlockNumber,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	err = chainHandle.ReportRelayEntryTimeout(request.BlockNumber)
+	if err == nil {
+		t.Fatal("expected an error when the timeout has already been reported")
+	}
+
+	if len(chain.GetRelayEntryTimeoutReports()) != 1 {
+		t.Errorf(
+			"unexpected number of timeout reports\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(chain.GetRelayEntryTimeoutReports()),
+		)
+	}
+}
+
 func TestLocalOnGroupRegistered(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
@@ -351,6 +476,170 @@ func TestLocalOnDKGResultSubmittedUnsubscribed(t *testing.T) {
 	}
 }
 
+func TestLocalOnDKGResultSubmittedConfirmation(t *testing.T) {
+	confirmations := uint64(2)
+
+	var tests = map[string]struct {
+		// reorg simulates a chain reorganization for the given block in which
+		// the result was submitted.
+		reorg             func(chain Chain, submissionBlock uint64)
+		expectedDelivered bool
+		// expectedBlockShift is the expected difference between the block
+		// of the delivered event and the submission block.
+		expectedBlockShift uint64
+	}{
+		"no reorg": {
+			reorg:             func(chain Chain, submissionBlock uint64) {},
+			expectedDelivered: true,
+		},
+		"submission reorged out": {
+			reorg: func(chain Chain, submissionBlock uint64) {
+				chain.SimulateReorg(submissionBlock, 0)
+			},
+		},
+		"submission mined again in a later block": {
+			reorg: func(chain Chain, submissionBlock uint64) {
+				chain.SimulateReorg(submissionBlock, submissionBlock+1)
+			},
+			expectedDelivered:  true,
+			expectedBlockShift: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, cancel := newTestContext()
+			defer cancel()
+
+			chain := Connect(10, 4, big.NewInt(200))
+			chain.SetEventConfirmations(confirmations)
+			chainHandle := chain.ThresholdRelay()
+
+			eventFired := make(chan *event.DKGResultSubmission)
+
+			subscription := chainHandle.OnDKGResultSubmitted(
+				func(event *event.DKGResultSubmission) {
+					eventFired <- event
+				},
+			)
+			defer subscription.Unsubscribe()
+
+			groupPublicKey := []byte("1")
+			memberIndex := relaychain.GroupMemberIndex(1)
+			dkgResult := &relaychain.DKGResult{GroupPublicKey: groupPublicKey}
+			signatures := map[relaychain.GroupMemberIndex][]byte{
+				1: []byte{101},
+				2: []byte{102},
+				3: []byte{103},
+				4: []byte{104},
+			}
+
+			submissionBlockChan := make(chan uint64, 1)
+			chainHandle.SubmitDKGResult(
+				memberIndex,
+				dkgResult,
+				signatures,
+			).OnSuccess(func(submission *event.DKGResultSubmission) {
+				submissionBlockChan <- submission.BlockNumber
+			})
+
+			submissionBlock := <-submissionBlockChan
+			test.reorg(chain, submissionBlock)
+
+			select {
+			case event := <-eventFired:
+				if !test.expectedDelivered {
+					t.Fatalf("event should have been dropped: [%v]", event)
+				}
+
+				expectedBlock := submissionBlock + test.expectedBlockShift
+				if event.BlockNumber != expectedBlock {
+					t.Fatalf(
+						"unexpected event block\nexpected: [%v]\nactual:   [%v]",
+						expectedBlock,
+						event.BlockNumber,
+					)
+				}
+
+				blockCounter, _ := chain.BlockCounter()
+				currentBlock, _ := blockCounter.CurrentBlock()
+				if currentBlock < expectedBlock+confirmations {
+					t.Fatalf(
+						"event delivered at block [%v] before [%v] confirmations",
+						currentBlock,
+						confirmations,
+					)
+				}
+			case <-ctx.Done():
+				if test.expectedDelivered {
+					t.Fatal(ctx.Err())
+				}
+			}
+		})
+	}
+}
+
+func TestLocalOnRelayEntrySubmittedConfirmation(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	chain := Connect(10, 4, big.NewInt(200))
+	chain.SetEventConfirmations(2)
+	chainHandle := chain.ThresholdRelay()
+
+	eventFired := make(chan *event.EntrySubmitted, 2)
+
+	subscription := chainHandle.OnRelayEntrySubmitted(
+		func(event *event.EntrySubmitted) {
+			eventFired <- event
+		},
+	)
+	defer subscription.Unsubscribe()
+
+	submitEntry := func(entry []byte) uint64 {
+		submissionBlockChan := make(chan uint64, 1)
+		chainHandle.SubmitRelayEntry(entry).OnSuccess(
+			func(submission *event.EntrySubmitted) {
+				submissionBlockChan <- submission.BlockNumber
+			},
+		)
+		return <-submissionBlockChan
+	}
+
+	reorgedBlock := submitEntry([]byte{0x01})
+	chain.SimulateReorg(reorgedBlock, 0)
+
+	// The other entry must not confirm the submission which has been
+	// reorged out.
+	submissionBlock := submitEntry([]byte{0x02})
+
+	deliveredCount := 0
+	for {
+		select {
+		case event := <-eventFired:
+			deliveredCount++
+
+			if event.BlockNumber != submissionBlock {
+				t.Fatalf(
+					"unexpected event block\nexpected: [%v]\nactual:   [%v]",
+					submissionBlock,
+					event.BlockNumber,
+				)
+			}
+		case <-ctx.Done():
+			if deliveredCount != 1 {
+				t.Fatalf(
+					"unexpected number of delivered events\n"+
+						"expected: [%v]\nactual:   [%v]",
+					1,
+					deliveredCount,
+				)
+			}
+			return
+		}
+	}
+}
+
 func TestWatchBlocks(t *testing.T) {
 	c := Connect(10, 4, big.NewInt(100))
 	blockCounter, err := c.BlockCounter()
@@ -600,13 +889,56 @@ func TestLocalSubmitDKGResult(t *testing.T) {
 	}
 }
 
+func TestLocalSubmitDKGResultSkipsRegisteredGroup(t *testing.T) {
+	chainHandle := Connect(10, 4, big.NewInt(200)).ThresholdRelay()
+
+	result := &relaychain.DKGResult{
+		GroupPublicKey: []byte{11},
+	}
+
+	signatures := map[relaychain.GroupMemberIndex][]byte{
+		1: []byte{101},
+		2: []byte{102},
+		3: []byte{103},
+		4: []byte{104},
+	}
+
+	submissionErrors := make(chan error, 2)
+	for _, memberIndex := range []relaychain.GroupMemberIndex{1, 2} {
+		chainHandle.SubmitDKGResult(memberIndex, result, signatures).OnComplete(
+			func(submission *event.DKGResultSubmission, err error) {
+				submissionErrors <- err
+			},
+		)
+	}
+
+	// Promise callbacks are not called in any particular order.
+	acceptedCount, alreadyDoneCount := 0, 0
+	for i := 0; i < 2; i++ {
+		err := <-submissionErrors
+		if err == nil {
+			acceptedCount++
+		} else if relaychain.IsAlreadyDone(err) {
+			alreadyDoneCount++
+		} else {
+			t.Fatal(err)
+		}
+	}
+
+	if acceptedCount != 1 || alreadyDoneCount != 1 {
+		t.Errorf(
+			"unexpected submissions\nexpected: [1 accepted, 1 already done]\n"+
+				"actual:   [%v accepted, %v already done]",
+			acceptedCount,
+			alreadyDoneCount,
+		)
+	}
+}
+
 func TestLocalSubmitDKGResultWithSignatures(t *testing.T) {
 	groupSize := 5
 	honestThreshold := 3
 
-	localChain := Connect(groupSize, honestThreshold, big.NewInt(200)).(*localChain)
-	chainHandle := localChain.ThresholdRelay()
-
 	var tests = map[string]struct {
 		signatures    map[relaychain.GroupMemberIndex][]byte
 		expectedError error
@@ -662,6 +994,14 @@ func TestLocalSubmitDKGResultWithSignatures(t *testing.T) {
 			ctx, cancel := newTestContext()
 			defer cancel()
 
+			// Each result is submitted to a separate chain as the chain
+			// accepts only the first result for the group.
+			chainHandle := Connect(
+				groupSize,
+				honestThreshold,
+				big.NewInt(200),
+			).ThresholdRelay()
+
 			errorChan := make(chan error)
 
 			memberIndex := uint8(1)
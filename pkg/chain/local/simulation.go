@@ -0,0 +1,32 @@
+package local
+
+import (
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+)
+
+// skipTransaction is called when a pre-flight check of the local chain state
+// shows the transaction calling the given method would be rejected for the
+// given reason. Just like the Ethereum chain does when the simulation of
+// a transaction at the block at which it becomes eligible reverts, it logs
+// the skipped transaction and returns relaychain.TransactionSkippedError.
+func skipTransaction(method string, reason string, alreadyDone bool) error {
+	if alreadyDone {
+		logger.Infof(
+			"skipping [%v] transaction; already done by someone else: [%v]",
+			method,
+			reason,
+		)
+	} else {
+		logger.Errorf(
+			"skipping [%v] transaction; simulation reverted: [%v]",
+			method,
+			reason,
+		)
+	}
+
+	return &relaychain.TransactionSkippedError{
+		Method:      method,
+		Reason:      reason,
+		AlreadyDone: alreadyDone,
+	}
+}
@@ -2,9 +2,12 @@ package diagnostics
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/diagnostics"
+	"github.com/keep-network/keep-core/pkg/beacon"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 )
@@ -24,10 +27,52 @@ func Initialize(port int) (*diagnostics.DiagnosticsRegistry, bool) {
 	return registry, true
 }
 
+// Registry registers diagnostics sources. It is implemented by the
+// diagnostics registry and by OperatorRegistry.
+type Registry interface {
+	RegisterSource(name string, source func() string)
+}
+
+// OperatorRegistry registers diagnostics sources of a single operator run by
+// the client. The diagnostics registry identifies sources by their names, so
+// names of sources of additional operators are suffixed with the operator
+// address; sources of the primary operator keep their names.
+type OperatorRegistry struct {
+	registry   *diagnostics.DiagnosticsRegistry
+	nameSuffix string
+}
+
+// NewOperatorRegistry returns a registry of diagnostics sources of the
+// operator with the given address. The primary operator is the operator with
+// index zero.
+func NewOperatorRegistry(
+	registry *diagnostics.DiagnosticsRegistry,
+	operatorIndex int,
+	operatorAddress string,
+) *OperatorRegistry {
+	nameSuffix := ""
+	if operatorIndex > 0 {
+		nameSuffix = "_" + strings.ToLower(operatorAddress)
+	}
+
+	return &OperatorRegistry{
+		registry:   registry,
+		nameSuffix: nameSuffix,
+	}
+}
+
+// RegisterSource registers the diagnostics source of the operator.
+func (or *OperatorRegistry) RegisterSource(
+	name string,
+	source func() string,
+) {
+	or.registry.RegisterSource(name+or.nameSuffix, source)
+}
+
 // RegisterConnectedPeersSource registers the diagnostics source providing
 // information about connected peers.
 func RegisterConnectedPeersSource(
-	registry *diagnostics.DiagnosticsRegistry,
+	registry Registry,
 	netProvider net.Provider,
 ) {
 	registry.RegisterSource("connected_peers", func() string {
@@ -62,7 +107,7 @@ func RegisterConnectedPeersSource(
 // RegisterClientInfoSource registers the diagnostics source providing
 // information about the client itself.
 func RegisterClientInfoSource(
-	registry *diagnostics.DiagnosticsRegistry,
+	registry Registry,
 	netProvider net.Provider,
 ) {
 	registry.RegisterSource("client_info", func() string {
@@ -89,3 +134,153 @@ func RegisterClientInfoSource(
 		return string(bytes)
 	})
 }
+
+// RegisterGroupsSource registers the diagnostics source providing
+// information about groups the operator is a member of.
+func RegisterGroupsSource(
+	registry Registry,
+	beacon *beacon.Beacon,
+) {
+	registry.RegisterSource("groups", func() string {
+		groups := beacon.Groups()
+
+		groupsList := make([]map[string]interface{}, len(groups))
+		for i, group := range groups {
+			groupInfo := map[string]interface{}{
+				"group_public_key": fmt.Sprintf("0x%x", group.GroupPublicKey),
+				"member_indices":   group.MemberIndices,
+				"stale":            group.IsStale,
+			}
+			if group.StaleCheckError != nil {
+				groupInfo["stale_check_error"] = group.StaleCheckError.Error()
+			}
+
+			groupsList[i] = groupInfo
+		}
+
+		return serialize("groups", groupsList)
+	})
+}
+
+// RegisterQuarantinedMembershipsSource registers the diagnostics source
+// providing information about memberships of the operator which failed the
+// integrity check at the client start and are not used for signing.
+func RegisterQuarantinedMembershipsSource(
+	registry Registry,
+	beacon *beacon.Beacon,
+) {
+	registry.RegisterSource("quarantined_memberships", func() string {
+		quarantined := beacon.QuarantinedMemberships()
+
+		quarantinedList := make([]map[string]interface{}, len(quarantined))
+		for i, quarantinedMembership := range quarantined {
+			signer := quarantinedMembership.Membership.Signer
+			quarantinedList[i] = map[string]interface{}{
+				"group_public_key": fmt.Sprintf("0x%x", signer.GroupPublicKeyBytes()),
+				"member_index":     signer.MemberID(),
+				"reason":           quarantinedMembership.Reason,
+				"quarantined_at":   quarantinedMembership.QuarantinedAt.Unix(),
+			}
+		}
+
+		return serialize("quarantined memberships", quarantinedList)
+	})
+}
+
+// RegisterPendingOperationsSource registers the diagnostics source providing
+// information about group selections and relay requests currently handled by
+// the operator.
+func RegisterPendingOperationsSource(
+	registry Registry,
+	beacon *beacon.Beacon,
+) {
+	registry.RegisterSource("pending_operations", func() string {
+		pendingOperations := map[string]interface{}{
+			"group_selection_seeds": prefixHex(beacon.PendingGroupSelections()),
+			"relay_request_previous_entries": prefixHex(
+				beacon.PendingRelayRequests(),
+			),
+		}
+
+		return serialize("pending operations", pendingOperations)
+	})
+}
+
+// RegisterRunningProtocolsSource registers the diagnostics source providing
+// information about the current phase of every key generation and relay entry
+// signing executed by the operator.
+func RegisterRunningProtocolsSource(
+	registry Registry,
+	beacon *beacon.Beacon,
+) {
+	registry.RegisterSource("running_protocols", func() string {
+		dkgs := beacon.RunningDKGs()
+		dkgsList := make([]map[string]interface{}, len(dkgs))
+		for i, dkg := range dkgs {
+			dkgsList[i] = map[string]interface{}{
+				"channel_name":      dkg.ChannelName,
+				"member_index":      dkg.MemberIndex,
+				"state":             dkg.State,
+				"state_start_block": dkg.StateStartBlock,
+			}
+		}
+
+		signings := beacon.RunningSignings()
+		signingsList := make([]map[string]interface{}, len(signings))
+		for i, signing := range signings {
+			signingsList[i] = map[string]interface{}{
+				"group_public_key": fmt.Sprintf("0x%x", signing.GroupPublicKey),
+				"member_index":     signing.MemberIndex,
+				"previous_entry":   fmt.Sprintf("0x%x", signing.PreviousEntry),
+				"start_block":      signing.StartBlock,
+			}
+		}
+
+		runningProtocols := map[string]interface{}{
+			"dkg":     dkgsList,
+			"signing": signingsList,
+		}
+
+		return serialize("running protocols", runningProtocols)
+	})
+}
+
+// RegisterChainConfigSource registers the diagnostics source providing
+// the relay configuration read from the chain.
+func RegisterChainConfigSource(
+	registry Registry,
+	beacon *beacon.Beacon,
+) {
+	registry.RegisterSource("chain_config", func() string {
+		chainConfig := beacon.ChainConfig()
+
+		chainConfigInfo := map[string]interface{}{
+			"group_size":                    chainConfig.GroupSize,
+			"honest_threshold":              chainConfig.HonestThreshold,
+			"ticket_submission_timeout":     chainConfig.TicketSubmissionTimeout,
+			"result_publication_block_step": chainConfig.ResultPublicationBlockStep,
+			"relay_entry_timeout":           chainConfig.RelayEntryTimeout,
+		}
+
+		return serialize("chain config", chainConfigInfo)
+	})
+}
+
+func serialize(name string, value interface{}) string {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		logger.Errorf("error on serializing %v to JSON: [%v]", name, err)
+		return ""
+	}
+
+	return string(bytes)
+}
+
+func prefixHex(values []string) []string {
+	prefixed := make([]string, len(values))
+	for i, value := range values {
+		prefixed[i] = "0x" + value
+	}
+
+	return prefixed
+}
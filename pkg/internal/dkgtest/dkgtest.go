@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +34,8 @@ type Result struct {
 	dkgResultSignatures map[group.MemberIndex][]byte
 	signers             []*dkg.ThresholdSigner
 	memberFailures      []error
+	summaries           []*dkg.Summary
+	honestThreshold     int
 }
 
 // GetSigners returns all signers created from DKG protocol execution.
@@ -42,6 +45,72 @@ func (r *Result) GetSigners() []*dkg.ThresholdSigner {
 	return r.signers
 }
 
+// GetDKGResult returns the DKG result published to the chain. If no result
+// was published, nil is returned.
+func (r *Result) GetDKGResult() *relaychain.DKGResult {
+	return r.dkgResult
+}
+
+// GetDKGResultSignatures returns signatures supporting the DKG result
+// published to the chain, indexed by the member who produced them.
+func (r *Result) GetDKGResultSignatures() map[group.MemberIndex][]byte {
+	return r.dkgResultSignatures
+}
+
+// GetMemberFailures returns errors of all members who failed the protocol
+// execution.
+func (r *Result) GetMemberFailures() []error {
+	return r.memberFailures
+}
+
+// GetInactiveMembers returns members marked as inactive during the key
+// generation by at least honest threshold of group members. Misbehaving
+// members may have a different view of the group so their markings are
+// not taken into account unless the honest majority agrees with them.
+func (r *Result) GetInactiveMembers() []group.MemberIndex {
+	return r.misbehavingMembers(func(phase *gjkr.PhaseReport) []group.MemberIndex {
+		return phase.InactiveMembers
+	})
+}
+
+// GetDisqualifiedMembers returns members marked as disqualified during the key
+// generation by at least honest threshold of group members.
+func (r *Result) GetDisqualifiedMembers() []group.MemberIndex {
+	return r.misbehavingMembers(func(phase *gjkr.PhaseReport) []group.MemberIndex {
+		return phase.DisqualifiedMembers
+	})
+}
+
+func (r *Result) misbehavingMembers(
+	markedInPhase func(phase *gjkr.PhaseReport) []group.MemberIndex,
+) []group.MemberIndex {
+	markings := make(map[group.MemberIndex]int)
+	for _, summary := range r.summaries {
+		marked := make(map[group.MemberIndex]bool)
+		for _, phase := range summary.Phases {
+			for _, memberIndex := range markedInPhase(phase) {
+				marked[memberIndex] = true
+			}
+		}
+
+		for memberIndex := range marked {
+			markings[memberIndex]++
+		}
+	}
+
+	members := make([]group.MemberIndex, 0)
+	for memberIndex, count := range markings {
+		if count >= r.honestThreshold {
+			members = append(members, memberIndex)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i] < members[j]
+	})
+
+	return members
+}
+
 // RandomSeed generates a random DKG seed value. It is important to do not
 // reuse the same seed value between integration tests run in parallel.
 // Broadcast channel name contains a seed to avoid mixing up channel messages
@@ -124,6 +193,9 @@ func executeDKG(
 
 	var memberFailures []error
 
+	var summariesMutex sync.Mutex
+	var summaries []*dkg.Summary
+
 	var wg sync.WaitGroup
 	wg.Add(relayConfig.GroupSize)
 
@@ -147,6 +219,7 @@ func executeDKG(
 	for i := 0; i < relayConfig.GroupSize; i++ {
 		i := i // capture for goroutine
 		go func() {
+			telemetry := dkg.NewTelemetry()
 			signer, err := dkg.ExecuteDKG(
 				seed,
 				uint8(i),
@@ -158,7 +231,16 @@ func executeDKG(
 				chain.ThresholdRelay(),
 				chain.Signing(),
 				broadcastChannel,
+				nil,
+				nil,
+				telemetry,
+				nil,
 			)
+			if summary := telemetry.Stats().LastSummary; summary != nil {
+				summariesMutex.Lock()
+				summaries = append(summaries, summary)
+				summariesMutex.Unlock()
+			}
 			if signer != nil {
 				signersMutex.Lock()
 				signers = append(signers, signer)
@@ -188,6 +270,8 @@ func executeDKG(
 			dkgResultSignatures,
 			signers,
 			memberFailures,
+			summaries,
+			relayConfig.HonestThreshold,
 		}, nil
 
 	case <-ctx.Done():
@@ -197,6 +281,8 @@ func executeDKG(
 			nil,
 			signers,
 			memberFailures,
+			summaries,
+			relayConfig.HonestThreshold,
 		}, nil
 	}
 }
@@ -0,0 +1,154 @@
+package dkgtest
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// RefreshResult of a share refresh test execution.
+type RefreshResult struct {
+	signers        []*dkg.ThresholdSigner
+	memberFailures []error
+}
+
+// GetSigners returns signers holding refreshed shares, sorted by their member
+// index. Signers of members who failed the refresh are not returned.
+func (r *RefreshResult) GetSigners() []*dkg.ThresholdSigner {
+	return r.signers
+}
+
+// GetMemberFailures returns errors of all members who failed the refresh.
+func (r *RefreshResult) GetMemberFailures() []error {
+	return r.memberFailures
+}
+
+// RunRefreshTest executes the full share refresh roundtrip test for the
+// provided signers of a group of the given size and honest threshold. Members
+// of the group without a signer do not take part in the refresh. The provided
+// interception rules are applied in the broadcast channel for the time of
+// the refresh execution.
+func RunRefreshTest(
+	signers []*dkg.ThresholdSigner,
+	groupSize int,
+	honestThreshold int,
+	seed *big.Int,
+	rules interception.Rules,
+) (*RefreshResult, error) {
+	privateKey, publicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	_, networkPublicKey := key.OperatorKeyToNetworkKey(privateKey, publicKey)
+
+	network := interception.NewNetwork(
+		netLocal.ConnectWithKey(networkPublicKey),
+		rules,
+	)
+
+	chain := chainLocal.ConnectWithKey(
+		groupSize,
+		honestThreshold,
+		minimumStake,
+		privateKey,
+	)
+
+	address := chain.Signing().PublicKeyBytesToAddress(
+		key.Marshal(networkPublicKey),
+	)
+
+	groupMembers := make([]relaychain.StakerAddress, groupSize)
+	for i := range groupMembers {
+		groupMembers[i] = address
+	}
+
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		return nil, err
+	}
+
+	broadcastChannel, err := network.BroadcastChannelFor(
+		fmt.Sprintf("refresh-test-%v", seed),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	currentBlockHeight, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for 3 blocks before starting the refresh to
+	// make sure all members are up.
+	startBlockHeight := currentBlockHeight + 3
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		groupMembers,
+		chain.Signing(),
+	)
+
+	var mutex sync.Mutex
+	result := &RefreshResult{}
+
+	var wg sync.WaitGroup
+	wg.Add(len(signers))
+
+	for _, signer := range signers {
+		signer := signer // capture for goroutine
+		go func() {
+			defer wg.Done()
+
+			refreshedSigner, refreshEndBlockHeight, err := dkg.RefreshShares(
+				signer,
+				seed,
+				groupSize,
+				groupSize-honestThreshold,
+				membershipValidator,
+				startBlockHeight,
+				blockCounter,
+				broadcastChannel,
+				nil,
+			)
+			if err == nil {
+				err = dkg.ConfirmRefresh(
+					refreshedSigner,
+					seed,
+					refreshEndBlockHeight,
+					membershipValidator,
+					blockCounter,
+					broadcastChannel,
+				)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				fmt.Printf("failed with: [%v]\n", err)
+				result.memberFailures = append(result.memberFailures, err)
+				return
+			}
+
+			result.signers = append(result.signers, refreshedSigner)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(result.signers, func(i, j int) bool {
+		return result.signers[i].MemberID() < result.signers[j].MemberID()
+	})
+
+	return result, nil
+}
@@ -16,9 +16,11 @@ import (
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/operator"
 
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
 	netLocal "github.com/keep-network/keep-core/pkg/net/local"
@@ -77,14 +79,66 @@ func RunTest(
 
 	chain := chainLocal.ConnectWithKey(len(signers), threshold, minimumStake, privateKey)
 
-	return executeSigning(signers, threshold, chain, network, previousEntry)
+	operators := make([][]*dkg.ThresholdSigner, len(signers))
+	for i, signer := range signers {
+		operators[i] = []*dkg.ThresholdSigner{signer}
+	}
+
+	return executeSigning(
+		operators,
+		threshold,
+		chain,
+		network,
+		networkPublicKey,
+		previousEntry,
+	)
+}
+
+// RunMultiSeatTest executes the full relay entry signing roundtrip test just
+// like RunTest but lets a single operator control multiple group members.
+// Each element of the operators slice is the set of signers controlled by one
+// operator; all of them sign in a single signing session.
+func RunMultiSeatTest(
+	operators [][]*dkg.ThresholdSigner,
+	threshold int,
+	rules interception.Rules,
+	previousEntry []byte,
+) (*Result, error) {
+	privateKey, publicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	_, networkPublicKey := key.OperatorKeyToNetworkKey(privateKey, publicKey)
+
+	network := interception.NewNetwork(
+		netLocal.ConnectWithKey(networkPublicKey),
+		rules,
+	)
+
+	groupSize := 0
+	for _, signers := range operators {
+		groupSize += len(signers)
+	}
+
+	chain := chainLocal.ConnectWithKey(groupSize, threshold, minimumStake, privateKey)
+
+	return executeSigning(
+		operators,
+		threshold,
+		chain,
+		network,
+		networkPublicKey,
+		previousEntry,
+	)
 }
 
 func executeSigning(
-	signers []*dkg.ThresholdSigner,
+	operators [][]*dkg.ThresholdSigner,
 	threshold int,
 	chain chainLocal.Chain,
 	network interception.Network,
+	networkPublicKey *key.NetworkPublic,
 	previousEntry []byte,
 ) (*Result, error) {
 	blockCounter, err := chain.BlockCounter()
@@ -117,7 +171,7 @@ func executeSigning(
 	var signerFailures []error
 
 	var wg sync.WaitGroup
-	wg.Add(len(signers))
+	wg.Add(len(operators))
 
 	currentBlockHeight, err := blockCounter.CurrentBlock()
 	if err != nil {
@@ -130,25 +184,50 @@ func executeSigning(
 
 	entry.RegisterUnmarshallers(broadcastChannel)
 
-	for _, signer := range signers {
-		go func(signer *dkg.ThresholdSigner) {
+	// All the signers share the same network key so the key holds all
+	// the positions in the group, up to the highest member index.
+	groupSize := 0
+	for _, signers := range operators {
+		for _, signer := range signers {
+			if int(signer.MemberID()) > groupSize {
+				groupSize = int(signer.MemberID())
+			}
+		}
+	}
+
+	address := chain.Signing().PublicKeyBytesToAddress(
+		key.Marshal(networkPublicKey),
+	)
+	groupMembers := make([]relaychain.StakerAddress, groupSize)
+	for i := range groupMembers {
+		groupMembers[i] = address
+	}
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		groupMembers,
+		chain.Signing(),
+	)
+
+	for _, signers := range operators {
+		go func(signers []*dkg.ThresholdSigner) {
 			err := entry.SignAndSubmit(
 				blockCounter,
 				broadcastChannel,
 				chain.ThresholdRelay(),
+				membershipValidator,
 				previousEntry,
 				threshold,
-				signer,
+				signers,
 				startBlockHeight,
 			)
 			if err != nil {
-				fmt.Printf("[signer:%v %v] failed with: [%v]\n", signer.MemberID(), previousEntry, err)
+				fmt.Printf("[signer:%v %v] failed with: [%v]\n", signers[0].MemberID(), previousEntry, err)
 				signerFailuresMutex.Lock()
 				signerFailures = append(signerFailures, err)
 				signerFailuresMutex.Unlock()
 			}
 			wg.Done()
-		}(signer)
+		}(signers)
 	}
 	wg.Wait()
 
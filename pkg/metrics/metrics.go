@@ -2,11 +2,15 @@ package metrics
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ipfs/go-log"
-	"github.com/keep-network/keep-common/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/beacon/observer"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
@@ -19,28 +23,78 @@ const (
 	// DefaultEthereumMetricsTick is the default duration of the
 	// observation tick for Ethereum metrics.
 	DefaultEthereumMetricsTick = 10 * time.Minute
+	// DefaultEthereumSubscriptionMetricsTick is the default duration of the
+	// observation tick for Ethereum event subscription metrics.
+	DefaultEthereumSubscriptionMetricsTick = 1 * time.Minute
+	// DefaultRelayMetricsTick is the default duration of the
+	// observation tick for random beacon activity metrics.
+	DefaultRelayMetricsTick = 1 * time.Minute
 )
 
 // Initialize set up the metrics registry and enables metrics server.
 func Initialize(
 	port int,
-) (*metrics.Registry, bool) {
+) (*GaugeRegistry, bool) {
 	if port == 0 {
 		return nil, false
 	}
 
-	registry := metrics.NewRegistry()
+	registry := NewGaugeRegistry()
 
 	registry.EnableServer(port)
 
 	return registry, true
 }
 
+// Registry registers metrics observing the given inputs. It is implemented by
+// GaugeRegistry and by OperatorRegistry.
+type Registry interface {
+	NewGaugeObserver(
+		name string,
+		input ObserverInput,
+		labels ...Label,
+	) (*Observer, error)
+}
+
+// OperatorRegistry registers metrics of a single operator run by the client.
+// All metrics are labelled with the operator address, so metrics of all
+// operators share their names.
+type OperatorRegistry struct {
+	registry *GaugeRegistry
+	label    Label
+}
+
+// NewOperatorRegistry returns a registry of metrics of the operator with the
+// given address.
+func NewOperatorRegistry(
+	registry *GaugeRegistry,
+	operatorAddress string,
+) *OperatorRegistry {
+	return &OperatorRegistry{
+		registry: registry,
+		label:    NewLabel("operator", operatorAddress),
+	}
+}
+
+// NewGaugeObserver registers a gauge of the operator observing the given
+// input.
+func (or *OperatorRegistry) NewGaugeObserver(
+	name string,
+	input ObserverInput,
+	labels ...Label,
+) (*Observer, error) {
+	return or.registry.NewGaugeObserver(
+		name,
+		input,
+		append([]Label{or.label}, labels...)...,
+	)
+}
+
 // ObserveConnectedPeersCount triggers an observation process of the
 // connected_peers_count metric.
 func ObserveConnectedPeersCount(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry Registry,
 	netProvider net.Provider,
 	tick time.Duration,
 ) {
@@ -62,7 +116,7 @@ func ObserveConnectedPeersCount(
 // connected_bootstrap_count metric.
 func ObserveConnectedBootstrapCount(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry Registry,
 	netProvider net.Provider,
 	bootstraps []string,
 	tick time.Duration,
@@ -92,7 +146,7 @@ func ObserveConnectedBootstrapCount(
 // eth_connectivity metric.
 func ObserveEthConnectivity(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry Registry,
 	stakeMonitor chain.StakeMonitor,
 	address string,
 	tick time.Duration,
@@ -116,11 +170,247 @@ func ObserveEthConnectivity(
 	)
 }
 
+// EthSubscriptionSource provides statistics of Ethereum contract event
+// subscriptions.
+type EthSubscriptionSource interface {
+	EventSubscriptionStats() ethereum.SubscriptionStats
+}
+
+// ObserveEthSubscriptions triggers an observation process of metrics
+// describing the health of Ethereum contract event subscriptions.
+func ObserveEthSubscriptions(
+	ctx context.Context,
+	registry Registry,
+	source EthSubscriptionSource,
+	tick time.Duration,
+) {
+	inputs := map[string]func(stats ethereum.SubscriptionStats) uint64{
+		"eth_subscriptions": func(stats ethereum.SubscriptionStats) uint64 {
+			return stats.Subscriptions
+		},
+		"eth_subscriptions_unhealthy": func(stats ethereum.SubscriptionStats) uint64 {
+			return stats.Unhealthy
+		},
+		"eth_subscription_failures": func(stats ethereum.SubscriptionStats) uint64 {
+			return stats.Failures
+		},
+		"eth_subscription_resubscriptions": func(stats ethereum.SubscriptionStats) uint64 {
+			return stats.Resubscriptions
+		},
+		"eth_subscription_delivered_events": func(stats ethereum.SubscriptionStats) uint64 {
+			return stats.DeliveredEvents
+		},
+		"eth_subscription_replayed_events": func(stats ethereum.SubscriptionStats) uint64 {
+			return stats.ReplayedEvents
+		},
+		"eth_subscription_duplicate_events": func(stats ethereum.SubscriptionStats) uint64 {
+			return stats.DuplicateEvents
+		},
+		"eth_subscription_last_delivered_block": func(stats ethereum.SubscriptionStats) uint64 {
+			return stats.LastDeliveredBlock
+		},
+	}
+
+	for name, statsInput := range inputs {
+		statsInput := statsInput
+		input := func() float64 {
+			return float64(statsInput(source.EventSubscriptionStats()))
+		}
+
+		observe(
+			ctx,
+			name,
+			input,
+			registry,
+			validateTick(tick, DefaultEthereumSubscriptionMetricsTick),
+		)
+	}
+}
+
+// ObserveRelayActivity triggers an observation process of metrics describing
+// the random beacon activity seen by the given observer.
+func ObserveRelayActivity(
+	ctx context.Context,
+	registry Registry,
+	relayObserver *observer.Observer,
+	tick time.Duration,
+) {
+	inputs := map[string]func(stats observer.Stats) uint64{
+		"relay_entries_requested": func(stats observer.Stats) uint64 {
+			return stats.RelayEntriesRequested
+		},
+		"relay_entries_submitted": func(stats observer.Stats) uint64 {
+			return stats.RelayEntriesSubmitted
+		},
+		"relay_entries_verified": func(stats observer.Stats) uint64 {
+			return stats.RelayEntriesVerified
+		},
+		"relay_entries_invalid": func(stats observer.Stats) uint64 {
+			return stats.RelayEntriesInvalid
+		},
+		"relay_entries_timed_out": func(stats observer.Stats) uint64 {
+			return stats.RelayEntriesTimedOut
+		},
+		"group_selections_started": func(stats observer.Stats) uint64 {
+			return stats.GroupSelectionsStarted
+		},
+		"dkg_results_submitted": func(stats observer.Stats) uint64 {
+			return stats.DKGResultsSubmitted
+		},
+		"groups_registered": func(stats observer.Stats) uint64 {
+			return stats.GroupsRegistered
+		},
+		"relay_last_event_block": func(stats observer.Stats) uint64 {
+			return stats.LastEventBlock
+		},
+	}
+
+	for name, statsInput := range inputs {
+		statsInput := statsInput
+		input := func() float64 {
+			return float64(statsInput(relayObserver.Stats()))
+		}
+
+		observe(
+			ctx,
+			name,
+			input,
+			registry,
+			validateTick(tick, DefaultRelayMetricsTick),
+		)
+	}
+}
+
+// DKGTelemetrySource provides statistics of distributed key generations
+// executed by the operator.
+type DKGTelemetrySource interface {
+	DKGTelemetry() dkg.TelemetryStats
+}
+
+// ObserveDKGActivity triggers an observation process of metrics describing
+// distributed key generations executed by the operator. Besides the overall
+// counters, metrics describe each protocol phase and the outcome of the most
+// recently completed key generation.
+func ObserveDKGActivity(
+	ctx context.Context,
+	registry Registry,
+	source DKGTelemetrySource,
+	tick time.Duration,
+) {
+	inputs := map[string]func(stats dkg.TelemetryStats) float64{
+		"dkg_executions": func(stats dkg.TelemetryStats) float64 {
+			return float64(stats.Executions)
+		},
+		"dkg_results_published": func(stats dkg.TelemetryStats) float64 {
+			return float64(stats.ResultsPublished)
+		},
+		"dkg_members_excluded": func(stats dkg.TelemetryStats) float64 {
+			return float64(stats.MembersExcluded)
+		},
+		"dkg_last_result_published": lastSummaryInput(
+			func(summary *dkg.Summary) float64 {
+				return boolToFloat(summary.ResultPublished)
+			},
+		),
+		"dkg_last_member_excluded": lastSummaryInput(
+			func(summary *dkg.Summary) float64 {
+				return boolToFloat(summary.Excluded)
+			},
+		),
+		"dkg_last_failed_phase": lastSummaryInput(
+			func(summary *dkg.Summary) float64 {
+				return float64(summary.FailedPhase)
+			},
+		),
+	}
+
+	for phase := uint32(1); phase <= gjkr.ProtocolPhases; phase++ {
+		phaseInputs := map[string]func(report *gjkr.PhaseReport) float64{
+			"duration_blocks": func(report *gjkr.PhaseReport) float64 {
+				return float64(report.Blocks())
+			},
+			"duration_seconds": func(report *gjkr.PhaseReport) float64 {
+				return report.Duration.Seconds()
+			},
+			"received_messages": func(report *gjkr.PhaseReport) float64 {
+				return float64(report.ReceivedMessages)
+			},
+			"inactive_members": func(report *gjkr.PhaseReport) float64 {
+				return float64(len(report.InactiveMembers))
+			},
+			"disqualified_members": func(report *gjkr.PhaseReport) float64 {
+				return float64(len(report.DisqualifiedMembers))
+			},
+		}
+
+		for name, phaseInput := range phaseInputs {
+			inputs[fmt.Sprintf("dkg_phase_%v_%v", phase, name)] =
+				lastPhaseInput(phase, phaseInput)
+		}
+	}
+
+	for name, statsInput := range inputs {
+		statsInput := statsInput
+		input := func() float64 {
+			return statsInput(source.DKGTelemetry())
+		}
+
+		observe(
+			ctx,
+			name,
+			input,
+			registry,
+			validateTick(tick, DefaultRelayMetricsTick),
+		)
+	}
+}
+
+// lastSummaryInput returns an input reading a value from the summary of the
+// most recently completed key generation. If there is no such summary, the
+// input returns zero.
+func lastSummaryInput(
+	summaryInput func(summary *dkg.Summary) float64,
+) func(stats dkg.TelemetryStats) float64 {
+	return func(stats dkg.TelemetryStats) float64 {
+		if stats.LastSummary == nil {
+			return 0
+		}
+
+		return summaryInput(stats.LastSummary)
+	}
+}
+
+// lastPhaseInput returns an input reading a value from the report of the
+// given phase of the most recently completed key generation. If the phase
+// has not been completed, the input returns zero.
+func lastPhaseInput(
+	phase uint32,
+	phaseInput func(report *gjkr.PhaseReport) float64,
+) func(stats dkg.TelemetryStats) float64 {
+	return lastSummaryInput(func(summary *dkg.Summary) float64 {
+		for _, report := range summary.Phases {
+			if report.Phase == phase {
+				return phaseInput(report)
+			}
+		}
+
+		return 0
+	})
+}
+
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
 func observe(
 	ctx context.Context,
 	name string,
-	input metrics.ObserverInput,
-	registry *metrics.Registry,
+	input ObserverInput,
+	registry Registry,
 	tick time.Duration,
 ) {
 	observer, err := registry.NewGaugeObserver(name, input)
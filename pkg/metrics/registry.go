@@ -0,0 +1,210 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ObserverInput defines a source of metric data.
+type ObserverInput func() float64
+
+// Label represents an arbitrary information attached to a metric.
+type Label struct {
+	name  string
+	value string
+}
+
+// NewLabel creates a new label using the given name and value.
+func NewLabel(name, value string) Label {
+	return Label{name, value}
+}
+
+// GaugeRegistry registers gauges and exposes them through the metrics server.
+// Unlike the keep-common metrics registry, it identifies gauges by their names
+// and labels, so gauges of several operators run by the client share names and
+// differ only in the operator label.
+type GaugeRegistry struct {
+	mutex sync.RWMutex
+	// families holds gauges by their names and rendered labels.
+	families map[string]map[string]*gauge
+}
+
+// NewGaugeRegistry creates a new registry of gauges.
+func NewGaugeRegistry() *GaugeRegistry {
+	return &GaugeRegistry{
+		families: make(map[string]map[string]*gauge),
+	}
+}
+
+// EnableServer enables the metrics server on the given port. Data will be
+// exposed on `/metrics` path.
+func (gr *GaugeRegistry) EnableServer(port int) {
+	server := &http.Server{Addr: ":" + strconv.Itoa(port)}
+
+	http.HandleFunc("/metrics", func(response http.ResponseWriter, _ *http.Request) {
+		if _, err := io.WriteString(response, gr.expose()); err != nil {
+			logger.Errorf("could not write response: [%v]", err)
+		}
+	})
+
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Errorf("metrics server error: [%v]", err)
+		}
+	}()
+}
+
+// NewGaugeObserver registers a gauge with the given name and labels and
+// returns an observer of the given input setting the gauge. In case a gauge
+// with the same name and labels already exists, an error is returned.
+func (gr *GaugeRegistry) NewGaugeObserver(
+	name string,
+	input ObserverInput,
+	labels ...Label,
+) (*Observer, error) {
+	gr.mutex.Lock()
+	defer gr.mutex.Unlock()
+
+	renderedLabels := renderLabels(labels)
+
+	family, exists := gr.families[name]
+	if !exists {
+		family = make(map[string]*gauge)
+		gr.families[name] = family
+	}
+
+	if _, exists := family[renderedLabels]; exists {
+		return nil, fmt.Errorf(
+			"metric [%v%v] already exists",
+			name,
+			renderedLabels,
+		)
+	}
+
+	output := &gauge{}
+	family[renderedLabels] = output
+
+	return &Observer{input: input, output: output}, nil
+}
+
+// expose returns all registered gauges in the text-based exposition format.
+// Gauges sharing a name are exposed as one metric family.
+func (gr *GaugeRegistry) expose() string {
+	gr.mutex.RLock()
+	defer gr.mutex.RUnlock()
+
+	names := make([]string, 0, len(gr.families))
+	for name := range gr.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	families := make([]string, 0, len(names))
+	for _, name := range names {
+		family := gr.families[name]
+
+		renderedLabels := make([]string, 0, len(family))
+		for labels := range family {
+			renderedLabels = append(renderedLabels, labels)
+		}
+		sort.Strings(renderedLabels)
+
+		lines := []string{fmt.Sprintf("# TYPE %v gauge", name)}
+		for _, labels := range renderedLabels {
+			value, timestamp := family[labels].get()
+			lines = append(
+				lines,
+				fmt.Sprintf("%v%v %v %v", name, labels, value, timestamp),
+			)
+		}
+
+		families = append(families, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(families, "\n\n")
+}
+
+// renderLabels returns labels in the exposition format, ordered by their
+// names. Labels with an empty name or value are skipped.
+func renderLabels(labels []Label) string {
+	values := make(map[string]string)
+	for _, label := range labels {
+		if label.name == "" || label.value == "" {
+			continue
+		}
+
+		values[label.name] = label.value
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rendered := make([]string, len(names))
+	for i, name := range names {
+		rendered[i] = fmt.Sprintf("%v=\"%v\"", name, values[name])
+	}
+
+	return "{" + strings.Join(rendered, ",") + "}"
+}
+
+// gauge represents a single numerical value that can arbitrarily go up and
+// down.
+type gauge struct {
+	mutex     sync.RWMutex
+	value     float64
+	timestamp int64 // timestamp expressed as milliseconds
+}
+
+func (g *gauge) set(value float64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.value = value
+	g.timestamp = time.Now().UnixNano() / 1e6
+}
+
+func (g *gauge) get() (float64, int64) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.value, g.timestamp
+}
+
+// Observer represents a cyclic observation of the input setting a gauge.
+type Observer struct {
+	input  ObserverInput
+	output *gauge
+}
+
+// Observe triggers a cyclic observation process.
+func (o *Observer) Observe(ctx context.Context, tick time.Duration) {
+	go func() {
+		o.output.set(o.input()) // execute the first check immediately
+
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				o.output.set(o.input())
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
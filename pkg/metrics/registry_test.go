@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestOperatorRegistries(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	registry := NewGaugeRegistry()
+
+	operators := []string{"0x01", "0x02"}
+	for i, operator := range operators {
+		value := float64(i + 1)
+
+		observer, err := NewOperatorRegistry(registry, operator).NewGaugeObserver(
+			"connected_peers_count",
+			func() float64 { return value },
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		observer.Observe(ctx, time.Minute)
+	}
+
+	_, err := NewOperatorRegistry(registry, "0x01").NewGaugeObserver(
+		"connected_peers_count",
+		func() float64 { return 0 },
+	)
+	if err == nil {
+		t.Errorf("expected an error registering the same metric twice")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Timestamps are replaced as they can not be known in advance.
+	exposed := regexp.MustCompile(` \d+$`).ReplaceAllString(
+		regexp.MustCompile(` \d+\n`).ReplaceAllString(registry.expose(), " T\n"),
+		" T",
+	)
+
+	expected := fmt.Sprintf(
+		"# TYPE connected_peers_count gauge\n"+
+			"connected_peers_count{operator=\"%v\"} 1 T\n"+
+			"connected_peers_count{operator=\"%v\"} 2 T",
+		operators[0],
+		operators[1],
+	)
+
+	if expected != exposed {
+		t.Errorf(
+			"unexpected exposed metrics\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			exposed,
+		)
+	}
+}
@@ -0,0 +1,47 @@
+// Package layout describes directories of the operator's persistence storage
+// shared by client components. Each component reading the whole storage skips
+// directories owned by other components.
+package layout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TransactionJournalDirectory is the directory of the persistence storage
+// in which records of the first generation of the transaction journal are
+// kept. Records of later generations are kept in directories returned by
+// TransactionJournalGenerationDirectory.
+const TransactionJournalDirectory = "transactions"
+
+// TransactionJournalGenerationDirectory returns the directory in which records
+// of the given generation of the transaction journal are kept.
+func TransactionJournalGenerationDirectory(generation uint64) string {
+	if generation == 0 {
+		return TransactionJournalDirectory
+	}
+
+	return fmt.Sprintf("%v_%v", TransactionJournalDirectory, generation)
+}
+
+// TransactionJournalGeneration returns the generation of the transaction
+// journal whose records are kept in the given directory. The second returned
+// value is false if the directory does not belong to the transaction journal.
+func TransactionJournalGeneration(directory string) (uint64, bool) {
+	if directory == TransactionJournalDirectory {
+		return 0, true
+	}
+
+	suffix := strings.TrimPrefix(directory, TransactionJournalDirectory+"_")
+	if suffix == directory {
+		return 0, false
+	}
+
+	generation, err := strconv.ParseUint(suffix, 10, 64)
+	if err != nil || generation == 0 {
+		return 0, false
+	}
+
+	return generation, true
+}